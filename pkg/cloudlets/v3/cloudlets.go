@@ -0,0 +1,45 @@
+// Package v3 provides access to the Akamai Cloudlets v3 API, which manages shared policies
+package v3
+
+import (
+	"errors"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// Cloudlets is the api interface for cloudlets v3 shared policies
+	Cloudlets interface {
+		Policies
+		PolicyActivations
+		PolicyProperties
+		PolicyVersions
+	}
+
+	cloudletsv3 struct {
+		session.Session
+	}
+
+	// Option defines a Cloudlets option
+	Option func(*cloudletsv3)
+
+	// ClientFunc is a Cloudlets client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) Cloudlets
+)
+
+// Client returns a new cloudlets v3 Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) Cloudlets {
+	c := &cloudletsv3{
+		Session: sess,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
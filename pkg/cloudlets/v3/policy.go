@@ -0,0 +1,426 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Policies is a cloudlets v3 shared policies API interface
+	Policies interface {
+		// ListPolicies lists shared policies
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policies
+		ListPolicies(context.Context, ListPoliciesRequest) (*ListPoliciesResponse, error)
+
+		// GetPolicy gets shared policy by policyID
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policy
+		GetPolicy(context.Context, GetPolicyRequest) (*Policy, error)
+
+		// CreatePolicy creates shared policy
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/post-policy
+		CreatePolicy(context.Context, CreatePolicyRequest) (*Policy, error)
+
+		// UpdatePolicy updates group and description of a shared policy
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/put-policy
+		UpdatePolicy(context.Context, UpdatePolicyRequest) (*Policy, error)
+
+		// ClonePolicy clones shared policy together with its latest and selected versions
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/post-policy-clone
+		ClonePolicy(context.Context, ClonePolicyRequest) (*Policy, error)
+
+		// DeletePolicy deletes shared policy which is not active on any network
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/delete-policy
+		DeletePolicy(context.Context, DeletePolicyRequest) error
+	}
+
+	// Policy represents a shared policy
+	Policy struct {
+		CloudletType       CloudletType       `json:"cloudletType"`
+		CreatedBy          string             `json:"createdBy"`
+		CreatedDate        time.Time          `json:"createdDate"`
+		CurrentActivations CurrentActivations `json:"currentActivations"`
+		Description        *string            `json:"description"`
+		GroupID            int64              `json:"groupId"`
+		ID                 int64              `json:"id"`
+		Links              []Link             `json:"links"`
+		ModifiedBy         string             `json:"modifiedBy"`
+		ModifiedDate       *time.Time         `json:"modifiedDate,omitempty"`
+		Name               string             `json:"name"`
+		PolicyType         PolicyType         `json:"policyType"`
+	}
+
+	// CurrentActivations contains the effective and latest activations of a policy on both networks
+	CurrentActivations struct {
+		Production ActivationInfo `json:"production"`
+		Staging    ActivationInfo `json:"staging"`
+	}
+
+	// ActivationInfo contains the effective activation, which is currently serving traffic,
+	// and the latest activation, which may still be in progress or may have failed
+	ActivationInfo struct {
+		Effective *PolicyActivation `json:"effective"`
+		Latest    *PolicyActivation `json:"latest"`
+	}
+
+	// Link represents a hypermedia link
+	Link struct {
+		Href string `json:"href"`
+		Rel  string `json:"rel"`
+	}
+
+	// Page contains pagination information of a list response
+	Page struct {
+		Number        int `json:"number"`
+		Size          int `json:"size"`
+		TotalElements int `json:"totalElements"`
+		TotalPages    int `json:"totalPages"`
+	}
+
+	// ListPoliciesRequest contains request parameters for ListPolicies
+	ListPoliciesRequest struct {
+		Page int
+		Size int
+	}
+
+	// ListPoliciesResponse contains the response data from ListPolicies
+	ListPoliciesResponse struct {
+		Content []Policy `json:"content"`
+		Links   []Link   `json:"links"`
+		Page    Page     `json:"page"`
+	}
+
+	// GetPolicyRequest contains request parameters for GetPolicy
+	GetPolicyRequest struct {
+		PolicyID int64
+	}
+
+	// CreatePolicyRequest contains request body for CreatePolicy
+	CreatePolicyRequest struct {
+		CloudletType CloudletType `json:"cloudletType"`
+		Description  *string      `json:"description,omitempty"`
+		GroupID      int64        `json:"groupId"`
+		Name         string       `json:"name"`
+		PolicyType   PolicyType   `json:"policyType,omitempty"`
+	}
+
+	// UpdatePolicyRequest contains request parameters for UpdatePolicy
+	UpdatePolicyRequest struct {
+		PolicyID   int64
+		BodyParams UpdatePolicyBodyParams
+	}
+
+	// UpdatePolicyBodyParams contains request body for UpdatePolicy
+	UpdatePolicyBodyParams struct {
+		GroupID     int64   `json:"groupId"`
+		Description *string `json:"description,omitempty"`
+	}
+
+	// ClonePolicyRequest contains request parameters for ClonePolicy
+	ClonePolicyRequest struct {
+		PolicyID   int64
+		BodyParams ClonePolicyBodyParams
+	}
+
+	// ClonePolicyBodyParams contains request body for ClonePolicy
+	ClonePolicyBodyParams struct {
+		AdditionalVersions []int64 `json:"additionalVersions,omitempty"`
+		GroupID            int64   `json:"groupId,omitempty"`
+		NewName            string  `json:"newName"`
+	}
+
+	// DeletePolicyRequest contains request parameters for DeletePolicy
+	DeletePolicyRequest struct {
+		PolicyID int64
+	}
+
+	// CloudletType represents the type of cloudlet of a shared policy
+	CloudletType string
+
+	// PolicyType represents the type of a policy
+	PolicyType string
+)
+
+const (
+	// CloudletTypeAP represents API Prioritization cloudlet
+	CloudletTypeAP CloudletType = "AP"
+	// CloudletTypeAS represents Application Segmentation cloudlet
+	CloudletTypeAS CloudletType = "AS"
+	// CloudletTypeCD represents Phased Release cloudlet
+	CloudletTypeCD CloudletType = "CD"
+	// CloudletTypeER represents Edge Redirector cloudlet
+	CloudletTypeER CloudletType = "ER"
+	// CloudletTypeFR represents Forward Rewrite cloudlet
+	CloudletTypeFR CloudletType = "FR"
+	// CloudletTypeIG represents Request Control cloudlet
+	CloudletTypeIG CloudletType = "IG"
+
+	// PolicyTypeShared represents a shared policy
+	PolicyTypeShared PolicyType = "SHARED"
+)
+
+var (
+	// ErrListPolicies is returned when ListPolicies fails
+	ErrListPolicies = errors.New("list shared policies")
+	// ErrGetPolicy is returned when GetPolicy fails
+	ErrGetPolicy = errors.New("get shared policy")
+	// ErrCreatePolicy is returned when CreatePolicy fails
+	ErrCreatePolicy = errors.New("create shared policy")
+	// ErrUpdatePolicy is returned when UpdatePolicy fails
+	ErrUpdatePolicy = errors.New("update shared policy")
+	// ErrClonePolicy is returned when ClonePolicy fails
+	ErrClonePolicy = errors.New("clone shared policy")
+	// ErrDeletePolicy is returned when DeletePolicy fails
+	ErrDeletePolicy = errors.New("delete shared policy")
+)
+
+var nameRegexp = regexp.MustCompile("^[a-z_A-Z0-9]+$")
+
+// Validate validates ListPoliciesRequest
+func (r ListPoliciesRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"Page": validation.Validate(r.Page, validation.Min(0)),
+		"Size": validation.Validate(r.Size, validation.Min(10)),
+	})
+}
+
+// Validate validates GetPolicyRequest
+func (r GetPolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+	})
+}
+
+// Validate validates CreatePolicyRequest
+func (r CreatePolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"CloudletType": validation.Validate(r.CloudletType, validation.Required, validation.In(CloudletTypeAP, CloudletTypeAS,
+			CloudletTypeCD, CloudletTypeER, CloudletTypeFR, CloudletTypeIG).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: 'AP', 'AS', 'CD', 'ER', 'FR' or 'IG'", r.CloudletType))),
+		"Name":        validation.Validate(r.Name, validation.Required, validation.Length(0, 64), validation.Match(nameRegexp)),
+		"Description": validation.Validate(r.Description, validation.Length(0, 255)),
+		"GroupID":     validation.Validate(r.GroupID, validation.Required),
+		"PolicyType": validation.Validate(r.PolicyType, validation.In(PolicyTypeShared).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: 'SHARED' or '' (empty)", r.PolicyType))),
+	})
+}
+
+// Validate validates UpdatePolicyRequest
+func (r UpdatePolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":    validation.Validate(r.PolicyID, validation.Required),
+		"GroupID":     validation.Validate(r.BodyParams.GroupID, validation.Required),
+		"Description": validation.Validate(r.BodyParams.Description, validation.Length(0, 255)),
+	})
+}
+
+// Validate validates ClonePolicyRequest
+func (r ClonePolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+		"NewName":  validation.Validate(r.BodyParams.NewName, validation.Required, validation.Length(0, 64), validation.Match(nameRegexp)),
+	})
+}
+
+// Validate validates DeletePolicyRequest
+func (r DeletePolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+	})
+}
+
+func (c *cloudletsv3) ListPolicies(ctx context.Context, params ListPoliciesRequest) (*ListPoliciesResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListPolicies")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrListPolicies, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse("/cloudlets/v3/policies")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListPolicies, err)
+	}
+
+	q := uri.Query()
+	if params.Page != 0 {
+		q.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.Size != 0 {
+		q.Add("size", strconv.Itoa(params.Size))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListPolicies, err)
+	}
+
+	var result ListPoliciesResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListPolicies, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListPolicies, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) GetPolicy(ctx context.Context, params GetPolicyRequest) (*Policy, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetPolicy")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrGetPolicy, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d", params.PolicyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPolicy, err)
+	}
+
+	var result Policy
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPolicy, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetPolicy, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) CreatePolicy(ctx context.Context, params CreatePolicyRequest) (*Policy, error) {
+	logger := c.Log(ctx)
+	logger.Debug("CreatePolicy")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreatePolicy, ErrStructValidation, err)
+	}
+
+	if params.PolicyType == "" {
+		params.PolicyType = PolicyTypeShared
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/cloudlets/v3/policies", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreatePolicy, err)
+	}
+
+	var result Policy
+	resp, err := c.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreatePolicy, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreatePolicy, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) UpdatePolicy(ctx context.Context, params UpdatePolicyRequest) (*Policy, error) {
+	logger := c.Log(ctx)
+	logger.Debug("UpdatePolicy")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrUpdatePolicy, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d", params.PolicyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdatePolicy, err)
+	}
+
+	var result Policy
+	resp, err := c.Exec(req, &result, params.BodyParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdatePolicy, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdatePolicy, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) ClonePolicy(ctx context.Context, params ClonePolicyRequest) (*Policy, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ClonePolicy")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrClonePolicy, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/clone", params.PolicyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrClonePolicy, err)
+	}
+
+	var result Policy
+	resp, err := c.Exec(req, &result, params.BodyParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrClonePolicy, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrClonePolicy, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) DeletePolicy(ctx context.Context, params DeletePolicyRequest) error {
+	logger := c.Log(ctx)
+	logger.Debug("DeletePolicy")
+
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w:\n%s", ErrDeletePolicy, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d", params.PolicyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeletePolicy, err)
+	}
+
+	resp, err := c.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeletePolicy, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrDeletePolicy, c.Error(resp))
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PolicyActivations is a cloudlets v3 shared policy activations API interface.
+	// Shared policies are activated on a network rather than on properties
+	PolicyActivations interface {
+		// ListPolicyActivations returns the activation history of a shared policy
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policy-activations
+		ListPolicyActivations(context.Context, ListPolicyActivationsRequest) (*ListPolicyActivationsResponse, error)
+
+		// GetPolicyActivation gets a shared policy activation
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policy-activation
+		GetPolicyActivation(context.Context, GetPolicyActivationRequest) (*PolicyActivation, error)
+
+		// ActivatePolicy activates a shared policy version on a network
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/post-policy-activations
+		ActivatePolicy(context.Context, ActivatePolicyRequest) (*PolicyActivation, error)
+
+		// DeactivatePolicy deactivates a shared policy version on a network
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/post-policy-activations
+		DeactivatePolicy(context.Context, DeactivatePolicyRequest) (*PolicyActivation, error)
+	}
+
+	// PolicyActivation represents an activation or deactivation of a shared policy version
+	PolicyActivation struct {
+		CreatedBy            string                    `json:"createdBy"`
+		CreatedDate          time.Time                 `json:"createdDate"`
+		FinishDate           *time.Time                `json:"finishDate"`
+		ID                   int64                     `json:"id"`
+		Network              ActivationNetwork         `json:"network"`
+		Operation            PolicyActivationOperation `json:"operation"`
+		PolicyID             int64                     `json:"policyId"`
+		Status               ActivationStatus          `json:"status"`
+		PolicyVersion        int64                     `json:"policyVersion"`
+		PolicyVersionDeleted bool                      `json:"policyVersionDeleted"`
+		Links                []Link                    `json:"links"`
+	}
+
+	// ListPolicyActivationsRequest contains request parameters for ListPolicyActivations
+	ListPolicyActivationsRequest struct {
+		PolicyID int64
+		Page     int
+		Size     int
+	}
+
+	// ListPolicyActivationsResponse contains the response data from ListPolicyActivations
+	ListPolicyActivationsResponse struct {
+		PolicyActivations []PolicyActivation `json:"content"`
+		Links             []Link             `json:"links"`
+		Page              Page               `json:"page"`
+	}
+
+	// GetPolicyActivationRequest contains request parameters for GetPolicyActivation
+	GetPolicyActivationRequest struct {
+		PolicyID     int64
+		ActivationID int64
+	}
+
+	// ActivatePolicyRequest contains request parameters for ActivatePolicy
+	ActivatePolicyRequest struct {
+		PolicyID      int64
+		Network       ActivationNetwork
+		PolicyVersion int64
+	}
+
+	// DeactivatePolicyRequest contains request parameters for DeactivatePolicy
+	DeactivatePolicyRequest struct {
+		PolicyID      int64
+		Network       ActivationNetwork
+		PolicyVersion int64
+	}
+
+	policyActivationBody struct {
+		Network       ActivationNetwork         `json:"network"`
+		Operation     PolicyActivationOperation `json:"operation"`
+		PolicyVersion int64                     `json:"policyVersion"`
+	}
+
+	// ActivationNetwork is the network a shared policy is activated on
+	ActivationNetwork string
+
+	// PolicyActivationOperation is the operation performed on a network
+	PolicyActivationOperation string
+
+	// ActivationStatus is the status of a shared policy activation
+	ActivationStatus string
+)
+
+const (
+	// StagingNetwork is the staging network
+	StagingNetwork ActivationNetwork = "STAGING"
+	// ProductionNetwork is the production network
+	ProductionNetwork ActivationNetwork = "PRODUCTION"
+
+	// OperationActivation activates a policy version
+	OperationActivation PolicyActivationOperation = "ACTIVATION"
+	// OperationDeactivation deactivates a policy version
+	OperationDeactivation PolicyActivationOperation = "DEACTIVATION"
+
+	// ActivationStatusInProgress is the status of an activation which is in progress
+	ActivationStatusInProgress ActivationStatus = "IN_PROGRESS"
+	// ActivationStatusSuccess is the status of an activation which succeeded
+	ActivationStatusSuccess ActivationStatus = "SUCCESS"
+	// ActivationStatusFailed is the status of an activation which failed
+	ActivationStatusFailed ActivationStatus = "FAILED"
+)
+
+var (
+	// ErrListPolicyActivations is returned when ListPolicyActivations fails
+	ErrListPolicyActivations = errors.New("list shared policy activations")
+	// ErrGetPolicyActivation is returned when GetPolicyActivation fails
+	ErrGetPolicyActivation = errors.New("get shared policy activation")
+	// ErrActivatePolicy is returned when ActivatePolicy fails
+	ErrActivatePolicy = errors.New("activate shared policy")
+	// ErrDeactivatePolicy is returned when DeactivatePolicy fails
+	ErrDeactivatePolicy = errors.New("deactivate shared policy")
+)
+
+// Validate validates ListPolicyActivationsRequest
+func (r ListPolicyActivationsRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+		"Page":     validation.Validate(r.Page, validation.Min(0)),
+		"Size":     validation.Validate(r.Size, validation.Min(10)),
+	})
+}
+
+// Validate validates GetPolicyActivationRequest
+func (r GetPolicyActivationRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":     validation.Validate(r.PolicyID, validation.Required),
+		"ActivationID": validation.Validate(r.ActivationID, validation.Required),
+	})
+}
+
+// Validate validates ActivatePolicyRequest
+func (r ActivatePolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":      validation.Validate(r.PolicyID, validation.Required),
+		"PolicyVersion": validation.Validate(r.PolicyVersion, validation.Required),
+		"Network":       validateNetwork(r.Network),
+	})
+}
+
+// Validate validates DeactivatePolicyRequest
+func (r DeactivatePolicyRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":      validation.Validate(r.PolicyID, validation.Required),
+		"PolicyVersion": validation.Validate(r.PolicyVersion, validation.Required),
+		"Network":       validateNetwork(r.Network),
+	})
+}
+
+func validateNetwork(network ActivationNetwork) error {
+	return validation.Validate(network, validation.Required, validation.In(StagingNetwork, ProductionNetwork).Error(
+		fmt.Sprintf("value '%s' is invalid. Must be one of: 'STAGING' or 'PRODUCTION'", network)))
+}
+
+func (c *cloudletsv3) ListPolicyActivations(ctx context.Context, params ListPolicyActivationsRequest) (*ListPolicyActivationsResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListPolicyActivations")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrListPolicyActivations, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/cloudlets/v3/policies/%d/activations", params.PolicyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListPolicyActivations, err)
+	}
+
+	q := uri.Query()
+	if params.Page != 0 {
+		q.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.Size != 0 {
+		q.Add("size", strconv.Itoa(params.Size))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListPolicyActivations, err)
+	}
+
+	var result ListPolicyActivationsResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListPolicyActivations, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListPolicyActivations, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) GetPolicyActivation(ctx context.Context, params GetPolicyActivationRequest) (*PolicyActivation, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetPolicyActivation")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrGetPolicyActivation, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/activations/%d", params.PolicyID, params.ActivationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPolicyActivation, err)
+	}
+
+	var result PolicyActivation
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPolicyActivation, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetPolicyActivation, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) ActivatePolicy(ctx context.Context, params ActivatePolicyRequest) (*PolicyActivation, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ActivatePolicy")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrActivatePolicy, ErrStructValidation, err)
+	}
+
+	result, err := c.createPolicyActivation(ctx, params.PolicyID, policyActivationBody{
+		Network:       params.Network,
+		Operation:     OperationActivation,
+		PolicyVersion: params.PolicyVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrActivatePolicy, err)
+	}
+
+	return result, nil
+}
+
+func (c *cloudletsv3) DeactivatePolicy(ctx context.Context, params DeactivatePolicyRequest) (*PolicyActivation, error) {
+	logger := c.Log(ctx)
+	logger.Debug("DeactivatePolicy")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrDeactivatePolicy, ErrStructValidation, err)
+	}
+
+	result, err := c.createPolicyActivation(ctx, params.PolicyID, policyActivationBody{
+		Network:       params.Network,
+		Operation:     OperationDeactivation,
+		PolicyVersion: params.PolicyVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrDeactivatePolicy, err)
+	}
+
+	return result, nil
+}
+
+func (c *cloudletsv3) createPolicyActivation(ctx context.Context, policyID int64, body policyActivationBody) (*PolicyActivation, error) {
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/activations", policyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	var result PolicyActivation
+	resp, err := c.Exec(req, &result, body)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, c.Error(resp)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,361 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListPolicyActivations(t *testing.T) {
+	tests := map[string]struct {
+		params           ListPolicyActivationsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListPolicyActivationsResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListPolicyActivationsRequest{PolicyID: 1001, Page: 1, Size: 10},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "content": [
+        {
+            "createdBy": "jsmith",
+            "createdDate": "2022-10-12T08:07:32.123Z",
+            "finishDate": null,
+            "id": 124,
+            "network": "PRODUCTION",
+            "operation": "DEACTIVATION",
+            "policyId": 1001,
+            "status": "IN_PROGRESS",
+            "policyVersion": 2,
+            "policyVersionDeleted": false,
+            "links": []
+        }
+    ],
+    "links": [],
+    "page": {
+        "number": 1,
+        "size": 10,
+        "totalElements": 11,
+        "totalPages": 2
+    }
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/activations?page=1&size=10",
+			expectedResponse: &ListPolicyActivationsResponse{
+				PolicyActivations: []PolicyActivation{
+					{
+						CreatedBy:     "jsmith",
+						CreatedDate:   mustParseTime(t, "2022-10-12T08:07:32.123Z"),
+						ID:            124,
+						Network:       ProductionNetwork,
+						Operation:     OperationDeactivation,
+						PolicyID:      1001,
+						Status:        ActivationStatusInProgress,
+						PolicyVersion: 2,
+						Links:         []Link{},
+					},
+				},
+				Links: []Link{},
+				Page:  Page{Number: 1, Size: 10, TotalElements: 11, TotalPages: 2},
+			},
+		},
+		"500 internal server error": {
+			params:         ListPolicyActivationsRequest{PolicyID: 1001},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error making request",
+  "status": 500
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/activations",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error making request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    ListPolicyActivationsRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListPolicyActivations(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetPolicyActivation(t *testing.T) {
+	tests := map[string]struct {
+		params           GetPolicyActivationRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *PolicyActivation
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetPolicyActivationRequest{PolicyID: 1001, ActivationID: 123},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-12T08:07:32.123Z",
+    "finishDate": "2022-10-12T08:09:32.123Z",
+    "id": 123,
+    "network": "STAGING",
+    "operation": "ACTIVATION",
+    "policyId": 1001,
+    "status": "FAILED",
+    "policyVersion": 2,
+    "policyVersionDeleted": true,
+    "links": []
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/activations/123",
+			expectedResponse: &PolicyActivation{
+				CreatedBy:            "jsmith",
+				CreatedDate:          mustParseTime(t, "2022-10-12T08:07:32.123Z"),
+				FinishDate:           timePtr(mustParseTime(t, "2022-10-12T08:09:32.123Z")),
+				ID:                   123,
+				Network:              StagingNetwork,
+				Operation:            OperationActivation,
+				PolicyID:             1001,
+				Status:               ActivationStatusFailed,
+				PolicyVersion:        2,
+				PolicyVersionDeleted: true,
+				Links:                []Link{},
+			},
+		},
+		"404 not found": {
+			params:         GetPolicyActivationRequest{PolicyID: 1001, ActivationID: 123},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+  "type": "not_found",
+  "title": "Not Found",
+  "detail": "Activation not found",
+  "status": 404
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/activations/123",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				Detail:     "Activation not found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing activation id": {
+			params:    GetPolicyActivationRequest{PolicyID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPolicyActivation(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestActivatePolicy(t *testing.T) {
+	tests := map[string]struct {
+		params              ActivatePolicyRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *PolicyActivation
+		withError           error
+	}{
+		"202 accepted": {
+			params:         ActivatePolicyRequest{PolicyID: 1001, Network: StagingNetwork, PolicyVersion: 2},
+			responseStatus: http.StatusAccepted,
+			responseBody: `
+{
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-12T08:07:32.123Z",
+    "finishDate": null,
+    "id": 125,
+    "network": "STAGING",
+    "operation": "ACTIVATION",
+    "policyId": 1001,
+    "status": "IN_PROGRESS",
+    "policyVersion": 2,
+    "policyVersionDeleted": false,
+    "links": []
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/activations",
+			expectedRequestBody: `{"network":"STAGING","operation":"ACTIVATION","policyVersion":2}`,
+			expectedResponse: &PolicyActivation{
+				CreatedBy:     "jsmith",
+				CreatedDate:   mustParseTime(t, "2022-10-12T08:07:32.123Z"),
+				ID:            125,
+				Network:       StagingNetwork,
+				Operation:     OperationActivation,
+				PolicyID:      1001,
+				Status:        ActivationStatusInProgress,
+				PolicyVersion: 2,
+				Links:         []Link{},
+			},
+		},
+		"400 bad request": {
+			params:         ActivatePolicyRequest{PolicyID: 1001, Network: ProductionNetwork, PolicyVersion: 20},
+			responseStatus: http.StatusBadRequest,
+			responseBody: `
+{
+  "type": "bad_request",
+  "title": "Bad Request",
+  "detail": "Policy version does not exist",
+  "status": 400
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/activations",
+			expectedRequestBody: `{"network":"PRODUCTION","operation":"ACTIVATION","policyVersion":20}`,
+			withError: &Error{
+				Type:       "bad_request",
+				Title:      "Bad Request",
+				Detail:     "Policy version does not exist",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		"validation error - invalid network": {
+			params:    ActivatePolicyRequest{PolicyID: 1001, Network: "prod", PolicyVersion: 2},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ActivatePolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDeactivatePolicy(t *testing.T) {
+	tests := map[string]struct {
+		params              DeactivatePolicyRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *PolicyActivation
+		withError           error
+	}{
+		"202 accepted": {
+			params:         DeactivatePolicyRequest{PolicyID: 1001, Network: ProductionNetwork, PolicyVersion: 2},
+			responseStatus: http.StatusAccepted,
+			responseBody: `
+{
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-12T08:07:32.123Z",
+    "finishDate": null,
+    "id": 126,
+    "network": "PRODUCTION",
+    "operation": "DEACTIVATION",
+    "policyId": 1001,
+    "status": "IN_PROGRESS",
+    "policyVersion": 2,
+    "policyVersionDeleted": false,
+    "links": []
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/activations",
+			expectedRequestBody: `{"network":"PRODUCTION","operation":"DEACTIVATION","policyVersion":2}`,
+			expectedResponse: &PolicyActivation{
+				CreatedBy:     "jsmith",
+				CreatedDate:   mustParseTime(t, "2022-10-12T08:07:32.123Z"),
+				ID:            126,
+				Network:       ProductionNetwork,
+				Operation:     OperationDeactivation,
+				PolicyID:      1001,
+				Status:        ActivationStatusInProgress,
+				PolicyVersion: 2,
+				Links:         []Link{},
+			},
+		},
+		"validation error - missing network": {
+			params:    DeactivatePolicyRequest{PolicyID: 1001, PolicyVersion: 2},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.DeactivatePolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
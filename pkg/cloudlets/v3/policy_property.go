@@ -0,0 +1,101 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PolicyProperties interface is a cloudlets v3 API interface for properties using a shared policy
+	PolicyProperties interface {
+		// ListActivePolicyProperties lists properties which are active on a network and reference the shared policy
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policy-properties
+		ListActivePolicyProperties(context.Context, ListActivePolicyPropertiesRequest) (*ListActivePolicyPropertiesResponse, error)
+	}
+
+	// ListActivePolicyPropertiesRequest contains request parameters for ListActivePolicyProperties
+	ListActivePolicyPropertiesRequest struct {
+		PolicyID int64
+		Page     int
+		Size     int
+	}
+
+	// ListActivePolicyPropertiesResponse contains the response data from ListActivePolicyProperties
+	ListActivePolicyPropertiesResponse struct {
+		Content []ActivePolicyProperty `json:"content"`
+		Links   []Link                 `json:"links"`
+		Page    Page                   `json:"page"`
+	}
+
+	// ActivePolicyProperty contains information about a property which uses the shared policy
+	ActivePolicyProperty struct {
+		GroupID int64             `json:"groupId"`
+		ID      int64             `json:"id"`
+		Name    string            `json:"name"`
+		Network ActivationNetwork `json:"network"`
+		Version int64             `json:"version"`
+	}
+)
+
+var (
+	// ErrListActivePolicyProperties is returned when ListActivePolicyProperties fails
+	ErrListActivePolicyProperties = errors.New("list active policy properties")
+)
+
+// Validate validates ListActivePolicyPropertiesRequest
+func (r ListActivePolicyPropertiesRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+		"Page":     validation.Validate(r.Page, validation.Min(0)),
+		"Size":     validation.Validate(r.Size, validation.Min(10)),
+	})
+}
+
+func (c *cloudletsv3) ListActivePolicyProperties(ctx context.Context, params ListActivePolicyPropertiesRequest) (*ListActivePolicyPropertiesResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListActivePolicyProperties")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrListActivePolicyProperties, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/cloudlets/v3/policies/%d/properties", params.PolicyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListActivePolicyProperties, err)
+	}
+
+	q := uri.Query()
+	if params.Page != 0 {
+		q.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.Size != 0 {
+		q.Add("size", strconv.Itoa(params.Size))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListActivePolicyProperties, err)
+	}
+
+	var result ListActivePolicyPropertiesResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListActivePolicyProperties, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListActivePolicyProperties, c.Error(resp))
+	}
+
+	return &result, nil
+}
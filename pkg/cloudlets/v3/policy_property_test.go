@@ -0,0 +1,116 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListActivePolicyProperties(t *testing.T) {
+	tests := map[string]struct {
+		params           ListActivePolicyPropertiesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListActivePolicyPropertiesResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListActivePolicyPropertiesRequest{PolicyID: 1001},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "content": [
+        {
+            "groupId": 1234,
+            "id": 5678,
+            "name": "www.example.com",
+            "network": "STAGING",
+            "version": 3
+        },
+        {
+            "groupId": 1234,
+            "id": 5678,
+            "name": "www.example.com",
+            "network": "PRODUCTION",
+            "version": 2
+        }
+    ],
+    "links": [],
+    "page": {
+        "number": 0,
+        "size": 1000,
+        "totalElements": 2,
+        "totalPages": 1
+    }
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/properties",
+			expectedResponse: &ListActivePolicyPropertiesResponse{
+				Content: []ActivePolicyProperty{
+					{GroupID: 1234, ID: 5678, Name: "www.example.com", Network: StagingNetwork, Version: 3},
+					{GroupID: 1234, ID: 5678, Name: "www.example.com", Network: ProductionNetwork, Version: 2},
+				},
+				Links: []Link{},
+				Page:  Page{Size: 1000, TotalElements: 2, TotalPages: 1},
+			},
+		},
+		"200 OK with params": {
+			params:         ListActivePolicyPropertiesRequest{PolicyID: 1001, Page: 3, Size: 50},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"content": [], "links": [], "page": {"number": 3, "size": 50, "totalElements": 2, "totalPages": 1}}`,
+			expectedPath:   "/cloudlets/v3/policies/1001/properties?page=3&size=50",
+			expectedResponse: &ListActivePolicyPropertiesResponse{
+				Content: []ActivePolicyProperty{},
+				Links:   []Link{},
+				Page:    Page{Number: 3, Size: 50, TotalElements: 2, TotalPages: 1},
+			},
+		},
+		"500 internal server error": {
+			params:         ListActivePolicyPropertiesRequest{PolicyID: 1001},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error making request",
+  "status": 500
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/properties",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error making request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    ListActivePolicyPropertiesRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListActivePolicyProperties(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
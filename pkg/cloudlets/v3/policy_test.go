@@ -0,0 +1,636 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/tools"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func mustParseTime(t *testing.T, value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, value)
+	require.NoError(t, err)
+	return parsed
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestListPolicies(t *testing.T) {
+	tests := map[string]struct {
+		params           ListPoliciesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListPoliciesResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListPoliciesRequest{},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "content": [
+        {
+            "cloudletType": "ER",
+            "createdBy": "jsmith",
+            "createdDate": "2022-10-11T10:07:32.123Z",
+            "currentActivations": {
+                "production": {
+                    "effective": null,
+                    "latest": null
+                },
+                "staging": {
+                    "effective": {
+                        "createdBy": "jsmith",
+                        "createdDate": "2022-10-12T08:07:32.123Z",
+                        "finishDate": "2022-10-12T08:09:32.123Z",
+                        "id": 123,
+                        "network": "STAGING",
+                        "operation": "ACTIVATION",
+                        "policyId": 1001,
+                        "status": "SUCCESS",
+                        "policyVersion": 2,
+                        "policyVersionDeleted": false,
+                        "links": []
+                    },
+                    "latest": null
+                }
+            },
+            "description": "Test policy",
+            "groupId": 1234,
+            "id": 1001,
+            "links": [
+                {
+                    "href": "/cloudlets/v3/policies/1001",
+                    "rel": "self"
+                }
+            ],
+            "modifiedBy": "jsmith",
+            "modifiedDate": "2022-10-12T08:07:32.123Z",
+            "name": "TestPolicy",
+            "policyType": "SHARED"
+        }
+    ],
+    "links": [],
+    "page": {
+        "number": 0,
+        "size": 1000,
+        "totalElements": 1,
+        "totalPages": 1
+    }
+}`,
+			expectedPath: "/cloudlets/v3/policies",
+			expectedResponse: &ListPoliciesResponse{
+				Content: []Policy{
+					{
+						CloudletType: CloudletTypeER,
+						CreatedBy:    "jsmith",
+						CreatedDate:  mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+						CurrentActivations: CurrentActivations{
+							Staging: ActivationInfo{
+								Effective: &PolicyActivation{
+									CreatedBy:     "jsmith",
+									CreatedDate:   mustParseTime(t, "2022-10-12T08:07:32.123Z"),
+									FinishDate:    timePtr(mustParseTime(t, "2022-10-12T08:09:32.123Z")),
+									ID:            123,
+									Network:       StagingNetwork,
+									Operation:     OperationActivation,
+									PolicyID:      1001,
+									Status:        ActivationStatusSuccess,
+									PolicyVersion: 2,
+									Links:         []Link{},
+								},
+							},
+						},
+						Description:  tools.StringPtr("Test policy"),
+						GroupID:      1234,
+						ID:           1001,
+						Links:        []Link{{Href: "/cloudlets/v3/policies/1001", Rel: "self"}},
+						ModifiedBy:   "jsmith",
+						ModifiedDate: timePtr(mustParseTime(t, "2022-10-12T08:07:32.123Z")),
+						Name:         "TestPolicy",
+						PolicyType:   PolicyTypeShared,
+					},
+				},
+				Links: []Link{},
+				Page:  Page{Size: 1000, TotalElements: 1, TotalPages: 1},
+			},
+		},
+		"200 OK with params": {
+			params:         ListPoliciesRequest{Page: 2, Size: 10},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "content": [],
+    "links": [],
+    "page": {
+        "number": 2,
+        "size": 10,
+        "totalElements": 20,
+        "totalPages": 2
+    }
+}`,
+			expectedPath: "/cloudlets/v3/policies?page=2&size=10",
+			expectedResponse: &ListPoliciesResponse{
+				Content: []Policy{},
+				Links:   []Link{},
+				Page:    Page{Number: 2, Size: 10, TotalElements: 20, TotalPages: 2},
+			},
+		},
+		"500 internal server error": {
+			params:         ListPoliciesRequest{},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error making request",
+  "status": 500
+}`,
+			expectedPath: "/cloudlets/v3/policies",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error making request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - size too small": {
+			params:    ListPoliciesRequest{Size: 5},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListPolicies(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetPolicy(t *testing.T) {
+	tests := map[string]struct {
+		params           GetPolicyRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *Policy
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetPolicyRequest{PolicyID: 1001},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "cloudletType": "AP",
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "currentActivations": {
+        "production": {
+            "effective": null,
+            "latest": null
+        },
+        "staging": {
+            "effective": null,
+            "latest": null
+        }
+    },
+    "description": null,
+    "groupId": 1234,
+    "id": 1001,
+    "links": [],
+    "modifiedBy": "jsmith",
+    "name": "TestPolicy",
+    "policyType": "SHARED"
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001",
+			expectedResponse: &Policy{
+				CloudletType: CloudletTypeAP,
+				CreatedBy:    "jsmith",
+				CreatedDate:  mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				GroupID:      1234,
+				ID:           1001,
+				Links:        []Link{},
+				ModifiedBy:   "jsmith",
+				Name:         "TestPolicy",
+				PolicyType:   PolicyTypeShared,
+			},
+		},
+		"404 not found": {
+			params:         GetPolicyRequest{PolicyID: 1001},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+  "type": "not_found",
+  "title": "Not Found",
+  "detail": "Policy not found",
+  "status": 404
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				Detail:     "Policy not found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    GetPolicyRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreatePolicy(t *testing.T) {
+	tests := map[string]struct {
+		params              CreatePolicyRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *Policy
+		withError           error
+	}{
+		"201 created": {
+			params: CreatePolicyRequest{
+				CloudletType: CloudletTypeFR,
+				Description:  tools.StringPtr("Forward rewrite"),
+				GroupID:      1234,
+				Name:         "TestPolicy",
+			},
+			responseStatus: http.StatusCreated,
+			responseBody: `
+{
+    "cloudletType": "FR",
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "currentActivations": {
+        "production": {
+            "effective": null,
+            "latest": null
+        },
+        "staging": {
+            "effective": null,
+            "latest": null
+        }
+    },
+    "description": "Forward rewrite",
+    "groupId": 1234,
+    "id": 1001,
+    "links": [],
+    "modifiedBy": "jsmith",
+    "name": "TestPolicy",
+    "policyType": "SHARED"
+}`,
+			expectedPath:        "/cloudlets/v3/policies",
+			expectedRequestBody: `{"cloudletType":"FR","description":"Forward rewrite","groupId":1234,"name":"TestPolicy","policyType":"SHARED"}`,
+			expectedResponse: &Policy{
+				CloudletType: CloudletTypeFR,
+				CreatedBy:    "jsmith",
+				CreatedDate:  mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				Description:  tools.StringPtr("Forward rewrite"),
+				GroupID:      1234,
+				ID:           1001,
+				Links:        []Link{},
+				ModifiedBy:   "jsmith",
+				Name:         "TestPolicy",
+				PolicyType:   PolicyTypeShared,
+			},
+		},
+		"500 internal server error": {
+			params: CreatePolicyRequest{
+				CloudletType: CloudletTypeFR,
+				GroupID:      1234,
+				Name:         "TestPolicy",
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error creating policy",
+  "status": 500
+}`,
+			expectedPath:        "/cloudlets/v3/policies",
+			expectedRequestBody: `{"cloudletType":"FR","groupId":1234,"name":"TestPolicy","policyType":"SHARED"}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating policy",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing required fields": {
+			params:    CreatePolicyRequest{},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid cloudlet type and name": {
+			params: CreatePolicyRequest{
+				CloudletType: "VP",
+				GroupID:      1234,
+				Name:         "invalid name",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreatePolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	tests := map[string]struct {
+		params              UpdatePolicyRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *Policy
+		withError           error
+	}{
+		"200 OK": {
+			params: UpdatePolicyRequest{
+				PolicyID: 1001,
+				BodyParams: UpdatePolicyBodyParams{
+					GroupID:     5678,
+					Description: tools.StringPtr("Updated"),
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "cloudletType": "FR",
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "description": "Updated",
+    "groupId": 5678,
+    "id": 1001,
+    "links": [],
+    "modifiedBy": "jsmith",
+    "name": "TestPolicy",
+    "policyType": "SHARED"
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001",
+			expectedRequestBody: `{"groupId":5678,"description":"Updated"}`,
+			expectedResponse: &Policy{
+				CloudletType: CloudletTypeFR,
+				CreatedBy:    "jsmith",
+				CreatedDate:  mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				Description:  tools.StringPtr("Updated"),
+				GroupID:      5678,
+				ID:           1001,
+				Links:        []Link{},
+				ModifiedBy:   "jsmith",
+				Name:         "TestPolicy",
+				PolicyType:   PolicyTypeShared,
+			},
+		},
+		"500 internal server error": {
+			params: UpdatePolicyRequest{
+				PolicyID:   1001,
+				BodyParams: UpdatePolicyBodyParams{GroupID: 5678},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error updating policy",
+  "status": 500
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001",
+			expectedRequestBody: `{"groupId":5678}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error updating policy",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing group id": {
+			params:    UpdatePolicyRequest{PolicyID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdatePolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestClonePolicy(t *testing.T) {
+	tests := map[string]struct {
+		params              ClonePolicyRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *Policy
+		withError           error
+	}{
+		"200 OK": {
+			params: ClonePolicyRequest{
+				PolicyID: 1001,
+				BodyParams: ClonePolicyBodyParams{
+					AdditionalVersions: []int64{1, 2},
+					GroupID:            5678,
+					NewName:            "ClonedPolicy",
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "cloudletType": "FR",
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "description": null,
+    "groupId": 5678,
+    "id": 1002,
+    "links": [],
+    "modifiedBy": "jsmith",
+    "name": "ClonedPolicy",
+    "policyType": "SHARED"
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/clone",
+			expectedRequestBody: `{"additionalVersions":[1,2],"groupId":5678,"newName":"ClonedPolicy"}`,
+			expectedResponse: &Policy{
+				CloudletType: CloudletTypeFR,
+				CreatedBy:    "jsmith",
+				CreatedDate:  mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				GroupID:      5678,
+				ID:           1002,
+				Links:        []Link{},
+				ModifiedBy:   "jsmith",
+				Name:         "ClonedPolicy",
+				PolicyType:   PolicyTypeShared,
+			},
+		},
+		"validation error - missing new name": {
+			params:    ClonePolicyRequest{PolicyID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ClonePolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDeletePolicy(t *testing.T) {
+	tests := map[string]struct {
+		params         DeletePolicyRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		withError      error
+	}{
+		"204 no content": {
+			params:         DeletePolicyRequest{PolicyID: 1001},
+			responseStatus: http.StatusNoContent,
+			expectedPath:   "/cloudlets/v3/policies/1001",
+		},
+		"403 forbidden - policy is active": {
+			params:         DeletePolicyRequest{PolicyID: 1001},
+			responseStatus: http.StatusForbidden,
+			responseBody: `
+{
+  "type": "forbidden",
+  "title": "Forbidden",
+  "detail": "Policy is active",
+  "status": 403
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001",
+			withError: &Error{
+				Type:       "forbidden",
+				Title:      "Forbidden",
+				Detail:     "Policy is active",
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    DeletePolicyRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DeletePolicy(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
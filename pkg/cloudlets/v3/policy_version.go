@@ -0,0 +1,337 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/cloudlets"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PolicyVersions is a cloudlets v3 shared policy versions API interface
+	PolicyVersions interface {
+		// ListPolicyVersions lists versions of a shared policy, without match rules
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policy-versions
+		ListPolicyVersions(context.Context, ListPolicyVersionsRequest) (*ListPolicyVersionsResponse, error)
+
+		// GetPolicyVersion gets shared policy version with its match rules
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/get-policy-version
+		GetPolicyVersion(context.Context, GetPolicyVersionRequest) (*PolicyVersion, error)
+
+		// CreatePolicyVersion creates shared policy version
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/post-policy-version
+		CreatePolicyVersion(context.Context, CreatePolicyVersionRequest) (*PolicyVersion, error)
+
+		// UpdatePolicyVersion updates shared policy version which has not been activated yet
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/put-policy-version
+		UpdatePolicyVersion(context.Context, UpdatePolicyVersionRequest) (*PolicyVersion, error)
+
+		// DeletePolicyVersion deletes shared policy version which has not been activated yet
+		//
+		// See: https://techdocs.akamai.com/cloudlets/reference/delete-policy-version
+		DeletePolicyVersion(context.Context, DeletePolicyVersionRequest) error
+	}
+
+	// PolicyVersion represents a shared policy version. Match rules reuse the v2 MatchRules types,
+	// which have the same shape in both API versions
+	PolicyVersion struct {
+		CreatedBy          string               `json:"createdBy"`
+		CreatedDate        time.Time            `json:"createdDate"`
+		Description        *string              `json:"description"`
+		ID                 int64                `json:"id"`
+		Immutable          bool                 `json:"immutable"`
+		MatchRules         cloudlets.MatchRules `json:"matchRules"`
+		MatchRulesWarnings []MatchRulesWarning  `json:"matchRulesWarnings"`
+		ModifiedBy         string               `json:"modifiedBy"`
+		ModifiedDate       *time.Time           `json:"modifiedDate,omitempty"`
+		PolicyID           int64                `json:"policyId"`
+		PolicyVersion      int64                `json:"version"`
+	}
+
+	// ListPolicyVersionsItem represents a shared policy version returned by ListPolicyVersions
+	ListPolicyVersionsItem struct {
+		CreatedBy     string     `json:"createdBy"`
+		CreatedDate   time.Time  `json:"createdDate"`
+		Description   *string    `json:"description"`
+		ID            int64      `json:"id"`
+		Immutable     bool       `json:"immutable"`
+		Links         []Link     `json:"links"`
+		ModifiedBy    string     `json:"modifiedBy"`
+		ModifiedDate  *time.Time `json:"modifiedDate,omitempty"`
+		PolicyID      int64      `json:"policyId"`
+		PolicyVersion int64      `json:"version"`
+	}
+
+	// ListPolicyVersionsResponse contains the response data from ListPolicyVersions
+	ListPolicyVersionsResponse struct {
+		PolicyVersions []ListPolicyVersionsItem `json:"content"`
+		Links          []Link                   `json:"links"`
+		Page           Page                     `json:"page"`
+	}
+
+	// MatchRulesWarning describes a problem found in the match rules of a policy version
+	MatchRulesWarning struct {
+		Detail      string `json:"detail"`
+		JSONPointer string `json:"jsonPointer,omitempty"`
+		Title       string `json:"title"`
+		Type        string `json:"type"`
+	}
+
+	// ListPolicyVersionsRequest contains request parameters for ListPolicyVersions
+	ListPolicyVersionsRequest struct {
+		PolicyID int64
+		Page     int
+		Size     int
+	}
+
+	// GetPolicyVersionRequest contains request parameters for GetPolicyVersion
+	GetPolicyVersionRequest struct {
+		PolicyID      int64
+		PolicyVersion int64
+	}
+
+	// CreatePolicyVersionRequest contains request parameters for CreatePolicyVersion
+	CreatePolicyVersionRequest struct {
+		PolicyID int64
+		PolicyVersionBody
+	}
+
+	// UpdatePolicyVersionRequest contains request parameters for UpdatePolicyVersion
+	UpdatePolicyVersionRequest struct {
+		PolicyID      int64
+		PolicyVersion int64
+		PolicyVersionBody
+	}
+
+	// PolicyVersionBody contains the request body of CreatePolicyVersion and UpdatePolicyVersion
+	PolicyVersionBody struct {
+		Description *string              `json:"description,omitempty"`
+		MatchRules  cloudlets.MatchRules `json:"matchRules"`
+	}
+
+	// DeletePolicyVersionRequest contains request parameters for DeletePolicyVersion
+	DeletePolicyVersionRequest struct {
+		PolicyID      int64
+		PolicyVersion int64
+	}
+)
+
+var (
+	// ErrListPolicyVersions is returned when ListPolicyVersions fails
+	ErrListPolicyVersions = errors.New("list shared policy versions")
+	// ErrGetPolicyVersion is returned when GetPolicyVersion fails
+	ErrGetPolicyVersion = errors.New("get shared policy version")
+	// ErrCreatePolicyVersion is returned when CreatePolicyVersion fails
+	ErrCreatePolicyVersion = errors.New("create shared policy version")
+	// ErrUpdatePolicyVersion is returned when UpdatePolicyVersion fails
+	ErrUpdatePolicyVersion = errors.New("update shared policy version")
+	// ErrDeletePolicyVersion is returned when DeletePolicyVersion fails
+	ErrDeletePolicyVersion = errors.New("delete shared policy version")
+)
+
+// Validate validates ListPolicyVersionsRequest
+func (r ListPolicyVersionsRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID": validation.Validate(r.PolicyID, validation.Required),
+		"Page":     validation.Validate(r.Page, validation.Min(0)),
+		"Size":     validation.Validate(r.Size, validation.Min(10)),
+	})
+}
+
+// Validate validates GetPolicyVersionRequest
+func (r GetPolicyVersionRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":      validation.Validate(r.PolicyID, validation.Required),
+		"PolicyVersion": validation.Validate(r.PolicyVersion, validation.Required),
+	})
+}
+
+// Validate validates CreatePolicyVersionRequest
+func (r CreatePolicyVersionRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":    validation.Validate(r.PolicyID, validation.Required),
+		"Description": validation.Validate(r.Description, validation.Length(0, 255)),
+		"MatchRules":  validation.Validate(r.MatchRules, validation.Length(0, 5000)),
+	})
+}
+
+// Validate validates UpdatePolicyVersionRequest
+func (r UpdatePolicyVersionRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":      validation.Validate(r.PolicyID, validation.Required),
+		"PolicyVersion": validation.Validate(r.PolicyVersion, validation.Required),
+		"Description":   validation.Validate(r.Description, validation.Length(0, 255)),
+		"MatchRules":    validation.Validate(r.MatchRules, validation.Length(0, 5000)),
+	})
+}
+
+// Validate validates DeletePolicyVersionRequest
+func (r DeletePolicyVersionRequest) Validate() error {
+	return edgegriderr.ParseValidationErrors(validation.Errors{
+		"PolicyID":      validation.Validate(r.PolicyID, validation.Required),
+		"PolicyVersion": validation.Validate(r.PolicyVersion, validation.Required),
+	})
+}
+
+func (c *cloudletsv3) ListPolicyVersions(ctx context.Context, params ListPolicyVersionsRequest) (*ListPolicyVersionsResponse, error) {
+	logger := c.Log(ctx)
+	logger.Debug("ListPolicyVersions")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrListPolicyVersions, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/cloudlets/v3/policies/%d/versions", params.PolicyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListPolicyVersions, err)
+	}
+
+	q := uri.Query()
+	if params.Page != 0 {
+		q.Add("page", strconv.Itoa(params.Page))
+	}
+	if params.Size != 0 {
+		q.Add("size", strconv.Itoa(params.Size))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListPolicyVersions, err)
+	}
+
+	var result ListPolicyVersionsResponse
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListPolicyVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListPolicyVersions, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) GetPolicyVersion(ctx context.Context, params GetPolicyVersionRequest) (*PolicyVersion, error) {
+	logger := c.Log(ctx)
+	logger.Debug("GetPolicyVersion")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrGetPolicyVersion, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/versions/%d", params.PolicyID, params.PolicyVersion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPolicyVersion, err)
+	}
+
+	var result PolicyVersion
+	resp, err := c.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPolicyVersion, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetPolicyVersion, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) CreatePolicyVersion(ctx context.Context, params CreatePolicyVersionRequest) (*PolicyVersion, error) {
+	logger := c.Log(ctx)
+	logger.Debug("CreatePolicyVersion")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreatePolicyVersion, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/versions", params.PolicyID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreatePolicyVersion, err)
+	}
+
+	var result PolicyVersion
+	resp, err := c.Exec(req, &result, params.PolicyVersionBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreatePolicyVersion, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreatePolicyVersion, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) UpdatePolicyVersion(ctx context.Context, params UpdatePolicyVersionRequest) (*PolicyVersion, error) {
+	logger := c.Log(ctx)
+	logger.Debug("UpdatePolicyVersion")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrUpdatePolicyVersion, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/versions/%d", params.PolicyID, params.PolicyVersion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdatePolicyVersion, err)
+	}
+
+	var result PolicyVersion
+	resp, err := c.Exec(req, &result, params.PolicyVersionBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdatePolicyVersion, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdatePolicyVersion, c.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (c *cloudletsv3) DeletePolicyVersion(ctx context.Context, params DeletePolicyVersionRequest) error {
+	logger := c.Log(ctx)
+	logger.Debug("DeletePolicyVersion")
+
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w:\n%s", ErrDeletePolicyVersion, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/cloudlets/v3/policies/%d/versions/%d", params.PolicyID, params.PolicyVersion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeletePolicyVersion, err)
+	}
+
+	resp, err := c.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeletePolicyVersion, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrDeletePolicyVersion, c.Error(resp))
+	}
+
+	return nil
+}
@@ -0,0 +1,501 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/cloudlets"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/tools"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListPolicyVersions(t *testing.T) {
+	tests := map[string]struct {
+		params           ListPolicyVersionsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListPolicyVersionsResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListPolicyVersionsRequest{PolicyID: 1001, Size: 10},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "content": [
+        {
+            "createdBy": "jsmith",
+            "createdDate": "2022-10-11T10:07:32.123Z",
+            "description": "First version",
+            "id": 6551191,
+            "immutable": true,
+            "links": [],
+            "modifiedBy": "jsmith",
+            "modifiedDate": "2022-10-11T10:07:32.123Z",
+            "policyId": 1001,
+            "version": 1
+        }
+    ],
+    "links": [],
+    "page": {
+        "number": 0,
+        "size": 10,
+        "totalElements": 1,
+        "totalPages": 1
+    }
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/versions?size=10",
+			expectedResponse: &ListPolicyVersionsResponse{
+				PolicyVersions: []ListPolicyVersionsItem{
+					{
+						CreatedBy:     "jsmith",
+						CreatedDate:   mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+						Description:   tools.StringPtr("First version"),
+						ID:            6551191,
+						Immutable:     true,
+						Links:         []Link{},
+						ModifiedBy:    "jsmith",
+						ModifiedDate:  timePtr(mustParseTime(t, "2022-10-11T10:07:32.123Z")),
+						PolicyID:      1001,
+						PolicyVersion: 1,
+					},
+				},
+				Links: []Link{},
+				Page:  Page{Size: 10, TotalElements: 1, TotalPages: 1},
+			},
+		},
+		"500 internal server error": {
+			params:         ListPolicyVersionsRequest{PolicyID: 1001},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error making request",
+  "status": 500
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/versions",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error making request",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    ListPolicyVersionsRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListPolicyVersions(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetPolicyVersion(t *testing.T) {
+	tests := map[string]struct {
+		params           GetPolicyVersionRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *PolicyVersion
+		withError        error
+	}{
+		"200 OK with ER match rules": {
+			params:         GetPolicyVersionRequest{PolicyID: 1001, PolicyVersion: 2},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "description": null,
+    "id": 6551192,
+    "immutable": false,
+    "matchRules": [
+        {
+            "type": "erMatchRule",
+            "end": 0,
+            "id": 0,
+            "matchURL": "/old",
+            "name": "redirect",
+            "redirectURL": "/new",
+            "start": 0,
+            "statusCode": 301,
+            "useIncomingQueryString": false,
+            "useRelativeUrl": "relative_url"
+        }
+    ],
+    "matchRulesWarnings": [
+        {
+            "detail": "Rule is never matched",
+            "jsonPointer": "/matchRules/0",
+            "title": "Unreachable rule",
+            "type": "/cloudlets/error-types/unreachable-rule"
+        }
+    ],
+    "modifiedBy": "jsmith",
+    "policyId": 1001,
+    "version": 2
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/versions/2",
+			expectedResponse: &PolicyVersion{
+				CreatedBy:   "jsmith",
+				CreatedDate: mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				ID:          6551192,
+				MatchRules: cloudlets.MatchRules{
+					&cloudlets.MatchRuleER{
+						Type:           "erMatchRule",
+						MatchURL:       "/old",
+						Name:           "redirect",
+						RedirectURL:    "/new",
+						StatusCode:     301,
+						UseRelativeURL: "relative_url",
+					},
+				},
+				MatchRulesWarnings: []MatchRulesWarning{
+					{
+						Detail:      "Rule is never matched",
+						JSONPointer: "/matchRules/0",
+						Title:       "Unreachable rule",
+						Type:        "/cloudlets/error-types/unreachable-rule",
+					},
+				},
+				ModifiedBy:    "jsmith",
+				PolicyID:      1001,
+				PolicyVersion: 2,
+			},
+		},
+		"404 not found": {
+			params:         GetPolicyVersionRequest{PolicyID: 1001, PolicyVersion: 2},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+  "type": "not_found",
+  "title": "Not Found",
+  "detail": "Policy version not found",
+  "status": 404
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/versions/2",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				Detail:     "Policy version not found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing version": {
+			params:    GetPolicyVersionRequest{PolicyID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPolicyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreatePolicyVersion(t *testing.T) {
+	tests := map[string]struct {
+		params              CreatePolicyVersionRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *PolicyVersion
+		withError           error
+	}{
+		"201 created": {
+			params: CreatePolicyVersionRequest{
+				PolicyID: 1001,
+				PolicyVersionBody: PolicyVersionBody{
+					Description: tools.StringPtr("New version"),
+					MatchRules: cloudlets.MatchRules{
+						&cloudlets.MatchRuleER{
+							Type:        "erMatchRule",
+							Name:        "redirect",
+							MatchURL:    "/old",
+							RedirectURL: "/new",
+							StatusCode:  302,
+						},
+					},
+				},
+			},
+			responseStatus: http.StatusCreated,
+			responseBody: `
+{
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "description": "New version",
+    "id": 6551193,
+    "immutable": false,
+    "matchRules": [
+        {
+            "type": "erMatchRule",
+            "matchURL": "/old",
+            "name": "redirect",
+            "redirectURL": "/new",
+            "statusCode": 302
+        }
+    ],
+    "matchRulesWarnings": [],
+    "modifiedBy": "jsmith",
+    "policyId": 1001,
+    "version": 3
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/versions",
+			expectedRequestBody: `{"description":"New version","matchRules":[{"name":"redirect","type":"erMatchRule","statusCode":302,"redirectURL":"/new","matchURL":"/old","useIncomingQueryString":false,"useIncomingSchemeAndHost":false}]}`,
+			expectedResponse: &PolicyVersion{
+				CreatedBy:   "jsmith",
+				CreatedDate: mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				Description: tools.StringPtr("New version"),
+				ID:          6551193,
+				MatchRules: cloudlets.MatchRules{
+					&cloudlets.MatchRuleER{
+						Type:        "erMatchRule",
+						MatchURL:    "/old",
+						Name:        "redirect",
+						RedirectURL: "/new",
+						StatusCode:  302,
+					},
+				},
+				MatchRulesWarnings: []MatchRulesWarning{},
+				ModifiedBy:         "jsmith",
+				PolicyID:           1001,
+				PolicyVersion:      3,
+			},
+		},
+		"500 internal server error": {
+			params:         CreatePolicyVersionRequest{PolicyID: 1001},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error creating version",
+  "status": 500
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/versions",
+			expectedRequestBody: `{"matchRules":null}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating version",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    CreatePolicyVersionRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreatePolicyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdatePolicyVersion(t *testing.T) {
+	tests := map[string]struct {
+		params              UpdatePolicyVersionRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *PolicyVersion
+		withError           error
+	}{
+		"200 OK": {
+			params: UpdatePolicyVersionRequest{
+				PolicyID:          1001,
+				PolicyVersion:     3,
+				PolicyVersionBody: PolicyVersionBody{Description: tools.StringPtr("Updated")},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "createdBy": "jsmith",
+    "createdDate": "2022-10-11T10:07:32.123Z",
+    "description": "Updated",
+    "id": 6551193,
+    "immutable": false,
+    "matchRules": null,
+    "modifiedBy": "jsmith",
+    "policyId": 1001,
+    "version": 3
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/versions/3",
+			expectedRequestBody: `{"description":"Updated","matchRules":null}`,
+			expectedResponse: &PolicyVersion{
+				CreatedBy:     "jsmith",
+				CreatedDate:   mustParseTime(t, "2022-10-11T10:07:32.123Z"),
+				Description:   tools.StringPtr("Updated"),
+				ID:            6551193,
+				ModifiedBy:    "jsmith",
+				PolicyID:      1001,
+				PolicyVersion: 3,
+			},
+		},
+		"403 forbidden - version is immutable": {
+			params:         UpdatePolicyVersionRequest{PolicyID: 1001, PolicyVersion: 1},
+			responseStatus: http.StatusForbidden,
+			responseBody: `
+{
+  "type": "forbidden",
+  "title": "Forbidden",
+  "detail": "Version is immutable",
+  "status": 403
+}`,
+			expectedPath:        "/cloudlets/v3/policies/1001/versions/1",
+			expectedRequestBody: `{"matchRules":null}`,
+			withError: &Error{
+				Type:       "forbidden",
+				Title:      "Forbidden",
+				Detail:     "Version is immutable",
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		"validation error - missing version": {
+			params:    UpdatePolicyVersionRequest{PolicyID: 1001},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdatePolicyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestDeletePolicyVersion(t *testing.T) {
+	tests := map[string]struct {
+		params         DeletePolicyVersionRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		withError      error
+	}{
+		"204 no content": {
+			params:         DeletePolicyVersionRequest{PolicyID: 1001, PolicyVersion: 3},
+			responseStatus: http.StatusNoContent,
+			expectedPath:   "/cloudlets/v3/policies/1001/versions/3",
+		},
+		"500 internal server error": {
+			params:         DeletePolicyVersionRequest{PolicyID: 1001, PolicyVersion: 3},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+  "type": "internal_error",
+  "title": "Internal Server Error",
+  "detail": "Error deleting version",
+  "status": 500
+}`,
+			expectedPath: "/cloudlets/v3/policies/1001/versions/3",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error deleting version",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing policy id": {
+			params:    DeletePolicyVersionRequest{PolicyVersion: 3},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DeletePolicyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
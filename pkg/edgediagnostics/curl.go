@@ -0,0 +1,136 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type (
+	// Curl is an edge diagnostics curl API interface
+	Curl interface {
+		// Curl runs the curl command from an edge server to get HTTP response details for a URL
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/post-curl
+		Curl(context.Context, CurlRequest) (*CurlResponse, error)
+	}
+
+	// CurlRequest contains request body used to run the curl command
+	CurlRequest struct {
+		URL            string    `json:"url"`
+		UserAgent      UserAgent `json:"userAgent,omitempty"`
+		EdgeLocationID string    `json:"edgeLocationId,omitempty"`
+		EdgeIP         string    `json:"edgeIp,omitempty"`
+		IPVersion      IPVersion `json:"ipVersion,omitempty"`
+		RequestHeaders []string  `json:"requestHeaders,omitempty"`
+	}
+
+	// CurlResponse represents a response object returned by Curl
+	CurlResponse struct {
+		CurlResults     CurlResults `json:"curlResults"`
+		InternalIP      string      `json:"internalIp,omitempty"`
+		ExecutionStatus string      `json:"executionStatus,omitempty"`
+	}
+
+	// CurlResults contains the result of the curl command
+	CurlResults struct {
+		HTTPStatusCode  int               `json:"httpStatusCode"`
+		ResponseHeaders map[string]string `json:"responseHeaders,omitempty"`
+		ResponseBody    string            `json:"responseBody,omitempty"`
+		Timing          *CurlTiming       `json:"timing,omitempty"`
+	}
+
+	// CurlTiming contains timing information of the curl request, in milliseconds
+	CurlTiming struct {
+		NameLookupTime    float64 `json:"nameLookupTime"`
+		ConnectTime       float64 `json:"connectTime"`
+		AppConnectTime    float64 `json:"appConnectTime"`
+		PreTransferTime   float64 `json:"preTransferTime"`
+		StartTransferTime float64 `json:"startTransferTime"`
+		RedirectTime      float64 `json:"redirectTime"`
+		TotalTime         float64 `json:"totalTime"`
+	}
+
+	// UserAgent represents the client type simulated by the curl request
+	UserAgent string
+
+	// IPVersion represents the IP version used for the request
+	IPVersion string
+)
+
+const (
+	// UserAgentAndroid simulates an Android phone
+	UserAgentAndroid UserAgent = "ANDROID_PHONE"
+	// UserAgentIPhone simulates an iPhone
+	UserAgentIPhone UserAgent = "IPHONE"
+	// UserAgentIPad simulates an iPad
+	UserAgentIPad UserAgent = "IPAD"
+	// UserAgentChromeWindows simulates Chrome on Windows
+	UserAgentChromeWindows UserAgent = "CHROME_WINDOWS"
+	// UserAgentFirefoxWindows simulates Firefox on Windows
+	UserAgentFirefoxWindows UserAgent = "FIREFOX_WINDOWS"
+	// UserAgentEdgeWindows simulates Edge on Windows
+	UserAgentEdgeWindows UserAgent = "EDGE_WINDOWS"
+	// UserAgentSafariMac simulates Safari on macOS
+	UserAgentSafariMac UserAgent = "SAFARI_MAC"
+	// UserAgentChromeMac simulates Chrome on macOS
+	UserAgentChromeMac UserAgent = "CHROME_MAC"
+	// UserAgentFirefoxMac simulates Firefox on macOS
+	UserAgentFirefoxMac UserAgent = "FIREFOX_MAC"
+
+	// IPVersionIPv4 is IPv4
+	IPVersionIPv4 IPVersion = "IPV4"
+	// IPVersionIPv6 is IPv6
+	IPVersionIPv6 IPVersion = "IPV6"
+)
+
+var (
+	// ErrCurl is returned when Curl fails
+	ErrCurl = errors.New("curl")
+)
+
+// Validate validates CurlRequest
+func (r CurlRequest) Validate() error {
+	return validation.Errors{
+		"URL": validation.Validate(r.URL, validation.Required, is.URL),
+		"UserAgent": validation.Validate(r.UserAgent, validation.In(UserAgentAndroid, UserAgentIPhone, UserAgentIPad,
+			UserAgentChromeWindows, UserAgentFirefoxWindows, UserAgentEdgeWindows, UserAgentSafariMac, UserAgentChromeMac,
+			UserAgentFirefoxMac).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: 'ANDROID_PHONE', 'IPHONE', 'IPAD', 'CHROME_WINDOWS', "+
+				"'FIREFOX_WINDOWS', 'EDGE_WINDOWS', 'SAFARI_MAC', 'CHROME_MAC' or 'FIREFOX_MAC'", r.UserAgent))),
+		"IPVersion": validation.Validate(r.IPVersion, validation.In(IPVersionIPv4, IPVersionIPv6).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", r.IPVersion, IPVersionIPv4, IPVersionIPv6))),
+		"EdgeIP": validation.Validate(r.EdgeIP, is.IP, validation.When(r.EdgeLocationID != "", validation.Empty.Error(
+			"cannot be provided together with EdgeLocationID"))),
+	}.Filter()
+}
+
+func (e *edgediagnostics) Curl(ctx context.Context, params CurlRequest) (*CurlResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("Curl")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCurl, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/edge-diagnostics/v1/curl", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCurl, err)
+	}
+
+	var result CurlResponse
+	resp, err := e.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCurl, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrCurl, e.Error(resp))
+	}
+
+	return &result, nil
+}
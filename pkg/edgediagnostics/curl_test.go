@@ -0,0 +1,105 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestCurl(t *testing.T) {
+	tests := map[string]struct {
+		params              CurlRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *CurlResponse
+		withError           error
+	}{
+		"200 OK": {
+			params: CurlRequest{
+				URL:            "https://www.example.com/index.html",
+				UserAgent:      UserAgentChromeMac,
+				EdgeIP:         "1.2.3.4",
+				IPVersion:      IPVersionIPv4,
+				RequestHeaders: []string{"Pragma: akamai-x-cache-on"},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "curlResults": {
+        "httpStatusCode": 200,
+        "responseHeaders": {
+            "X-Cache": "TCP_HIT from a1-2-3-4"
+        },
+        "responseBody": "<html></html>",
+        "timing": {
+            "nameLookupTime": 1.5,
+            "connectTime": 2.5,
+            "totalTime": 10
+        }
+    }
+}`,
+			expectedRequestBody: `{"url":"https://www.example.com/index.html","userAgent":"CHROME_MAC","edgeIp":"1.2.3.4","ipVersion":"IPV4","requestHeaders":["Pragma: akamai-x-cache-on"]}`,
+			expectedResponse: &CurlResponse{
+				CurlResults: CurlResults{
+					HTTPStatusCode:  200,
+					ResponseHeaders: map[string]string{"X-Cache": "TCP_HIT from a1-2-3-4"},
+					ResponseBody:    "<html></html>",
+					Timing: &CurlTiming{
+						NameLookupTime: 1.5,
+						ConnectTime:    2.5,
+						TotalTime:      10,
+					},
+				},
+			},
+		},
+		"validation error - invalid URL": {
+			params:    CurlRequest{URL: "not a url"},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid user agent": {
+			params:    CurlRequest{URL: "https://www.example.com", UserAgent: "LYNX"},
+			withError: ErrStructValidation,
+		},
+		"500 internal server error": {
+			params:              CurlRequest{URL: "https://www.example.com"},
+			responseStatus:      http.StatusInternalServerError,
+			responseBody:        `{"type": "internal-error", "title": "Internal Server Error", "status": 500}`,
+			expectedRequestBody: `{"url":"https://www.example.com"}`,
+			withError: &Error{
+				Type:   "internal-error",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/edge-diagnostics/v1/curl", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.Curl(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
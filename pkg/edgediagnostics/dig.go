@@ -0,0 +1,128 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Dig is an edge diagnostics dig API interface
+	Dig interface {
+		// Dig runs the dig command from an edge server to get DNS details for a hostname
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/post-dig
+		Dig(context.Context, DigRequest) (*DigResponse, error)
+	}
+
+	// DigRequest contains request body used to run the dig command
+	DigRequest struct {
+		Hostname       string       `json:"hostname"`
+		QueryType      DigQueryType `json:"queryType,omitempty"`
+		EdgeLocationID string       `json:"edgeLocationId,omitempty"`
+		EdgeIP         string       `json:"edgeIp,omitempty"`
+		IsGTMHostname  bool         `json:"isGtmHostname,omitempty"`
+	}
+
+	// DigResponse represents a response object returned by Dig
+	DigResponse struct {
+		DigInfo         DigInfo `json:"digInfo"`
+		InternalIP      string  `json:"internalIp,omitempty"`
+		ExecutionStatus string  `json:"executionStatus,omitempty"`
+	}
+
+	// DigInfo contains the result of the dig command
+	DigInfo struct {
+		Hostname         string         `json:"hostname"`
+		QueryType        DigQueryType   `json:"queryType"`
+		AnswerSection    []DigRecord    `json:"answerSection,omitempty"`
+		AuthoritySection []DigRecord    `json:"authoritySection,omitempty"`
+		Result           string         `json:"result"`
+		IsCname          bool           `json:"isCname,omitempty"`
+		SuggestedActions []string       `json:"suggestedActions,omitempty"`
+		EdgeIP           string         `json:"edgeIp,omitempty"`
+		Errors           []ErrorEntry   `json:"errors,omitempty"`
+		EdgeLocation     *EdgeLocation  `json:"edgeLocation,omitempty"`
+		Metadata         map[string]int `json:"metadata,omitempty"`
+	}
+
+	// DigRecord represents a single DNS record returned by dig
+	DigRecord struct {
+		Domain           string `json:"domain"`
+		TTL              int    `json:"ttl"`
+		RecordClass      string `json:"recordClass"`
+		RecordType       string `json:"recordType"`
+		PreferenceValues string `json:"preferenceValues,omitempty"`
+		Value            string `json:"value"`
+	}
+
+	// DigQueryType represents the DNS record type queried by dig
+	DigQueryType string
+)
+
+const (
+	// DigQueryTypeA is the A record type
+	DigQueryTypeA DigQueryType = "A"
+	// DigQueryTypeAAAA is the AAAA record type
+	DigQueryTypeAAAA DigQueryType = "AAAA"
+	// DigQueryTypeCNAME is the CNAME record type
+	DigQueryTypeCNAME DigQueryType = "CNAME"
+	// DigQueryTypeMX is the MX record type
+	DigQueryTypeMX DigQueryType = "MX"
+	// DigQueryTypeNS is the NS record type
+	DigQueryTypeNS DigQueryType = "NS"
+	// DigQueryTypePTR is the PTR record type
+	DigQueryTypePTR DigQueryType = "PTR"
+	// DigQueryTypeSOA is the SOA record type
+	DigQueryTypeSOA DigQueryType = "SOA"
+	// DigQueryTypeTXT is the TXT record type
+	DigQueryTypeTXT DigQueryType = "TXT"
+	// DigQueryTypeCAA is the CAA record type
+	DigQueryTypeCAA DigQueryType = "CAA"
+)
+
+var (
+	// ErrDig is returned when Dig fails
+	ErrDig = errors.New("dig")
+)
+
+// Validate validates DigRequest
+func (r DigRequest) Validate() error {
+	return validation.Errors{
+		"Hostname": validation.Validate(r.Hostname, validation.Required),
+		"QueryType": validation.Validate(r.QueryType, validation.In(DigQueryTypeA, DigQueryTypeAAAA, DigQueryTypeCNAME,
+			DigQueryTypeMX, DigQueryTypeNS, DigQueryTypePTR, DigQueryTypeSOA, DigQueryTypeTXT, DigQueryTypeCAA).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: 'A', 'AAAA', 'CNAME', 'MX', 'NS', 'PTR', 'SOA', 'TXT' or 'CAA'", r.QueryType))),
+		"EdgeIP": validation.Validate(r.EdgeIP, validation.When(r.EdgeLocationID != "", validation.Empty.Error(
+			"cannot be provided together with EdgeLocationID"))),
+	}.Filter()
+}
+
+func (e *edgediagnostics) Dig(ctx context.Context, params DigRequest) (*DigResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("Dig")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrDig, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/edge-diagnostics/v1/dig", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrDig, err)
+	}
+
+	var result DigResponse
+	resp, err := e.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrDig, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrDig, e.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,116 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDig(t *testing.T) {
+	tests := map[string]struct {
+		params              DigRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *DigResponse
+		withError           error
+	}{
+		"200 OK": {
+			params: DigRequest{
+				Hostname:       "www.example.com",
+				QueryType:      DigQueryTypeA,
+				EdgeLocationID: "sanjose-ca-unitedstates",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "digInfo": {
+        "hostname": "www.example.com",
+        "queryType": "A",
+        "answerSection": [
+            {
+                "domain": "www.example.com.",
+                "ttl": 300,
+                "recordClass": "IN",
+                "recordType": "CNAME",
+                "value": "www.example.com.edgekey.net."
+            }
+        ],
+        "result": "; <<>> DiG <<>> www.example.com A"
+    },
+    "internalIp": "10.0.0.1"
+}`,
+			expectedRequestBody: `{"hostname":"www.example.com","queryType":"A","edgeLocationId":"sanjose-ca-unitedstates"}`,
+			expectedResponse: &DigResponse{
+				DigInfo: DigInfo{
+					Hostname:  "www.example.com",
+					QueryType: DigQueryTypeA,
+					AnswerSection: []DigRecord{
+						{
+							Domain:      "www.example.com.",
+							TTL:         300,
+							RecordClass: "IN",
+							RecordType:  "CNAME",
+							Value:       "www.example.com.edgekey.net.",
+						},
+					},
+					Result: "; <<>> DiG <<>> www.example.com A",
+				},
+				InternalIP: "10.0.0.1",
+			},
+		},
+		"validation error - missing hostname": {
+			params:    DigRequest{QueryType: DigQueryTypeA},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid query type": {
+			params:    DigRequest{Hostname: "www.example.com", QueryType: "SRV"},
+			withError: ErrStructValidation,
+		},
+		"validation error - edge IP and location": {
+			params:    DigRequest{Hostname: "www.example.com", EdgeIP: "1.2.3.4", EdgeLocationID: "sanjose-ca-unitedstates"},
+			withError: ErrStructValidation,
+		},
+		"400 bad request": {
+			params:              DigRequest{Hostname: "www.example.com"},
+			responseStatus:      http.StatusBadRequest,
+			responseBody:        `{"type": "bad-request", "title": "Bad Request", "detail": "Invalid hostname", "status": 400}`,
+			expectedRequestBody: `{"hostname":"www.example.com"}`,
+			withError: &Error{
+				Type:   "bad-request",
+				Title:  "Bad Request",
+				Detail: "Invalid hostname",
+				Status: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/edge-diagnostics/v1/dig", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.Dig(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
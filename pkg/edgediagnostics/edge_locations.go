@@ -0,0 +1,56 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type (
+	// EdgeLocations is an edge diagnostics edge locations API interface
+	EdgeLocations interface {
+		// ListEdgeLocations lists edge server locations from which diagnostic commands can be run
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/get-edge-locations
+		ListEdgeLocations(context.Context) (*ListEdgeLocationsResponse, error)
+	}
+
+	// ListEdgeLocationsResponse represents a response object returned by ListEdgeLocations
+	ListEdgeLocationsResponse struct {
+		EdgeLocations []EdgeLocation `json:"edgeLocations"`
+	}
+
+	// EdgeLocation represents an edge server location
+	EdgeLocation struct {
+		ID    string `json:"id"`
+		Value string `json:"value"`
+	}
+)
+
+var (
+	// ErrListEdgeLocations is returned when ListEdgeLocations fails
+	ErrListEdgeLocations = errors.New("list edge locations")
+)
+
+func (e *edgediagnostics) ListEdgeLocations(ctx context.Context) (*ListEdgeLocationsResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("ListEdgeLocations")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/edge-diagnostics/v1/edge-locations", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListEdgeLocations, err)
+	}
+
+	var result ListEdgeLocationsResponse
+	resp, err := e.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListEdgeLocations, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListEdgeLocations, e.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,67 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListEdgeLocations(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus   int
+		responseBody     string
+		expectedResponse *ListEdgeLocationsResponse
+		withError        error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "edgeLocations": [
+        {"id": "sanjose-ca-unitedstates", "value": "San Jose, CA, United States"},
+        {"id": "krakow-poland", "value": "Krakow, Poland"}
+    ]
+}`,
+			expectedResponse: &ListEdgeLocationsResponse{
+				EdgeLocations: []EdgeLocation{
+					{ID: "sanjose-ca-unitedstates", Value: "San Jose, CA, United States"},
+					{ID: "krakow-poland", Value: "Krakow, Poland"},
+				},
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal-error", "title": "Internal Server Error", "status": 500}`,
+			withError: &Error{
+				Type:   "internal-error",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/edge-diagnostics/v1/edge-locations", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListEdgeLocations(context.Background())
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
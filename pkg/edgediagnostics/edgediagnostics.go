@@ -0,0 +1,68 @@
+// Package edgediagnostics provides access to the Akamai Edge Diagnostics API
+package edgediagnostics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// EdgeDiagnostics is the api interface for Edge Diagnostics
+	EdgeDiagnostics interface {
+		Curl
+		Dig
+		EdgeLocations
+		ErrorTranslator
+		Grep
+		MTR
+		URLHealthCheck
+	}
+
+	edgediagnostics struct {
+		session.Session
+		pollInterval    time.Duration
+		maxPollInterval time.Duration
+	}
+
+	// Option defines an EdgeDiagnostics option
+	Option func(*edgediagnostics)
+
+	// ClientFunc is an EdgeDiagnostics client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) EdgeDiagnostics
+)
+
+const (
+	defaultPollInterval    = 5 * time.Second
+	defaultMaxPollInterval = time.Minute
+)
+
+// Client returns a new edgediagnostics Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) EdgeDiagnostics {
+	e := &edgediagnostics{
+		Session:         sess,
+		pollInterval:    defaultPollInterval,
+		maxPollInterval: defaultMaxPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// WithPollInterval sets the initial and maximum interval used when polling asynchronous requests for completion.
+// The interval is doubled after each poll until it reaches the maximum.
+// Non-positive initial interval falls back to the default and maximum shorter than initial interval is raised to it
+func WithPollInterval(initial, max time.Duration) Option {
+	return func(e *edgediagnostics) {
+		e.pollInterval = initial
+		e.maxPollInterval = max
+	}
+}
@@ -0,0 +1,65 @@
+package edgediagnostics
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+func mockAPIClient(t *testing.T, mockServer *httptest.Server) EdgeDiagnostics {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	assert.NoError(t, err)
+	return Client(s, WithPollInterval(time.Millisecond, 5*time.Millisecond))
+}
+
+func TestClient(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	tests := map[string]struct {
+		options  []Option
+		expected *edgediagnostics
+	}{
+		"no options provided, return default": {
+			options: nil,
+			expected: &edgediagnostics{
+				Session:         sess,
+				pollInterval:    defaultPollInterval,
+				maxPollInterval: defaultMaxPollInterval,
+			},
+		},
+		"poll interval provided": {
+			options: []Option{WithPollInterval(time.Second, 10*time.Second)},
+			expected: &edgediagnostics{
+				Session:         sess,
+				pollInterval:    time.Second,
+				maxPollInterval: 10 * time.Second,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := Client(sess, test.options...)
+			assert.Equal(t, res, test.expected)
+		})
+	}
+}
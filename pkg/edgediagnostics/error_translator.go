@@ -0,0 +1,177 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ErrorTranslator is an edge diagnostics error translator API interface
+	ErrorTranslator interface {
+		// CreateErrorTranslation starts an asynchronous translation of an error statement (reference) string
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/post-error-translator
+		CreateErrorTranslation(context.Context, ErrorTranslationRequest) (*AsyncRequest, error)
+
+		// GetErrorTranslation fetches the status and result of an error statement translation
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/get-error-translator-request
+		GetErrorTranslation(context.Context, GetErrorTranslationRequest) (*GetErrorTranslationResponse, error)
+
+		// TranslateError starts an error statement translation and polls for its result until the translation completes
+		TranslateError(context.Context, ErrorTranslationRequest) (*GetErrorTranslationResponse, error)
+	}
+
+	// ErrorTranslationRequest contains request body used to translate an error statement
+	ErrorTranslationRequest struct {
+		ErrorCode        string `json:"errorCode"`
+		TraceForwardLogs bool   `json:"traceForwardLogs"`
+	}
+
+	// GetErrorTranslationRequest contains parameters used to fetch an error statement translation
+	GetErrorTranslationRequest struct {
+		RequestID string
+	}
+
+	// GetErrorTranslationResponse represents a response object returned by GetErrorTranslation
+	GetErrorTranslationResponse struct {
+		AsyncRequestStatus
+		Request *ErrorTranslationRequest `json:"request,omitempty"`
+		Result  *ErrorTranslationResult  `json:"result,omitempty"`
+	}
+
+	// ErrorTranslationResult contains the result of an error statement translation
+	ErrorTranslationResult struct {
+		TranslatedError TranslatedError `json:"translatedError"`
+	}
+
+	// TranslatedError contains details of the request which caused the error
+	TranslatedError struct {
+		URL              string          `json:"url"`
+		HTTPResponseCode int             `json:"httpResponseCode"`
+		Timestamp        string          `json:"timestamp"`
+		EpochTime        int64           `json:"epochTime"`
+		ClientIP         string          `json:"clientIp"`
+		ConnectingIP     string          `json:"connectingIp"`
+		ServerIP         string          `json:"serverIp"`
+		OriginHostname   string          `json:"originHostname"`
+		OriginIP         string          `json:"originIp"`
+		UserAgent        string          `json:"userAgent"`
+		RequestMethod    string          `json:"requestMethod"`
+		ReasonForFailure string          `json:"reasonForFailure"`
+		WAFDetails       string          `json:"wafDetails,omitempty"`
+		Logs             []TranslatedLog `json:"logs,omitempty"`
+	}
+
+	// TranslatedLog represents a single log entry related to the translated error
+	TranslatedLog struct {
+		Description string            `json:"description"`
+		Fields      map[string]string `json:"fields"`
+	}
+)
+
+var (
+	// ErrCreateErrorTranslation is returned when CreateErrorTranslation fails
+	ErrCreateErrorTranslation = errors.New("create error translation")
+	// ErrGetErrorTranslation is returned when GetErrorTranslation fails
+	ErrGetErrorTranslation = errors.New("get error translation")
+	// ErrTranslateError is returned when TranslateError fails
+	ErrTranslateError = errors.New("translate error")
+)
+
+// Validate validates ErrorTranslationRequest
+func (r ErrorTranslationRequest) Validate() error {
+	return validation.Errors{
+		"ErrorCode": validation.Validate(r.ErrorCode, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetErrorTranslationRequest
+func (r GetErrorTranslationRequest) Validate() error {
+	return validation.Errors{
+		"RequestID": validation.Validate(r.RequestID, validation.Required),
+	}.Filter()
+}
+
+func (e *edgediagnostics) CreateErrorTranslation(ctx context.Context, params ErrorTranslationRequest) (*AsyncRequest, error) {
+	logger := e.Log(ctx)
+	logger.Debug("CreateErrorTranslation")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateErrorTranslation, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/edge-diagnostics/v1/error-translator", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateErrorTranslation, err)
+	}
+
+	var result AsyncRequest
+	resp, err := e.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateErrorTranslation, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrCreateErrorTranslation, e.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (e *edgediagnostics) GetErrorTranslation(ctx context.Context, params GetErrorTranslationRequest) (*GetErrorTranslationResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("GetErrorTranslation")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetErrorTranslation, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/edge-diagnostics/v1/error-translator/requests/%s", url.PathEscape(params.RequestID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetErrorTranslation, err)
+	}
+
+	var result GetErrorTranslationResponse
+	resp, err := e.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetErrorTranslation, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetErrorTranslation, e.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (e *edgediagnostics) TranslateError(ctx context.Context, params ErrorTranslationRequest) (*GetErrorTranslationResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("TranslateError")
+
+	created, err := e.CreateErrorTranslation(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrTranslateError, err)
+	}
+
+	var result *GetErrorTranslationResponse
+	err = e.poll(ctx, created.RetryAfter, func() (*AsyncRequestStatus, error) {
+		result, err = e.GetErrorTranslation(ctx, GetErrorTranslationRequest{RequestID: created.RequestID})
+		if err != nil {
+			return nil, err
+		}
+		return &result.AsyncRequestStatus, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrTranslateError, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetErrorTranslation(t *testing.T) {
+	tests := map[string]struct {
+		params           GetErrorTranslationRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *GetErrorTranslationResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetErrorTranslationRequest{RequestID: "tr-1"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "executionStatus": "SUCCESS",
+    "requestId": "tr-1",
+    "result": {
+        "translatedError": {
+            "url": "https://www.example.com/",
+            "httpResponseCode": 503,
+            "timestamp": "2022-10-01T10:00:00Z",
+            "epochTime": 1664618400,
+            "clientIp": "5.6.7.8",
+            "serverIp": "1.2.3.4",
+            "originHostname": "origin.example.com",
+            "requestMethod": "GET",
+            "reasonForFailure": "Origin connection timed out",
+            "logs": [
+                {"description": "Client request", "fields": {"status": "503"}}
+            ]
+        }
+    }
+}`,
+			expectedResponse: &GetErrorTranslationResponse{
+				AsyncRequestStatus: AsyncRequestStatus{
+					ExecutionStatus: ExecutionStatusSuccess,
+					RequestID:       "tr-1",
+				},
+				Result: &ErrorTranslationResult{
+					TranslatedError: TranslatedError{
+						URL:              "https://www.example.com/",
+						HTTPResponseCode: 503,
+						Timestamp:        "2022-10-01T10:00:00Z",
+						EpochTime:        1664618400,
+						ClientIP:         "5.6.7.8",
+						ServerIP:         "1.2.3.4",
+						OriginHostname:   "origin.example.com",
+						RequestMethod:    "GET",
+						ReasonForFailure: "Origin connection timed out",
+						Logs: []TranslatedLog{
+							{Description: "Client request", Fields: map[string]string{"status": "503"}},
+						},
+					},
+				},
+			},
+		},
+		"validation error": {
+			params:    GetErrorTranslationRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/edge-diagnostics/v1/error-translator/requests/tr-1", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetErrorTranslation(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	tests := map[string]struct {
+		params           ErrorTranslationRequest
+		pollStatus       int
+		pollResponse     string
+		expectedResponse *GetErrorTranslationResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:       ErrorTranslationRequest{ErrorCode: "9.6f64d440.1318965461.2f2b078"},
+			pollStatus:   http.StatusOK,
+			pollResponse: `{"executionStatus": "SUCCESS", "requestId": "tr-1", "result": {"translatedError": {"httpResponseCode": 503}}}`,
+			expectedResponse: &GetErrorTranslationResponse{
+				AsyncRequestStatus: AsyncRequestStatus{
+					ExecutionStatus: ExecutionStatusSuccess,
+					RequestID:       "tr-1",
+				},
+				Result: &ErrorTranslationResult{
+					TranslatedError: TranslatedError{HTTPResponseCode: 503},
+				},
+			},
+		},
+		"poll fails": {
+			params:       ErrorTranslationRequest{ErrorCode: "9.6f64d440.1318965461.2f2b078"},
+			pollStatus:   http.StatusInternalServerError,
+			pollResponse: `{"type": "internal-error", "title": "Internal Server Error", "status": 500}`,
+			withError: &Error{
+				Type:   "internal-error",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			params:    ErrorTranslationRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					assert.Equal(t, "/edge-diagnostics/v1/error-translator", r.URL.String())
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"errorCode":"9.6f64d440.1318965461.2f2b078","traceForwardLogs":false}`, string(body))
+					w.WriteHeader(http.StatusAccepted)
+					_, err = w.Write([]byte(`{"executionStatus": "IN_PROGRESS", "requestId": "tr-1"}`))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, "/edge-diagnostics/v1/error-translator/requests/tr-1", r.URL.String())
+				w.WriteHeader(test.pollStatus)
+				_, err := w.Write([]byte(test.pollResponse))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.TranslateError(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package edgediagnostics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+type (
+	// Error is a edgediagnostics error implementation
+	Error struct {
+		Type     string       `json:"type,omitempty"`
+		Title    string       `json:"title,omitempty"`
+		Detail   string       `json:"detail,omitempty"`
+		Instance string       `json:"instance,omitempty"`
+		Status   int          `json:"status,omitempty"`
+		Errors   []ErrorEntry `json:"errors,omitempty"`
+	}
+
+	// ErrorEntry contains details about a single problem reported in the error response
+	ErrorEntry struct {
+		Type   string `json:"type,omitempty"`
+		Title  string `json:"title,omitempty"`
+		Detail string `json:"detail,omitempty"`
+	}
+)
+
+// Error parses an error from the response
+func (e *edgediagnostics) Error(resp *http.Response) error {
+	var result Error
+	var body []byte
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		e.Log(resp.Request.Context()).Errorf("reading error response body: %s", err)
+		result.Status = resp.StatusCode
+		result.Title = "Failed to read error body"
+		result.Detail = err.Error()
+		return &result
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		e.Log(resp.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		result.Title = string(body)
+	}
+	result.Status = resp.StatusCode
+
+	return &result
+}
+
+func (e *Error) Error() string {
+	msg, err := json.MarshalIndent(e, "", "\t")
+	if err != nil {
+		return fmt.Sprintf("error marshaling API error: %s", err)
+	}
+	return fmt.Sprintf("API error: \n%s", msg)
+}
+
+// Is handles error comparisons
+func (e *Error) Is(target error) bool {
+	var t *Error
+	if !errors.As(target, &t) {
+		return false
+	}
+
+	if e == t {
+		return true
+	}
+
+	if e.Status != t.Status {
+		return false
+	}
+
+	return e.Error() == t.Error()
+}
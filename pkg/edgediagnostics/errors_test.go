@@ -0,0 +1,101 @@
+package edgediagnostics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+func TestNewError(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(
+		http.MethodHead,
+		"/",
+		nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		response *http.Response
+		expected *Error
+	}{
+		"valid response, status code 500": {
+			response: &http.Response{
+				Status:     "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+				Body: ioutil.NopCloser(strings.NewReader(
+					`{"type":"a","title":"b","detail":"c","status":500}`),
+				),
+				Request: req,
+			},
+			expected: &Error{
+				Type:   "a",
+				Title:  "b",
+				Detail: "c",
+				Status: http.StatusInternalServerError,
+			},
+		},
+		"invalid response body, assign status code": {
+			response: &http.Response{
+				Status:     "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+				Body: ioutil.NopCloser(strings.NewReader(
+					`test`),
+				),
+				Request: req,
+			},
+			expected: &Error{
+				Title:  "test",
+				Detail: "",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := Client(sess).(*edgediagnostics).Error(test.response)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestIs(t *testing.T) {
+	tests := map[string]struct {
+		err      Error
+		target   Error
+		expected bool
+	}{
+		"different error code": {
+			err:      Error{Status: 404},
+			target:   Error{Status: 401},
+			expected: false,
+		},
+		"same error code": {
+			err:      Error{Status: 404},
+			target:   Error{Status: 404},
+			expected: true,
+		},
+		"same error code and title": {
+			err:      Error{Status: 404, Title: "some error"},
+			target:   Error{Status: 404, Title: "some error"},
+			expected: true,
+		},
+		"same error code and different error message": {
+			err:      Error{Status: 404, Title: "some error"},
+			target:   Error{Status: 404, Title: "other error"},
+			expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.err.Is(&test.target), test.expected)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type (
+	// Grep is an edge diagnostics log grep API interface
+	Grep interface {
+		// CreateGrep starts an asynchronous search of edge server logs for a CP code
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/post-grep
+		CreateGrep(context.Context, GrepRequest) (*AsyncRequest, error)
+
+		// GetGrep fetches the status and result of a log search
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/get-grep-request
+		GetGrep(context.Context, GetGrepRequest) (*GetGrepResponse, error)
+
+		// RunGrep starts a log search and polls for its result until the search completes
+		RunGrep(context.Context, GrepRequest) (*GetGrepResponse, error)
+	}
+
+	// GrepRequest contains request body used to search edge server logs
+	GrepRequest struct {
+		EdgeIP           string           `json:"edgeIp"`
+		CPCode           int              `json:"cpCode"`
+		Start            string           `json:"start"`
+		End              string           `json:"end"`
+		LogType          GrepLogType      `json:"logType"`
+		Hostnames        []string         `json:"hostnames,omitempty"`
+		UserAgents       []string         `json:"userAgents,omitempty"`
+		HTTPStatusCodes  *HTTPStatusCodes `json:"httpStatusCodes,omitempty"`
+		ARLs             []string         `json:"arls,omitempty"`
+		ClientIPs        []string         `json:"clientIps,omitempty"`
+		ErrorStatusCodes []int            `json:"errorStatusCodes,omitempty"`
+	}
+
+	// HTTPStatusCodes contains HTTP status code filter of a log search
+	HTTPStatusCodes struct {
+		Comparison StatusCodeComparison `json:"comparison"`
+		Value      []string             `json:"value"`
+	}
+
+	// GetGrepRequest contains parameters used to fetch a log search
+	GetGrepRequest struct {
+		RequestID string
+	}
+
+	// GetGrepResponse represents a response object returned by GetGrep
+	GetGrepResponse struct {
+		AsyncRequestStatus
+		Request *GrepRequest `json:"request,omitempty"`
+		Result  *GrepResult  `json:"result,omitempty"`
+	}
+
+	// GrepResult contains log lines matched by a log search
+	GrepResult struct {
+		Headers         []string `json:"headers,omitempty"`
+		Logs            []string `json:"logs"`
+		MaxLinesReached bool     `json:"maxLinesReached,omitempty"`
+	}
+
+	// GrepLogType represents the type of logs searched
+	GrepLogType string
+
+	// StatusCodeComparison represents the operator used to compare HTTP status codes
+	StatusCodeComparison string
+)
+
+const (
+	// GrepLogTypeClientRequest searches client request (R) logs
+	GrepLogTypeClientRequest GrepLogType = "R"
+	// GrepLogTypeForwardRequest searches forward request (F) logs
+	GrepLogTypeForwardRequest GrepLogType = "F"
+	// GrepLogTypeAll searches both client and forward request logs
+	GrepLogTypeAll GrepLogType = "R_F"
+
+	// StatusCodeComparisonEquals matches status codes equal to any of the given values
+	StatusCodeComparisonEquals StatusCodeComparison = "EQUALS"
+	// StatusCodeComparisonNotEquals matches status codes other than the given values
+	StatusCodeComparisonNotEquals StatusCodeComparison = "NOT_EQUALS"
+)
+
+var (
+	// ErrCreateGrep is returned when CreateGrep fails
+	ErrCreateGrep = errors.New("create grep")
+	// ErrGetGrep is returned when GetGrep fails
+	ErrGetGrep = errors.New("get grep")
+	// ErrRunGrep is returned when RunGrep fails
+	ErrRunGrep = errors.New("run grep")
+)
+
+// Validate validates GrepRequest
+func (r GrepRequest) Validate() error {
+	return validation.Errors{
+		"EdgeIP": validation.Validate(r.EdgeIP, validation.Required, is.IP),
+		"CPCode": validation.Validate(r.CPCode, validation.Required),
+		"Start": validation.Validate(r.Start, validation.Required, validation.Date(time.RFC3339).Error(
+			fmt.Sprintf("value '%s' is invalid. It must have format '%s'", r.Start, time.RFC3339))),
+		"End": validation.Validate(r.End, validation.Required, validation.Date(time.RFC3339).Error(
+			fmt.Sprintf("value '%s' is invalid. It must have format '%s'", r.End, time.RFC3339))),
+		"LogType": validation.Validate(r.LogType, validation.Required, validation.In(GrepLogTypeClientRequest,
+			GrepLogTypeForwardRequest, GrepLogTypeAll).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s' or '%s'", r.LogType, GrepLogTypeClientRequest,
+				GrepLogTypeForwardRequest, GrepLogTypeAll))),
+		"HTTPStatusCodes": validation.Validate(r.HTTPStatusCodes),
+		"ClientIPs":       validation.Validate(r.ClientIPs, validation.Each(is.IP)),
+	}.Filter()
+}
+
+// Validate validates HTTPStatusCodes
+func (c HTTPStatusCodes) Validate() error {
+	return validation.Errors{
+		"Comparison": validation.Validate(c.Comparison, validation.Required, validation.In(StatusCodeComparisonEquals,
+			StatusCodeComparisonNotEquals).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", c.Comparison, StatusCodeComparisonEquals,
+				StatusCodeComparisonNotEquals))),
+		"Value": validation.Validate(c.Value, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetGrepRequest
+func (r GetGrepRequest) Validate() error {
+	return validation.Errors{
+		"RequestID": validation.Validate(r.RequestID, validation.Required),
+	}.Filter()
+}
+
+func (e *edgediagnostics) CreateGrep(ctx context.Context, params GrepRequest) (*AsyncRequest, error) {
+	logger := e.Log(ctx)
+	logger.Debug("CreateGrep")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateGrep, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/edge-diagnostics/v1/grep", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateGrep, err)
+	}
+
+	var result AsyncRequest
+	resp, err := e.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateGrep, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrCreateGrep, e.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (e *edgediagnostics) GetGrep(ctx context.Context, params GetGrepRequest) (*GetGrepResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("GetGrep")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetGrep, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/edge-diagnostics/v1/grep/requests/%s", url.PathEscape(params.RequestID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetGrep, err)
+	}
+
+	var result GetGrepResponse
+	resp, err := e.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetGrep, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetGrep, e.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (e *edgediagnostics) RunGrep(ctx context.Context, params GrepRequest) (*GetGrepResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("RunGrep")
+
+	created, err := e.CreateGrep(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRunGrep, err)
+	}
+
+	var result *GetGrepResponse
+	err = e.poll(ctx, created.RetryAfter, func() (*AsyncRequestStatus, error) {
+		result, err = e.GetGrep(ctx, GetGrepRequest{RequestID: created.RequestID})
+		if err != nil {
+			return nil, err
+		}
+		return &result.AsyncRequestStatus, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRunGrep, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGrepRequest_Validate(t *testing.T) {
+	valid := GrepRequest{
+		EdgeIP:  "1.2.3.4",
+		CPCode:  123,
+		Start:   "2022-10-01T10:00:00Z",
+		End:     "2022-10-01T10:10:00Z",
+		LogType: GrepLogTypeAll,
+	}
+	tests := map[string]struct {
+		params    func() GrepRequest
+		withError string
+	}{
+		"valid": {
+			params: func() GrepRequest { return valid },
+		},
+		"invalid log type": {
+			params: func() GrepRequest {
+				r := valid
+				r.LogType = "X"
+				return r
+			},
+			withError: "LogType: value 'X' is invalid. Must be one of: 'R', 'F' or 'R_F'",
+		},
+		"invalid start": {
+			params: func() GrepRequest {
+				r := valid
+				r.Start = "2022-10-01"
+				return r
+			},
+			withError: "Start: value '2022-10-01' is invalid",
+		},
+		"invalid status code filter": {
+			params: func() GrepRequest {
+				r := valid
+				r.HTTPStatusCodes = &HTTPStatusCodes{Comparison: "LESS"}
+				return r
+			},
+			withError: "HTTPStatusCodes: (Comparison: value 'LESS' is invalid",
+		},
+		"invalid client IP": {
+			params: func() GrepRequest {
+				r := valid
+				r.ClientIPs = []string{"1.2.3"}
+				return r
+			},
+			withError: "ClientIPs: (0: must be a valid IP address.)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.params().Validate()
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRunGrep(t *testing.T) {
+	tests := map[string]struct {
+		params              GrepRequest
+		createStatus        int
+		createResponse      string
+		pollResponses       []string
+		expectedRequestBody string
+		expectedResponse    *GetGrepResponse
+		withError           error
+	}{
+		"completes after polling": {
+			params: GrepRequest{
+				EdgeIP:  "1.2.3.4",
+				CPCode:  123,
+				Start:   "2022-10-01T10:00:00Z",
+				End:     "2022-10-01T10:10:00Z",
+				LogType: GrepLogTypeClientRequest,
+				HTTPStatusCodes: &HTTPStatusCodes{
+					Comparison: StatusCodeComparisonEquals,
+					Value:      []string{"503"},
+				},
+			},
+			createStatus:   http.StatusAccepted,
+			createResponse: `{"executionStatus": "IN_PROGRESS", "requestId": "grep-1"}`,
+			pollResponses: []string{
+				`{"executionStatus": "IN_PROGRESS", "requestId": "grep-1"}`,
+				`{"executionStatus": "SUCCESS", "requestId": "grep-1", "result": {"logs": ["r 1664618400.000 503"]}}`,
+			},
+			expectedRequestBody: `{"edgeIp":"1.2.3.4","cpCode":123,"start":"2022-10-01T10:00:00Z","end":"2022-10-01T10:10:00Z","logType":"R","httpStatusCodes":{"comparison":"EQUALS","value":["503"]}}`,
+			expectedResponse: &GetGrepResponse{
+				AsyncRequestStatus: AsyncRequestStatus{
+					ExecutionStatus: ExecutionStatusSuccess,
+					RequestID:       "grep-1",
+				},
+				Result: &GrepResult{
+					Logs: []string{"r 1664618400.000 503"},
+				},
+			},
+		},
+		"create fails": {
+			params: GrepRequest{
+				EdgeIP:  "1.2.3.4",
+				CPCode:  123,
+				Start:   "2022-10-01T10:00:00Z",
+				End:     "2022-10-01T10:10:00Z",
+				LogType: GrepLogTypeClientRequest,
+			},
+			createStatus:        http.StatusTooManyRequests,
+			createResponse:      `{"type": "too-many-requests", "title": "Too Many Requests", "status": 429}`,
+			expectedRequestBody: `{"edgeIp":"1.2.3.4","cpCode":123,"start":"2022-10-01T10:00:00Z","end":"2022-10-01T10:10:00Z","logType":"R"}`,
+			withError: &Error{
+				Type:   "too-many-requests",
+				Title:  "Too Many Requests",
+				Status: http.StatusTooManyRequests,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			polls := 0
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					assert.Equal(t, "/edge-diagnostics/v1/grep", r.URL.String())
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRequestBody, string(body))
+					w.WriteHeader(test.createStatus)
+					_, err = w.Write([]byte(test.createResponse))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, "/edge-diagnostics/v1/grep/requests/grep-1", r.URL.String())
+				if polls >= len(test.pollResponses) {
+					t.Errorf("unexpected poll request %d", polls+1)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.pollResponses[polls]))
+				assert.NoError(t, err)
+				polls++
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.RunGrep(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
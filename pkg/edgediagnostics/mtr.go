@@ -0,0 +1,125 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type (
+	// MTR is an edge diagnostics mtr API interface
+	MTR interface {
+		// MTR runs the mtr command from an edge server to check connectivity and packet loss to a destination
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/post-mtr
+		MTR(context.Context, MTRRequest) (*MTRResponse, error)
+	}
+
+	// MTRRequest contains request body used to run the mtr command
+	MTRRequest struct {
+		DestinationDomain  string        `json:"destinationDomain,omitempty"`
+		DestinationIP      string        `json:"destinationIp,omitempty"`
+		EdgeLocationID     string        `json:"edgeLocationId,omitempty"`
+		EdgeIP             string        `json:"edgeIp,omitempty"`
+		PacketType         MTRPacketType `json:"packetType,omitempty"`
+		Port               int           `json:"port,omitempty"`
+		ResolveDNS         bool          `json:"resolveDns"`
+		ShowIPs            bool          `json:"showIps"`
+		ShowLocations      bool          `json:"showLocations,omitempty"`
+		SiteShieldHostname string        `json:"siteShieldHostname,omitempty"`
+	}
+
+	// MTRResponse represents a response object returned by MTR
+	MTRResponse struct {
+		MTR             MTRData `json:"mtr"`
+		InternalIP      string  `json:"internalIp,omitempty"`
+		ExecutionStatus string  `json:"executionStatus,omitempty"`
+	}
+
+	// MTRData contains the result of the mtr command
+	MTRData struct {
+		Source      string   `json:"source"`
+		Destination string   `json:"destination"`
+		StartTime   string   `json:"startTime"`
+		Host        string   `json:"host"`
+		PacketLoss  float64  `json:"packetLoss"`
+		AvgLatency  float64  `json:"avgLatency"`
+		Analysis    string   `json:"analysis"`
+		Hops        []MTRHop `json:"hops"`
+		Result      string   `json:"result,omitempty"`
+	}
+
+	// MTRHop contains statistics of a single network hop
+	MTRHop struct {
+		Number            int     `json:"number"`
+		Host              string  `json:"host"`
+		PacketLoss        float64 `json:"packetLoss"`
+		SentPackets       int     `json:"sentPackets"`
+		LastPacketLatency float64 `json:"lastPacketLatency"`
+		AvgLatency        float64 `json:"avgLatency"`
+		BestRTT           float64 `json:"bestRtt"`
+		WorstRTT          float64 `json:"worstRtt"`
+		StandardDeviation float64 `json:"standardDeviation"`
+	}
+
+	// MTRPacketType represents the type of packets sent by mtr
+	MTRPacketType string
+)
+
+const (
+	// MTRPacketTypeTCP sends TCP packets
+	MTRPacketTypeTCP MTRPacketType = "TCP"
+	// MTRPacketTypeICMP sends ICMP packets
+	MTRPacketTypeICMP MTRPacketType = "ICMP"
+)
+
+var (
+	// ErrMTR is returned when MTR fails
+	ErrMTR = errors.New("mtr")
+)
+
+// Validate validates MTRRequest
+func (r MTRRequest) Validate() error {
+	return validation.Errors{
+		"DestinationDomain": validation.Validate(r.DestinationDomain, validation.Required.When(r.DestinationIP == "").Error(
+			"one of DestinationDomain or DestinationIP is required")),
+		"DestinationIP": validation.Validate(r.DestinationIP, is.IP, validation.When(r.DestinationDomain != "", validation.Empty.Error(
+			"cannot be provided together with DestinationDomain"))),
+		"EdgeIP": validation.Validate(r.EdgeIP, is.IP, validation.When(r.EdgeLocationID != "", validation.Empty.Error(
+			"cannot be provided together with EdgeLocationID"))),
+		"PacketType": validation.Validate(r.PacketType, validation.In(MTRPacketTypeTCP, MTRPacketTypeICMP).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", r.PacketType, MTRPacketTypeTCP, MTRPacketTypeICMP))),
+		"Port": validation.Validate(r.Port, validation.When(r.PacketType != MTRPacketTypeTCP, validation.Empty.Error(
+			"can only be provided for TCP packet type")), validation.Max(65535)),
+	}.Filter()
+}
+
+func (e *edgediagnostics) MTR(ctx context.Context, params MTRRequest) (*MTRResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("MTR")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrMTR, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/edge-diagnostics/v1/mtr", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrMTR, err)
+	}
+
+	var result MTRResponse
+	resp, err := e.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrMTR, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrMTR, e.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,125 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestMTR(t *testing.T) {
+	tests := map[string]struct {
+		params              MTRRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *MTRResponse
+		withError           error
+	}{
+		"200 OK": {
+			params: MTRRequest{
+				DestinationDomain: "origin.example.com",
+				EdgeIP:            "1.2.3.4",
+				PacketType:        MTRPacketTypeTCP,
+				Port:              443,
+				ResolveDNS:        true,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "mtr": {
+        "source": "1.2.3.4",
+        "destination": "origin.example.com",
+        "startTime": "2022-10-01T10:00:00Z",
+        "host": "a1-2-3-4",
+        "packetLoss": 0,
+        "avgLatency": 12.5,
+        "analysis": "No packet loss detected",
+        "hops": [
+            {
+                "number": 1,
+                "host": "gateway",
+                "packetLoss": 0,
+                "sentPackets": 10,
+                "lastPacketLatency": 0.3,
+                "avgLatency": 0.4,
+                "bestRtt": 0.2,
+                "worstRtt": 0.9,
+                "standardDeviation": 0.1
+            }
+        ]
+    }
+}`,
+			expectedRequestBody: `{"destinationDomain":"origin.example.com","edgeIp":"1.2.3.4","packetType":"TCP","port":443,"resolveDns":true,"showIps":false}`,
+			expectedResponse: &MTRResponse{
+				MTR: MTRData{
+					Source:      "1.2.3.4",
+					Destination: "origin.example.com",
+					StartTime:   "2022-10-01T10:00:00Z",
+					Host:        "a1-2-3-4",
+					AvgLatency:  12.5,
+					Analysis:    "No packet loss detected",
+					Hops: []MTRHop{
+						{
+							Number:            1,
+							Host:              "gateway",
+							SentPackets:       10,
+							LastPacketLatency: 0.3,
+							AvgLatency:        0.4,
+							BestRTT:           0.2,
+							WorstRTT:          0.9,
+							StandardDeviation: 0.1,
+						},
+					},
+				},
+			},
+		},
+		"validation error - missing destination": {
+			params:    MTRRequest{PacketType: MTRPacketTypeICMP},
+			withError: ErrStructValidation,
+		},
+		"validation error - port with ICMP": {
+			params:    MTRRequest{DestinationIP: "5.6.7.8", PacketType: MTRPacketTypeICMP, Port: 80},
+			withError: ErrStructValidation,
+		},
+		"403 forbidden": {
+			params:              MTRRequest{DestinationIP: "5.6.7.8"},
+			responseStatus:      http.StatusForbidden,
+			responseBody:        `{"type": "forbidden", "title": "Forbidden", "status": 403}`,
+			expectedRequestBody: `{"destinationIp":"5.6.7.8","resolveDns":false,"showIps":false}`,
+			withError: &Error{
+				Type:   "forbidden",
+				Title:  "Forbidden",
+				Status: http.StatusForbidden,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/edge-diagnostics/v1/mtr", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.MTR(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type (
+	// AsyncRequest contains data returned when an asynchronous request is created
+	AsyncRequest struct {
+		ExecutionStatus ExecutionStatus `json:"executionStatus"`
+		CreatedBy       string          `json:"createdBy"`
+		CreatedTime     string          `json:"createdTime"`
+		RequestID       string          `json:"requestId"`
+		Link            string          `json:"link"`
+		RetryAfter      int             `json:"retryAfter"`
+	}
+
+	// AsyncRequestStatus contains status data common to all asynchronous request results
+	AsyncRequestStatus struct {
+		ExecutionStatus ExecutionStatus `json:"executionStatus"`
+		CreatedBy       string          `json:"createdBy"`
+		CreatedTime     string          `json:"createdTime"`
+		CompletedTime   string          `json:"completedTime,omitempty"`
+		RequestID       string          `json:"requestId"`
+		RetryAfter      int             `json:"retryAfter,omitempty"`
+	}
+
+	// ExecutionStatus represents the execution status of an asynchronous request
+	ExecutionStatus string
+)
+
+const (
+	// ExecutionStatusInProgress means the request is still being processed
+	ExecutionStatusInProgress ExecutionStatus = "IN_PROGRESS"
+	// ExecutionStatusSuccess means the request completed successfully
+	ExecutionStatusSuccess ExecutionStatus = "SUCCESS"
+	// ExecutionStatusFailure means the request completed with a failure
+	ExecutionStatusFailure ExecutionStatus = "FAILURE"
+)
+
+var (
+	// ErrPolling is returned when polling an asynchronous request for completion fails
+	ErrPolling = errors.New("polling request")
+)
+
+// Done returns true if the request is no longer in progress
+func (s AsyncRequestStatus) Done() bool {
+	return s.ExecutionStatus != ExecutionStatusInProgress
+}
+
+// poll calls check until it reports that the request is done, the context is canceled or check returns an error.
+// The wait between calls starts at the configured poll interval and is doubled each time up to the configured maximum,
+// but is never shorter than the retryAfter value (in seconds) returned by the API.
+// Non-positive poll interval is replaced by the default one, so that the API is not called in a busy loop
+func (e *edgediagnostics) poll(ctx context.Context, retryAfter int, check func() (*AsyncRequestStatus, error)) error {
+	interval, maxInterval := e.pollInterval, e.maxPollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	for {
+		wait := interval
+		if serverWait := time.Duration(retryAfter) * time.Second; serverWait > wait {
+			wait = serverWait
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %s", ErrPolling, ctx.Err())
+		case <-timer.C:
+		}
+
+		status, err := check()
+		if err != nil {
+			return err
+		}
+		if status.Done() {
+			return nil
+		}
+		retryAfter = status.RetryAfter
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
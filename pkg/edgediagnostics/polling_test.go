@@ -0,0 +1,76 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestPoll(t *testing.T) {
+	t.Run("interval is doubled up to maximum", func(t *testing.T) {
+		e := &edgediagnostics{pollInterval: time.Millisecond, maxPollInterval: 4 * time.Millisecond}
+		var calls []time.Time
+		err := e.poll(context.Background(), 0, func() (*AsyncRequestStatus, error) {
+			calls = append(calls, time.Now())
+			if len(calls) < 5 {
+				return &AsyncRequestStatus{ExecutionStatus: ExecutionStatusInProgress}, nil
+			}
+			return &AsyncRequestStatus{ExecutionStatus: ExecutionStatusSuccess}, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, calls, 5)
+		assert.GreaterOrEqual(t, int64(calls[4].Sub(calls[3])), int64(4*time.Millisecond))
+	})
+
+	t.Run("zero interval falls back to default", func(t *testing.T) {
+		e := &edgediagnostics{}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		var calls int
+		err := e.poll(ctx, 0, func() (*AsyncRequestStatus, error) {
+			calls++
+			return &AsyncRequestStatus{ExecutionStatus: ExecutionStatusInProgress}, nil
+		})
+		assert.True(t, errors.Is(err, ErrPolling))
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("maximum shorter than interval is raised to interval", func(t *testing.T) {
+		e := &edgediagnostics{pollInterval: 2 * time.Millisecond, maxPollInterval: -1}
+		var calls []time.Time
+		err := e.poll(context.Background(), 0, func() (*AsyncRequestStatus, error) {
+			calls = append(calls, time.Now())
+			if len(calls) < 3 {
+				return &AsyncRequestStatus{ExecutionStatus: ExecutionStatusInProgress}, nil
+			}
+			return &AsyncRequestStatus{ExecutionStatus: ExecutionStatusSuccess}, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, calls, 3)
+		assert.GreaterOrEqual(t, int64(calls[2].Sub(calls[1])), int64(2*time.Millisecond))
+	})
+
+	t.Run("check error is returned", func(t *testing.T) {
+		e := &edgediagnostics{pollInterval: time.Millisecond, maxPollInterval: time.Millisecond}
+		checkErr := errors.New("oops")
+		err := e.poll(context.Background(), 0, func() (*AsyncRequestStatus, error) {
+			return nil, checkErr
+		})
+		assert.True(t, errors.Is(err, checkErr))
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		e := &edgediagnostics{pollInterval: time.Millisecond, maxPollInterval: time.Millisecond}
+		ctx, cancel := context.WithCancel(context.Background())
+		err := e.poll(ctx, 0, func() (*AsyncRequestStatus, error) {
+			cancel()
+			return &AsyncRequestStatus{ExecutionStatus: ExecutionStatusInProgress}, nil
+		})
+		assert.True(t, errors.Is(err, ErrPolling))
+		assert.Contains(t, err.Error(), context.Canceled.Error())
+	})
+}
@@ -0,0 +1,179 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type (
+	// URLHealthCheck is an edge diagnostics URL health check API interface
+	URLHealthCheck interface {
+		// CreateURLHealthCheck starts an asynchronous URL health check which runs dig, curl, mtr and log grep for a URL
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/post-url-health-check
+		CreateURLHealthCheck(context.Context, URLHealthCheckRequest) (*AsyncRequest, error)
+
+		// GetURLHealthCheck fetches the status and result of a URL health check
+		//
+		// See: https://techdocs.akamai.com/edge-diagnostics/reference/get-url-health-check-request
+		GetURLHealthCheck(context.Context, GetURLHealthCheckRequest) (*GetURLHealthCheckResponse, error)
+
+		// RunURLHealthCheck starts a URL health check and polls for its result until the check completes
+		RunURLHealthCheck(context.Context, URLHealthCheckRequest) (*GetURLHealthCheckResponse, error)
+	}
+
+	// URLHealthCheckRequest contains request body used to start a URL health check
+	URLHealthCheckRequest struct {
+		URL                        string        `json:"url"`
+		EdgeLocationID             string        `json:"edgeLocationId,omitempty"`
+		EdgeIP                     string        `json:"edgeIp,omitempty"`
+		IPVersion                  IPVersion     `json:"ipVersion,omitempty"`
+		PacketType                 MTRPacketType `json:"packetType,omitempty"`
+		Port                       int           `json:"port,omitempty"`
+		QueryType                  DigQueryType  `json:"queryType,omitempty"`
+		RequestHeaders             []string      `json:"requestHeaders,omitempty"`
+		SpoofEdgeIP                string        `json:"spoofEdgeIp,omitempty"`
+		RunFromSiteShield          bool          `json:"runFromSiteShield,omitempty"`
+		ViewsAllowed               []string      `json:"viewsAllowed,omitempty"`
+		SensitiveRequestHeaderKeys []string      `json:"sensitiveRequestHeaderKeys,omitempty"`
+	}
+
+	// GetURLHealthCheckRequest contains parameters used to fetch a URL health check
+	GetURLHealthCheckRequest struct {
+		RequestID string
+	}
+
+	// GetURLHealthCheckResponse represents a response object returned by GetURLHealthCheck
+	GetURLHealthCheckResponse struct {
+		AsyncRequestStatus
+		Request          *URLHealthCheckRequest `json:"request,omitempty"`
+		Result           *URLHealthCheckResult  `json:"result,omitempty"`
+		SuggestedActions []string               `json:"suggestedActions,omitempty"`
+	}
+
+	// URLHealthCheckResult contains results of all diagnostic commands run by a URL health check
+	URLHealthCheckResult struct {
+		Dig      *DigInfo     `json:"dig,omitempty"`
+		Curl     *CurlResults `json:"curl,omitempty"`
+		MTR      *MTRData     `json:"mtr,omitempty"`
+		LogLines *GrepResult  `json:"logLines,omitempty"`
+	}
+)
+
+var (
+	// ErrCreateURLHealthCheck is returned when CreateURLHealthCheck fails
+	ErrCreateURLHealthCheck = errors.New("create URL health check")
+	// ErrGetURLHealthCheck is returned when GetURLHealthCheck fails
+	ErrGetURLHealthCheck = errors.New("get URL health check")
+	// ErrRunURLHealthCheck is returned when RunURLHealthCheck fails
+	ErrRunURLHealthCheck = errors.New("run URL health check")
+)
+
+// Validate validates URLHealthCheckRequest
+func (r URLHealthCheckRequest) Validate() error {
+	return validation.Errors{
+		"URL": validation.Validate(r.URL, validation.Required, is.URL),
+		"EdgeIP": validation.Validate(r.EdgeIP, is.IP, validation.When(r.EdgeLocationID != "", validation.Empty.Error(
+			"cannot be provided together with EdgeLocationID"))),
+		"SpoofEdgeIP": validation.Validate(r.SpoofEdgeIP, is.IP),
+		"IPVersion": validation.Validate(r.IPVersion, validation.In(IPVersionIPv4, IPVersionIPv6).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", r.IPVersion, IPVersionIPv4, IPVersionIPv6))),
+		"PacketType": validation.Validate(r.PacketType, validation.In(MTRPacketTypeTCP, MTRPacketTypeICMP).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", r.PacketType, MTRPacketTypeTCP, MTRPacketTypeICMP))),
+		"Port": validation.Validate(r.Port, validation.Max(65535)),
+		"QueryType": validation.Validate(r.QueryType, validation.In(DigQueryTypeA, DigQueryTypeAAAA, DigQueryTypeCNAME,
+			DigQueryTypeMX, DigQueryTypeNS, DigQueryTypePTR, DigQueryTypeSOA, DigQueryTypeTXT, DigQueryTypeCAA).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: 'A', 'AAAA', 'CNAME', 'MX', 'NS', 'PTR', 'SOA', 'TXT' or 'CAA'", r.QueryType))),
+	}.Filter()
+}
+
+// Validate validates GetURLHealthCheckRequest
+func (r GetURLHealthCheckRequest) Validate() error {
+	return validation.Errors{
+		"RequestID": validation.Validate(r.RequestID, validation.Required),
+	}.Filter()
+}
+
+func (e *edgediagnostics) CreateURLHealthCheck(ctx context.Context, params URLHealthCheckRequest) (*AsyncRequest, error) {
+	logger := e.Log(ctx)
+	logger.Debug("CreateURLHealthCheck")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateURLHealthCheck, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/edge-diagnostics/v1/url-health-check", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateURLHealthCheck, err)
+	}
+
+	var result AsyncRequest
+	resp, err := e.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateURLHealthCheck, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrCreateURLHealthCheck, e.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (e *edgediagnostics) GetURLHealthCheck(ctx context.Context, params GetURLHealthCheckRequest) (*GetURLHealthCheckResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("GetURLHealthCheck")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetURLHealthCheck, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/edge-diagnostics/v1/url-health-check/requests/%s", url.PathEscape(params.RequestID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetURLHealthCheck, err)
+	}
+
+	var result GetURLHealthCheckResponse
+	resp, err := e.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetURLHealthCheck, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetURLHealthCheck, e.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (e *edgediagnostics) RunURLHealthCheck(ctx context.Context, params URLHealthCheckRequest) (*GetURLHealthCheckResponse, error) {
+	logger := e.Log(ctx)
+	logger.Debug("RunURLHealthCheck")
+
+	created, err := e.CreateURLHealthCheck(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRunURLHealthCheck, err)
+	}
+
+	var result *GetURLHealthCheckResponse
+	err = e.poll(ctx, created.RetryAfter, func() (*AsyncRequestStatus, error) {
+		result, err = e.GetURLHealthCheck(ctx, GetURLHealthCheckRequest{RequestID: created.RequestID})
+		if err != nil {
+			return nil, err
+		}
+		return &result.AsyncRequestStatus, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRunURLHealthCheck, err)
+	}
+
+	return result, nil
+}
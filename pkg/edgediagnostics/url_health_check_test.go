@@ -0,0 +1,247 @@
+package edgediagnostics
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestCreateURLHealthCheck(t *testing.T) {
+	tests := map[string]struct {
+		params              URLHealthCheckRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *AsyncRequest
+		withError           error
+	}{
+		"202 Accepted": {
+			params: URLHealthCheckRequest{
+				URL:            "https://www.example.com",
+				EdgeLocationID: "sanjose-ca-unitedstates",
+				PacketType:     MTRPacketTypeTCP,
+				Port:           443,
+			},
+			responseStatus: http.StatusAccepted,
+			responseBody: `
+{
+    "executionStatus": "IN_PROGRESS",
+    "createdBy": "jsmith",
+    "createdTime": "2022-10-01T10:00:00Z",
+    "requestId": "10ff2b86-2b17-4ed3-8a87-1f5ad9e1a8a0",
+    "link": "/edge-diagnostics/v1/url-health-check/requests/10ff2b86-2b17-4ed3-8a87-1f5ad9e1a8a0",
+    "retryAfter": 10
+}`,
+			expectedRequestBody: `{"url":"https://www.example.com","edgeLocationId":"sanjose-ca-unitedstates","packetType":"TCP","port":443}`,
+			expectedResponse: &AsyncRequest{
+				ExecutionStatus: ExecutionStatusInProgress,
+				CreatedBy:       "jsmith",
+				CreatedTime:     "2022-10-01T10:00:00Z",
+				RequestID:       "10ff2b86-2b17-4ed3-8a87-1f5ad9e1a8a0",
+				Link:            "/edge-diagnostics/v1/url-health-check/requests/10ff2b86-2b17-4ed3-8a87-1f5ad9e1a8a0",
+				RetryAfter:      10,
+			},
+		},
+		"validation error": {
+			params:    URLHealthCheckRequest{URL: "https://www.example.com", IPVersion: "IPV5"},
+			withError: ErrStructValidation,
+		},
+		"400 bad request": {
+			params:              URLHealthCheckRequest{URL: "https://www.example.com"},
+			responseStatus:      http.StatusBadRequest,
+			responseBody:        `{"type": "bad-request", "title": "Bad Request", "status": 400}`,
+			expectedRequestBody: `{"url":"https://www.example.com"}`,
+			withError: &Error{
+				Type:   "bad-request",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/edge-diagnostics/v1/url-health-check", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateURLHealthCheck(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetURLHealthCheck(t *testing.T) {
+	tests := map[string]struct {
+		params           GetURLHealthCheckRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *GetURLHealthCheckResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetURLHealthCheckRequest{RequestID: "abc"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "executionStatus": "SUCCESS",
+    "createdBy": "jsmith",
+    "createdTime": "2022-10-01T10:00:00Z",
+    "completedTime": "2022-10-01T10:01:00Z",
+    "requestId": "abc",
+    "request": {"url": "https://www.example.com"},
+    "result": {
+        "curl": {"httpStatusCode": 200},
+        "logLines": {"logs": ["r 1664618400.000 ..."]}
+    },
+    "suggestedActions": ["No issues found"]
+}`,
+			expectedPath: "/edge-diagnostics/v1/url-health-check/requests/abc",
+			expectedResponse: &GetURLHealthCheckResponse{
+				AsyncRequestStatus: AsyncRequestStatus{
+					ExecutionStatus: ExecutionStatusSuccess,
+					CreatedBy:       "jsmith",
+					CreatedTime:     "2022-10-01T10:00:00Z",
+					CompletedTime:   "2022-10-01T10:01:00Z",
+					RequestID:       "abc",
+				},
+				Request: &URLHealthCheckRequest{URL: "https://www.example.com"},
+				Result: &URLHealthCheckResult{
+					Curl:     &CurlResults{HTTPStatusCode: 200},
+					LogLines: &GrepResult{Logs: []string{"r 1664618400.000 ..."}},
+				},
+				SuggestedActions: []string{"No issues found"},
+			},
+		},
+		"validation error": {
+			params:    GetURLHealthCheckRequest{},
+			withError: ErrStructValidation,
+		},
+		"404 not found": {
+			params:         GetURLHealthCheckRequest{RequestID: "abc"},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not-found", "title": "Not Found", "status": 404}`,
+			expectedPath:   "/edge-diagnostics/v1/url-health-check/requests/abc",
+			withError: &Error{
+				Type:   "not-found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetURLHealthCheck(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestRunURLHealthCheck(t *testing.T) {
+	tests := map[string]struct {
+		params           URLHealthCheckRequest
+		pollResponses    []string
+		expectedResponse *GetURLHealthCheckResponse
+		withError        error
+	}{
+		"completes after polling": {
+			params: URLHealthCheckRequest{URL: "https://www.example.com"},
+			pollResponses: []string{
+				`{"executionStatus": "IN_PROGRESS", "requestId": "abc"}`,
+				`{"executionStatus": "IN_PROGRESS", "requestId": "abc"}`,
+				`{"executionStatus": "SUCCESS", "requestId": "abc", "result": {"curl": {"httpStatusCode": 200}}}`,
+			},
+			expectedResponse: &GetURLHealthCheckResponse{
+				AsyncRequestStatus: AsyncRequestStatus{
+					ExecutionStatus: ExecutionStatusSuccess,
+					RequestID:       "abc",
+				},
+				Result: &URLHealthCheckResult{
+					Curl: &CurlResults{HTTPStatusCode: 200},
+				},
+			},
+		},
+		"returns failed check": {
+			params: URLHealthCheckRequest{URL: "https://www.example.com"},
+			pollResponses: []string{
+				`{"executionStatus": "FAILURE", "requestId": "abc"}`,
+			},
+			expectedResponse: &GetURLHealthCheckResponse{
+				AsyncRequestStatus: AsyncRequestStatus{
+					ExecutionStatus: ExecutionStatusFailure,
+					RequestID:       "abc",
+				},
+			},
+		},
+		"validation error": {
+			params:    URLHealthCheckRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			polls := 0
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					assert.Equal(t, "/edge-diagnostics/v1/url-health-check", r.URL.String())
+					w.WriteHeader(http.StatusAccepted)
+					_, err := w.Write([]byte(`{"executionStatus": "IN_PROGRESS", "requestId": "abc"}`))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, "/edge-diagnostics/v1/url-health-check/requests/abc", r.URL.String())
+				if polls >= len(test.pollResponses) {
+					t.Errorf("unexpected poll request %d", polls+1)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.pollResponses[polls]))
+				assert.NoError(t, err)
+				polls++
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.RunURLHealthCheck(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			assert.Equal(t, len(test.pollResponses), polls)
+		})
+	}
+}
@@ -0,0 +1,518 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Graph links hostnames with the entities serving them:
+	//
+	//	HOSTNAME -SERVED_BY-> PROPERTY_VERSION
+	//	HOSTNAME -CNAME_TO-> EDGE_HOSTNAME
+	//	HOSTNAME -COVERED_BY-> ENROLLMENT
+	//	HOSTNAME -PROTECTED_BY-> SECURITY_CONFIGURATION
+	//	HOSTNAME -PUBLISHED_AS-> DNS_RECORD -POINTS_TO-> EDGE_HOSTNAME
+	//
+	// Nodes and edges are in the order of entities in the Snapshot the graph was built from
+	Graph struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+
+		snapshot *Snapshot
+		nodes    map[string]int
+		edges    map[GraphEdge]bool
+		from     map[string][]GraphEdge
+		to       map[string][]GraphEdge
+	}
+
+	// GraphNode is a node of Graph. Active is set on property versions active on staging or production
+	GraphNode struct {
+		ID     string   `json:"id"`
+		Type   NodeType `json:"type"`
+		Label  string   `json:"label"`
+		Active bool     `json:"active,omitempty"`
+	}
+
+	// GraphEdge is a directed edge between nodes of Graph
+	GraphEdge struct {
+		From string   `json:"from"`
+		To   string   `json:"to"`
+		Type EdgeType `json:"type"`
+	}
+
+	// GraphIssue is an orphaned node or a broken chain found in Graph
+	GraphIssue struct {
+		Type   IssueType `json:"type"`
+		NodeID string    `json:"nodeId"`
+		Detail string    `json:"detail"`
+	}
+
+	// NodeType represents type of GraphNode
+	NodeType string
+
+	// EdgeType represents type of GraphEdge
+	EdgeType string
+
+	// IssueType represents type of GraphIssue
+	IssueType string
+)
+
+const (
+	// NodeHostname is a hostname of a property, security configuration or DNS record
+	NodeHostname NodeType = "HOSTNAME"
+	// NodePropertyVersion is a property version
+	NodePropertyVersion NodeType = "PROPERTY_VERSION"
+	// NodeEdgeHostname is an edge hostname
+	NodeEdgeHostname NodeType = "EDGE_HOSTNAME"
+	// NodeEnrollment is a CPS enrollment
+	NodeEnrollment NodeType = "ENROLLMENT"
+	// NodeSecurityConfiguration is an application security configuration
+	NodeSecurityConfiguration NodeType = "SECURITY_CONFIGURATION"
+	// NodeDNSRecord is an Edge DNS CNAME record
+	NodeDNSRecord NodeType = "DNS_RECORD"
+
+	// EdgeServedBy links a hostname with property versions it is a hostname of
+	EdgeServedBy EdgeType = "SERVED_BY"
+	// EdgeCnameTo links a hostname with the edge hostname set for it in a property
+	EdgeCnameTo EdgeType = "CNAME_TO"
+	// EdgeCoveredBy links a hostname with enrollments whose common name or SANs cover it
+	EdgeCoveredBy EdgeType = "COVERED_BY"
+	// EdgeProtectedBy links a hostname with security configurations selecting it or using it in match targets
+	EdgeProtectedBy EdgeType = "PROTECTED_BY"
+	// EdgePublishedAs links a hostname with its DNS record
+	EdgePublishedAs EdgeType = "PUBLISHED_AS"
+	// EdgePointsTo links a DNS record with the edge hostname it targets
+	EdgePointsTo EdgeType = "POINTS_TO"
+
+	// IssueUnusedEdgeHostname is reported for edge hostnames not used by any property hostname.
+	// Edge hostnames of contracts with groups or properties whose hostnames were not listed are not reported
+	IssueUnusedEdgeHostname IssueType = "UNUSED_EDGE_HOSTNAME"
+	// IssueUnusedEnrollment is reported for enrollments not covering any property hostname
+	IssueUnusedEnrollment IssueType = "UNUSED_ENROLLMENT"
+	// IssueUncoveredHostname is reported for property hostnames not covered by any enrollment of the property contract.
+	// Hostnames with DEFAULT certificate provisioning type, hostnames on non-secure or shared certificate edge hostnames
+	// and hostnames of contracts whose enrollments were not listed are not reported
+	IssueUncoveredHostname IssueType = "UNCOVERED_HOSTNAME"
+	// IssueOrphanedSecurityHostname is reported for hostnames of security configurations not on any active property version.
+	// They are not reported if hostnames of any group or property were not listed, as the hostname may belong to it
+	IssueOrphanedSecurityHostname IssueType = "ORPHANED_SECURITY_HOSTNAME"
+	// IssueMissingEdgeHostname is reported for property hostnames using an edge hostname which was not found
+	IssueMissingEdgeHostname IssueType = "MISSING_EDGE_HOSTNAME"
+	// IssueMissingDNSRecord is reported for hostnames of active property versions without a CNAME record in a crawled zone
+	IssueMissingDNSRecord IssueType = "MISSING_DNS_RECORD"
+	// IssueDNSTargetMismatch is reported for DNS records targeting other name than the edge hostname set in the property
+	IssueDNSTargetMismatch IssueType = "DNS_TARGET_MISMATCH"
+	// IssueDNSRecordWithoutProperty is reported for DNS records pointing to an edge hostname whose name
+	// is not a hostname of any active property version
+	IssueDNSRecordWithoutProperty IssueType = "DNS_RECORD_WITHOUT_PROPERTY"
+)
+
+// edgeHostnameSuffixes are domains of edge hostnames, used to recognize DNS records pointing to edge hostnames
+// which were not crawled
+var edgeHostnameSuffixes = []string{".edgesuite.net", ".edgekey.net", ".akamaized.net"}
+
+// NewGraph builds the hostname dependency graph of the snapshot
+func NewGraph(s *Snapshot) *Graph {
+	g := &Graph{
+		snapshot: s,
+		nodes:    map[string]int{},
+		edges:    map[GraphEdge]bool{},
+		from:     map[string][]GraphEdge{},
+		to:       map[string][]GraphEdge{},
+	}
+
+	properties := map[string]Property{}
+	for _, p := range s.Properties {
+		properties[p.PropertyID] = p
+	}
+	edgeHostnamesByDomain := map[string]string{}
+	for _, e := range s.EdgeHostnames {
+		g.addNode(GraphNode{ID: edgeHostnameNodeID(e.EdgeHostnameID), Type: NodeEdgeHostname, Label: e.EdgeHostname})
+		edgeHostnamesByDomain[strings.ToLower(e.EdgeHostname)] = e.EdgeHostnameID
+	}
+	for _, e := range s.Enrollments {
+		g.addNode(GraphNode{ID: enrollmentNodeID(e.EnrollmentID), Type: NodeEnrollment, Label: e.CN})
+	}
+	for _, c := range s.SecurityConfigurations {
+		g.addNode(GraphNode{ID: securityConfigurationNodeID(c.ConfigID), Type: NodeSecurityConfiguration, Label: c.Name})
+	}
+
+	for _, h := range s.PropertyHostnames {
+		hostname := g.addNode(GraphNode{ID: hostnameNodeID(h.Hostname), Type: NodeHostname, Label: h.Hostname})
+		property := properties[h.PropertyID]
+		version := g.addNode(GraphNode{
+			ID:     propertyVersionNodeID(h.PropertyID, h.PropertyVersion),
+			Type:   NodePropertyVersion,
+			Label:  fmt.Sprintf("%s v%d", property.PropertyName, h.PropertyVersion),
+			Active: h.PropertyVersion == property.StagingVersion || h.PropertyVersion == property.ProductionVersion,
+		})
+		g.addEdge(hostname, version, EdgeServedBy)
+		if g.hasNode(edgeHostnameNodeID(h.EdgeHostnameID)) {
+			g.addEdge(hostname, edgeHostnameNodeID(h.EdgeHostnameID), EdgeCnameTo)
+		}
+		for _, id := range h.EnrollmentIDs {
+			g.addEdge(hostname, enrollmentNodeID(id), EdgeCoveredBy)
+		}
+	}
+	for _, c := range s.SecurityConfigurations {
+		for _, name := range append(append([]string{}, c.SelectedHostnames...), c.MatchTargetHostnames...) {
+			hostname := g.addNode(GraphNode{ID: hostnameNodeID(name), Type: NodeHostname, Label: name})
+			g.addEdge(hostname, securityConfigurationNodeID(c.ConfigID), EdgeProtectedBy)
+		}
+	}
+	for _, r := range s.DNSRecords {
+		var target string
+		for _, t := range r.Targets {
+			if id, ok := edgeHostnamesByDomain[strings.ToLower(t)]; ok {
+				target = edgeHostnameNodeID(id)
+			}
+		}
+		if target == "" && !g.hasNode(hostnameNodeID(r.Name)) && !isEdgeHostnameDomain(r.Targets) {
+			continue
+		}
+		hostname := g.addNode(GraphNode{ID: hostnameNodeID(r.Name), Type: NodeHostname, Label: r.Name})
+		record := g.addNode(GraphNode{ID: dnsRecordNodeID(r.Zone, r.Name), Type: NodeDNSRecord, Label: r.Name + " CNAME " + strings.Join(r.Targets, ",")})
+		g.addEdge(hostname, record, EdgePublishedAs)
+		if target != "" {
+			g.addEdge(record, target, EdgePointsTo)
+		}
+	}
+	return g
+}
+
+// Node returns the node with the given ID
+func (g *Graph) Node(id string) (GraphNode, bool) {
+	i, ok := g.nodes[id]
+	if !ok {
+		return GraphNode{}, false
+	}
+	return g.Nodes[i], true
+}
+
+// Dependencies returns edges from the node with the given ID
+func (g *Graph) Dependencies(id string) []GraphEdge {
+	return g.from[id]
+}
+
+// Dependents returns edges to the node with the given ID
+func (g *Graph) Dependents(id string) []GraphEdge {
+	return g.to[id]
+}
+
+// Orphans returns edge hostnames and enrollments not used by any property hostname, property hostnames not covered
+// by any enrollment and hostnames of security configurations which are not on any active property version.
+// Nodes which could be used by properties whose hostnames were not listed are not reported
+func (g *Graph) Orphans() []GraphIssue {
+	var issues []GraphIssue
+	for _, e := range g.snapshot.EdgeHostnames {
+		id := edgeHostnameNodeID(e.EdgeHostnameID)
+		if !g.hasEdgeTo(id, EdgeCnameTo) && g.snapshot.hostnamesListed(e.ContractID) {
+			issues = append(issues, GraphIssue{Type: IssueUnusedEdgeHostname, NodeID: id,
+				Detail: fmt.Sprintf("edge hostname %s is not used by any property hostname", e.EdgeHostname)})
+		}
+	}
+	for _, e := range g.snapshot.Enrollments {
+		id := enrollmentNodeID(e.EnrollmentID)
+		if !g.hasEdgeTo(id, EdgeCoveredBy) {
+			issues = append(issues, GraphIssue{Type: IssueUnusedEnrollment, NodeID: id,
+				Detail: fmt.Sprintf("enrollment %d (%s) does not cover any property hostname", e.EnrollmentID, e.CN)})
+		}
+	}
+	properties := map[string]Property{}
+	for _, p := range g.snapshot.Properties {
+		properties[p.PropertyID] = p
+	}
+	for _, h := range g.snapshot.PropertyHostnames {
+		id := hostnameNodeID(h.Hostname)
+		if !needsEnrollment(h) || !containsString(g.snapshot.EnrollmentContractIDs, properties[h.PropertyID].ContractID) ||
+			g.hasEdgeFrom(id, EdgeCoveredBy) {
+			continue
+		}
+		issues = appendIssue(issues, GraphIssue{Type: IssueUncoveredHostname, NodeID: id,
+			Detail: fmt.Sprintf("hostname %s is not covered by any enrollment", h.Hostname)})
+	}
+	allListed := g.snapshot.hostnamesListed("")
+	for _, c := range g.snapshot.SecurityConfigurations {
+		for _, name := range append(append([]string{}, c.SelectedHostnames...), c.MatchTargetHostnames...) {
+			id := hostnameNodeID(name)
+			if !allListed || g.isServedByActiveVersion(id) {
+				continue
+			}
+			issues = appendIssue(issues, GraphIssue{Type: IssueOrphanedSecurityHostname, NodeID: id,
+				Detail: fmt.Sprintf("hostname %s of security configuration %d is not on any active property version", name, c.ConfigID)})
+		}
+	}
+	sortIssues(issues)
+	return issues
+}
+
+// BrokenChains returns property hostnames using edge hostnames which were not found, hostnames of active property
+// versions without DNS record or with a DNS record targeting a different edge hostname, and DNS records pointing
+// to edge hostnames for names which are not on any active property version.
+// DNS records are checked only for hostnames in zones listed by the crawl
+func (g *Graph) BrokenChains() []GraphIssue {
+	var issues []GraphIssue
+	for _, h := range g.snapshot.PropertyHostnames {
+		id := hostnameNodeID(h.Hostname)
+		if h.EdgeHostnameID != "" && !g.hasNode(edgeHostnameNodeID(h.EdgeHostnameID)) {
+			issues = append(issues, GraphIssue{Type: IssueMissingEdgeHostname, NodeID: id,
+				Detail: fmt.Sprintf("edge hostname %s of hostname %s was not found", h.EdgeHostnameID, h.Hostname)})
+		}
+	}
+
+	for _, h := range g.snapshot.PropertyHostnames {
+		id := hostnameNodeID(h.Hostname)
+		if !g.isServedByActiveVersion(id) || g.snapshot.dnsZone(h.Hostname) == "" {
+			continue
+		}
+		records := g.dnsRecords(h.Hostname)
+		if len(records) == 0 {
+			issues = appendIssue(issues, GraphIssue{Type: IssueMissingDNSRecord, NodeID: id,
+				Detail: fmt.Sprintf("hostname %s has no CNAME record in zone %s", h.Hostname, g.snapshot.dnsZone(h.Hostname))})
+			continue
+		}
+		if h.CnameTo == "" {
+			continue
+		}
+		for _, r := range records {
+			if !containsFold(r.Targets, h.CnameTo) {
+				issues = appendIssue(issues, GraphIssue{Type: IssueDNSTargetMismatch, NodeID: dnsRecordNodeID(r.Zone, r.Name),
+					Detail: fmt.Sprintf("CNAME record of %s targets %s instead of %s", r.Name, strings.Join(r.Targets, ","), h.CnameTo)})
+			}
+		}
+	}
+
+	for _, r := range g.snapshot.DNSRecords {
+		id := dnsRecordNodeID(r.Zone, r.Name)
+		if !g.hasNode(id) || (!g.hasEdgeFrom(id, EdgePointsTo) && !isEdgeHostnameDomain(r.Targets)) {
+			continue
+		}
+		if !g.isServedByActiveVersion(hostnameNodeID(r.Name)) {
+			issues = append(issues, GraphIssue{Type: IssueDNSRecordWithoutProperty, NodeID: id,
+				Detail: fmt.Sprintf("CNAME record of %s points to %s but %s is not on any active property version",
+					r.Name, strings.Join(r.Targets, ","), r.Name)})
+		}
+	}
+	sortIssues(issues)
+	return issues
+}
+
+// WriteJSON writes nodes, edges, orphans and broken chains of the graph to w as indented JSON
+func (g *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Nodes        []GraphNode  `json:"nodes"`
+		Edges        []GraphEdge  `json:"edges"`
+		Orphans      []GraphIssue `json:"orphans"`
+		BrokenChains []GraphIssue `json:"brokenChains"`
+	}{
+		Nodes:        g.Nodes,
+		Edges:        g.Edges,
+		Orphans:      g.Orphans(),
+		BrokenChains: g.BrokenChains(),
+	})
+}
+
+// WriteDOT writes the graph to w in Graphviz DOT format. Nodes with orphans or broken chains are drawn in red
+// and inactive property versions with dashed lines
+func (g *Graph) WriteDOT(w io.Writer) error {
+	issues := map[string]bool{}
+	for _, issue := range append(g.Orphans(), g.BrokenChains()...) {
+		issues[issue.NodeID] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph hostnames {\n\trankdir=LR;\n")
+	for _, n := range g.Nodes {
+		attrs := []string{"label=" + strconv.Quote(n.Label), "shape=" + nodeShapes[n.Type]}
+		if n.Type == NodePropertyVersion && !n.Active {
+			attrs = append(attrs, "style=dashed")
+		}
+		if issues[n.ID] {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&b, "\t%s [%s];\n", strconv.Quote(n.ID), strings.Join(attrs, ", "))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(string(e.Type)))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var nodeShapes = map[NodeType]string{
+	NodeHostname:              "ellipse",
+	NodePropertyVersion:       "box",
+	NodeEdgeHostname:          "hexagon",
+	NodeEnrollment:            "note",
+	NodeSecurityConfiguration: "octagon",
+	NodeDNSRecord:             "cds",
+}
+
+func (g *Graph) addNode(node GraphNode) string {
+	if _, ok := g.nodes[node.ID]; !ok {
+		g.nodes[node.ID] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, node)
+	}
+	return node.ID
+}
+
+func (g *Graph) hasNode(id string) bool {
+	_, ok := g.nodes[id]
+	return ok
+}
+
+func (g *Graph) addEdge(from, to string, edgeType EdgeType) {
+	edge := GraphEdge{From: from, To: to, Type: edgeType}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, edge)
+	g.from[from] = append(g.from[from], edge)
+	g.to[to] = append(g.to[to], edge)
+}
+
+func (g *Graph) hasEdgeFrom(id string, edgeType EdgeType) bool {
+	for _, e := range g.from[id] {
+		if e.Type == edgeType {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Graph) hasEdgeTo(id string, edgeType EdgeType) bool {
+	for _, e := range g.to[id] {
+		if e.Type == edgeType {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Graph) isServedByActiveVersion(hostnameID string) bool {
+	for _, e := range g.from[hostnameID] {
+		if node, ok := g.Node(e.To); ok && e.Type == EdgeServedBy && node.Active {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Graph) dnsRecords(hostname string) []DNSRecord {
+	var records []DNSRecord
+	for _, r := range g.snapshot.DNSRecords {
+		if strings.EqualFold(r.Name, hostname) {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// hostnamesListed returns true if properties of all groups of the contract and hostnames of all its properties
+// were listed completely. If contractID is empty, groups and properties of all contracts are checked
+func (s *Snapshot) hostnamesListed(contractID string) bool {
+	for _, group := range s.Groups {
+		if (contractID == "" || containsString(group.ContractIDs, contractID)) && !containsString(s.PropertyGroupIDs, group.GroupID) {
+			return false
+		}
+	}
+	for _, property := range s.Properties {
+		if (contractID == "" || property.ContractID == contractID) && !containsString(s.HostnamePropertyIDs, property.PropertyID) {
+			return false
+		}
+	}
+	return true
+}
+
+// dnsZone returns the longest crawled DNS zone the hostname belongs to, or an empty string
+func (s *Snapshot) dnsZone(hostname string) string {
+	var zone string
+	for _, z := range s.DNSZones {
+		if (strings.EqualFold(hostname, z) || strings.HasSuffix(strings.ToLower(hostname), "."+strings.ToLower(z))) && len(z) > len(zone) {
+			zone = z
+		}
+	}
+	return zone
+}
+
+// needsEnrollment returns false for hostnames whose certificate is not managed in CPS: hostnames with DEFAULT
+// certificate provisioning type and hostnames on non-secure (edgesuite.net) or shared certificate (akamaized.net) edge hostnames
+func needsEnrollment(h PropertyHostname) bool {
+	cnameTo := strings.ToLower(h.CnameTo)
+	return h.CertProvisioningType != "DEFAULT" &&
+		!strings.HasSuffix(cnameTo, ".edgesuite.net") && !strings.HasSuffix(cnameTo, ".akamaized.net")
+}
+
+func isEdgeHostnameDomain(targets []string) bool {
+	for _, t := range targets {
+		for _, suffix := range edgeHostnameSuffixes {
+			if strings.HasSuffix(strings.ToLower(t), suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// appendIssue appends the issue unless the same issue was already reported for the node,
+// e.g. for a hostname of several property versions
+func appendIssue(issues []GraphIssue, issue GraphIssue) []GraphIssue {
+	for _, i := range issues {
+		if i.Type == issue.Type && i.NodeID == issue.NodeID {
+			return issues
+		}
+	}
+	return append(issues, issue)
+}
+
+func sortIssues(issues []GraphIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Type != issues[j].Type {
+			return issues[i].Type < issues[j].Type
+		}
+		return issues[i].NodeID < issues[j].NodeID
+	})
+}
+
+func hostnameNodeID(hostname string) string {
+	return "hostname:" + strings.ToLower(hostname)
+}
+
+func propertyVersionNodeID(propertyID string, version int) string {
+	return fmt.Sprintf("propertyVersion:%s:%d", propertyID, version)
+}
+
+func edgeHostnameNodeID(id string) string {
+	return "edgeHostname:" + id
+}
+
+func enrollmentNodeID(id int) string {
+	return "enrollment:" + strconv.Itoa(id)
+}
+
+func securityConfigurationNodeID(id int) string {
+	return "securityConfiguration:" + strconv.Itoa(id)
+}
+
+func dnsRecordNodeID(zone, name string) string {
+	return "dnsRecord:" + zone + ":" + strings.ToLower(name)
+}
@@ -0,0 +1,206 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var graphSnapshot = Snapshot{
+	Properties: []Property{
+		{PropertyID: "prp_1", PropertyName: "www", ContractID: "ctr_1", StagingVersion: 4, ProductionVersion: 3, HostnamesVersions: []int{3, 4}},
+		{PropertyID: "prp_2", PropertyName: "new", ContractID: "ctr_1", LatestVersion: 1, HostnamesVersions: []int{1}},
+		{PropertyID: "prp_3", PropertyName: "other", ContractID: "ctr_2", LatestVersion: 1, HostnamesVersions: []int{1}},
+	},
+	HostnamePropertyIDs: []string{"prp_1", "prp_2", "prp_3"},
+	PropertyHostnames: []PropertyHostname{
+		{
+			Hostname: "www.example.com", PropertyID: "prp_1", PropertyVersion: 3, EdgeHostnameID: "ehn_1",
+			CnameTo: "www.example.com.edgekey.net", CertProvisioningType: "CPS_MANAGED", EnrollmentIDs: []int{10002}, SecurityConfigIDs: []int{43253},
+		},
+		{
+			Hostname: "api.example.com", PropertyID: "prp_1", PropertyVersion: 3, EdgeHostnameID: "ehn_1",
+			CnameTo: "www.example.com.edgekey.net", CertProvisioningType: "CPS_MANAGED", EnrollmentIDs: []int{10002}, SecurityConfigIDs: []int{43253},
+		},
+		{
+			Hostname: "beta.example.com", PropertyID: "prp_1", PropertyVersion: 4, EdgeHostnameID: "ehn_1",
+			CnameTo: "www.example.com.edgekey.net", CertProvisioningType: "CPS_MANAGED", EnrollmentIDs: []int{10002}, SecurityConfigIDs: []int{43253},
+		},
+		{
+			Hostname: "legacy.example.com", PropertyID: "prp_1", PropertyVersion: 3, EdgeHostnameID: "ehn_404",
+			CnameTo: "legacy.example.com.edgesuite.net", CertProvisioningType: "DEFAULT",
+		},
+		{
+			Hostname: "static.example.net", PropertyID: "prp_1", PropertyVersion: 3, EdgeHostnameID: "ehn_4",
+			CnameTo: "static.example.net.edgesuite.net", CertProvisioningType: "CPS_MANAGED",
+		},
+		{
+			Hostname: "new.example.org", PropertyID: "prp_2", PropertyVersion: 1, EdgeHostnameID: "ehn_2",
+			CnameTo: "new.example.org.edgekey.net", CertProvisioningType: "CPS_MANAGED",
+		},
+		{
+			Hostname: "other.example.org", PropertyID: "prp_3", PropertyVersion: 1, EdgeHostnameID: "ehn_5",
+			CnameTo: "other.example.org.edgekey.net", CertProvisioningType: "CPS_MANAGED",
+		},
+	},
+	EdgeHostnames: []EdgeHostname{
+		{EdgeHostnameID: "ehn_1", ContractID: "ctr_1", EdgeHostname: "www.example.com.edgekey.net"},
+		{EdgeHostnameID: "ehn_2", ContractID: "ctr_1", EdgeHostname: "new.example.org.edgekey.net"},
+		{EdgeHostnameID: "ehn_3", ContractID: "ctr_1", EdgeHostname: "old.example.com.edgesuite.net"},
+		{EdgeHostnameID: "ehn_4", ContractID: "ctr_1", EdgeHostname: "static.example.net.edgesuite.net"},
+		{EdgeHostnameID: "ehn_5", ContractID: "ctr_2", EdgeHostname: "other.example.org.edgekey.net"},
+		{EdgeHostnameID: "ehn_6", ContractID: "ctr_2", EdgeHostname: "unused.example.org.edgekey.net"},
+	},
+	EnrollmentContractIDs: []string{"ctr_1"},
+	Enrollments: []Enrollment{
+		{EnrollmentID: 10002, CN: "www.example.com"},
+		{EnrollmentID: 10003, CN: "unused.example.com"},
+	},
+	SecurityConfigurations: []SecurityConfiguration{
+		{
+			ConfigID: 43253, Name: "WAF", SelectedHostnames: []string{"www.example.com", "beta.example.com", "shop.example.com"},
+			MatchTargetHostnames: []string{"api.example.com"},
+		},
+	},
+	DNSZones: []string{"example.com"},
+	DNSRecords: []DNSRecord{
+		{Zone: "example.com", Name: "www.example.com", Type: "CNAME", Targets: []string{"www.example.com.edgekey.net"}},
+		{Zone: "example.com", Name: "beta.example.com", Type: "CNAME", Targets: []string{"www.example.com.edgekey.net"}},
+		{Zone: "example.com", Name: "api.example.com", Type: "CNAME", Targets: []string{"api.example.com.cdn.example.net"}},
+		{Zone: "example.com", Name: "old.example.com", Type: "CNAME", Targets: []string{"old.example.com.edgesuite.net"}},
+		{Zone: "example.com", Name: "mail.example.com", Type: "CNAME", Targets: []string{"mail.example.net"}},
+	},
+}
+
+func TestNewGraph(t *testing.T) {
+	g := NewGraph(&graphSnapshot)
+
+	node, ok := g.Node("propertyVersion:prp_1:3")
+	require.True(t, ok)
+	assert.Equal(t, GraphNode{ID: "propertyVersion:prp_1:3", Type: NodePropertyVersion, Label: "www v3", Active: true}, node)
+	node, ok = g.Node("propertyVersion:prp_1:4")
+	require.True(t, ok)
+	assert.True(t, node.Active)
+	node, ok = g.Node("propertyVersion:prp_2:1")
+	require.True(t, ok)
+	assert.False(t, node.Active)
+	_, ok = g.Node("dnsRecord:example.com:mail.example.com")
+	assert.False(t, ok)
+
+	assert.Equal(t, []GraphEdge{
+		{From: "hostname:www.example.com", To: "propertyVersion:prp_1:3", Type: EdgeServedBy},
+		{From: "hostname:www.example.com", To: "edgeHostname:ehn_1", Type: EdgeCnameTo},
+		{From: "hostname:www.example.com", To: "enrollment:10002", Type: EdgeCoveredBy},
+		{From: "hostname:www.example.com", To: "securityConfiguration:43253", Type: EdgeProtectedBy},
+		{From: "hostname:www.example.com", To: "dnsRecord:example.com:www.example.com", Type: EdgePublishedAs},
+	}, g.Dependencies("hostname:www.example.com"))
+	assert.Equal(t, []GraphEdge{
+		{From: "dnsRecord:example.com:old.example.com", To: "edgeHostname:ehn_3", Type: EdgePointsTo},
+	}, g.Dependents("edgeHostname:ehn_3"))
+}
+
+func TestGraph_Orphans(t *testing.T) {
+	issues := NewGraph(&graphSnapshot).Orphans()
+
+	assert.Equal(t, [][]string{
+		{string(IssueOrphanedSecurityHostname), "hostname:shop.example.com"},
+		{string(IssueUncoveredHostname), "hostname:new.example.org"},
+		{string(IssueUnusedEdgeHostname), "edgeHostname:ehn_3"},
+		{string(IssueUnusedEdgeHostname), "edgeHostname:ehn_6"},
+		{string(IssueUnusedEnrollment), "enrollment:10003"},
+	}, issueKeys(issues))
+	assert.Equal(t, "hostname shop.example.com of security configuration 43253 is not on any active property version", issues[0].Detail)
+}
+
+func TestGraph_OrphansIncompleteCrawl(t *testing.T) {
+	tests := map[string]struct {
+		modify   func(*Snapshot)
+		expected [][]string
+	}{
+		"hostnames of a property not listed": {
+			modify: func(s *Snapshot) {
+				s.HostnamePropertyIDs = []string{"prp_1", "prp_2"}
+			},
+			expected: [][]string{
+				{string(IssueUncoveredHostname), "hostname:new.example.org"},
+				{string(IssueUnusedEdgeHostname), "edgeHostname:ehn_3"},
+				{string(IssueUnusedEnrollment), "enrollment:10003"},
+			},
+		},
+		"properties of a group not listed": {
+			modify: func(s *Snapshot) {
+				s.Groups = []Group{
+					{GroupID: "grp_1", ContractIDs: []string{"ctr_1"}},
+					{GroupID: "grp_2", ContractIDs: []string{"ctr_2"}},
+				}
+				s.PropertyGroupIDs = []string{"grp_2"}
+			},
+			expected: [][]string{
+				{string(IssueUncoveredHostname), "hostname:new.example.org"},
+				{string(IssueUnusedEdgeHostname), "edgeHostname:ehn_6"},
+				{string(IssueUnusedEnrollment), "enrollment:10003"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			snapshot := graphSnapshot
+			test.modify(&snapshot)
+			assert.Equal(t, test.expected, issueKeys(NewGraph(&snapshot).Orphans()))
+		})
+	}
+}
+
+func TestGraph_BrokenChains(t *testing.T) {
+	issues := NewGraph(&graphSnapshot).BrokenChains()
+
+	assert.Equal(t, [][]string{
+		{string(IssueDNSRecordWithoutProperty), "dnsRecord:example.com:old.example.com"},
+		{string(IssueDNSTargetMismatch), "dnsRecord:example.com:api.example.com"},
+		{string(IssueMissingDNSRecord), "hostname:legacy.example.com"},
+		{string(IssueMissingEdgeHostname), "hostname:legacy.example.com"},
+	}, issueKeys(issues))
+	assert.Equal(t, "CNAME record of api.example.com targets api.example.com.cdn.example.net instead of www.example.com.edgekey.net", issues[1].Detail)
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, NewGraph(&graphSnapshot).WriteDOT(&buf))
+
+	dot := buf.String()
+	assert.Contains(t, dot, "digraph hostnames {\n")
+	assert.Contains(t, dot, "\t\"propertyVersion:prp_2:1\" [label=\"new v1\", shape=box, style=dashed];\n")
+	assert.Contains(t, dot, "\t\"edgeHostname:ehn_3\" [label=\"old.example.com.edgesuite.net\", shape=hexagon, color=red];\n")
+	assert.Contains(t, dot, "\t\"hostname:www.example.com\" -> \"edgeHostname:ehn_1\" [label=\"CNAME_TO\"];\n")
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("}\n")))
+}
+
+func TestGraph_WriteJSON(t *testing.T) {
+	g := NewGraph(&graphSnapshot)
+	var buf bytes.Buffer
+	require.NoError(t, g.WriteJSON(&buf))
+
+	var decoded struct {
+		Nodes        []GraphNode  `json:"nodes"`
+		Edges        []GraphEdge  `json:"edges"`
+		Orphans      []GraphIssue `json:"orphans"`
+		BrokenChains []GraphIssue `json:"brokenChains"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, g.Nodes, decoded.Nodes)
+	assert.Equal(t, g.Edges, decoded.Edges)
+	assert.Equal(t, g.Orphans(), decoded.Orphans)
+	assert.Equal(t, g.BrokenChains(), decoded.BrokenChains)
+}
+
+func issueKeys(issues []GraphIssue) [][]string {
+	keys := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		keys = append(keys, []string{string(issue.Type), issue.NodeID})
+	}
+	return keys
+}
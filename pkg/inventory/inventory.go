@@ -0,0 +1,709 @@
+// Package inventory provides a crawler taking a snapshot of contracts, groups, properties, hostnames, edge hostnames,
+// CPS enrollments, security configurations and DNS records existing in an account, and a dependency graph of hostnames
+// built from the snapshot
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/appsec"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/cps"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/dns"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrCrawl represents error when inventory cannot be crawled
+	ErrCrawl = errors.New("crawling inventory")
+)
+
+type (
+	// Inventory is the interface of the account inventory crawler
+	Inventory interface {
+		// Crawl walks PAPI, HAPI, CPS, Application Security and Edge DNS APIs and returns a snapshot of what exists
+		// in the account. Only listing contracts and groups is required to succeed, other failures, e.g. 403 on groups
+		// the client cannot access, are recorded in Snapshot.Errors and the crawl continues
+		Crawl(context.Context, CrawlRequest) (*Snapshot, error)
+	}
+
+	inventory struct {
+		papi        papi.PAPI
+		hapi        hapi.HAPI
+		cps         cps.CPS
+		appsec      appsec.APPSEC
+		dns         dns.DNS
+		concurrency int
+		now         func() time.Time
+	}
+
+	// Option defines an Inventory option
+	Option func(*inventory)
+
+	// ClientFunc is an Inventory client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) Inventory
+
+	// CrawlRequest limits the crawled part of the account
+	CrawlRequest struct {
+		// ContractIDs limits the crawl to the given contracts, all contracts are crawled if empty
+		ContractIDs []string
+		// SkipEnrollments disables listing CPS enrollments
+		SkipEnrollments bool
+		// SkipSecurityConfigurations disables listing application security configurations
+		SkipSecurityConfigurations bool
+		// DNSZones are Edge DNS zones from which CNAME records are listed
+		DNSZones []string
+	}
+
+	contractGroup struct {
+		contractID string
+		groupID    string
+	}
+
+	// crawl holds the state of a single Crawl call
+	crawl struct {
+		*inventory
+		snapshot *Snapshot
+		mu       sync.Mutex
+	}
+)
+
+// DefaultConcurrency is the number of concurrent requests made by the crawler if not set with WithConcurrency
+const DefaultConcurrency = 5
+
+// Client returns a new inventory Client instance, using the given session for PAPI, HAPI, CPS, Application Security
+// and Edge DNS clients
+func Client(sess session.Session, opts ...Option) Inventory {
+	i := &inventory{
+		papi:        papi.Client(sess),
+		hapi:        hapi.Client(sess),
+		cps:         cps.Client(sess),
+		appsec:      appsec.Client(sess),
+		dns:         dns.Client(sess),
+		concurrency: DefaultConcurrency,
+		now:         time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// WithConcurrency sets the maximum number of concurrent requests made by the crawler
+func WithConcurrency(concurrency int) Option {
+	return func(i *inventory) {
+		if concurrency > 0 {
+			i.concurrency = concurrency
+		}
+	}
+}
+
+// WithPAPI sets the PAPI client used by the crawler
+func WithPAPI(client papi.PAPI) Option {
+	return func(i *inventory) {
+		i.papi = client
+	}
+}
+
+// WithHAPI sets the HAPI client used by the crawler
+func WithHAPI(client hapi.HAPI) Option {
+	return func(i *inventory) {
+		i.hapi = client
+	}
+}
+
+// WithCPS sets the CPS client used by the crawler
+func WithCPS(client cps.CPS) Option {
+	return func(i *inventory) {
+		i.cps = client
+	}
+}
+
+// WithAppSec sets the Application Security client used by the crawler
+func WithAppSec(client appsec.APPSEC) Option {
+	return func(i *inventory) {
+		i.appsec = client
+	}
+}
+
+// WithDNS sets the Edge DNS client used by the crawler
+func WithDNS(client dns.DNS) Option {
+	return func(i *inventory) {
+		i.dns = client
+	}
+}
+
+func (i *inventory) Crawl(ctx context.Context, params CrawlRequest) (*Snapshot, error) {
+	c := &crawl{
+		inventory: i,
+		snapshot:  &Snapshot{CreatedAt: i.now().UTC()},
+	}
+
+	if err := c.crawlContractsAndGroups(ctx, params); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCrawl, err)
+	}
+	c.crawlProperties(ctx)
+	c.crawlPropertyHostnames(ctx)
+	c.crawlEdgeHostnames(ctx)
+	if !params.SkipEnrollments {
+		c.crawlEnrollments(ctx)
+	}
+	if !params.SkipSecurityConfigurations {
+		c.crawlSecurityConfigurations(ctx)
+	}
+	c.crawlDNSRecords(ctx, params.DNSZones)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCrawl, err)
+	}
+
+	c.snapshot.link()
+	return c.snapshot, nil
+}
+
+func (c *crawl) crawlContractsAndGroups(ctx context.Context, params CrawlRequest) error {
+	contracts, err := c.papi.GetContracts(ctx)
+	if err != nil {
+		return err
+	}
+	groups, err := c.papi.GetGroups(ctx)
+	if err != nil {
+		return err
+	}
+	c.snapshot.AccountID = groups.AccountID
+
+	included := func(contractID string) bool {
+		if len(params.ContractIDs) == 0 {
+			return true
+		}
+		for _, id := range params.ContractIDs {
+			if papi.ContractID(id).Bare() == papi.ContractID(contractID).Bare() {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, contract := range contracts.Contracts.Items {
+		if !included(contract.ContractID) {
+			continue
+		}
+		c.snapshot.Contracts = append(c.snapshot.Contracts, Contract{
+			ContractID:       contract.ContractID,
+			ContractTypeName: contract.ContractTypeName,
+		})
+	}
+	for _, group := range groups.Groups.Items {
+		var contractIDs []string
+		for _, id := range group.ContractIDs {
+			if included(id) {
+				contractIDs = append(contractIDs, id)
+			}
+		}
+		if len(contractIDs) == 0 {
+			continue
+		}
+		c.snapshot.Groups = append(c.snapshot.Groups, Group{
+			GroupID:       group.GroupID,
+			GroupName:     group.GroupName,
+			ParentGroupID: group.ParentGroupID,
+			ContractIDs:   contractIDs,
+		})
+	}
+	return nil
+}
+
+// contractGroups returns every pair of crawled group and its contract, which PAPI requires to list properties and edge hostnames
+func (c *crawl) contractGroups() []contractGroup {
+	var pairs []contractGroup
+	for _, group := range c.snapshot.Groups {
+		for _, contractID := range group.ContractIDs {
+			pairs = append(pairs, contractGroup{contractID: contractID, groupID: group.GroupID})
+		}
+	}
+	return pairs
+}
+
+func (c *crawl) crawlProperties(ctx context.Context) {
+	pairs := c.contractGroups()
+	results := make([][]Property, len(pairs))
+	listed := make([]bool, len(pairs))
+	c.forEach(ctx, len(pairs), func(ctx context.Context, i int) {
+		resp, err := c.papi.GetProperties(ctx, papi.GetPropertiesRequest{ContractID: pairs[i].contractID, GroupID: pairs[i].groupID})
+		if err != nil {
+			c.recordError(EntityGroups, pairs[i].groupID, err)
+			return
+		}
+		listed[i] = true
+		for _, property := range resp.Properties.Items {
+			results[i] = append(results[i], Property{
+				PropertyID:        property.PropertyID,
+				PropertyName:      property.PropertyName,
+				ContractID:        pairs[i].contractID,
+				GroupID:           pairs[i].groupID,
+				ProductID:         property.ProductID,
+				RuleFormat:        property.RuleFormat,
+				LatestVersion:     property.LatestVersion,
+				StagingVersion:    intValue(property.StagingVersion),
+				ProductionVersion: intValue(property.ProductionVersion),
+			})
+		}
+	})
+	// a group is listed completely only if its properties were listed in all of its contracts
+	unlisted := map[string]bool{}
+	for i, properties := range results {
+		if !listed[i] {
+			unlisted[pairs[i].groupID] = true
+		}
+		c.snapshot.Properties = append(c.snapshot.Properties, properties...)
+	}
+	for _, group := range c.snapshot.Groups {
+		if !unlisted[group.GroupID] {
+			c.snapshot.PropertyGroupIDs = append(c.snapshot.PropertyGroupIDs, group.GroupID)
+		}
+	}
+}
+
+// crawlPropertyHostnames lists hostnames of versions active on production and staging or, if there is none, of the latest version
+func (c *crawl) crawlPropertyHostnames(ctx context.Context) {
+	type propertyVersion struct {
+		property *Property
+		version  int
+	}
+	var versions []propertyVersion
+	for i := range c.snapshot.Properties {
+		property := &c.snapshot.Properties[i]
+		for _, version := range hostnamesVersions(*property) {
+			versions = append(versions, propertyVersion{property: property, version: version})
+		}
+	}
+
+	results := make([][]PropertyHostname, len(versions))
+	listed := make([]bool, len(versions))
+	c.forEach(ctx, len(versions), func(ctx context.Context, i int) {
+		property, version := versions[i].property, versions[i].version
+		resp, err := c.papi.GetPropertyVersionHostnames(ctx, papi.GetPropertyVersionHostnamesRequest{
+			PropertyID:      property.PropertyID,
+			PropertyVersion: version,
+			ContractID:      property.ContractID,
+			GroupID:         property.GroupID,
+		})
+		if err != nil {
+			c.recordError(EntityProperties, property.PropertyID, err)
+			return
+		}
+		listed[i] = true
+		for _, hostname := range resp.Hostnames.Items {
+			results[i] = append(results[i], PropertyHostname{
+				Hostname:             hostname.CnameFrom,
+				PropertyID:           property.PropertyID,
+				PropertyVersion:      version,
+				CnameType:            string(hostname.CnameType),
+				EdgeHostnameID:       hostname.EdgeHostnameID,
+				CnameTo:              hostname.CnameTo,
+				CertProvisioningType: hostname.CertProvisioningType,
+			})
+		}
+	})
+	unlisted := map[string]bool{}
+	for i, hostnames := range results {
+		if listed[i] {
+			versions[i].property.HostnamesVersions = append(versions[i].property.HostnamesVersions, versions[i].version)
+		} else {
+			unlisted[versions[i].property.PropertyID] = true
+		}
+		c.snapshot.PropertyHostnames = append(c.snapshot.PropertyHostnames, hostnames...)
+	}
+	for _, property := range c.snapshot.Properties {
+		if !unlisted[property.PropertyID] {
+			c.snapshot.HostnamePropertyIDs = append(c.snapshot.HostnamePropertyIDs, property.PropertyID)
+		}
+	}
+}
+
+// hostnamesVersions returns versions of the property active on production and staging, or the latest version if it is not active
+func hostnamesVersions(property Property) []int {
+	var versions []int
+	if property.ProductionVersion != 0 {
+		versions = append(versions, property.ProductionVersion)
+	}
+	if property.StagingVersion != 0 && property.StagingVersion != property.ProductionVersion {
+		versions = append(versions, property.StagingVersion)
+	}
+	if len(versions) == 0 && property.LatestVersion != 0 {
+		versions = append(versions, property.LatestVersion)
+	}
+	return versions
+}
+
+// crawlEdgeHostnames lists edge hostnames of every group and fetches details of them, and of edge hostnames
+// referenced by property hostnames, from HAPI
+func (c *crawl) crawlEdgeHostnames(ctx context.Context) {
+	pairs := c.contractGroups()
+	listed := make([][]papi.EdgeHostnameGetItem, len(pairs))
+	c.forEach(ctx, len(pairs), func(ctx context.Context, i int) {
+		resp, err := c.papi.GetEdgeHostnames(ctx, papi.GetEdgeHostnamesRequest{ContractID: pairs[i].contractID, GroupID: pairs[i].groupID})
+		if err != nil {
+			c.recordError(EntityGroups, pairs[i].groupID, err)
+			return
+		}
+		listed[i] = resp.EdgeHostnames.Items
+	})
+
+	var ids []string
+	edgeHostnames := map[string]*EdgeHostname{}
+	for i, items := range listed {
+		for _, item := range items {
+			if edgeHostnames[item.ID] != nil {
+				continue
+			}
+			ids = append(ids, item.ID)
+			edgeHostnames[item.ID] = &EdgeHostname{
+				EdgeHostnameID:    item.ID,
+				ContractID:        pairs[i].contractID,
+				EdgeHostname:      item.Domain,
+				ProductID:         item.ProductID,
+				IPVersionBehavior: item.IPVersionBehavior,
+			}
+		}
+	}
+	for _, hostname := range c.snapshot.PropertyHostnames {
+		if hostname.EdgeHostnameID == "" {
+			continue
+		}
+		if _, ok := edgeHostnames[hostname.EdgeHostnameID]; !ok {
+			ids = append(ids, hostname.EdgeHostnameID)
+			edgeHostnames[hostname.EdgeHostnameID] = nil
+		}
+	}
+
+	details := make([]*hapi.GetEdgeHostnameResponse, len(ids))
+	c.forEach(ctx, len(ids), func(ctx context.Context, i int) {
+		id, err := strconv.Atoi(papi.EdgeHostnameID(ids[i]).Bare())
+		if err != nil {
+			c.recordError(EntityEdgeHostnames, ids[i], err)
+			return
+		}
+		resp, err := c.hapi.GetEdgeHostname(ctx, id)
+		if err != nil {
+			c.recordError(EntityEdgeHostnames, ids[i], err)
+			return
+		}
+		details[i] = resp
+	})
+	for i, id := range ids {
+		edgeHostname, resp := edgeHostnames[id], details[i]
+		if resp != nil {
+			if edgeHostname == nil {
+				edgeHostname = &EdgeHostname{EdgeHostnameID: id, IPVersionBehavior: resp.IPVersionBehavior}
+			}
+			edgeHostname.EdgeHostname = resp.RecordName + "." + resp.DNSZone
+			edgeHostname.ProductID = resp.ProductID
+			edgeHostname.SecurityType = resp.SecurityType
+			edgeHostname.Map = resp.Map
+			edgeHostname.TTL = resp.TTL
+		}
+		if edgeHostname != nil {
+			c.snapshot.EdgeHostnames = append(c.snapshot.EdgeHostnames, *edgeHostname)
+		}
+	}
+}
+
+func (c *crawl) crawlEnrollments(ctx context.Context) {
+	contracts := c.snapshot.Contracts
+	results := make([][]Enrollment, len(contracts))
+	listed := make([]bool, len(contracts))
+	c.forEach(ctx, len(contracts), func(ctx context.Context, i int) {
+		contractID := contracts[i].ContractID
+		resp, err := c.cps.ListEnrollments(ctx, cps.ListEnrollmentsRequest{ContractID: papi.ContractID(contractID).Bare()})
+		if err != nil {
+			c.recordError(EntityEnrollments, contractID, err)
+			return
+		}
+		listed[i] = true
+		for _, enrollment := range resp.Enrollments {
+			id, err := cps.GetIDFromLocation(enrollment.Location)
+			if err != nil {
+				c.recordError(EntityEnrollments, enrollment.Location, err)
+				listed[i] = false
+				continue
+			}
+			e := Enrollment{
+				EnrollmentID:    id,
+				ContractID:      contractID,
+				CertificateType: enrollment.CertificateType,
+				ValidationType:  enrollment.ValidationType,
+				RA:              enrollment.RA,
+			}
+			if enrollment.CSR != nil {
+				e.CN = enrollment.CSR.CN
+				e.SANs = enrollment.CSR.SANS
+			}
+			if enrollment.NetworkConfiguration != nil {
+				e.SecureNetwork = enrollment.NetworkConfiguration.SecureNetwork
+			}
+			results[i] = append(results[i], e)
+		}
+	})
+	for i, enrollments := range results {
+		if listed[i] {
+			c.snapshot.EnrollmentContractIDs = append(c.snapshot.EnrollmentContractIDs, contracts[i].ContractID)
+		}
+		c.snapshot.Enrollments = append(c.snapshot.Enrollments, enrollments...)
+	}
+}
+
+// crawlSecurityConfigurations lists selected hostnames and hostnames of match targets of the version active on production, staging or,
+// if there is none, the latest version of every security configuration
+func (c *crawl) crawlSecurityConfigurations(ctx context.Context) {
+	resp, err := c.appsec.GetConfigurations(ctx, appsec.GetConfigurationsRequest{})
+	if err != nil {
+		c.recordError(EntitySecurityConfigurations, "", err)
+		return
+	}
+
+	configurations := make([]SecurityConfiguration, len(resp.Configurations))
+	for i, configuration := range resp.Configurations {
+		configurations[i] = SecurityConfiguration{
+			ConfigID:            configuration.ID,
+			Name:                configuration.Name,
+			LatestVersion:       configuration.LatestVersion,
+			StagingVersion:      configuration.StagingVersion,
+			ProductionVersion:   configuration.ProductionVersion,
+			ProductionHostnames: configuration.ProductionHostnames,
+		}
+	}
+	c.forEach(ctx, len(configurations), func(ctx context.Context, i int) {
+		configuration := &configurations[i]
+		version := configuration.ProductionVersion
+		if version == 0 {
+			version = configuration.StagingVersion
+		}
+		if version == 0 {
+			version = configuration.LatestVersion
+		}
+		selected, err := c.appsec.GetSelectedHostnames(ctx, appsec.GetSelectedHostnamesRequest{ConfigID: configuration.ConfigID, Version: version})
+		if err != nil {
+			c.recordError(EntitySecurityConfigurations, strconv.Itoa(configuration.ConfigID), err)
+			return
+		}
+		configuration.HostnamesVersion = version
+		for _, hostname := range selected.HostnameList {
+			configuration.SelectedHostnames = append(configuration.SelectedHostnames, hostname.Hostname)
+		}
+
+		targets, err := c.appsec.GetMatchTargets(ctx, appsec.GetMatchTargetsRequest{ConfigID: configuration.ConfigID, ConfigVersion: version})
+		if err != nil {
+			c.recordError(EntitySecurityConfigurations, strconv.Itoa(configuration.ConfigID), err)
+			return
+		}
+		for _, target := range targets.MatchTargets.WebsiteTargets {
+			for _, hostname := range target.Hostnames {
+				configuration.MatchTargetHostnames = appendUnique(configuration.MatchTargetHostnames, hostname)
+			}
+		}
+	})
+	c.snapshot.SecurityConfigurations = configurations
+}
+
+func (c *crawl) crawlDNSRecords(ctx context.Context, zones []string) {
+	results := make([][]DNSRecord, len(zones))
+	listed := make([]bool, len(zones))
+	c.forEach(ctx, len(zones), func(ctx context.Context, i int) {
+		zone := strings.TrimSuffix(zones[i], ".")
+		resp, err := c.dns.GetRecordsets(ctx, zone, dns.RecordsetQueryArgs{ShowAll: true, Types: "CNAME"})
+		if err != nil {
+			c.recordError(EntityDNSRecords, zone, err)
+			return
+		}
+		listed[i] = true
+		for _, record := range resp.Recordsets {
+			targets := make([]string, len(record.Rdata))
+			for j, target := range record.Rdata {
+				targets[j] = strings.TrimSuffix(target, ".")
+			}
+			results[i] = append(results[i], DNSRecord{
+				Zone:    zone,
+				Name:    strings.TrimSuffix(record.Name, "."),
+				Type:    record.Type,
+				TTL:     record.TTL,
+				Targets: targets,
+			})
+		}
+	})
+	for i, records := range results {
+		if listed[i] {
+			c.snapshot.DNSZones = append(c.snapshot.DNSZones, strings.TrimSuffix(zones[i], "."))
+		}
+		c.snapshot.DNSRecords = append(c.snapshot.DNSRecords, records...)
+	}
+}
+
+// forEach calls fn for indexes from 0 to n-1 with at most concurrency calls running at the same time.
+// No new calls are made once ctx is done
+func (c *crawl) forEach(ctx context.Context, n int, fn func(context.Context, int)) {
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (c *crawl) recordError(entity Entity, id string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot.Errors = append(c.snapshot.Errors, CrawlError{
+		Entity:     entity,
+		ID:         id,
+		StatusCode: statusCode(err),
+		Detail:     err.Error(),
+	})
+}
+
+// statusCode returns HTTP status code of the API error wrapped in err, or 0 if there is none
+func statusCode(err error) int {
+	var papiErr *papi.Error
+	if errors.As(err, &papiErr) {
+		return papiErr.StatusCode
+	}
+	var hapiErr *hapi.Error
+	if errors.As(err, &hapiErr) {
+		return hapiErr.Status
+	}
+	var cpsErr *cps.Error
+	if errors.As(err, &cpsErr) {
+		return cpsErr.StatusCode
+	}
+	var appsecErr *appsec.Error
+	if errors.As(err, &appsecErr) {
+		return appsecErr.StatusCode
+	}
+	return 0
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// link fills cross-references between entities and sorts errors, which are recorded in the order requests complete
+func (s *Snapshot) link() {
+	sort.Slice(s.Errors, func(i, j int) bool {
+		if s.Errors[i].Entity != s.Errors[j].Entity {
+			return s.Errors[i].Entity < s.Errors[j].Entity
+		}
+		return s.Errors[i].ID < s.Errors[j].ID
+	})
+
+	groupsByID := map[string]*Group{}
+	for i := range s.Groups {
+		groupsByID[s.Groups[i].GroupID] = &s.Groups[i]
+	}
+	for i := range s.Contracts {
+		for _, group := range s.Groups {
+			if containsString(group.ContractIDs, s.Contracts[i].ContractID) {
+				s.Contracts[i].GroupIDs = append(s.Contracts[i].GroupIDs, group.GroupID)
+			}
+		}
+	}
+
+	propertiesByID := map[string]*Property{}
+	for i := range s.Properties {
+		property := &s.Properties[i]
+		propertiesByID[property.PropertyID] = property
+		if group, ok := groupsByID[property.GroupID]; ok {
+			group.PropertyIDs = append(group.PropertyIDs, property.PropertyID)
+		}
+	}
+
+	edgeHostnamesByID := map[string]*EdgeHostname{}
+	for i := range s.EdgeHostnames {
+		edgeHostnamesByID[s.EdgeHostnames[i].EdgeHostnameID] = &s.EdgeHostnames[i]
+	}
+
+	for i := range s.PropertyHostnames {
+		hostname := &s.PropertyHostnames[i]
+		property, ok := propertiesByID[hostname.PropertyID]
+		if ok {
+			property.Hostnames = appendUnique(property.Hostnames, hostname.Hostname)
+		}
+		if edgeHostname, ok := edgeHostnamesByID[hostname.EdgeHostnameID]; ok {
+			edgeHostname.Hostnames = appendUnique(edgeHostname.Hostnames, hostname.Hostname)
+			if hostname.CnameTo == "" {
+				hostname.CnameTo = edgeHostname.EdgeHostname
+			}
+		}
+		for j := range s.Enrollments {
+			enrollment := &s.Enrollments[j]
+			if property != nil && enrollment.ContractID == property.ContractID && enrollment.covers(hostname.Hostname) {
+				hostname.EnrollmentIDs = append(hostname.EnrollmentIDs, enrollment.EnrollmentID)
+				enrollment.Hostnames = appendUnique(enrollment.Hostnames, hostname.Hostname)
+			}
+		}
+		for j := range s.SecurityConfigurations {
+			configuration := &s.SecurityConfigurations[j]
+			if configuration.protects(hostname.Hostname) {
+				hostname.SecurityConfigIDs = append(hostname.SecurityConfigIDs, configuration.ConfigID)
+				configuration.PropertyIDs = appendUnique(configuration.PropertyIDs, hostname.PropertyID)
+			}
+		}
+	}
+}
+
+// covers returns true if the hostname is the common name or one of SANs of the enrollment, including wildcard names
+func (e Enrollment) covers(hostname string) bool {
+	for _, name := range append([]string{e.CN}, e.SANs...) {
+		if strings.EqualFold(name, hostname) {
+			return true
+		}
+		if strings.HasPrefix(name, "*.") {
+			if dot := strings.Index(hostname, "."); dot > 0 && strings.EqualFold(name[1:], hostname[dot:]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// protects returns true if the hostname is selected in the configuration or used by one of its match targets
+func (c SecurityConfiguration) protects(hostname string) bool {
+	return containsString(c.SelectedHostnames, hostname) || containsString(c.MatchTargetHostnames, hostname)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
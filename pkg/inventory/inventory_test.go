@@ -0,0 +1,354 @@
+package inventory
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) Inventory {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	assert.NoError(t, err)
+	return Client(s, opts...)
+}
+
+type mockResponse struct {
+	status int
+	body   string
+}
+
+var inventoryResponses = map[string]mockResponse{
+	"/papi/v1/contracts": {http.StatusOK, `
+{
+    "accountId": "act_1-1TJZFB",
+    "contracts": {"items": [
+        {"contractId": "ctr_1-1TJZFW", "contractTypeName": "DIRECT_CUSTOMER"},
+        {"contractId": "ctr_1-5C13O2", "contractTypeName": "DIRECT_CUSTOMER"}
+    ]}
+}`},
+	"/papi/v1/groups": {http.StatusOK, `
+{
+    "accountId": "act_1-1TJZFB",
+    "groups": {"items": [
+        {"groupId": "grp_15166", "groupName": "Example", "contractIds": ["ctr_1-1TJZFW"]},
+        {"groupId": "grp_15225", "groupName": "Restricted", "parentGroupId": "grp_15166", "contractIds": ["ctr_1-1TJZFW"]},
+        {"groupId": "grp_20000", "groupName": "Other contract", "contractIds": ["ctr_1-5C13O2"]}
+    ]}
+}`},
+	"/papi/v1/properties?groupId=grp_15166": {http.StatusOK, `
+{
+    "properties": {"items": [
+        {"propertyId": "prp_175780", "propertyName": "www.example.com", "contractId": "ctr_1-1TJZFW", "groupId": "grp_15166",
+         "productId": "prd_Fresca", "ruleFormat": "v2022-10-18", "latestVersion": 4, "stagingVersion": 4, "productionVersion": 3},
+        {"propertyId": "prp_175781", "propertyName": "new.example.com", "contractId": "ctr_1-1TJZFW", "groupId": "grp_15166",
+         "productId": "prd_Fresca", "ruleFormat": "v2022-10-18", "latestVersion": 1}
+    ]}
+}`},
+	"/papi/v1/properties?groupId=grp_15225": {http.StatusForbidden, `
+{"type": "forbidden", "title": "Forbidden", "detail": "You don't have access to group grp_15225", "status": 403}`},
+	"/papi/v1/properties/prp_175780/versions/3/hostnames": {http.StatusOK, `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "hostnames": {"items": [
+        {"cnameType": "EDGE_HOSTNAME", "edgeHostnameId": "ehn_895822", "cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net"},
+        {"cnameType": "EDGE_HOSTNAME", "edgeHostnameId": "ehn_895822", "cnameFrom": "api.example.com"}
+    ]}
+}`},
+	"/papi/v1/properties/prp_175780/versions/4/hostnames": {http.StatusOK, `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 4,
+    "hostnames": {"items": [
+        {"cnameType": "EDGE_HOSTNAME", "edgeHostnameId": "ehn_895822", "cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net"},
+        {"cnameType": "EDGE_HOSTNAME", "edgeHostnameId": "ehn_895822", "cnameFrom": "beta.example.com"}
+    ]}
+}`},
+	"/papi/v1/properties/prp_175781/versions/1/hostnames": {http.StatusOK, `
+{
+    "propertyId": "prp_175781",
+    "propertyVersion": 1,
+    "hostnames": {"items": [
+        {"cnameType": "EDGE_HOSTNAME", "edgeHostnameId": "ehn_895823", "cnameFrom": "new.example.org", "cnameTo": "new.example.org.edgesuite.net"}
+    ]}
+}`},
+	"/papi/v1/edgehostnames?groupId=grp_15166": {http.StatusOK, `
+{
+    "edgeHostnames": {"items": [
+        {"edgeHostnameId": "ehn_895822", "edgeHostnameDomain": "www.example.com.edgekey.net", "productId": "prd_Fresca",
+         "domainPrefix": "www.example.com", "domainSuffix": "edgekey.net", "secure": true, "ipVersionBehavior": "IPV4"},
+        {"edgeHostnameId": "ehn_900001", "edgeHostnameDomain": "old.example.com.edgesuite.net", "productId": "prd_Fresca",
+         "domainPrefix": "old.example.com", "domainSuffix": "edgesuite.net", "secure": false, "ipVersionBehavior": "IPV6_COMPLIANCE"}
+    ]}
+}`},
+	"/papi/v1/edgehostnames?groupId=grp_15225": {http.StatusForbidden, `
+{"type": "forbidden", "title": "Forbidden", "detail": "You don't have access to group grp_15225", "status": 403}`},
+	"/hapi/v1/edge-hostnames/900001": {http.StatusInternalServerError, `
+{"type": "internal-error", "title": "Internal Server Error", "status": 500}`},
+	"/hapi/v1/edge-hostnames/895822": {http.StatusOK, `
+{"edgeHostnameId": 895822, "recordName": "www.example.com", "dnsZone": "edgekey.net", "securityType": "ENHANCED-TLS",
+ "ipVersionBehavior": "IPV4", "productId": "DSA", "ttl": 21600, "map": "e1.a.akamaiedge.net"}`},
+	"/hapi/v1/edge-hostnames/895823": {http.StatusNotFound, `
+{"type": "not-found", "title": "Not Found", "detail": "Edge hostname not found", "status": 404}`},
+	"/cps/v2/enrollments": {http.StatusOK, `
+{
+    "enrollments": [
+        {"location": "/cps/v2/enrollments/10002", "ra": "lets-encrypt", "validationType": "dv", "certificateType": "san",
+         "csr": {"cn": "www.example.com", "sans": ["www.example.com", "*.example.com"]},
+         "networkConfiguration": {"secureNetwork": "enhanced-tls", "sniOnly": true}}
+    ]
+}`},
+	"/appsec/v1/configs": {http.StatusOK, `
+{
+    "configurations": [
+        {"id": 43253, "name": "WAF", "latestVersion": 7, "productionVersion": 6, "productionHostnames": ["www.example.com"]}
+    ]
+}`},
+	"/appsec/v1/configs/43253/versions/6/selected-hostnames": {http.StatusOK, `
+{"hostnameList": [{"hostname": "www.example.com"}, {"hostname": "shop.example.com"}]}`},
+	"/appsec/v1/configs/43253/versions/6/match-targets": {http.StatusOK, `
+{
+    "matchTargets": {"websiteTargets": [
+        {"targetId": 1, "type": "website", "hostnames": ["www.example.com", "api.example.com"]},
+        {"targetId": 2, "type": "website", "hostnames": ["api.example.com"]}
+    ]}
+}`},
+	"/config-dns/v2/zones/example.com/recordsets": {http.StatusOK, `
+{
+    "metadata": {"totalElements": 2},
+    "recordsets": [
+        {"name": "www.example.com", "type": "CNAME", "ttl": 300, "rdata": ["www.example.com.edgekey.net."]},
+        {"name": "old.example.com", "type": "CNAME", "ttl": 300, "rdata": ["old.example.com.edgesuite.net."]}
+    ]
+}`},
+}
+
+func newMockServer(t *testing.T, responses map[string]mockResponse, inFlight *int, maxInFlight *int) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*inFlight++
+		if *inFlight > *maxInFlight {
+			*maxInFlight = *inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			*inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(5 * time.Millisecond)
+
+		key := r.URL.Path
+		if key == "/papi/v1/properties" || key == "/papi/v1/edgehostnames" {
+			key += "?groupId=" + r.URL.Query().Get("groupId")
+		}
+		if key == "/config-dns/v2/zones/example.com/recordsets" {
+			assert.Equal(t, "CNAME", r.URL.Query().Get("types"))
+		}
+		if key == "/cps/v2/enrollments" {
+			assert.Equal(t, "1-1TJZFW", r.URL.Query().Get("contractId"))
+		}
+		resp, ok := responses[key]
+		if !ok {
+			t.Errorf("unexpected request: %s", r.URL)
+			resp = mockResponse{http.StatusNotFound, `{"type": "not-found", "title": "Not Found", "status": 404}`}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.status)
+		_, err := w.Write([]byte(resp.body))
+		assert.NoError(t, err)
+	}))
+}
+
+func TestInventory_Crawl(t *testing.T) {
+	var inFlight, maxInFlight int
+	mockServer := newMockServer(t, inventoryResponses, &inFlight, &maxInFlight)
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer, WithConcurrency(2))
+	client.(*inventory).now = func() time.Time { return time.Date(2022, 11, 2, 10, 0, 0, 0, time.UTC) }
+
+	snapshot, err := client.Crawl(context.Background(), CrawlRequest{ContractIDs: []string{"1-1TJZFW"}, DNSZones: []string{"example.com."}})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, maxInFlight, 2)
+
+	assert.Equal(t, "act_1-1TJZFB", snapshot.AccountID)
+	assert.Equal(t, time.Date(2022, 11, 2, 10, 0, 0, 0, time.UTC), snapshot.CreatedAt)
+	assert.Equal(t, []Contract{
+		{ContractID: "ctr_1-1TJZFW", ContractTypeName: "DIRECT_CUSTOMER", GroupIDs: []string{"grp_15166", "grp_15225"}},
+	}, snapshot.Contracts)
+	assert.Equal(t, []Group{
+		{GroupID: "grp_15166", GroupName: "Example", ContractIDs: []string{"ctr_1-1TJZFW"}, PropertyIDs: []string{"prp_175780", "prp_175781"}},
+		{GroupID: "grp_15225", GroupName: "Restricted", ParentGroupID: "grp_15166", ContractIDs: []string{"ctr_1-1TJZFW"}},
+	}, snapshot.Groups)
+	assert.Equal(t, []string{"grp_15166"}, snapshot.PropertyGroupIDs)
+	assert.Equal(t, []Property{
+		{
+			PropertyID: "prp_175780", PropertyName: "www.example.com", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166",
+			ProductID: "prd_Fresca", RuleFormat: "v2022-10-18", LatestVersion: 4, StagingVersion: 4, ProductionVersion: 3,
+			HostnamesVersions: []int{3, 4}, Hostnames: []string{"www.example.com", "api.example.com", "beta.example.com"},
+		},
+		{
+			PropertyID: "prp_175781", PropertyName: "new.example.com", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166",
+			ProductID: "prd_Fresca", RuleFormat: "v2022-10-18", LatestVersion: 1,
+			HostnamesVersions: []int{1}, Hostnames: []string{"new.example.org"},
+		},
+	}, snapshot.Properties)
+	assert.Equal(t, []string{"prp_175780", "prp_175781"}, snapshot.HostnamePropertyIDs)
+	assert.Equal(t, []PropertyHostname{
+		{
+			Hostname: "www.example.com", PropertyID: "prp_175780", PropertyVersion: 3, CnameType: "EDGE_HOSTNAME",
+			EdgeHostnameID: "ehn_895822", CnameTo: "www.example.com.edgekey.net",
+			EnrollmentIDs: []int{10002}, SecurityConfigIDs: []int{43253},
+		},
+		{
+			Hostname: "api.example.com", PropertyID: "prp_175780", PropertyVersion: 3, CnameType: "EDGE_HOSTNAME",
+			EdgeHostnameID: "ehn_895822", CnameTo: "www.example.com.edgekey.net",
+			EnrollmentIDs: []int{10002}, SecurityConfigIDs: []int{43253},
+		},
+		{
+			Hostname: "www.example.com", PropertyID: "prp_175780", PropertyVersion: 4, CnameType: "EDGE_HOSTNAME",
+			EdgeHostnameID: "ehn_895822", CnameTo: "www.example.com.edgekey.net",
+			EnrollmentIDs: []int{10002}, SecurityConfigIDs: []int{43253},
+		},
+		{
+			Hostname: "beta.example.com", PropertyID: "prp_175780", PropertyVersion: 4, CnameType: "EDGE_HOSTNAME",
+			EdgeHostnameID: "ehn_895822", CnameTo: "www.example.com.edgekey.net", EnrollmentIDs: []int{10002},
+		},
+		{
+			Hostname: "new.example.org", PropertyID: "prp_175781", PropertyVersion: 1, CnameType: "EDGE_HOSTNAME",
+			EdgeHostnameID: "ehn_895823", CnameTo: "new.example.org.edgesuite.net",
+		},
+	}, snapshot.PropertyHostnames)
+	assert.Equal(t, []EdgeHostname{
+		{
+			EdgeHostnameID: "ehn_895822", ContractID: "ctr_1-1TJZFW", EdgeHostname: "www.example.com.edgekey.net", ProductID: "DSA", SecurityType: "ENHANCED-TLS",
+			IPVersionBehavior: "IPV4", Map: "e1.a.akamaiedge.net", TTL: 21600,
+			Hostnames: []string{"www.example.com", "api.example.com", "beta.example.com"},
+		},
+		{
+			EdgeHostnameID: "ehn_900001", ContractID: "ctr_1-1TJZFW", EdgeHostname: "old.example.com.edgesuite.net", ProductID: "prd_Fresca",
+			IPVersionBehavior: "IPV6_COMPLIANCE",
+		},
+	}, snapshot.EdgeHostnames)
+	assert.Equal(t, []string{"ctr_1-1TJZFW"}, snapshot.EnrollmentContractIDs)
+	assert.Equal(t, []Enrollment{
+		{
+			EnrollmentID: 10002, ContractID: "ctr_1-1TJZFW", CN: "www.example.com", SANs: []string{"www.example.com", "*.example.com"},
+			CertificateType: "san", ValidationType: "dv", RA: "lets-encrypt", SecureNetwork: "enhanced-tls",
+			Hostnames: []string{"www.example.com", "api.example.com", "beta.example.com"},
+		},
+	}, snapshot.Enrollments)
+	assert.Equal(t, []SecurityConfiguration{
+		{
+			ConfigID: 43253, Name: "WAF", LatestVersion: 7, ProductionVersion: 6, HostnamesVersion: 6,
+			SelectedHostnames: []string{"www.example.com", "shop.example.com"}, MatchTargetHostnames: []string{"www.example.com", "api.example.com"},
+			ProductionHostnames: []string{"www.example.com"}, PropertyIDs: []string{"prp_175780"},
+		},
+	}, snapshot.SecurityConfigurations)
+	assert.Equal(t, []string{"example.com"}, snapshot.DNSZones)
+	assert.Equal(t, []DNSRecord{
+		{Zone: "example.com", Name: "www.example.com", Type: "CNAME", TTL: 300, Targets: []string{"www.example.com.edgekey.net"}},
+		{Zone: "example.com", Name: "old.example.com", Type: "CNAME", TTL: 300, Targets: []string{"old.example.com.edgesuite.net"}},
+	}, snapshot.DNSRecords)
+
+	// properties of grp_15225 were not listed, so the unused edge hostname of its contract and the security hostname
+	// which may be on one of its properties are not orphans
+	assert.Empty(t, NewGraph(snapshot).Orphans())
+
+	errs := make([][]interface{}, 0, len(snapshot.Errors))
+	for _, e := range snapshot.Errors {
+		errs = append(errs, []interface{}{e.Entity, e.ID, e.StatusCode})
+	}
+	assert.Equal(t, [][]interface{}{
+		{EntityEdgeHostnames, "ehn_895823", http.StatusNotFound},
+		{EntityEdgeHostnames, "ehn_900001", http.StatusInternalServerError},
+		{EntityGroups, "grp_15225", http.StatusForbidden},
+		{EntityGroups, "grp_15225", http.StatusForbidden},
+	}, errs)
+}
+
+func TestInventory_CrawlSkip(t *testing.T) {
+	responses := map[string]mockResponse{}
+	for key, resp := range inventoryResponses {
+		responses[key] = resp
+	}
+	delete(responses, "/cps/v2/enrollments")
+	delete(responses, "/appsec/v1/configs")
+	delete(responses, "/appsec/v1/configs/43253/versions/6/selected-hostnames")
+	var inFlight, maxInFlight int
+	mockServer := newMockServer(t, responses, &inFlight, &maxInFlight)
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	snapshot, err := client.Crawl(context.Background(), CrawlRequest{
+		ContractIDs:                []string{"ctr_1-1TJZFW"},
+		SkipEnrollments:            true,
+		SkipSecurityConfigurations: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, snapshot.Enrollments)
+	assert.Empty(t, snapshot.EnrollmentContractIDs)
+	assert.Empty(t, snapshot.SecurityConfigurations)
+	assert.Empty(t, snapshot.PropertyHostnames[0].EnrollmentIDs)
+}
+
+func TestInventory_CrawlError(t *testing.T) {
+	responses := map[string]mockResponse{
+		"/papi/v1/contracts": inventoryResponses["/papi/v1/contracts"],
+		"/papi/v1/groups":    {http.StatusInternalServerError, `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`},
+	}
+	var inFlight, maxInFlight int
+	mockServer := newMockServer(t, responses, &inFlight, &maxInFlight)
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	_, err := client.Crawl(context.Background(), CrawlRequest{})
+	assert.True(t, errors.Is(err, ErrCrawl), "want: %s; got: %s", ErrCrawl, err)
+}
+
+func TestSnapshot_link(t *testing.T) {
+	snapshot := Snapshot{
+		Properties: []Property{
+			{PropertyID: "prp_1", ContractID: "ctr_1"},
+			{PropertyID: "prp_2", ContractID: "ctr_2"},
+		},
+		PropertyHostnames: []PropertyHostname{
+			{Hostname: "www.example.com", PropertyID: "prp_1"},
+			{Hostname: "shop.example.com", PropertyID: "prp_2"},
+		},
+		Enrollments: []Enrollment{
+			{EnrollmentID: 1, ContractID: "ctr_1", CN: "*.example.com"},
+			{EnrollmentID: 2, ContractID: "ctr_3", CN: "shop.example.com"},
+		},
+	}
+	snapshot.link()
+
+	assert.Equal(t, []int{1}, snapshot.PropertyHostnames[0].EnrollmentIDs)
+	assert.Empty(t, snapshot.PropertyHostnames[1].EnrollmentIDs)
+	assert.Equal(t, []string{"www.example.com"}, snapshot.Enrollments[0].Hostnames)
+	assert.Empty(t, snapshot.Enrollments[1].Hostnames)
+}
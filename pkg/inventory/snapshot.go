@@ -0,0 +1,322 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Snapshot contains entities existing in an account, with cross-references between them.
+	// PropertyGroupIDs, HostnamePropertyIDs, EnrollmentContractIDs and DNSZones list groups, properties, contracts and zones
+	// whose properties, hostnames, enrollments and records were listed completely
+	Snapshot struct {
+		CreatedAt              time.Time               `json:"createdAt"`
+		AccountID              string                  `json:"accountId"`
+		Contracts              []Contract              `json:"contracts"`
+		Groups                 []Group                 `json:"groups"`
+		PropertyGroupIDs       []string                `json:"propertyGroupIds"`
+		Properties             []Property              `json:"properties"`
+		HostnamePropertyIDs    []string                `json:"hostnamePropertyIds"`
+		PropertyHostnames      []PropertyHostname      `json:"propertyHostnames"`
+		EdgeHostnames          []EdgeHostname          `json:"edgeHostnames"`
+		EnrollmentContractIDs  []string                `json:"enrollmentContractIds"`
+		Enrollments            []Enrollment            `json:"enrollments"`
+		SecurityConfigurations []SecurityConfiguration `json:"securityConfigurations"`
+		DNSZones               []string                `json:"dnsZones"`
+		DNSRecords             []DNSRecord             `json:"dnsRecords"`
+		Errors                 []CrawlError            `json:"errors"`
+	}
+
+	// Contract is a contract with IDs of its groups
+	Contract struct {
+		ContractID       string   `json:"contractId"`
+		ContractTypeName string   `json:"contractTypeName"`
+		GroupIDs         []string `json:"groupIds"`
+	}
+
+	// Group is a group with IDs of its properties
+	Group struct {
+		GroupID       string   `json:"groupId"`
+		GroupName     string   `json:"groupName"`
+		ParentGroupID string   `json:"parentGroupId,omitempty"`
+		ContractIDs   []string `json:"contractIds"`
+		PropertyIDs   []string `json:"propertyIds"`
+	}
+
+	// Property is a property with its active versions and hostnames of HostnamesVersions, which are versions
+	// active on production and staging or, if the property is not active, the latest version.
+	// StagingVersion and ProductionVersion are 0 if the property is not active on the network
+	Property struct {
+		PropertyID        string   `json:"propertyId"`
+		PropertyName      string   `json:"propertyName"`
+		ContractID        string   `json:"contractId"`
+		GroupID           string   `json:"groupId"`
+		ProductID         string   `json:"productId"`
+		RuleFormat        string   `json:"ruleFormat"`
+		LatestVersion     int      `json:"latestVersion"`
+		StagingVersion    int      `json:"stagingVersion"`
+		ProductionVersion int      `json:"productionVersion"`
+		HostnamesVersions []int    `json:"hostnamesVersions"`
+		Hostnames         []string `json:"hostnames"`
+	}
+
+	// PropertyHostname is a hostname of a property version with enrollments of the property contract covering it
+	// and security configurations selecting it
+	PropertyHostname struct {
+		Hostname             string `json:"hostname"`
+		PropertyID           string `json:"propertyId"`
+		PropertyVersion      int    `json:"propertyVersion"`
+		CnameType            string `json:"cnameType"`
+		EdgeHostnameID       string `json:"edgeHostnameId,omitempty"`
+		CnameTo              string `json:"cnameTo,omitempty"`
+		CertProvisioningType string `json:"certProvisioningType,omitempty"`
+		EnrollmentIDs        []int  `json:"enrollmentIds"`
+		SecurityConfigIDs    []int  `json:"securityConfigIds"`
+	}
+
+	// EdgeHostname is an edge hostname of a crawled group or referenced by property hostnames, with hostnames using it.
+	// ContractID is set for edge hostnames listed in a group
+	EdgeHostname struct {
+		EdgeHostnameID    string   `json:"edgeHostnameId"`
+		ContractID        string   `json:"contractId,omitempty"`
+		EdgeHostname      string   `json:"edgeHostname"`
+		ProductID         string   `json:"productId"`
+		SecurityType      string   `json:"securityType"`
+		IPVersionBehavior string   `json:"ipVersionBehavior"`
+		Map               string   `json:"map,omitempty"`
+		TTL               int      `json:"ttl"`
+		Hostnames         []string `json:"hostnames"`
+	}
+
+	// Enrollment is a CPS enrollment with property hostnames covered by its common name or SANs
+	Enrollment struct {
+		EnrollmentID    int      `json:"enrollmentId"`
+		ContractID      string   `json:"contractId"`
+		CN              string   `json:"cn"`
+		SANs            []string `json:"sans"`
+		CertificateType string   `json:"certificateType"`
+		ValidationType  string   `json:"validationType"`
+		RA              string   `json:"ra"`
+		SecureNetwork   string   `json:"secureNetwork,omitempty"`
+		Hostnames       []string `json:"hostnames"`
+	}
+
+	// SecurityConfiguration is an application security configuration with hostnames selected, or used by match targets,
+	// in HostnamesVersion and IDs of properties serving them
+	SecurityConfiguration struct {
+		ConfigID             int      `json:"configId"`
+		Name                 string   `json:"name"`
+		LatestVersion        int      `json:"latestVersion"`
+		StagingVersion       int      `json:"stagingVersion"`
+		ProductionVersion    int      `json:"productionVersion"`
+		HostnamesVersion     int      `json:"hostnamesVersion"`
+		SelectedHostnames    []string `json:"selectedHostnames"`
+		MatchTargetHostnames []string `json:"matchTargetHostnames"`
+		ProductionHostnames  []string `json:"productionHostnames"`
+		PropertyIDs          []string `json:"propertyIds"`
+	}
+
+	// DNSRecord is a CNAME record of an Edge DNS zone, names and targets are without the trailing dot
+	DNSRecord struct {
+		Zone    string   `json:"zone"`
+		Name    string   `json:"name"`
+		Type    string   `json:"type"`
+		TTL     int      `json:"ttl"`
+		Targets []string `json:"targets"`
+	}
+
+	// CrawlError is a failure to fetch an entity, or entities under it, which did not stop the crawl.
+	// ID is the ID of the entity the request was made for, e.g. the group of listed properties
+	CrawlError struct {
+		Entity     Entity `json:"entity"`
+		ID         string `json:"id"`
+		StatusCode int    `json:"statusCode,omitempty"`
+		Detail     string `json:"detail"`
+	}
+
+	// Entity is a type of entities in a Snapshot
+	Entity string
+)
+
+const (
+	// EntityContracts represents Snapshot.Contracts
+	EntityContracts Entity = "contracts"
+	// EntityGroups represents Snapshot.Groups
+	EntityGroups Entity = "groups"
+	// EntityProperties represents Snapshot.Properties
+	EntityProperties Entity = "properties"
+	// EntityPropertyHostnames represents Snapshot.PropertyHostnames
+	EntityPropertyHostnames Entity = "propertyHostnames"
+	// EntityEdgeHostnames represents Snapshot.EdgeHostnames
+	EntityEdgeHostnames Entity = "edgeHostnames"
+	// EntityEnrollments represents Snapshot.Enrollments
+	EntityEnrollments Entity = "enrollments"
+	// EntitySecurityConfigurations represents Snapshot.SecurityConfigurations
+	EntitySecurityConfigurations Entity = "securityConfigurations"
+	// EntityDNSRecords represents Snapshot.DNSRecords
+	EntityDNSRecords Entity = "dnsRecords"
+	// EntityErrors represents Snapshot.Errors
+	EntityErrors Entity = "errors"
+
+	// csvListSeparator separates values of list columns in CSV
+	csvListSeparator = ";"
+)
+
+var (
+	// ErrUnknownEntity is returned when CSV is requested for an unknown entity
+	ErrUnknownEntity = errors.New("unknown entity")
+
+	// Entities lists all entities of a Snapshot in the order of CSV files written by WriteCSVFiles
+	Entities = []Entity{
+		EntityContracts,
+		EntityGroups,
+		EntityProperties,
+		EntityPropertyHostnames,
+		EntityEdgeHostnames,
+		EntityEnrollments,
+		EntitySecurityConfigurations,
+		EntityDNSRecords,
+		EntityErrors,
+	}
+)
+
+// WriteJSON writes the snapshot to w as indented JSON
+func (s *Snapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// WriteCSV writes entities of the given type to w in CSV format, with a header row containing column names.
+// List columns are joined with semicolons
+func (s *Snapshot) WriteCSV(entity Entity, w io.Writer) error {
+	header, rows, err := s.csvRows(entity)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteCSVFiles writes a CSV file named after every entity, e.g. properties.csv, to the directory
+func (s *Snapshot) WriteCSVFiles(dir string) error {
+	for _, entity := range Entities {
+		if err := s.writeCSVFile(entity, filepath.Join(dir, string(entity)+".csv")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Snapshot) writeCSVFile(entity Entity, path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return s.WriteCSV(entity, f)
+}
+
+func (s *Snapshot) csvRows(entity Entity) ([]string, [][]string, error) {
+	var rows [][]string
+	switch entity {
+	case EntityContracts:
+		for _, c := range s.Contracts {
+			rows = append(rows, []string{c.ContractID, c.ContractTypeName, joinList(c.GroupIDs)})
+		}
+		return []string{"contractId", "contractTypeName", "groupIds"}, rows, nil
+	case EntityGroups:
+		for _, g := range s.Groups {
+			rows = append(rows, []string{g.GroupID, g.GroupName, g.ParentGroupID, joinList(g.ContractIDs), joinList(g.PropertyIDs)})
+		}
+		return []string{"groupId", "groupName", "parentGroupId", "contractIds", "propertyIds"}, rows, nil
+	case EntityProperties:
+		for _, p := range s.Properties {
+			rows = append(rows, []string{p.PropertyID, p.PropertyName, p.ContractID, p.GroupID, p.ProductID, p.RuleFormat,
+				formatVersion(p.LatestVersion), formatVersion(p.StagingVersion), formatVersion(p.ProductionVersion),
+				joinInts(p.HostnamesVersions), joinList(p.Hostnames)})
+		}
+		return []string{"propertyId", "propertyName", "contractId", "groupId", "productId", "ruleFormat",
+			"latestVersion", "stagingVersion", "productionVersion", "hostnamesVersions", "hostnames"}, rows, nil
+	case EntityPropertyHostnames:
+		for _, h := range s.PropertyHostnames {
+			rows = append(rows, []string{h.Hostname, h.PropertyID, formatVersion(h.PropertyVersion), h.CnameType,
+				h.EdgeHostnameID, h.CnameTo, h.CertProvisioningType, joinInts(h.EnrollmentIDs), joinInts(h.SecurityConfigIDs)})
+		}
+		return []string{"hostname", "propertyId", "propertyVersion", "cnameType", "edgeHostnameId", "cnameTo",
+			"certProvisioningType", "enrollmentIds", "securityConfigIds"}, rows, nil
+	case EntityEdgeHostnames:
+		for _, e := range s.EdgeHostnames {
+			rows = append(rows, []string{e.EdgeHostnameID, e.ContractID, e.EdgeHostname, e.ProductID, e.SecurityType, e.IPVersionBehavior,
+				e.Map, strconv.Itoa(e.TTL), joinList(e.Hostnames)})
+		}
+		return []string{"edgeHostnameId", "contractId", "edgeHostname", "productId", "securityType", "ipVersionBehavior", "map", "ttl",
+			"hostnames"}, rows, nil
+	case EntityEnrollments:
+		for _, e := range s.Enrollments {
+			rows = append(rows, []string{strconv.Itoa(e.EnrollmentID), e.ContractID, e.CN, joinList(e.SANs), e.CertificateType,
+				e.ValidationType, e.RA, e.SecureNetwork, joinList(e.Hostnames)})
+		}
+		return []string{"enrollmentId", "contractId", "cn", "sans", "certificateType", "validationType", "ra",
+			"secureNetwork", "hostnames"}, rows, nil
+	case EntitySecurityConfigurations:
+		for _, c := range s.SecurityConfigurations {
+			rows = append(rows, []string{strconv.Itoa(c.ConfigID), c.Name, formatVersion(c.LatestVersion),
+				formatVersion(c.StagingVersion), formatVersion(c.ProductionVersion), formatVersion(c.HostnamesVersion),
+				joinList(c.SelectedHostnames), joinList(c.MatchTargetHostnames), joinList(c.ProductionHostnames),
+				joinList(c.PropertyIDs)})
+		}
+		return []string{"configId", "name", "latestVersion", "stagingVersion", "productionVersion", "hostnamesVersion",
+			"selectedHostnames", "matchTargetHostnames", "productionHostnames", "propertyIds"}, rows, nil
+	case EntityDNSRecords:
+		for _, r := range s.DNSRecords {
+			rows = append(rows, []string{r.Zone, r.Name, r.Type, strconv.Itoa(r.TTL), joinList(r.Targets)})
+		}
+		return []string{"zone", "name", "type", "ttl", "targets"}, rows, nil
+	case EntityErrors:
+		for _, e := range s.Errors {
+			rows = append(rows, []string{string(e.Entity), e.ID, formatVersion(e.StatusCode), e.Detail})
+		}
+		return []string{"entity", "id", "statusCode", "detail"}, rows, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrUnknownEntity, entity)
+}
+
+func joinList(values []string) string {
+	return strings.Join(values, csvListSeparator)
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return joinList(s)
+}
+
+// formatVersion returns an empty string for 0, which marks versions not active on a network
+func formatVersion(version int) string {
+	if version == 0 {
+		return ""
+	}
+	return strconv.Itoa(version)
+}
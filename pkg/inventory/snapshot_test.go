@@ -0,0 +1,113 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSnapshot = Snapshot{
+	CreatedAt: time.Date(2022, 11, 2, 10, 0, 0, 0, time.UTC),
+	AccountID: "act_1-1TJZFB",
+	Contracts: []Contract{
+		{ContractID: "ctr_1-1TJZFW", ContractTypeName: "DIRECT_CUSTOMER", GroupIDs: []string{"grp_15166", "grp_15225"}},
+	},
+	Properties: []Property{
+		{
+			PropertyID: "prp_175780", PropertyName: "www.example.com", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166",
+			ProductID: "prd_Fresca", RuleFormat: "v2022-10-18", LatestVersion: 4, ProductionVersion: 3,
+			HostnamesVersions: []int{3}, Hostnames: []string{"www.example.com", "api.example.com"},
+		},
+	},
+	PropertyHostnames: []PropertyHostname{
+		{
+			Hostname: "www.example.com", PropertyID: "prp_175780", PropertyVersion: 3, CnameType: "EDGE_HOSTNAME",
+			EdgeHostnameID: "ehn_895822", CnameTo: "www.example.com.edgekey.net",
+			EnrollmentIDs: []int{10002, 10003}, SecurityConfigIDs: []int{43253},
+		},
+	},
+	Errors: []CrawlError{
+		{Entity: EntityGroups, ID: "grp_15225", StatusCode: 403, Detail: "Title: Forbidden; Type: forbidden; Detail: no access, \"grp_15225\""},
+	},
+}
+
+func TestSnapshot_WriteCSV(t *testing.T) {
+	tests := map[string]struct {
+		entity    Entity
+		expected  string
+		withError error
+	}{
+		"contracts": {
+			entity:   EntityContracts,
+			expected: "contractId,contractTypeName,groupIds\nctr_1-1TJZFW,DIRECT_CUSTOMER,grp_15166;grp_15225\n",
+		},
+		"properties without staging version": {
+			entity: EntityProperties,
+			expected: "propertyId,propertyName,contractId,groupId,productId,ruleFormat,latestVersion,stagingVersion,productionVersion,hostnamesVersions,hostnames\n" +
+				"prp_175780,www.example.com,ctr_1-1TJZFW,grp_15166,prd_Fresca,v2022-10-18,4,,3,3,www.example.com;api.example.com\n",
+		},
+		"property hostnames": {
+			entity: EntityPropertyHostnames,
+			expected: "hostname,propertyId,propertyVersion,cnameType,edgeHostnameId,cnameTo,certProvisioningType,enrollmentIds,securityConfigIds\n" +
+				"www.example.com,prp_175780,3,EDGE_HOSTNAME,ehn_895822,www.example.com.edgekey.net,,10002;10003,43253\n",
+		},
+		"errors are quoted": {
+			entity: EntityErrors,
+			expected: "entity,id,statusCode,detail\n" +
+				"groups,grp_15225,403,\"Title: Forbidden; Type: forbidden; Detail: no access, \"\"grp_15225\"\"\"\n",
+		},
+		"no entities": {
+			entity:   EntityEnrollments,
+			expected: "enrollmentId,contractId,cn,sans,certificateType,validationType,ra,secureNetwork,hostnames\n",
+		},
+		"unknown entity": {
+			entity:    "dnsZones",
+			withError: ErrUnknownEntity,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := testSnapshot.WriteCSV(test.entity, &buf)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestSnapshot_WriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testSnapshot.WriteJSON(&buf))
+
+	var decoded Snapshot
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, testSnapshot, decoded)
+	assert.Contains(t, buf.String(), `"enrollmentIds": [`)
+}
+
+func TestSnapshot_WriteCSVFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, testSnapshot.WriteCSVFiles(dir))
+
+	for _, entity := range Entities {
+		_, err := ioutil.ReadFile(filepath.Join(dir, string(entity)+".csv"))
+		assert.NoError(t, err)
+	}
+	contracts, err := ioutil.ReadFile(filepath.Join(dir, "contracts.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "contractId,contractTypeName,groupIds\nctr_1-1TJZFW,DIRECT_CUSTOMER,grp_15166;grp_15225\n", string(contracts))
+
+	assert.Error(t, testSnapshot.WriteCSVFiles(filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,518 @@
+// Package onboarding provides a workflow onboarding a new site on Akamai, combining PAPI, CPS, HAPI and Edge DNS calls
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/cps"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/dns"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/hapi"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+
+	// ErrOnboard represents error when onboarding workflow fails
+	ErrOnboard = errors.New("onboarding")
+)
+
+type (
+	// Onboarding is the interface of the site onboarding workflow
+	Onboarding interface {
+		// Onboard creates a CP code, an optional CPS DV enrollment, an edge hostname and a property with the given hostnames,
+		// activates the property on staging and publishes CNAME records of the hostnames in an Edge DNS zone.
+		// Steps already completed in the given state are skipped, so a failed run can be resumed by passing the returned state
+		// together with the same request. The state is returned also when an error occurs
+		Onboard(context.Context, OnboardRequest, *State) (*State, error)
+	}
+
+	onboarding struct {
+		papi papi.PAPI
+		cps  cps.CPS
+		hapi hapi.HAPI
+		dns  dns.DNS
+	}
+
+	// Option defines an Onboarding option
+	Option func(*onboarding)
+
+	// ClientFunc is an Onboarding client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) Onboarding
+
+	// OnboardRequest contains the site to onboard
+	OnboardRequest struct {
+		ContractID string
+		GroupID    string
+		ProductID  string
+		// Hostnames are the property hostnames, the first one is used as the edge hostname prefix and the CSR common name
+		Hostnames []string
+		// Origin is the hostname of the origin server set in the default rule
+		Origin string
+		// PropertyName defaults to the first hostname
+		PropertyName string
+		// CPCodeName defaults to the property name
+		CPCodeName string
+		RuleFormat string
+		// RuleTemplate is the rule tree of the new property, the rules created by PAPI for the product are used if not set.
+		// The cpCode and origin behaviors of the default rule are set, or added when missing
+		RuleTemplate *papi.Rules
+		// IPVersionBehavior of the edge hostname, defaults to IPV4
+		IPVersionBehavior string
+		// Enrollment, when set, is used to create a CPS DV enrollment for the hostnames and an Enhanced TLS edge hostname.
+		// Only contacts and organization are required, CSR, network configuration and validation are filled in.
+		// Completing the DV challenges is not part of the workflow
+		Enrollment *cps.Enrollment
+		// DNSZone, when set, is the Edge DNS zone in which CNAME records of the hostnames are created
+		DNSZone string
+		// DNSTTL is the TTL of created records, defaults to 300
+		DNSTTL                 int
+		NotifyEmails           []string
+		AcknowledgeAllWarnings bool
+	}
+
+	// State contains the progress of onboarding and the identifiers of created resources.
+	// It can be serialized to JSON and used to resume onboarding
+	State struct {
+		CompletedSteps   []Step   `json:"completedSteps"`
+		FailedStep       Step     `json:"failedStep,omitempty"`
+		CPCodeID         string   `json:"cpCodeId,omitempty"`
+		EnrollmentID     int      `json:"enrollmentId,omitempty"`
+		EdgeHostnameID   string   `json:"edgeHostnameId,omitempty"`
+		EdgeHostname     string   `json:"edgeHostname,omitempty"`
+		PropertyID       string   `json:"propertyId,omitempty"`
+		PropertyVersion  int      `json:"propertyVersion,omitempty"`
+		ActivationID     string   `json:"activationId,omitempty"`
+		PublishedRecords []string `json:"publishedRecords,omitempty"`
+	}
+
+	// Step is a single step of onboarding
+	Step string
+
+	stepFunc func(context.Context, OnboardRequest, *State) error
+)
+
+const (
+	// StepCPCode creates the CP code
+	StepCPCode Step = "CP_CODE"
+	// StepEnrollment creates the CPS DV enrollment
+	StepEnrollment Step = "ENROLLMENT"
+	// StepEdgeHostname creates the edge hostname
+	StepEdgeHostname Step = "EDGE_HOSTNAME"
+	// StepProperty creates the property
+	StepProperty Step = "PROPERTY"
+	// StepRules updates rules of the property version
+	StepRules Step = "RULES"
+	// StepHostnames sets hostnames of the property version
+	StepHostnames Step = "HOSTNAMES"
+	// StepActivation activates the property version on staging
+	StepActivation Step = "ACTIVATION"
+	// StepDNS creates CNAME records of the hostnames
+	StepDNS Step = "DNS"
+
+	defaultDNSTTL = 300
+)
+
+// Client returns a new onboarding Client instance, using the given session for PAPI, CPS, HAPI and Edge DNS clients
+func Client(sess session.Session, opts ...Option) Onboarding {
+	o := &onboarding{
+		papi: papi.Client(sess),
+		cps:  cps.Client(sess),
+		hapi: hapi.Client(sess),
+		dns:  dns.Client(sess),
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithPAPI sets the PAPI client used by the workflow
+func WithPAPI(client papi.PAPI) Option {
+	return func(o *onboarding) {
+		o.papi = client
+	}
+}
+
+// WithCPS sets the CPS client used by the workflow
+func WithCPS(client cps.CPS) Option {
+	return func(o *onboarding) {
+		o.cps = client
+	}
+}
+
+// WithHAPI sets the HAPI client used by the workflow
+func WithHAPI(client hapi.HAPI) Option {
+	return func(o *onboarding) {
+		o.hapi = client
+	}
+}
+
+// WithDNS sets the Edge DNS client used by the workflow
+func WithDNS(client dns.DNS) Option {
+	return func(o *onboarding) {
+		o.dns = client
+	}
+}
+
+// Validate validates OnboardRequest
+func (r OnboardRequest) Validate() error {
+	return validation.Errors{
+		"ContractID":   validation.Validate(r.ContractID, validation.Required),
+		"GroupID":      validation.Validate(r.GroupID, validation.Required),
+		"ProductID":    validation.Validate(r.ProductID, validation.Required),
+		"Hostnames":    validation.Validate(r.Hostnames, validation.Required, validation.Each(validation.Required)),
+		"Origin":       validation.Validate(r.Origin, validation.Required),
+		"NotifyEmails": validation.Validate(r.NotifyEmails, validation.Required),
+		"DNSZone":      validation.Validate(r.DNSZone, validation.When(r.DNSZone != "", validation.By(r.hostnamesInZone))),
+		"DNSTTL":       validation.Validate(r.DNSTTL, validation.Min(0)),
+		"Enrollment":   validation.Validate(r.Enrollment, validation.By(enrollmentContacts), validation.Skip),
+	}.Filter()
+}
+
+// enrollmentContacts validates only the enrollment fields which are not filled in by the workflow
+func enrollmentContacts(value interface{}) error {
+	enrollment := value.(*cps.Enrollment)
+	if enrollment == nil {
+		return nil
+	}
+	return validation.Errors{
+		"AdminContact": validation.Validate(enrollment.AdminContact, validation.Required),
+		"TechContact":  validation.Validate(enrollment.TechContact, validation.Required),
+		"Org":          validation.Validate(enrollment.Org, validation.Required),
+	}.Filter()
+}
+
+func (r OnboardRequest) hostnamesInZone(interface{}) error {
+	zone := strings.TrimSuffix(r.DNSZone, ".")
+	for _, hostname := range r.Hostnames {
+		if hostname != zone && !strings.HasSuffix(hostname, "."+zone) {
+			return fmt.Errorf("hostname %q is not in zone %q", hostname, zone)
+		}
+	}
+	return nil
+}
+
+// IsCompleted returns true if the step is completed
+func (s *State) IsCompleted(step Step) bool {
+	for _, completed := range s.CompletedSteps {
+		if completed == step {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *onboarding) Onboard(ctx context.Context, params OnboardRequest, state *State) (*State, error) {
+	if state == nil {
+		state = &State{}
+	}
+	if err := params.Validate(); err != nil {
+		return state, fmt.Errorf("%s: %w: %s", ErrOnboard, ErrStructValidation, err)
+	}
+
+	steps := []struct {
+		step Step
+		fn   stepFunc
+		skip bool
+	}{
+		{step: StepCPCode, fn: o.createCPCode},
+		{step: StepEnrollment, fn: o.createEnrollment, skip: params.Enrollment == nil},
+		{step: StepEdgeHostname, fn: o.createEdgeHostname},
+		{step: StepProperty, fn: o.createProperty},
+		{step: StepRules, fn: o.updateRules},
+		{step: StepHostnames, fn: o.updateHostnames},
+		{step: StepActivation, fn: o.activate},
+		{step: StepDNS, fn: o.publishRecords, skip: params.DNSZone == ""},
+	}
+
+	state.FailedStep = ""
+	for _, s := range steps {
+		if s.skip || state.IsCompleted(s.step) {
+			continue
+		}
+		if err := s.fn(ctx, params, state); err != nil {
+			state.FailedStep = s.step
+			return state, fmt.Errorf("%s: %s step: %w", ErrOnboard, s.step, err)
+		}
+		state.CompletedSteps = append(state.CompletedSteps, s.step)
+	}
+	return state, nil
+}
+
+func (o *onboarding) createCPCode(ctx context.Context, params OnboardRequest, state *State) error {
+	resp, err := o.papi.CreateCPCode(ctx, papi.CreateCPCodeRequest{
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		CPCode: papi.CreateCPCode{
+			ProductID:  params.ProductID,
+			CPCodeName: params.cpCodeName(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	state.CPCodeID = resp.CPCodeID
+	return nil
+}
+
+func (o *onboarding) createEnrollment(ctx context.Context, params OnboardRequest, state *State) error {
+	enrollment := *params.Enrollment
+	if enrollment.CSR == nil {
+		enrollment.CSR = &cps.CSR{}
+	}
+	if enrollment.CSR.CN == "" {
+		csr := *enrollment.CSR
+		csr.CN = params.Hostnames[0]
+		csr.SANS = params.Hostnames
+		enrollment.CSR = &csr
+	}
+	if enrollment.NetworkConfiguration == nil {
+		enrollment.NetworkConfiguration = &cps.NetworkConfiguration{
+			DNSNameSettings: &cps.DNSNameSettings{CloneDNSNames: true},
+			Geography:       "core",
+			SecureNetwork:   "enhanced-tls",
+			SNIOnly:         true,
+		}
+	}
+	if enrollment.RA == "" {
+		enrollment.RA = "lets-encrypt"
+	}
+	if enrollment.ValidationType == "" {
+		enrollment.ValidationType = "dv"
+	}
+	if enrollment.CertificateType == "" {
+		enrollment.CertificateType = "san"
+	}
+
+	resp, err := o.cps.CreateEnrollment(ctx, cps.CreateEnrollmentRequest{
+		Enrollment: enrollment,
+		ContractID: papi.ContractID(params.ContractID).Bare(),
+	})
+	if err != nil {
+		return err
+	}
+	state.EnrollmentID = resp.ID
+	return nil
+}
+
+func (o *onboarding) createEdgeHostname(ctx context.Context, params OnboardRequest, state *State) error {
+	edgeHostname := papi.EdgeHostnameCreate{
+		ProductID:         params.ProductID,
+		DomainPrefix:      params.Hostnames[0],
+		DomainSuffix:      "edgesuite.net",
+		IPVersionBehavior: params.IPVersionBehavior,
+	}
+	if edgeHostname.IPVersionBehavior == "" {
+		edgeHostname.IPVersionBehavior = papi.EHIPVersionV4
+	}
+	if state.EnrollmentID != 0 {
+		edgeHostname.DomainSuffix = "edgekey.net"
+		edgeHostname.SecureNetwork = papi.EHSecureNetworkEnhancedTLS
+		edgeHostname.CertEnrollmentID = state.EnrollmentID
+	}
+
+	resp, err := o.papi.CreateEdgeHostname(ctx, papi.CreateEdgeHostnameRequest{
+		ContractID:   params.ContractID,
+		GroupID:      params.GroupID,
+		EdgeHostname: edgeHostname,
+	})
+	if err != nil {
+		return err
+	}
+	state.EdgeHostnameID = resp.EdgeHostnameID
+	state.EdgeHostname = edgeHostname.DomainPrefix + "." + edgeHostname.DomainSuffix
+	return nil
+}
+
+func (o *onboarding) createProperty(ctx context.Context, params OnboardRequest, state *State) error {
+	resp, err := o.papi.CreateProperty(ctx, papi.CreatePropertyRequest{
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		Property: papi.PropertyCreate{
+			ProductID:    params.ProductID,
+			PropertyName: params.propertyName(),
+			RuleFormat:   params.RuleFormat,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	state.PropertyID = resp.PropertyID
+	state.PropertyVersion = 1
+	return nil
+}
+
+func (o *onboarding) updateRules(ctx context.Context, params OnboardRequest, state *State) error {
+	var rules papi.Rules
+	if params.RuleTemplate != nil {
+		rules = *params.RuleTemplate
+	} else {
+		tree, err := o.papi.GetRuleTree(ctx, papi.GetRuleTreeRequest{
+			PropertyID:      state.PropertyID,
+			PropertyVersion: state.PropertyVersion,
+			ContractID:      params.ContractID,
+			GroupID:         params.GroupID,
+		})
+		if err != nil {
+			return err
+		}
+		rules = tree.Rules
+	}
+
+	cpCode, err := papi.CPCodeID(state.CPCodeID).Int()
+	if err != nil {
+		return err
+	}
+	rules, err = setBehaviorOptions(rules, "cpCode", papi.RuleOptionsMap{
+		"value": map[string]interface{}{"id": cpCode},
+	})
+	if err != nil {
+		return err
+	}
+	rules, err = setBehaviorOptions(rules, "origin", papi.RuleOptionsMap{
+		"originType":        "CUSTOMER",
+		"hostname":          params.Origin,
+		"forwardHostHeader": "REQUEST_HOST_HEADER",
+		"cacheKeyHostname":  "ORIGIN_HOSTNAME",
+	}, "originType", "forwardHostHeader", "cacheKeyHostname")
+	if err != nil {
+		return err
+	}
+
+	_, err = o.papi.UpdateRuleTree(ctx, papi.UpdateRulesRequest{
+		PropertyID:      state.PropertyID,
+		PropertyVersion: state.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+		Rules:           papi.RulesUpdate{Rules: rules},
+	})
+	return err
+}
+
+// setBehaviorOptions sets options of the behavior in the default rule, adding the behavior when it is missing.
+// Options listed in defaults are only set on added behaviors
+func setBehaviorOptions(rules papi.Rules, behavior string, options papi.RuleOptionsMap, defaults ...string) (papi.Rules, error) {
+	for _, b := range rules.Behaviors {
+		if b.Name != behavior {
+			continue
+		}
+		existing := papi.RuleOptionsMap{}
+		for key, value := range options {
+			existing[key] = value
+		}
+		for _, key := range defaults {
+			delete(existing, key)
+		}
+		return rules.SetBehaviorOptions(nil, behavior, existing)
+	}
+	return rules.AddBehavior(nil, papi.RuleBehavior{Name: behavior, Options: options})
+}
+
+func (o *onboarding) updateHostnames(ctx context.Context, params OnboardRequest, state *State) error {
+	hostnames := make([]papi.Hostname, 0, len(params.Hostnames))
+	for _, hostname := range params.Hostnames {
+		hostnames = append(hostnames, papi.Hostname{
+			CnameType:            papi.HostnameCnameTypeEdgeHostname,
+			EdgeHostnameID:       state.EdgeHostnameID,
+			CnameFrom:            hostname,
+			CertProvisioningType: string(papi.CertProvisioningTypeCPSManaged),
+		})
+	}
+	_, err := o.papi.UpdatePropertyVersionHostnames(ctx, papi.UpdatePropertyVersionHostnamesRequest{
+		PropertyID:      state.PropertyID,
+		PropertyVersion: state.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+		Hostnames:       hostnames,
+	})
+	return err
+}
+
+func (o *onboarding) activate(ctx context.Context, params OnboardRequest, state *State) error {
+	resp, err := o.papi.CreateActivation(ctx, papi.CreateActivationRequest{
+		PropertyID: state.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		Activation: papi.Activation{
+			ActivationType:         papi.ActivationTypeActivate,
+			Network:                papi.ActivationNetworkStaging,
+			PropertyVersion:        state.PropertyVersion,
+			NotifyEmails:           params.NotifyEmails,
+			AcknowledgeAllWarnings: params.AcknowledgeAllWarnings,
+			Note:                   "Onboarding " + params.propertyName(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	state.ActivationID = resp.ActivationID
+	return nil
+}
+
+// publishRecords creates CNAME records of hostnames not published yet, pointing to the edge hostname.
+// The target is read from HAPI, so the step fails until the edge hostname created by PAPI is available there
+func (o *onboarding) publishRecords(ctx context.Context, params OnboardRequest, state *State) error {
+	id, err := strconv.Atoi(papi.EdgeHostnameID(state.EdgeHostnameID).Bare())
+	if err != nil {
+		return fmt.Errorf("invalid edge hostname ID %q: %w", state.EdgeHostnameID, err)
+	}
+	edgeHostname, err := o.hapi.GetEdgeHostname(ctx, id)
+	if err != nil {
+		return err
+	}
+	target := edgeHostname.RecordName + "." + edgeHostname.DNSZone
+
+	ttl := params.DNSTTL
+	if ttl == 0 {
+		ttl = defaultDNSTTL
+	}
+	zone := strings.TrimSuffix(params.DNSZone, ".")
+	for _, hostname := range params.Hostnames {
+		if state.isPublished(hostname) {
+			continue
+		}
+		err := o.dns.CreateRecord(ctx, &dns.RecordBody{
+			Name:       hostname,
+			RecordType: "CNAME",
+			TTL:        ttl,
+			Target:     []string{target},
+		}, zone)
+		if err != nil {
+			return err
+		}
+		state.PublishedRecords = append(state.PublishedRecords, hostname)
+	}
+	return nil
+}
+
+func (s *State) isPublished(hostname string) bool {
+	for _, published := range s.PublishedRecords {
+		if published == hostname {
+			return true
+		}
+	}
+	return false
+}
+
+func (r OnboardRequest) propertyName() string {
+	if r.PropertyName != "" {
+		return r.PropertyName
+	}
+	return r.Hostnames[0]
+}
+
+func (r OnboardRequest) cpCodeName() string {
+	if r.CPCodeName != "" {
+		return r.CPCodeName
+	}
+	return r.propertyName()
+}
@@ -0,0 +1,278 @@
+package onboarding
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/cps"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) Onboarding {
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
+	assert.NoError(t, err)
+	return Client(s, opts...)
+}
+
+type mockResponse struct {
+	status int
+	body   string
+}
+
+type mockOnboardingAPI struct {
+	t         *testing.T
+	responses map[string][]mockResponse
+	requests  []string
+	bodies    map[string]json.RawMessage
+}
+
+func (m *mockOnboardingAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + r.URL.Path
+	m.requests = append(m.requests, key)
+	body, err := ioutil.ReadAll(r.Body)
+	require.NoError(m.t, err)
+	m.bodies[key] = body
+
+	responses := m.responses[key]
+	if len(responses) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		_, err = w.Write([]byte(`{"type": "not_found", "title": "Not Found", "status": 404}`))
+		require.NoError(m.t, err)
+		return
+	}
+	resp := responses[0]
+	if len(responses) > 1 {
+		m.responses[key] = responses[1:]
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.status)
+	_, err = w.Write([]byte(resp.body))
+	require.NoError(m.t, err)
+}
+
+func newMockOnboardingAPI(t *testing.T) *mockOnboardingAPI {
+	return &mockOnboardingAPI{
+		t:      t,
+		bodies: map[string]json.RawMessage{},
+		responses: map[string][]mockResponse{
+			"POST /papi/v1/cpcodes": {{
+				status: http.StatusCreated,
+				body:   `{"cpcodeLink": "/papi/v1/cpcodes/cpc_33190?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			}},
+			"POST /cps/v2/enrollments": {{
+				status: http.StatusAccepted,
+				body:   `{"enrollment": "/cps/v2/enrollments/10002", "changes": ["/cps/v2/enrollments/10002/changes/10002"]}`,
+			}},
+			"POST /papi/v1/edgehostnames": {{
+				status: http.StatusCreated,
+				body:   `{"edgeHostnameLink": "/papi/v1/edgehostnames/ehn_895822?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			}},
+			"POST /papi/v1/properties": {{
+				status: http.StatusCreated,
+				body:   `{"propertyLink": "/papi/v1/properties/prp_175780?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			}},
+			"PUT /papi/v1/properties/prp_175780/versions/1/rules": {{
+				status: http.StatusOK,
+				body:   `{"propertyId": "prp_175780", "propertyVersion": 1, "rules": {"name": "default"}}`,
+			}},
+			"PUT /papi/v1/properties/prp_175780/versions/1/hostnames": {{
+				status: http.StatusOK,
+				body:   `{"propertyId": "prp_175780", "propertyVersion": 1, "hostnames": {"items": []}}`,
+			}},
+			"POST /papi/v1/properties/prp_175780/activations": {{
+				status: http.StatusCreated,
+				body:   `{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			}},
+			"GET /hapi/v1/edge-hostnames/895822": {{
+				status: http.StatusOK,
+				body:   `{"edgeHostnameId": 895822, "recordName": "www.example.com", "dnsZone": "edgekey.net", "securityType": "ENHANCED-TLS"}`,
+			}},
+			"POST /config-dns/v2/zones/example.com/names/www.example.com/types/CNAME": {{
+				status: http.StatusCreated,
+				body:   `{"name": "www.example.com", "type": "CNAME", "ttl": 600, "rdata": ["www.example.com.edgekey.net"]}`,
+			}},
+			"POST /config-dns/v2/zones/example.com/names/example.com/types/CNAME": {{
+				status: http.StatusCreated,
+				body:   `{"name": "example.com", "type": "CNAME", "ttl": 600, "rdata": ["www.example.com.edgekey.net"]}`,
+			}},
+		},
+	}
+}
+
+func onboardRequest() OnboardRequest {
+	return OnboardRequest{
+		ContractID: "ctr_1-1TJZFW",
+		GroupID:    "grp_15166",
+		ProductID:  "prd_Fresca",
+		Hostnames:  []string{"www.example.com", "example.com"},
+		Origin:     "origin.example.com",
+		RuleTemplate: &papi.Rules{
+			Name: "default",
+			Behaviors: []papi.RuleBehavior{
+				{Name: "origin", Options: papi.RuleOptionsMap{"hostname": "placeholder", "forwardHostHeader": "ORIGIN_HOSTNAME"}},
+				{Name: "caching", Options: papi.RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "1d"}},
+			},
+		},
+		Enrollment: &cps.Enrollment{
+			AdminContact: &cps.Contact{Email: "admin@example.com"},
+			TechContact:  &cps.Contact{Email: "tech@akamai.com"},
+			Org:          &cps.Org{Name: "Example"},
+		},
+		DNSZone:      "example.com",
+		DNSTTL:       600,
+		NotifyEmails: []string{"admin@example.com"},
+	}
+}
+
+func TestOnboarding_Onboard(t *testing.T) {
+	api := newMockOnboardingAPI(t)
+	mockServer := httptest.NewTLSServer(api)
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	state, err := client.Onboard(context.Background(), onboardRequest(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, &State{
+		CompletedSteps:   []Step{StepCPCode, StepEnrollment, StepEdgeHostname, StepProperty, StepRules, StepHostnames, StepActivation, StepDNS},
+		CPCodeID:         "cpc_33190",
+		EnrollmentID:     10002,
+		EdgeHostnameID:   "ehn_895822",
+		EdgeHostname:     "www.example.com.edgekey.net",
+		PropertyID:       "prp_175780",
+		PropertyVersion:  1,
+		ActivationID:     "atv_67037",
+		PublishedRecords: []string{"www.example.com", "example.com"},
+	}, state)
+
+	var enrollment cps.Enrollment
+	require.NoError(t, json.Unmarshal(api.bodies["POST /cps/v2/enrollments"], &enrollment))
+	assert.Equal(t, &cps.CSR{CN: "www.example.com", SANS: []string{"www.example.com", "example.com"}}, enrollment.CSR)
+	assert.Equal(t, "dv", enrollment.ValidationType)
+
+	var edgeHostname papi.EdgeHostnameCreate
+	require.NoError(t, json.Unmarshal(api.bodies["POST /papi/v1/edgehostnames"], &edgeHostname))
+	assert.Equal(t, papi.EdgeHostnameCreate{
+		ProductID:         "prd_Fresca",
+		DomainPrefix:      "www.example.com",
+		DomainSuffix:      "edgekey.net",
+		SecureNetwork:     papi.EHSecureNetworkEnhancedTLS,
+		IPVersionBehavior: papi.EHIPVersionV4,
+		CertEnrollmentID:  10002,
+	}, edgeHostname)
+
+	assert.JSONEq(t, `{"rules": {"name": "default", "options": {}, "behaviors": [
+		{"name": "origin", "options": {"hostname": "origin.example.com", "forwardHostHeader": "ORIGIN_HOSTNAME"}},
+		{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}},
+		{"name": "cpCode", "options": {"value": {"id": 33190}}}
+	]}}`, string(api.bodies["PUT /papi/v1/properties/prp_175780/versions/1/rules"]))
+
+	var hostnames []papi.Hostname
+	require.NoError(t, json.Unmarshal(api.bodies["PUT /papi/v1/properties/prp_175780/versions/1/hostnames"], &hostnames))
+	require.Len(t, hostnames, 2)
+	assert.Equal(t, "example.com", hostnames[1].CnameFrom)
+	assert.Equal(t, "ehn_895822", hostnames[1].EdgeHostnameID)
+
+	assert.JSONEq(t, `{"name": "example.com", "type": "CNAME", "ttl": 600, "rdata": ["www.example.com.edgekey.net"]}`,
+		string(api.bodies["POST /config-dns/v2/zones/example.com/names/example.com/types/CNAME"]))
+}
+
+func TestOnboarding_OnboardResume(t *testing.T) {
+	api := newMockOnboardingAPI(t)
+	api.responses["POST /config-dns/v2/zones/example.com/names/example.com/types/CNAME"] = []mockResponse{
+		{status: http.StatusInternalServerError, body: `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`},
+		{status: http.StatusCreated, body: `{"name": "example.com", "type": "CNAME", "ttl": 600, "rdata": ["www.example.com.edgekey.net"]}`},
+	}
+	mockServer := httptest.NewTLSServer(api)
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	request := onboardRequest()
+	request.Enrollment = nil
+	state, err := client.Onboard(context.Background(), request, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DNS step")
+	assert.Equal(t, StepDNS, state.FailedStep)
+	assert.Equal(t, []Step{StepCPCode, StepEdgeHostname, StepProperty, StepRules, StepHostnames, StepActivation}, state.CompletedSteps)
+	assert.Equal(t, []string{"www.example.com"}, state.PublishedRecords)
+	assert.Equal(t, "www.example.com.edgesuite.net", state.EdgeHostname)
+
+	// the state survives serialization
+	encoded, err := json.Marshal(state)
+	require.NoError(t, err)
+	var resumed State
+	require.NoError(t, json.Unmarshal(encoded, &resumed))
+
+	api.requests = nil
+	result, err := client.Onboard(context.Background(), request, &resumed)
+	require.NoError(t, err)
+	assert.Equal(t, Step(""), result.FailedStep)
+	assert.Equal(t, []string{"www.example.com", "example.com"}, result.PublishedRecords)
+	assert.Equal(t, []string{
+		"GET /hapi/v1/edge-hostnames/895822",
+		"POST /config-dns/v2/zones/example.com/names/example.com/types/CNAME",
+	}, api.requests)
+}
+
+func TestOnboarding_OnboardValidation(t *testing.T) {
+	tests := map[string]struct {
+		request OnboardRequest
+	}{
+		"missing origin": {
+			request: func() OnboardRequest {
+				r := onboardRequest()
+				r.Origin = ""
+				return r
+			}(),
+		},
+		"hostname outside of DNS zone": {
+			request: func() OnboardRequest {
+				r := onboardRequest()
+				r.Hostnames = append(r.Hostnames, "www.example.org")
+				return r
+			}(),
+		},
+		"enrollment without contacts": {
+			request: func() OnboardRequest {
+				r := onboardRequest()
+				r.Enrollment = &cps.Enrollment{Org: &cps.Org{Name: "Example"}}
+				return r
+			}(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			state, err := client.Onboard(context.Background(), test.request, nil)
+			assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+			assert.Empty(t, state.CompletedSteps)
+		})
+	}
+}
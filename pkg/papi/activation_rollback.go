@@ -0,0 +1,238 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ActivationRollbacks contains helpers reverting a property to the version active before the current one
+	ActivationRollbacks interface {
+		// RollbackActivation inspects activation history of a property on the given network, determines the version
+		// which was active before the current one and activates it again, using fast fallback when it is still available.
+		// If rule validation is requested and the rollback version has errors, no activation is created and the report
+		// is returned along with ErrRollbackValidation
+		RollbackActivation(context.Context, RollbackActivationRequest) (*RollbackActivationReport, error)
+	}
+
+	// RollbackActivationRequest contains parameters of a rollback activation
+	RollbackActivationRequest struct {
+		PropertyID             string
+		ContractID             string
+		GroupID                string
+		Network                ActivationNetwork
+		NotifyEmails           []string
+		Note                   string
+		AcknowledgeAllWarnings bool
+		// ValidateRules fetches rule tree of the rollback version with full validation and refuses to activate it
+		// if PAPI reports errors
+		ValidateRules bool
+		// RuleFormat, if set, validates rule tree of the rollback version converted to this rule format
+		RuleFormat string
+		// DryRun determines the rollback version and validates it without creating an activation
+		DryRun bool
+	}
+
+	// RollbackActivationReport describes what was (or, in dry run, would be) activated by RollbackActivation and why
+	RollbackActivationReport struct {
+		PropertyID string
+		Network    ActivationNetwork
+		// FromVersion is the version active on the network when the rollback started
+		FromVersion      int
+		FromActivationID string
+		// ToVersion is the version activated by the rollback
+		ToVersion int
+		// ToActivationID is the activation which previously made ToVersion active
+		ToActivationID   string
+		UseFastFallback  bool
+		Reasons          []string
+		ValidationErrors []*Error
+		DryRun           bool
+		// ActivationID and ActivationLink identify the rollback activation, they are empty in dry run
+		ActivationID   string
+		ActivationLink string
+	}
+
+	// ActivationHistory is activation history of a property on a single network
+	ActivationHistory struct {
+		Network ActivationNetwork
+		// Activations contains activations on the network ordered from the most recently submitted
+		Activations []*Activation
+		// Current is the activation of the currently active version, nil if no version is active
+		Current *Activation
+		// Previous is the activation of the version which was active before the current one, nil if there is none
+		Previous *Activation
+	}
+)
+
+var (
+	// ErrRollbackActivation represents error when rolling back activation fails
+	ErrRollbackActivation = errors.New("rolling back activation")
+	// ErrNoActiveVersion is returned when no property version is active on the network
+	ErrNoActiveVersion = errors.New("no active version")
+	// ErrNoRollbackVersion is returned when activation history contains no version to roll back to
+	ErrNoRollbackVersion = errors.New("no previously active version")
+	// ErrRollbackValidation is returned when rule tree of the rollback version does not pass validation
+	ErrRollbackValidation = errors.New("rollback version has validation errors")
+)
+
+// Validate validates RollbackActivationRequest
+func (r RollbackActivationRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":   validation.Validate(r.PropertyID, validation.Required),
+		"Network":      validation.Validate(r.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+		"NotifyEmails": validation.Validate(r.NotifyEmails, validation.When(!r.DryRun, validation.Required)),
+		"RuleFormat":   validation.Validate(r.RuleFormat, validation.Match(validRuleFormat)),
+	}.Filter()
+}
+
+// NewActivationHistory returns history of activations on the given network.
+// A version counts as previously active if it was activated before the current one and later superseded,
+// activations which failed or were aborted are skipped
+func NewActivationHistory(activations []*Activation, network ActivationNetwork) *ActivationHistory {
+	history := ActivationHistory{Network: network}
+	for _, activation := range activations {
+		if activation != nil && activation.Network == network {
+			history.Activations = append(history.Activations, activation)
+		}
+	}
+	sort.SliceStable(history.Activations, func(i, j int) bool {
+		return history.Activations[i].SubmitDate > history.Activations[j].SubmitDate
+	})
+
+	for _, activation := range history.Activations {
+		if activation.Status == ActivationStatusFailed || activation.Status == ActivationStatusAborted {
+			continue
+		}
+		if activation.ActivationType == ActivationTypeDeactivate {
+			if history.Current == nil {
+				// the property has been deactivated since its last activation
+				return &history
+			}
+			break
+		}
+		if history.Current == nil {
+			if activation.Status == ActivationStatusActive {
+				history.Current = activation
+			}
+			continue
+		}
+		if activation.Status == ActivationStatusInactive && activation.PropertyVersion != history.Current.PropertyVersion {
+			history.Previous = activation
+			break
+		}
+	}
+	return &history
+}
+
+// CanFastFallback returns true if the network can fast fall back from the current to the previous version at the given time
+func (h ActivationHistory) CanFastFallback(now time.Time) bool {
+	if h.Current == nil || h.Previous == nil || h.Current.FallbackInfo == nil {
+		return false
+	}
+	info := h.Current.FallbackInfo
+	return info.CanFastFallback && info.FallbackVersion == h.Previous.PropertyVersion &&
+		now.Unix() < int64(info.FastFallbackExpirationTime)
+}
+
+func (p *papi) RollbackActivation(ctx context.Context, params RollbackActivationRequest) (*RollbackActivationReport, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrRollbackActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("RollbackActivation")
+
+	activations, err := p.GetActivations(ctx, GetActivationsRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRollbackActivation, err)
+	}
+
+	history := NewActivationHistory(activations.Activations.Items, params.Network)
+	if history.Current == nil {
+		return nil, fmt.Errorf("%s: %w on %s network", ErrRollbackActivation, ErrNoActiveVersion, params.Network)
+	}
+	if history.Previous == nil {
+		return nil, fmt.Errorf("%s: %w before version %d on %s network", ErrRollbackActivation, ErrNoRollbackVersion,
+			history.Current.PropertyVersion, params.Network)
+	}
+
+	report := RollbackActivationReport{
+		PropertyID:       params.PropertyID,
+		Network:          params.Network,
+		FromVersion:      history.Current.PropertyVersion,
+		FromActivationID: history.Current.ActivationID,
+		ToVersion:        history.Previous.PropertyVersion,
+		ToActivationID:   history.Previous.ActivationID,
+		UseFastFallback:  history.CanFastFallback(time.Now()),
+		DryRun:           params.DryRun,
+	}
+	report.Reasons = append(report.Reasons, fmt.Sprintf("version %d was active on %s before version %d (activation %s)",
+		report.ToVersion, params.Network, report.FromVersion, report.ToActivationID))
+	if report.UseFastFallback {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("fast fallback to version %d is available until %s",
+			report.ToVersion, time.Unix(int64(history.Current.FallbackInfo.FastFallbackExpirationTime), 0).UTC().Format(time.RFC3339)))
+	} else {
+		report.Reasons = append(report.Reasons, "fast fallback is not available, the version is activated regularly")
+	}
+
+	if params.ValidateRules {
+		rules, err := p.GetRuleTree(ctx, GetRuleTreeRequest{
+			PropertyID:      params.PropertyID,
+			PropertyVersion: report.ToVersion,
+			ContractID:      params.ContractID,
+			GroupID:         params.GroupID,
+			ValidateMode:    RuleValidateModeFull,
+			ValidateRules:   true,
+			RuleFormat:      params.RuleFormat,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrRollbackActivation, err)
+		}
+		report.ValidationErrors = rules.Errors
+		if len(report.ValidationErrors) > 0 {
+			return &report, fmt.Errorf("%s: %w: version %d has %d errors", ErrRollbackActivation, ErrRollbackValidation,
+				report.ToVersion, len(report.ValidationErrors))
+		}
+		report.Reasons = append(report.Reasons, fmt.Sprintf("rule tree of version %d passed validation", report.ToVersion))
+	}
+
+	if params.DryRun {
+		return &report, nil
+	}
+
+	note := params.Note
+	if note == "" {
+		note = fmt.Sprintf("Rollback from version %d to version %d", report.FromVersion, report.ToVersion)
+	}
+	activation, err := p.CreateActivation(ctx, CreateActivationRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		Activation: Activation{
+			ActivationType:         ActivationTypeActivate,
+			PropertyVersion:        report.ToVersion,
+			Network:                params.Network,
+			UseFastFallback:        report.UseFastFallback,
+			AcknowledgeAllWarnings: params.AcknowledgeAllWarnings,
+			Note:                   note,
+			NotifyEmails:           params.NotifyEmails,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRollbackActivation, err)
+	}
+	report.ActivationID = activation.ActivationID
+	report.ActivationLink = activation.ActivationLink
+
+	return &report, nil
+}
@@ -0,0 +1,339 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewActivationHistory(t *testing.T) {
+	activation := func(id string, version int, network ActivationNetwork, activationType ActivationType, status ActivationStatus, submitDate string) *Activation {
+		return &Activation{
+			ActivationID:    id,
+			PropertyVersion: version,
+			Network:         network,
+			ActivationType:  activationType,
+			Status:          status,
+			SubmitDate:      submitDate,
+		}
+	}
+
+	tests := map[string]struct {
+		activations      []*Activation
+		network          ActivationNetwork
+		expectedCurrent  string
+		expectedPrevious string
+	}{
+		"previous version is the last superseded one": {
+			activations: []*Activation{
+				activation("atv_3", 3, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusInactive, "2022-10-10T10:00:00Z"),
+				activation("atv_5", 5, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusActive, "2022-10-18T10:00:00Z"),
+				activation("atv_4", 4, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusInactive, "2022-10-15T10:00:00Z"),
+				activation("atv_s5", 5, ActivationNetworkStaging, ActivationTypeActivate, ActivationStatusActive, "2022-10-17T10:00:00Z"),
+			},
+			network:          ActivationNetworkProduction,
+			expectedCurrent:  "atv_5",
+			expectedPrevious: "atv_4",
+		},
+		"failed activations and re-activations of the current version are skipped": {
+			activations: []*Activation{
+				activation("atv_6", 6, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusFailed, "2022-10-18T12:00:00Z"),
+				activation("atv_5b", 5, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusActive, "2022-10-18T10:00:00Z"),
+				activation("atv_5a", 5, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusInactive, "2022-10-16T10:00:00Z"),
+				activation("atv_4x", 4, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusAborted, "2022-10-15T12:00:00Z"),
+				activation("atv_3", 3, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusInactive, "2022-10-10T10:00:00Z"),
+			},
+			network:          ActivationNetworkProduction,
+			expectedCurrent:  "atv_5b",
+			expectedPrevious: "atv_3",
+		},
+		"pending activation is not current": {
+			activations: []*Activation{
+				activation("atv_6", 6, ActivationNetworkStaging, ActivationTypeActivate, ActivationStatusPending, "2022-10-18T12:00:00Z"),
+				activation("atv_5", 5, ActivationNetworkStaging, ActivationTypeActivate, ActivationStatusActive, "2022-10-18T10:00:00Z"),
+				activation("atv_4", 4, ActivationNetworkStaging, ActivationTypeActivate, ActivationStatusInactive, "2022-10-15T10:00:00Z"),
+			},
+			network:          ActivationNetworkStaging,
+			expectedCurrent:  "atv_5",
+			expectedPrevious: "atv_4",
+		},
+		"deactivated property has no current version": {
+			activations: []*Activation{
+				activation("atv_d", 5, ActivationNetworkProduction, ActivationTypeDeactivate, ActivationStatusDeactivated, "2022-10-18T12:00:00Z"),
+				activation("atv_5", 5, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusInactive, "2022-10-18T10:00:00Z"),
+			},
+			network: ActivationNetworkProduction,
+		},
+		"history before deactivation is not used": {
+			activations: []*Activation{
+				activation("atv_5", 5, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusActive, "2022-10-18T12:00:00Z"),
+				activation("atv_d", 4, ActivationNetworkProduction, ActivationTypeDeactivate, ActivationStatusDeactivated, "2022-10-18T10:00:00Z"),
+				activation("atv_4", 4, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusInactive, "2022-10-15T10:00:00Z"),
+			},
+			network:         ActivationNetworkProduction,
+			expectedCurrent: "atv_5",
+		},
+		"single activation": {
+			activations: []*Activation{
+				activation("atv_1", 1, ActivationNetworkProduction, ActivationTypeActivate, ActivationStatusActive, "2022-10-18T10:00:00Z"),
+			},
+			network:         ActivationNetworkProduction,
+			expectedCurrent: "atv_1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			history := NewActivationHistory(test.activations, test.network)
+			for _, activation := range history.Activations {
+				assert.Equal(t, test.network, activation.Network)
+			}
+			var current, previous string
+			if history.Current != nil {
+				current = history.Current.ActivationID
+			}
+			if history.Previous != nil {
+				previous = history.Previous.ActivationID
+			}
+			assert.Equal(t, test.expectedCurrent, current)
+			assert.Equal(t, test.expectedPrevious, previous)
+		})
+	}
+}
+
+func TestActivationHistory_CanFastFallback(t *testing.T) {
+	now := time.Date(2022, 10, 18, 12, 0, 0, 0, time.UTC)
+	history := func(info *ActivationFallbackInfo) ActivationHistory {
+		return ActivationHistory{
+			Current:  &Activation{PropertyVersion: 5, FallbackInfo: info},
+			Previous: &Activation{PropertyVersion: 4},
+		}
+	}
+
+	assert.True(t, history(&ActivationFallbackInfo{
+		CanFastFallback: true, FallbackVersion: 4, FastFallbackExpirationTime: int(now.Add(time.Minute).Unix()),
+	}).CanFastFallback(now))
+	assert.False(t, history(&ActivationFallbackInfo{
+		CanFastFallback: true, FallbackVersion: 4, FastFallbackExpirationTime: int(now.Add(-time.Minute).Unix()),
+	}).CanFastFallback(now), "expired")
+	assert.False(t, history(&ActivationFallbackInfo{
+		CanFastFallback: true, FallbackVersion: 3, FastFallbackExpirationTime: int(now.Add(time.Minute).Unix()),
+	}).CanFastFallback(now), "different fallback version")
+	assert.False(t, history(&ActivationFallbackInfo{
+		FallbackVersion: 4, FastFallbackExpirationTime: int(now.Add(time.Minute).Unix()),
+	}).CanFastFallback(now), "fast fallback not possible")
+	assert.False(t, history(nil).CanFastFallback(now), "no fallback info")
+}
+
+func TestPapi_RollbackActivation(t *testing.T) {
+	activationsBody := func(expiration int64) string {
+		return fmt.Sprintf(`
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15225",
+    "activations": {
+        "items": [
+            {
+                "activationId": "atv_5",
+                "propertyId": "prp_173136",
+                "propertyVersion": 5,
+                "network": "PRODUCTION",
+                "activationType": "ACTIVATE",
+                "status": "ACTIVE",
+                "submitDate": "2022-10-18T10:00:00Z",
+                "notifyEmails": ["you@example.com"],
+                "fallbackInfo": {
+                    "fastFallbackAttempted": false,
+                    "fallbackVersion": 4,
+                    "canFastFallback": true,
+                    "steadyStateTime": 1666087200,
+                    "fastFallbackExpirationTime": %d
+                }
+            },
+            {
+                "activationId": "atv_4",
+                "propertyId": "prp_173136",
+                "propertyVersion": 4,
+                "network": "PRODUCTION",
+                "activationType": "ACTIVATE",
+                "status": "INACTIVE",
+                "submitDate": "2022-10-12T10:00:00Z",
+                "notifyEmails": ["you@example.com"]
+            }
+        ]
+    }
+}`, expiration)
+	}
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Hour).Unix()
+
+	tests := map[string]struct {
+		request                   RollbackActivationRequest
+		activationsBody           string
+		rulesBody                 string
+		expectedActivationRequest string
+		expectedReport            *RollbackActivationReport
+		withError                 error
+	}{
+		"rollback with fast fallback": {
+			request: RollbackActivationRequest{
+				PropertyID:   "prp_173136",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15225",
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"you@example.com"},
+			},
+			activationsBody:           activationsBody(future),
+			expectedActivationRequest: `{"activationType":"ACTIVATE","useFastFallback":true,"acknowledgeAllWarnings":false,"propertyVersion":4,"network":"PRODUCTION","note":"Rollback from version 5 to version 4","notifyEmails":["you@example.com"]}`,
+			expectedReport: &RollbackActivationReport{
+				PropertyID:       "prp_173136",
+				Network:          ActivationNetworkProduction,
+				FromVersion:      5,
+				FromActivationID: "atv_5",
+				ToVersion:        4,
+				ToActivationID:   "atv_4",
+				UseFastFallback:  true,
+				ActivationID:     "atv_6",
+				ActivationLink:   "/papi/v1/properties/prp_173136/activations/atv_6",
+			},
+		},
+		"regular rollback with validation": {
+			request: RollbackActivationRequest{
+				PropertyID:             "prp_173136",
+				ContractID:             "ctr_1-1TJZFW",
+				GroupID:                "grp_15225",
+				Network:                ActivationNetworkProduction,
+				NotifyEmails:           []string{"you@example.com"},
+				Note:                   "incident 42",
+				AcknowledgeAllWarnings: true,
+				ValidateRules:          true,
+			},
+			activationsBody:           activationsBody(past),
+			rulesBody:                 `{"propertyId": "prp_173136", "propertyVersion": 4, "ruleFormat": "v2022-10-18", "rules": {"name": "default"}}`,
+			expectedActivationRequest: `{"activationType":"ACTIVATE","useFastFallback":false,"acknowledgeAllWarnings":true,"propertyVersion":4,"network":"PRODUCTION","note":"incident 42","notifyEmails":["you@example.com"]}`,
+			expectedReport: &RollbackActivationReport{
+				PropertyID:       "prp_173136",
+				Network:          ActivationNetworkProduction,
+				FromVersion:      5,
+				FromActivationID: "atv_5",
+				ToVersion:        4,
+				ToActivationID:   "atv_4",
+				ActivationID:     "atv_6",
+				ActivationLink:   "/papi/v1/properties/prp_173136/activations/atv_6",
+			},
+		},
+		"dry run": {
+			request: RollbackActivationRequest{
+				PropertyID: "prp_173136",
+				Network:    ActivationNetworkProduction,
+				DryRun:     true,
+			},
+			activationsBody: activationsBody(future),
+			expectedReport: &RollbackActivationReport{
+				PropertyID:       "prp_173136",
+				Network:          ActivationNetworkProduction,
+				FromVersion:      5,
+				FromActivationID: "atv_5",
+				ToVersion:        4,
+				ToActivationID:   "atv_4",
+				UseFastFallback:  true,
+				DryRun:           true,
+			},
+		},
+		"validation errors prevent activation": {
+			request: RollbackActivationRequest{
+				PropertyID:    "prp_173136",
+				Network:       ActivationNetworkProduction,
+				NotifyEmails:  []string{"you@example.com"},
+				ValidateRules: true,
+			},
+			activationsBody: activationsBody(future),
+			rulesBody:       `{"propertyId": "prp_173136", "propertyVersion": 4, "rules": {"name": "default"}, "errors": [{"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required", "title": "Required attribute missing", "detail": "origin hostname is required"}]}`,
+			expectedReport: &RollbackActivationReport{
+				PropertyID:       "prp_173136",
+				Network:          ActivationNetworkProduction,
+				FromVersion:      5,
+				FromActivationID: "atv_5",
+				ToVersion:        4,
+				ToActivationID:   "atv_4",
+				UseFastFallback:  true,
+				ValidationErrors: []*Error{{
+					Type:   "https://problems.luna.akamaiapis.net/papi/v0/validation/attribute_required",
+					Title:  "Required attribute missing",
+					Detail: "origin hostname is required",
+				}},
+			},
+			withError: ErrRollbackValidation,
+		},
+		"no previous version": {
+			request: RollbackActivationRequest{
+				PropertyID:   "prp_173136",
+				Network:      ActivationNetworkStaging,
+				NotifyEmails: []string{"you@example.com"},
+			},
+			activationsBody: activationsBody(future),
+			withError:       ErrNoActiveVersion,
+		},
+		"validation error - missing notify emails": {
+			request: RollbackActivationRequest{
+				PropertyID: "prp_173136",
+				Network:    ActivationNetworkProduction,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var activated bool
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/properties/prp_173136/activations":
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(test.activationsBody))
+					assert.NoError(t, err)
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/properties/prp_173136/versions/4/rules":
+					assert.NotEqual(t, "false", r.URL.Query().Get("validateRules"))
+					assert.Equal(t, RuleValidateModeFull, r.URL.Query().Get("validateMode"))
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(test.rulesBody))
+					assert.NoError(t, err)
+				case r.Method == http.MethodPost && r.URL.Path == "/papi/v1/properties/prp_173136/activations":
+					activated = true
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedActivationRequest, string(body))
+					w.WriteHeader(http.StatusCreated)
+					_, err = w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_173136/activations/atv_6"}`))
+					assert.NoError(t, err)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+			report, err := client.RollbackActivation(context.Background(), test.request)
+			assert.Equal(t, test.expectedActivationRequest != "", activated)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				if test.expectedReport == nil {
+					return
+				}
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, report)
+			assert.NotEmpty(t, report.Reasons)
+			report.Reasons = nil
+			assert.Equal(t, test.expectedReport, report)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// BulkActivations contains operations available on bulk activation resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/bulk-activation
+	BulkActivations interface {
+		// BulkActivate submits activation of many property versions
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-bulk-activations
+		BulkActivate(context.Context, BulkActivateRequest) (*BulkActivateResponse, error)
+
+		// GetBulkActivation gets status of a bulk activation and of every activated property version
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-bulk-activation
+		GetBulkActivation(context.Context, GetBulkActivationRequest) (*BulkActivationResult, error)
+
+		// WaitForBulkActivation polls a bulk activation until it is complete or the context is canceled.
+		// Property versions which could not be activated are reported by BulkActivationResult.Failures, not as an error
+		WaitForBulkActivation(context.Context, GetBulkActivationRequest) (*BulkActivationResult, error)
+	}
+
+	// BulkActivateRequest contains query params and request body of POST /bulk/activations request
+	BulkActivateRequest struct {
+		ContractID                string                        `json:"-"`
+		GroupID                   string                        `json:"-"`
+		DefaultActivationSettings *BulkActivationSettings       `json:"defaultActivationSettings,omitempty"`
+		ActivatePropertyVersions  []BulkActivatePropertyVersion `json:"activatePropertyVersions"`
+	}
+
+	// BulkActivationSettings contains settings applied to every activation of a bulk activation
+	BulkActivationSettings struct {
+		NotifyEmails           []string `json:"notifyEmails,omitempty"`
+		AcknowledgeAllWarnings bool     `json:"acknowledgeAllWarnings"`
+		UseFastFallback        bool     `json:"useFastFallback"`
+		FastPush               bool     `json:"fastPush"`
+	}
+
+	// BulkActivatePropertyVersion describes activation of a single property version
+	BulkActivatePropertyVersion struct {
+		PropertyID             string            `json:"propertyId"`
+		PropertyVersion        int               `json:"propertyVersion"`
+		Network                ActivationNetwork `json:"network"`
+		Note                   string            `json:"note,omitempty"`
+		NotifyEmails           []string          `json:"notifyEmails,omitempty"`
+		AcknowledgeWarnings    []string          `json:"acknowledgeWarnings,omitempty"`
+		AcknowledgeAllWarnings *bool             `json:"acknowledgeAllWarnings,omitempty"`
+	}
+
+	// BulkActivateResponse contains the response returned by BulkActivate
+	BulkActivateResponse struct {
+		BulkActivationID   int    `json:"-"`
+		BulkActivationLink string `json:"bulkActivationLink"`
+	}
+
+	// GetBulkActivationRequest contains path and query params used to fetch a bulk activation
+	GetBulkActivationRequest struct {
+		BulkActivationID int
+		ContractID       string
+		GroupID          string
+	}
+
+	// BulkActivationResult represents a bulk activation resource
+	BulkActivationResult struct {
+		BulkActivationID          int                                 `json:"bulkActivationId"`
+		BulkActivationStatus      BulkStatus                          `json:"bulkActivationStatus"`
+		SubmitDate                string                              `json:"submitDate,omitempty"`
+		UpdateDate                string                              `json:"updateDate,omitempty"`
+		DefaultActivationSettings *BulkActivationSettings             `json:"defaultActivationSettings,omitempty"`
+		ActivatePropertyVersions  []BulkActivatePropertyVersionResult `json:"activatePropertyVersions"`
+	}
+
+	// BulkActivatePropertyVersionResult contains outcome of activating a single property version
+	BulkActivatePropertyVersionResult struct {
+		PropertyID       string            `json:"propertyId"`
+		PropertyName     string            `json:"propertyName"`
+		PropertyVersion  int               `json:"propertyVersion"`
+		Network          ActivationNetwork `json:"network"`
+		Note             string            `json:"note,omitempty"`
+		NotifyEmails     []string          `json:"notifyEmails,omitempty"`
+		TaskStatus       BulkItemStatus    `json:"taskStatus"`
+		ActivationID     string            `json:"activationId,omitempty"`
+		ActivationLink   string            `json:"activationLink,omitempty"`
+		ActivationStatus ActivationStatus  `json:"activationStatus,omitempty"`
+		FatalError       string            `json:"fatalError,omitempty"`
+	}
+)
+
+var (
+	// ErrBulkActivate represents error when submitting bulk activation fails
+	ErrBulkActivate = errors.New("submitting bulk activation")
+	// ErrGetBulkActivation represents error when fetching bulk activation fails
+	ErrGetBulkActivation = errors.New("fetching bulk activation")
+	// ErrWaitForBulkActivation represents error when waiting for bulk activation fails
+	ErrWaitForBulkActivation = errors.New("waiting for bulk activation")
+)
+
+// Validate validates BulkActivateRequest struct
+func (r BulkActivateRequest) Validate() error {
+	return validation.Errors{
+		"ActivatePropertyVersions": validation.Validate(r.ActivatePropertyVersions, validation.Required),
+	}.Filter()
+}
+
+// Validate validates BulkActivatePropertyVersion struct
+func (v BulkActivatePropertyVersion) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(v.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(v.PropertyVersion, validation.Required),
+		"Network":         validation.Validate(v.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+	}.Filter()
+}
+
+// Validate validates GetBulkActivationRequest struct
+func (r GetBulkActivationRequest) Validate() error {
+	return validation.Errors{
+		"BulkActivationID": validation.Validate(r.BulkActivationID, validation.Required),
+	}.Filter()
+}
+
+// Failures returns property versions which could not be activated, including activations which PAPI accepted but which failed
+func (r BulkActivationResult) Failures() []BulkActivatePropertyVersionResult {
+	var failures []BulkActivatePropertyVersionResult
+	for _, version := range r.ActivatePropertyVersions {
+		if version.TaskStatus.Failed() || version.FatalError != "" ||
+			version.ActivationStatus == ActivationStatusFailed || version.ActivationStatus == ActivationStatusAborted {
+			failures = append(failures, version)
+		}
+	}
+	return failures
+}
+
+func (p *papi) BulkActivate(ctx context.Context, params BulkActivateRequest) (*BulkActivateResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkActivate, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("BulkActivate")
+
+	uri, err := bulkURL("/papi/v1/bulk/activations", params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrBulkActivate, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrBulkActivate, err)
+	}
+
+	var rval BulkActivateResponse
+	resp, err := p.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrBulkActivate, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrBulkActivate, p.Error(resp))
+	}
+
+	id, err := parseBulkLink(rval.BulkActivationLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkActivate, ErrInvalidResponseLink, err)
+	}
+	rval.BulkActivationID = id
+
+	return &rval, nil
+}
+
+func (p *papi) GetBulkActivation(ctx context.Context, params GetBulkActivationRequest) (*BulkActivationResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetBulkActivation")
+
+	uri, err := bulkURL(fmt.Sprintf("/papi/v1/bulk/activations/%d", params.BulkActivationID), params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetBulkActivation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkActivation, err)
+	}
+
+	var rval BulkActivationResult
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkActivation, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkActivation, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) WaitForBulkActivation(ctx context.Context, params GetBulkActivationRequest) (*BulkActivationResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForBulkActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("WaitForBulkActivation")
+
+	var result *BulkActivationResult
+	err := p.poll(ctx, func() (bool, error) {
+		var err error
+		result, err = p.GetBulkActivation(ctx, params)
+		if err != nil {
+			return false, err
+		}
+		return !result.BulkActivationStatus.Pending(), nil
+	})
+	if err != nil {
+		return nil, pollError(ctx, ErrWaitForBulkActivation, err)
+	}
+	return result, nil
+}
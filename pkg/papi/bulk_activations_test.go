@@ -0,0 +1,293 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_BulkActivate(t *testing.T) {
+	tests := map[string]struct {
+		request             BulkActivateRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *BulkActivateResponse
+		withError           error
+	}{
+		"202 accepted": {
+			request: BulkActivateRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				DefaultActivationSettings: &BulkActivationSettings{
+					NotifyEmails:           []string{"jsmith@example.com"},
+					AcknowledgeAllWarnings: true,
+					UseFastFallback:        false,
+					FastPush:               true,
+				},
+				ActivatePropertyVersions: []BulkActivatePropertyVersion{
+					{PropertyID: "prp_15", PropertyVersion: 4, Network: ActivationNetworkStaging, Note: "origin migration"},
+					{PropertyID: "prp_16", PropertyVersion: 3, Network: ActivationNetworkProduction},
+				},
+			},
+			responseStatus:      http.StatusAccepted,
+			responseBody:        `{"bulkActivationLink": "/papi/v1/bulk/activations/21?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/bulk/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"defaultActivationSettings":{"notifyEmails":["jsmith@example.com"],"acknowledgeAllWarnings":true,"useFastFallback":false,"fastPush":true},"activatePropertyVersions":[{"propertyId":"prp_15","propertyVersion":4,"network":"STAGING","note":"origin migration"},{"propertyId":"prp_16","propertyVersion":3,"network":"PRODUCTION"}]}`,
+			expectedResponse: &BulkActivateResponse{
+				BulkActivationID:   21,
+				BulkActivationLink: "/papi/v1/bulk/activations/21?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"400 bad request": {
+			request: BulkActivateRequest{
+				ActivatePropertyVersions: []BulkActivatePropertyVersion{
+					{PropertyID: "prp_15", PropertyVersion: 4, Network: ActivationNetworkStaging},
+				},
+			},
+			responseStatus: http.StatusBadRequest,
+			responseBody: `
+{
+    "type": "bad_request",
+    "title": "Bad Request",
+    "detail": "Missing notify emails",
+    "status": 400
+}`,
+			expectedPath:        "/papi/v1/bulk/activations",
+			expectedRequestBody: `{"activatePropertyVersions":[{"propertyId":"prp_15","propertyVersion":4,"network":"STAGING"}]}`,
+			withError: &Error{
+				Type:       "bad_request",
+				Title:      "Bad Request",
+				Detail:     "Missing notify emails",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		"validation error - missing property versions": {
+			request:   BulkActivateRequest{DefaultActivationSettings: &BulkActivationSettings{}},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid network": {
+			request: BulkActivateRequest{
+				ActivatePropertyVersions: []BulkActivatePropertyVersion{
+					{PropertyID: "prp_15", PropertyVersion: 4, Network: "QA"},
+				},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.BulkActivate(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetBulkActivation(t *testing.T) {
+	tests := map[string]struct {
+		request          GetBulkActivationRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *BulkActivationResult
+		expectedFailures []string
+		withError        error
+	}{
+		"200 OK": {
+			request:        GetBulkActivationRequest{BulkActivationID: 21, ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "bulkActivationId": 21,
+    "bulkActivationStatus": "COMPLETE",
+    "submitDate": "2022-10-18T14:30:00Z",
+    "updateDate": "2022-10-18T14:45:00Z",
+    "activatePropertyVersions": [
+        {
+            "propertyId": "prp_15",
+            "propertyName": "example.com",
+            "propertyVersion": 4,
+            "network": "STAGING",
+            "taskStatus": "COMPLETE",
+            "activationId": "atv_101",
+            "activationLink": "/papi/v1/properties/prp_15/activations/atv_101",
+            "activationStatus": "ACTIVE"
+        },
+        {
+            "propertyId": "prp_16",
+            "propertyName": "example.net",
+            "propertyVersion": 3,
+            "network": "PRODUCTION",
+            "taskStatus": "COMPLETE",
+            "activationId": "atv_102",
+            "activationStatus": "FAILED"
+        },
+        {
+            "propertyId": "prp_17",
+            "propertyName": "example.org",
+            "propertyVersion": 5,
+            "network": "STAGING",
+            "taskStatus": "SUBMISSION_ERROR",
+            "fatalError": "Version has validation errors"
+        }
+    ]
+}`,
+			expectedPath: "/papi/v1/bulk/activations/21?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &BulkActivationResult{
+				BulkActivationID:     21,
+				BulkActivationStatus: BulkStatusComplete,
+				SubmitDate:           "2022-10-18T14:30:00Z",
+				UpdateDate:           "2022-10-18T14:45:00Z",
+				ActivatePropertyVersions: []BulkActivatePropertyVersionResult{
+					{
+						PropertyID:       "prp_15",
+						PropertyName:     "example.com",
+						PropertyVersion:  4,
+						Network:          ActivationNetworkStaging,
+						TaskStatus:       BulkItemStatusComplete,
+						ActivationID:     "atv_101",
+						ActivationLink:   "/papi/v1/properties/prp_15/activations/atv_101",
+						ActivationStatus: ActivationStatusActive,
+					},
+					{
+						PropertyID:       "prp_16",
+						PropertyName:     "example.net",
+						PropertyVersion:  3,
+						Network:          ActivationNetworkProduction,
+						TaskStatus:       BulkItemStatusComplete,
+						ActivationID:     "atv_102",
+						ActivationStatus: ActivationStatusFailed,
+					},
+					{
+						PropertyID:      "prp_17",
+						PropertyName:    "example.org",
+						PropertyVersion: 5,
+						Network:         ActivationNetworkStaging,
+						TaskStatus:      BulkItemStatusSubmissionError,
+						FatalError:      "Version has validation errors",
+					},
+				},
+			},
+			expectedFailures: []string{"prp_16", "prp_17"},
+		},
+		"500 internal server error": {
+			request:        GetBulkActivationRequest{BulkActivationID: 22},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/bulk/activations/22",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing ID": {
+			request:   GetBulkActivationRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetBulkActivation(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			var failures []string
+			for _, failure := range result.Failures() {
+				failures = append(failures, failure.PropertyID)
+			}
+			assert.Equal(t, test.expectedFailures, failures)
+		})
+	}
+}
+
+func TestPapi_WaitForBulkActivation(t *testing.T) {
+	request := GetBulkActivationRequest{BulkActivationID: 21}
+	activationBody := func(status BulkStatus) string {
+		return fmt.Sprintf(`{"bulkActivationId": 21, "bulkActivationStatus": "%s", "activatePropertyVersions": []}`, status)
+	}
+
+	t.Run("polls until complete", func(t *testing.T) {
+		statuses := []BulkStatus{BulkStatusSubmitted, BulkStatusInProgress, BulkStatusInProgress, BulkStatusComplete}
+		var requests int
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/papi/v1/bulk/activations/21", r.URL.String())
+			status := statuses[requests]
+			requests++
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(activationBody(status)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Millisecond, 2*time.Millisecond))
+		result, err := client.WaitForBulkActivation(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, 4, requests)
+		assert.Equal(t, BulkStatusComplete, result.BulkActivationStatus)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(activationBody(BulkStatusInProgress)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Hour, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForBulkActivation(ctx, request)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrWaitForBulkActivation), "want: %s; got: %s", ErrWaitForBulkActivation, err)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := client.WaitForBulkActivation(context.Background(), GetBulkActivationRequest{})
+		assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+	})
+}
@@ -0,0 +1,213 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// BulkPatches contains operations available on bulk patch resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/bulk-patch
+	BulkPatches interface {
+		// BulkPatch submits JSON patches to rule trees of many property versions, typically the ones matched by BulkSearch
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-bulk-patch
+		BulkPatch(context.Context, BulkPatchRequest) (*BulkPatchResponse, error)
+
+		// GetBulkPatch gets status of a bulk patch and of every patched property version
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-bulk-patch
+		GetBulkPatch(context.Context, GetBulkPatchRequest) (*BulkPatchResult, error)
+
+		// WaitForBulkPatch polls a bulk patch until it is complete or the context is canceled.
+		// Property versions which could not be patched are reported by BulkPatchResult.Failures, not as an error
+		WaitForBulkPatch(context.Context, GetBulkPatchRequest) (*BulkPatchResult, error)
+	}
+
+	// BulkPatchRequest contains query params and request body of POST /bulk/rules-patch-requests request
+	BulkPatchRequest struct {
+		ContractID            string                     `json:"-"`
+		GroupID               string                     `json:"-"`
+		BulkSearchID          int                        `json:"bulkSearchId,omitempty"`
+		PatchPropertyVersions []BulkPatchPropertyVersion `json:"patchPropertyVersions"`
+	}
+
+	// BulkPatchPropertyVersion contains JSON patch operations applied to rule tree of a single property version
+	BulkPatchPropertyVersion struct {
+		PropertyID      string           `json:"propertyId"`
+		PropertyVersion int              `json:"propertyVersion"`
+		Etag            string           `json:"etag"`
+		Patches         []PatchOperation `json:"patches"`
+	}
+
+	// BulkPatchResponse contains the response returned by BulkPatch
+	BulkPatchResponse struct {
+		BulkPatchID   int    `json:"-"`
+		BulkPatchLink string `json:"bulkPatchLink"`
+	}
+
+	// GetBulkPatchRequest contains path and query params used to fetch a bulk patch
+	GetBulkPatchRequest struct {
+		BulkPatchID int
+		ContractID  string
+		GroupID     string
+	}
+
+	// BulkPatchResult represents a bulk patch resource
+	BulkPatchResult struct {
+		BulkPatchID           int                              `json:"bulkPatchId"`
+		BulkPatchStatus       BulkStatus                       `json:"bulkPatchStatus"`
+		SubmitDate            string                           `json:"submitDate,omitempty"`
+		UpdateDate            string                           `json:"updateDate,omitempty"`
+		PatchPropertyVersions []BulkPatchPropertyVersionResult `json:"patchPropertyVersions"`
+	}
+
+	// BulkPatchPropertyVersionResult contains outcome of patching a single property version
+	BulkPatchPropertyVersionResult struct {
+		PropertyID               string         `json:"propertyId"`
+		PropertyName             string         `json:"propertyName"`
+		PropertyVersion          int            `json:"propertyVersion"`
+		Etag                     string         `json:"etag,omitempty"`
+		Status                   BulkItemStatus `json:"status"`
+		PatchPropertyVersionLink string         `json:"patchPropertyVersionLink,omitempty"`
+		FailureCause             string         `json:"failureCause,omitempty"`
+		Errors                   []*Error       `json:"errors,omitempty"`
+	}
+)
+
+var (
+	// ErrBulkPatch represents error when submitting bulk patch fails
+	ErrBulkPatch = errors.New("submitting bulk patch")
+	// ErrGetBulkPatch represents error when fetching bulk patch fails
+	ErrGetBulkPatch = errors.New("fetching bulk patch")
+	// ErrWaitForBulkPatch represents error when waiting for bulk patch fails
+	ErrWaitForBulkPatch = errors.New("waiting for bulk patch")
+)
+
+// Validate validates BulkPatchRequest struct
+func (r BulkPatchRequest) Validate() error {
+	return validation.Errors{
+		"PatchPropertyVersions": validation.Validate(r.PatchPropertyVersions, validation.Required),
+	}.Filter()
+}
+
+// Validate validates BulkPatchPropertyVersion struct
+func (v BulkPatchPropertyVersion) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(v.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(v.PropertyVersion, validation.Required),
+		"Etag":            validation.Validate(v.Etag, validation.Required),
+		"Patches":         validation.Validate(v.Patches, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetBulkPatchRequest struct
+func (r GetBulkPatchRequest) Validate() error {
+	return validation.Errors{
+		"BulkPatchID": validation.Validate(r.BulkPatchID, validation.Required),
+	}.Filter()
+}
+
+// Failures returns property versions which could not be patched
+func (r BulkPatchResult) Failures() []BulkPatchPropertyVersionResult {
+	var failures []BulkPatchPropertyVersionResult
+	for _, version := range r.PatchPropertyVersions {
+		if version.Status.Failed() || len(version.Errors) > 0 {
+			failures = append(failures, version)
+		}
+	}
+	return failures
+}
+
+func (p *papi) BulkPatch(ctx context.Context, params BulkPatchRequest) (*BulkPatchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkPatch, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("BulkPatch")
+
+	uri, err := bulkURL("/papi/v1/bulk/rules-patch-requests", params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrBulkPatch, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrBulkPatch, err)
+	}
+
+	var rval BulkPatchResponse
+	resp, err := p.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrBulkPatch, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrBulkPatch, p.Error(resp))
+	}
+
+	id, err := parseBulkLink(rval.BulkPatchLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkPatch, ErrInvalidResponseLink, err)
+	}
+	rval.BulkPatchID = id
+
+	return &rval, nil
+}
+
+func (p *papi) GetBulkPatch(ctx context.Context, params GetBulkPatchRequest) (*BulkPatchResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkPatch, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetBulkPatch")
+
+	uri, err := bulkURL(fmt.Sprintf("/papi/v1/bulk/rules-patch-requests/%d", params.BulkPatchID), params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetBulkPatch, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkPatch, err)
+	}
+
+	var rval BulkPatchResult
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkPatch, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkPatch, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) WaitForBulkPatch(ctx context.Context, params GetBulkPatchRequest) (*BulkPatchResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForBulkPatch, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("WaitForBulkPatch")
+
+	var result *BulkPatchResult
+	err := p.poll(ctx, func() (bool, error) {
+		var err error
+		result, err = p.GetBulkPatch(ctx, params)
+		if err != nil {
+			return false, err
+		}
+		return !result.BulkPatchStatus.Pending(), nil
+	})
+	if err != nil {
+		return nil, pollError(ctx, ErrWaitForBulkPatch, err)
+	}
+	return result, nil
+}
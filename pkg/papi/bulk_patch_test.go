@@ -0,0 +1,304 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_BulkPatch(t *testing.T) {
+	tests := map[string]struct {
+		request             BulkPatchRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *BulkPatchResponse
+		withError           error
+	}{
+		"202 accepted": {
+			request: BulkPatchRequest{
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				BulkSearchID: 5,
+				PatchPropertyVersions: []BulkPatchPropertyVersion{
+					{
+						PropertyID:      "prp_15",
+						PropertyVersion: 3,
+						Etag:            "a9dfe78cf93090516bde891d009eaf57",
+						Patches: []PatchOperation{
+							{Op: PatchOperationReplace, Path: "/rules/behaviors/0/options/hostname", Value: "origin-new.example.com"},
+						},
+					},
+				},
+			},
+			responseStatus:      http.StatusAccepted,
+			responseBody:        `{"bulkPatchLink": "/papi/v1/bulk/rules-patch-requests/9?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/bulk/rules-patch-requests?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"bulkSearchId":5,"patchPropertyVersions":[{"propertyId":"prp_15","propertyVersion":3,"etag":"a9dfe78cf93090516bde891d009eaf57","patches":[{"op":"replace","path":"/rules/behaviors/0/options/hostname","value":"origin-new.example.com"}]}]}`,
+			expectedResponse: &BulkPatchResponse{
+				BulkPatchID:   9,
+				BulkPatchLink: "/papi/v1/bulk/rules-patch-requests/9?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"500 internal server error": {
+			request: BulkPatchRequest{
+				PatchPropertyVersions: []BulkPatchPropertyVersion{
+					{
+						PropertyID:      "prp_15",
+						PropertyVersion: 3,
+						Etag:            "a9dfe78cf93090516bde891d009eaf57",
+						Patches:         []PatchOperation{{Op: PatchOperationRemove, Path: "/rules/behaviors/1"}},
+					},
+				},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "status": 500
+}`,
+			expectedPath:        "/papi/v1/bulk/rules-patch-requests",
+			expectedRequestBody: `{"patchPropertyVersions":[{"propertyId":"prp_15","propertyVersion":3,"etag":"a9dfe78cf93090516bde891d009eaf57","patches":[{"op":"remove","path":"/rules/behaviors/1"}]}]}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing property versions": {
+			request:   BulkPatchRequest{BulkSearchID: 5},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing etag": {
+			request: BulkPatchRequest{
+				PatchPropertyVersions: []BulkPatchPropertyVersion{
+					{
+						PropertyID:      "prp_15",
+						PropertyVersion: 3,
+						Patches:         []PatchOperation{{Op: PatchOperationRemove, Path: "/rules/behaviors/1"}},
+					},
+				},
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid patch": {
+			request: BulkPatchRequest{
+				PatchPropertyVersions: []BulkPatchPropertyVersion{
+					{
+						PropertyID:      "prp_15",
+						PropertyVersion: 3,
+						Etag:            "a9dfe78cf93090516bde891d009eaf57",
+						Patches:         []PatchOperation{{Op: "merge", Path: "/rules"}},
+					},
+				},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.BulkPatch(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetBulkPatch(t *testing.T) {
+	tests := map[string]struct {
+		request          GetBulkPatchRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *BulkPatchResult
+		expectedFailures []string
+		withError        error
+	}{
+		"200 OK": {
+			request:        GetBulkPatchRequest{BulkPatchID: 9, ContractID: "ctr_1-1TJZFW"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "bulkPatchId": 9,
+    "bulkPatchStatus": "COMPLETE",
+    "submitDate": "2022-10-18T14:10:00Z",
+    "updateDate": "2022-10-18T14:11:00Z",
+    "patchPropertyVersions": [
+        {
+            "propertyId": "prp_15",
+            "propertyName": "example.com",
+            "propertyVersion": 3,
+            "etag": "2ea8a1ce0e5d2b2c3b3e1a83b1b43b7e",
+            "status": "UPDATED",
+            "patchPropertyVersionLink": "/papi/v1/properties/prp_15/versions/3"
+        },
+        {
+            "propertyId": "prp_16",
+            "propertyName": "example.net",
+            "propertyVersion": 2,
+            "status": "PRECONDITION_FAILED",
+            "failureCause": "Etag does not match"
+        },
+        {
+            "propertyId": "prp_17",
+            "propertyName": "example.org",
+            "propertyVersion": 4,
+            "status": "UPDATED",
+            "errors": [{"type": "validation_error", "title": "Invalid option", "detail": "hostname is invalid"}]
+        }
+    ]
+}`,
+			expectedPath: "/papi/v1/bulk/rules-patch-requests/9?contractId=ctr_1-1TJZFW",
+			expectedResponse: &BulkPatchResult{
+				BulkPatchID:     9,
+				BulkPatchStatus: BulkStatusComplete,
+				SubmitDate:      "2022-10-18T14:10:00Z",
+				UpdateDate:      "2022-10-18T14:11:00Z",
+				PatchPropertyVersions: []BulkPatchPropertyVersionResult{
+					{
+						PropertyID:               "prp_15",
+						PropertyName:             "example.com",
+						PropertyVersion:          3,
+						Etag:                     "2ea8a1ce0e5d2b2c3b3e1a83b1b43b7e",
+						Status:                   BulkItemStatusUpdated,
+						PatchPropertyVersionLink: "/papi/v1/properties/prp_15/versions/3",
+					},
+					{
+						PropertyID:      "prp_16",
+						PropertyName:    "example.net",
+						PropertyVersion: 2,
+						Status:          BulkItemStatusPreconditionFailed,
+						FailureCause:    "Etag does not match",
+					},
+					{
+						PropertyID:      "prp_17",
+						PropertyName:    "example.org",
+						PropertyVersion: 4,
+						Status:          BulkItemStatusUpdated,
+						Errors:          []*Error{{Type: "validation_error", Title: "Invalid option", Detail: "hostname is invalid"}},
+					},
+				},
+			},
+			expectedFailures: []string{"prp_16", "prp_17"},
+		},
+		"404 not found": {
+			request:        GetBulkPatchRequest{BulkPatchID: 10},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/bulk/rules-patch-requests/10",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing ID": {
+			request:   GetBulkPatchRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetBulkPatch(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			var failures []string
+			for _, failure := range result.Failures() {
+				failures = append(failures, failure.PropertyID)
+			}
+			assert.Equal(t, test.expectedFailures, failures)
+		})
+	}
+}
+
+func TestPapi_WaitForBulkPatch(t *testing.T) {
+	request := GetBulkPatchRequest{BulkPatchID: 9}
+	patchBody := func(status BulkStatus) string {
+		return fmt.Sprintf(`{"bulkPatchId": 9, "bulkPatchStatus": "%s", "patchPropertyVersions": []}`, status)
+	}
+
+	t.Run("polls until complete", func(t *testing.T) {
+		statuses := []BulkStatus{BulkStatusSubmitted, BulkStatusInProgress, BulkStatusComplete}
+		var requests int
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/papi/v1/bulk/rules-patch-requests/9", r.URL.String())
+			status := statuses[requests]
+			requests++
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(patchBody(status)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Millisecond, 2*time.Millisecond))
+		result, err := client.WaitForBulkPatch(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, 3, requests)
+		assert.Equal(t, BulkStatusComplete, result.BulkPatchStatus)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(patchBody(BulkStatusInProgress)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Hour, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForBulkPatch(ctx, request)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrWaitForBulkPatch), "want: %s; got: %s", ErrWaitForBulkPatch, err)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := client.WaitForBulkPatch(context.Background(), GetBulkPatchRequest{})
+		assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+	})
+}
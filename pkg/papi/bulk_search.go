@@ -0,0 +1,283 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// BulkSearches contains operations available on bulk search resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/bulk-search
+	BulkSearches interface {
+		// BulkSearch submits an asynchronous search of rule trees of the latest, staging and production property versions
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-bulk-search
+		BulkSearch(context.Context, BulkSearchRequest) (*BulkSearchResponse, error)
+
+		// GetBulkSearch gets status and results of a bulk search
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-bulk-search
+		GetBulkSearch(context.Context, GetBulkSearchRequest) (*BulkSearchResult, error)
+
+		// WaitForBulkSearch polls a bulk search until it is complete or the context is canceled
+		WaitForBulkSearch(context.Context, GetBulkSearchRequest) (*BulkSearchResult, error)
+	}
+
+	// BulkSearchRequest contains query params and request body of POST /bulk/rules-search-requests request
+	BulkSearchRequest struct {
+		ContractID string
+		GroupID    string
+		Query      BulkSearchQuery
+	}
+
+	// BulkSearchQuery describes rule tree elements to match
+	BulkSearchQuery struct {
+		Syntax BulkSearchSyntax `json:"syntax"`
+		// Match is a JSONPath expression, e.g. $..behaviors[?(@.name == 'origin')].options.hostname
+		Match string `json:"match"`
+		// BulkSearchQualifiers are JSONPath expressions which must also match for a property version to be reported
+		BulkSearchQualifiers []string `json:"bulkSearchQualifiers,omitempty"`
+	}
+
+	// BulkSearchResponse contains the response returned by BulkSearch
+	BulkSearchResponse struct {
+		BulkSearchID   int    `json:"-"`
+		BulkSearchLink string `json:"bulkSearchLink"`
+	}
+
+	// GetBulkSearchRequest contains path and query params used to fetch a bulk search
+	GetBulkSearchRequest struct {
+		BulkSearchID int
+		ContractID   string
+		GroupID      string
+	}
+
+	// BulkSearchResult represents a bulk search resource
+	BulkSearchResult struct {
+		BulkSearchID       int               `json:"bulkSearchId"`
+		SearchTargetStatus BulkStatus        `json:"searchTargetStatus"`
+		SearchSubmitDate   string            `json:"searchSubmitDate,omitempty"`
+		SearchUpdateDate   string            `json:"searchUpdateDate,omitempty"`
+		BulkSearchQuery    BulkSearchQuery   `json:"bulkSearchQuery"`
+		Results            []BulkSearchMatch `json:"results"`
+	}
+
+	// BulkSearchMatch is a property version matching the bulk search query
+	BulkSearchMatch struct {
+		AccountID        string        `json:"accountId"`
+		PropertyID       string        `json:"propertyId"`
+		PropertyName     string        `json:"propertyName"`
+		PropertyVersion  int           `json:"propertyVersion"`
+		PropertyType     string        `json:"propertyType,omitempty"`
+		IsLatest         bool          `json:"isLatest"`
+		IsLocked         bool          `json:"isLocked"`
+		IsSecure         bool          `json:"isSecure"`
+		ProductionStatus VersionStatus `json:"productionStatus"`
+		StagingStatus    VersionStatus `json:"stagingStatus"`
+		LastModifiedTime string        `json:"lastModifiedTime,omitempty"`
+		// MatchLocations contains JSON pointers to the matched rule tree elements, e.g. /rules/behaviors/0/options/hostname
+		MatchLocations []string `json:"matchLocations"`
+	}
+
+	// BulkSearchSyntax represents syntax of bulk search query
+	BulkSearchSyntax string
+
+	// BulkStatus represents status of an asynchronous bulk job
+	BulkStatus string
+
+	// BulkItemStatus represents status of a single property version within a bulk job
+	BulkItemStatus string
+)
+
+const (
+	// BulkSearchSyntaxJSONPath const
+	BulkSearchSyntaxJSONPath BulkSearchSyntax = "JSONPATH"
+
+	// BulkStatusSubmitted const
+	BulkStatusSubmitted BulkStatus = "SUBMITTED"
+	// BulkStatusInProgress const
+	BulkStatusInProgress BulkStatus = "IN_PROGRESS"
+	// BulkStatusComplete const
+	BulkStatusComplete BulkStatus = "COMPLETE"
+
+	// BulkItemStatusPending const
+	BulkItemStatusPending BulkItemStatus = "PENDING"
+	// BulkItemStatusSubmitted const
+	BulkItemStatusSubmitted BulkItemStatus = "SUBMITTED"
+	// BulkItemStatusInProgress const
+	BulkItemStatusInProgress BulkItemStatus = "IN_PROGRESS"
+	// BulkItemStatusComplete const
+	BulkItemStatusComplete BulkItemStatus = "COMPLETE"
+	// BulkItemStatusUpdated const
+	BulkItemStatusUpdated BulkItemStatus = "UPDATED"
+	// BulkItemStatusSubmissionError const
+	BulkItemStatusSubmissionError BulkItemStatus = "SUBMISSION_ERROR"
+	// BulkItemStatusPreconditionFailed const
+	BulkItemStatusPreconditionFailed BulkItemStatus = "PRECONDITION_FAILED"
+)
+
+var (
+	// ErrBulkSearch represents error when submitting bulk search fails
+	ErrBulkSearch = errors.New("submitting bulk search")
+	// ErrGetBulkSearch represents error when fetching bulk search fails
+	ErrGetBulkSearch = errors.New("fetching bulk search")
+	// ErrWaitForBulkSearch represents error when waiting for bulk search fails
+	ErrWaitForBulkSearch = errors.New("waiting for bulk search")
+)
+
+// Pending returns true while the bulk job is being processed
+func (s BulkStatus) Pending() bool {
+	return s == BulkStatusSubmitted || s == BulkStatusInProgress
+}
+
+// Failed returns true if processing of a property version within a bulk job failed
+func (s BulkItemStatus) Failed() bool {
+	return s == BulkItemStatusSubmissionError || s == BulkItemStatusPreconditionFailed
+}
+
+// Validate validates BulkSearchRequest struct
+func (r BulkSearchRequest) Validate() error {
+	return validation.Errors{
+		"Query": validation.Validate(r.Query),
+	}.Filter()
+}
+
+// Validate validates BulkSearchQuery struct
+func (q BulkSearchQuery) Validate() error {
+	return validation.Errors{
+		"Syntax": validation.Validate(q.Syntax, validation.Required, validation.In(BulkSearchSyntaxJSONPath)),
+		"Match":  validation.Validate(q.Match, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetBulkSearchRequest struct
+func (r GetBulkSearchRequest) Validate() error {
+	return validation.Errors{
+		"BulkSearchID": validation.Validate(r.BulkSearchID, validation.Required),
+	}.Filter()
+}
+
+func (p *papi) BulkSearch(ctx context.Context, params BulkSearchRequest) (*BulkSearchResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkSearch, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("BulkSearch")
+
+	uri, err := bulkURL("/papi/v1/bulk/rules-search-requests", params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrBulkSearch, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrBulkSearch, err)
+	}
+
+	body := struct {
+		BulkSearchQuery BulkSearchQuery `json:"bulkSearchQuery"`
+	}{BulkSearchQuery: params.Query}
+
+	var rval BulkSearchResponse
+	resp, err := p.Exec(req, &rval, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrBulkSearch, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrBulkSearch, p.Error(resp))
+	}
+
+	id, err := parseBulkLink(rval.BulkSearchLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkSearch, ErrInvalidResponseLink, err)
+	}
+	rval.BulkSearchID = id
+
+	return &rval, nil
+}
+
+func (p *papi) GetBulkSearch(ctx context.Context, params GetBulkSearchRequest) (*BulkSearchResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkSearch, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetBulkSearch")
+
+	uri, err := bulkURL(fmt.Sprintf("/papi/v1/bulk/rules-search-requests/%d", params.BulkSearchID), params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetBulkSearch, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkSearch, err)
+	}
+
+	var rval BulkSearchResult
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkSearch, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkSearch, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) WaitForBulkSearch(ctx context.Context, params GetBulkSearchRequest) (*BulkSearchResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForBulkSearch, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("WaitForBulkSearch")
+
+	var result *BulkSearchResult
+	err := p.poll(ctx, func() (bool, error) {
+		var err error
+		result, err = p.GetBulkSearch(ctx, params)
+		if err != nil {
+			return false, err
+		}
+		return !result.SearchTargetStatus.Pending(), nil
+	})
+	if err != nil {
+		return nil, pollError(ctx, ErrWaitForBulkSearch, err)
+	}
+	return result, nil
+}
+
+// bulkURL appends optional contractId and groupId query params to path of a bulk resource
+func bulkURL(path, contractID, groupID string) (string, error) {
+	uri, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	q := uri.Query()
+	if contractID != "" {
+		q.Add("contractId", contractID)
+	}
+	if groupID != "" {
+		q.Add("groupId", groupID)
+	}
+	uri.RawQuery = q.Encode()
+	return uri.String(), nil
+}
+
+// parseBulkLink returns numeric ID of a bulk job from its link
+func parseBulkLink(link string) (int, error) {
+	id, err := ResponseLinkParse(link)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(id)
+}
@@ -0,0 +1,321 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_BulkSearch(t *testing.T) {
+	tests := map[string]struct {
+		request             BulkSearchRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *BulkSearchResponse
+		withError           error
+	}{
+		"202 accepted": {
+			request: BulkSearchRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Query: BulkSearchQuery{
+					Syntax:               BulkSearchSyntaxJSONPath,
+					Match:                "$..behaviors[?(@.name == 'origin')].options.hostname",
+					BulkSearchQualifiers: []string{"$.options[?(@.secure == \"true\")]"},
+				},
+			},
+			responseStatus:      http.StatusAccepted,
+			responseBody:        `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/5?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/bulk/rules-search-requests?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"bulkSearchQuery":{"syntax":"JSONPATH","match":"$..behaviors[?(@.name == 'origin')].options.hostname","bulkSearchQualifiers":["$.options[?(@.secure == \"true\")]"]}}`,
+			expectedResponse: &BulkSearchResponse{
+				BulkSearchID:   5,
+				BulkSearchLink: "/papi/v1/bulk/rules-search-requests/5?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"202 accepted without contract and group": {
+			request: BulkSearchRequest{
+				Query: BulkSearchQuery{
+					Syntax: BulkSearchSyntaxJSONPath,
+					Match:  "$..behaviors[?(@.name == 'cpCode')]",
+				},
+			},
+			responseStatus:      http.StatusAccepted,
+			responseBody:        `{"bulkSearchLink": "/papi/v1/bulk/rules-search-requests/6"}`,
+			expectedPath:        "/papi/v1/bulk/rules-search-requests",
+			expectedRequestBody: `{"bulkSearchQuery":{"syntax":"JSONPATH","match":"$..behaviors[?(@.name == 'cpCode')]"}}`,
+			expectedResponse: &BulkSearchResponse{
+				BulkSearchID:   6,
+				BulkSearchLink: "/papi/v1/bulk/rules-search-requests/6",
+			},
+		},
+		"invalid response link": {
+			request: BulkSearchRequest{
+				Query: BulkSearchQuery{
+					Syntax: BulkSearchSyntaxJSONPath,
+					Match:  "$..behaviors",
+				},
+			},
+			responseStatus:      http.StatusAccepted,
+			responseBody:        `{"bulkSearchLink": ":"}`,
+			expectedPath:        "/papi/v1/bulk/rules-search-requests",
+			expectedRequestBody: `{"bulkSearchQuery":{"syntax":"JSONPATH","match":"$..behaviors"}}`,
+			withError:           ErrInvalidResponseLink,
+		},
+		"400 bad request": {
+			request: BulkSearchRequest{
+				Query: BulkSearchQuery{
+					Syntax: BulkSearchSyntaxJSONPath,
+					Match:  "$..[",
+				},
+			},
+			responseStatus: http.StatusBadRequest,
+			responseBody: `
+{
+    "type": "bad_request",
+    "title": "Bad Request",
+    "detail": "Invalid JSONPath expression",
+    "status": 400
+}`,
+			expectedPath:        "/papi/v1/bulk/rules-search-requests",
+			expectedRequestBody: `{"bulkSearchQuery":{"syntax":"JSONPATH","match":"$..["}}`,
+			withError: &Error{
+				Type:       "bad_request",
+				Title:      "Bad Request",
+				Detail:     "Invalid JSONPath expression",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		"validation error - missing match": {
+			request: BulkSearchRequest{
+				Query: BulkSearchQuery{Syntax: BulkSearchSyntaxJSONPath},
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid syntax": {
+			request: BulkSearchRequest{
+				Query: BulkSearchQuery{Syntax: "XPATH", Match: "//behaviors"},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.BulkSearch(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetBulkSearch(t *testing.T) {
+	tests := map[string]struct {
+		request          GetBulkSearchRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *BulkSearchResult
+		withError        error
+	}{
+		"200 OK": {
+			request: GetBulkSearchRequest{
+				BulkSearchID: 5,
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "bulkSearchId": 5,
+    "searchTargetStatus": "COMPLETE",
+    "searchSubmitDate": "2022-10-18T14:07:10Z",
+    "searchUpdateDate": "2022-10-18T14:07:35Z",
+    "bulkSearchQuery": {
+        "syntax": "JSONPATH",
+        "match": "$..behaviors[?(@.name == 'origin')].options.hostname"
+    },
+    "results": [
+        {
+            "accountId": "act_1-1TJZFB",
+            "propertyId": "prp_15",
+            "propertyName": "example.com",
+            "propertyVersion": 3,
+            "propertyType": "TRADITIONAL",
+            "isLatest": true,
+            "isLocked": false,
+            "isSecure": true,
+            "productionStatus": "ACTIVE",
+            "stagingStatus": "INACTIVE",
+            "lastModifiedTime": "2022-10-17T09:00:00Z",
+            "matchLocations": ["/rules/behaviors/0/options/hostname"]
+        }
+    ]
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests/5?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &BulkSearchResult{
+				BulkSearchID:       5,
+				SearchTargetStatus: BulkStatusComplete,
+				SearchSubmitDate:   "2022-10-18T14:07:10Z",
+				SearchUpdateDate:   "2022-10-18T14:07:35Z",
+				BulkSearchQuery: BulkSearchQuery{
+					Syntax: BulkSearchSyntaxJSONPath,
+					Match:  "$..behaviors[?(@.name == 'origin')].options.hostname",
+				},
+				Results: []BulkSearchMatch{
+					{
+						AccountID:        "act_1-1TJZFB",
+						PropertyID:       "prp_15",
+						PropertyName:     "example.com",
+						PropertyVersion:  3,
+						PropertyType:     "TRADITIONAL",
+						IsLatest:         true,
+						IsSecure:         true,
+						ProductionStatus: VersionStatusActive,
+						StagingStatus:    VersionStatusInactive,
+						LastModifiedTime: "2022-10-17T09:00:00Z",
+						MatchLocations:   []string{"/rules/behaviors/0/options/hostname"},
+					},
+				},
+			},
+		},
+		"404 not found": {
+			request:        GetBulkSearchRequest{BulkSearchID: 7},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/bulk/rules-search-requests/7",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing ID": {
+			request:   GetBulkSearchRequest{ContractID: "ctr_1-1TJZFW"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetBulkSearch(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_WaitForBulkSearch(t *testing.T) {
+	request := GetBulkSearchRequest{BulkSearchID: 5}
+	searchBody := func(status BulkStatus) string {
+		return fmt.Sprintf(`{"bulkSearchId": 5, "searchTargetStatus": "%s", "results": []}`, status)
+	}
+
+	tests := map[string]struct {
+		statuses         []BulkStatus
+		responseStatus   int
+		expectedRequests int
+		withError        error
+	}{
+		"polls until complete": {
+			statuses:         []BulkStatus{BulkStatusSubmitted, BulkStatusInProgress, BulkStatusComplete},
+			responseStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		"api error stops polling": {
+			statuses:         []BulkStatus{BulkStatusSubmitted},
+			responseStatus:   http.StatusInternalServerError,
+			expectedRequests: 1,
+			withError:        &Error{StatusCode: http.StatusInternalServerError},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/bulk/rules-search-requests/5", r.URL.String())
+				status := test.statuses[requests]
+				requests++
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(searchBody(status)))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, WithPollInterval(time.Millisecond, 2*time.Millisecond))
+			result, err := client.WaitForBulkSearch(context.Background(), request)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, BulkStatusComplete, result.SearchTargetStatus)
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(searchBody(BulkStatusInProgress)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Hour, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForBulkSearch(ctx, request)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrWaitForBulkSearch), "want: %s; got: %s", ErrWaitForBulkSearch, err)
+		assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := client.WaitForBulkSearch(context.Background(), GetBulkSearchRequest{})
+		assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+	})
+}
@@ -0,0 +1,209 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// BulkVersionCreations contains operations available on bulk property version creation resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/bulk-versioning
+	BulkVersionCreations interface {
+		// BulkCreateVersions submits creation of new versions of many properties
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-bulk-versioning
+		BulkCreateVersions(context.Context, BulkCreateVersionsRequest) (*BulkCreateVersionsResponse, error)
+
+		// GetBulkCreateVersions gets status of a bulk version creation and of every created version
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-bulk-versioning
+		GetBulkCreateVersions(context.Context, GetBulkCreateVersionsRequest) (*BulkCreateVersionsResult, error)
+
+		// WaitForBulkCreateVersions polls a bulk version creation until it is complete or the context is canceled.
+		// Versions which could not be created are reported by BulkCreateVersionsResult.Failures, not as an error
+		WaitForBulkCreateVersions(context.Context, GetBulkCreateVersionsRequest) (*BulkCreateVersionsResult, error)
+	}
+
+	// BulkCreateVersionsRequest contains query params and request body of POST /bulk/property-version-creations request
+	BulkCreateVersionsRequest struct {
+		ContractID             string                      `json:"-"`
+		GroupID                string                      `json:"-"`
+		CreatePropertyVersions []BulkCreatePropertyVersion `json:"createPropertyVersions"`
+	}
+
+	// BulkCreatePropertyVersion identifies the version a new property version is based on
+	BulkCreatePropertyVersion struct {
+		PropertyID            string `json:"propertyId"`
+		CreateFromVersion     int    `json:"createFromVersion"`
+		CreateFromVersionEtag string `json:"createFromVersionEtag,omitempty"`
+	}
+
+	// BulkCreateVersionsResponse contains the response returned by BulkCreateVersions
+	BulkCreateVersionsResponse struct {
+		BulkCreateVersionsID  int    `json:"-"`
+		BulkCreateVersionLink string `json:"bulkCreateVersionLink"`
+	}
+
+	// GetBulkCreateVersionsRequest contains path and query params used to fetch a bulk version creation
+	GetBulkCreateVersionsRequest struct {
+		BulkCreateVersionsID int
+		ContractID           string
+		GroupID              string
+	}
+
+	// BulkCreateVersionsResult represents a bulk version creation resource
+	BulkCreateVersionsResult struct {
+		BulkCreateVersionsID     int                               `json:"bulkCreateVersionsId"`
+		BulkCreateVersionsStatus BulkStatus                        `json:"bulkCreateVersionsStatus"`
+		SubmitDate               string                            `json:"submitDate,omitempty"`
+		UpdateDate               string                            `json:"updateDate,omitempty"`
+		CreatePropertyVersions   []BulkCreatePropertyVersionResult `json:"createPropertyVersions"`
+	}
+
+	// BulkCreatePropertyVersionResult contains outcome of creating a single property version
+	BulkCreatePropertyVersionResult struct {
+		PropertyID        string         `json:"propertyId"`
+		PropertyName      string         `json:"propertyName"`
+		CreateFromVersion int            `json:"createFromVersion"`
+		PropertyVersion   int            `json:"propertyVersion,omitempty"`
+		Etag              string         `json:"etag,omitempty"`
+		Status            BulkItemStatus `json:"status"`
+		CreateVersionLink string         `json:"createVersionLink,omitempty"`
+		FatalError        string         `json:"fatalError,omitempty"`
+	}
+)
+
+var (
+	// ErrBulkCreateVersions represents error when submitting bulk version creation fails
+	ErrBulkCreateVersions = errors.New("submitting bulk version creation")
+	// ErrGetBulkCreateVersions represents error when fetching bulk version creation fails
+	ErrGetBulkCreateVersions = errors.New("fetching bulk version creation")
+	// ErrWaitForBulkCreateVersions represents error when waiting for bulk version creation fails
+	ErrWaitForBulkCreateVersions = errors.New("waiting for bulk version creation")
+)
+
+// Validate validates BulkCreateVersionsRequest struct
+func (r BulkCreateVersionsRequest) Validate() error {
+	return validation.Errors{
+		"CreatePropertyVersions": validation.Validate(r.CreatePropertyVersions, validation.Required),
+	}.Filter()
+}
+
+// Validate validates BulkCreatePropertyVersion struct
+func (v BulkCreatePropertyVersion) Validate() error {
+	return validation.Errors{
+		"PropertyID":        validation.Validate(v.PropertyID, validation.Required),
+		"CreateFromVersion": validation.Validate(v.CreateFromVersion, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetBulkCreateVersionsRequest struct
+func (r GetBulkCreateVersionsRequest) Validate() error {
+	return validation.Errors{
+		"BulkCreateVersionsID": validation.Validate(r.BulkCreateVersionsID, validation.Required),
+	}.Filter()
+}
+
+// Failures returns property versions which could not be created
+func (r BulkCreateVersionsResult) Failures() []BulkCreatePropertyVersionResult {
+	var failures []BulkCreatePropertyVersionResult
+	for _, version := range r.CreatePropertyVersions {
+		if version.Status.Failed() || version.FatalError != "" {
+			failures = append(failures, version)
+		}
+	}
+	return failures
+}
+
+func (p *papi) BulkCreateVersions(ctx context.Context, params BulkCreateVersionsRequest) (*BulkCreateVersionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkCreateVersions, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("BulkCreateVersions")
+
+	uri, err := bulkURL("/papi/v1/bulk/property-version-creations", params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrBulkCreateVersions, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrBulkCreateVersions, err)
+	}
+
+	var rval BulkCreateVersionsResponse
+	resp, err := p.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrBulkCreateVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrBulkCreateVersions, p.Error(resp))
+	}
+
+	id, err := parseBulkLink(rval.BulkCreateVersionLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrBulkCreateVersions, ErrInvalidResponseLink, err)
+	}
+	rval.BulkCreateVersionsID = id
+
+	return &rval, nil
+}
+
+func (p *papi) GetBulkCreateVersions(ctx context.Context, params GetBulkCreateVersionsRequest) (*BulkCreateVersionsResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetBulkCreateVersions, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetBulkCreateVersions")
+
+	uri, err := bulkURL(fmt.Sprintf("/papi/v1/bulk/property-version-creations/%d", params.BulkCreateVersionsID), params.ContractID, params.GroupID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetBulkCreateVersions, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetBulkCreateVersions, err)
+	}
+
+	var rval BulkCreateVersionsResult
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetBulkCreateVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetBulkCreateVersions, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) WaitForBulkCreateVersions(ctx context.Context, params GetBulkCreateVersionsRequest) (*BulkCreateVersionsResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForBulkCreateVersions, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("WaitForBulkCreateVersions")
+
+	var result *BulkCreateVersionsResult
+	err := p.poll(ctx, func() (bool, error) {
+		var err error
+		result, err = p.GetBulkCreateVersions(ctx, params)
+		if err != nil {
+			return false, err
+		}
+		return !result.BulkCreateVersionsStatus.Pending(), nil
+	})
+	if err != nil {
+		return nil, pollError(ctx, ErrWaitForBulkCreateVersions, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,250 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_BulkCreateVersions(t *testing.T) {
+	tests := map[string]struct {
+		request             BulkCreateVersionsRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *BulkCreateVersionsResponse
+		withError           error
+	}{
+		"202 accepted": {
+			request: BulkCreateVersionsRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				CreatePropertyVersions: []BulkCreatePropertyVersion{
+					{PropertyID: "prp_15", CreateFromVersion: 3, CreateFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57"},
+					{PropertyID: "prp_16", CreateFromVersion: 2},
+				},
+			},
+			responseStatus:      http.StatusAccepted,
+			responseBody:        `{"bulkCreateVersionLink": "/papi/v1/bulk/property-version-creations/12?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/bulk/property-version-creations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"createPropertyVersions":[{"propertyId":"prp_15","createFromVersion":3,"createFromVersionEtag":"a9dfe78cf93090516bde891d009eaf57"},{"propertyId":"prp_16","createFromVersion":2}]}`,
+			expectedResponse: &BulkCreateVersionsResponse{
+				BulkCreateVersionsID:  12,
+				BulkCreateVersionLink: "/papi/v1/bulk/property-version-creations/12?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"403 forbidden": {
+			request: BulkCreateVersionsRequest{
+				CreatePropertyVersions: []BulkCreatePropertyVersion{{PropertyID: "prp_15", CreateFromVersion: 3}},
+			},
+			responseStatus: http.StatusForbidden,
+			responseBody: `
+{
+    "type": "forbidden",
+    "title": "Forbidden",
+    "status": 403
+}`,
+			expectedPath:        "/papi/v1/bulk/property-version-creations",
+			expectedRequestBody: `{"createPropertyVersions":[{"propertyId":"prp_15","createFromVersion":3}]}`,
+			withError: &Error{
+				Type:       "forbidden",
+				Title:      "Forbidden",
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		"validation error - missing property versions": {
+			request:   BulkCreateVersionsRequest{ContractID: "ctr_1-1TJZFW"},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing create from version": {
+			request: BulkCreateVersionsRequest{
+				CreatePropertyVersions: []BulkCreatePropertyVersion{{PropertyID: "prp_15"}},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.BulkCreateVersions(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetBulkCreateVersions(t *testing.T) {
+	tests := map[string]struct {
+		request          GetBulkCreateVersionsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *BulkCreateVersionsResult
+		expectedFailures []string
+		withError        error
+	}{
+		"200 OK": {
+			request:        GetBulkCreateVersionsRequest{BulkCreateVersionsID: 12, GroupID: "grp_15166"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "bulkCreateVersionsId": 12,
+    "bulkCreateVersionsStatus": "COMPLETE",
+    "submitDate": "2022-10-18T14:20:00Z",
+    "updateDate": "2022-10-18T14:21:00Z",
+    "createPropertyVersions": [
+        {
+            "propertyId": "prp_15",
+            "propertyName": "example.com",
+            "createFromVersion": 3,
+            "propertyVersion": 4,
+            "etag": "2ea8a1ce0e5d2b2c3b3e1a83b1b43b7e",
+            "status": "COMPLETE",
+            "createVersionLink": "/papi/v1/properties/prp_15/versions/4"
+        },
+        {
+            "propertyId": "prp_16",
+            "propertyName": "example.net",
+            "createFromVersion": 2,
+            "status": "SUBMISSION_ERROR",
+            "fatalError": "Property is locked"
+        }
+    ]
+}`,
+			expectedPath: "/papi/v1/bulk/property-version-creations/12?groupId=grp_15166",
+			expectedResponse: &BulkCreateVersionsResult{
+				BulkCreateVersionsID:     12,
+				BulkCreateVersionsStatus: BulkStatusComplete,
+				SubmitDate:               "2022-10-18T14:20:00Z",
+				UpdateDate:               "2022-10-18T14:21:00Z",
+				CreatePropertyVersions: []BulkCreatePropertyVersionResult{
+					{
+						PropertyID:        "prp_15",
+						PropertyName:      "example.com",
+						CreateFromVersion: 3,
+						PropertyVersion:   4,
+						Etag:              "2ea8a1ce0e5d2b2c3b3e1a83b1b43b7e",
+						Status:            BulkItemStatusComplete,
+						CreateVersionLink: "/papi/v1/properties/prp_15/versions/4",
+					},
+					{
+						PropertyID:        "prp_16",
+						PropertyName:      "example.net",
+						CreateFromVersion: 2,
+						Status:            BulkItemStatusSubmissionError,
+						FatalError:        "Property is locked",
+					},
+				},
+			},
+			expectedFailures: []string{"prp_16"},
+		},
+		"404 not found": {
+			request:        GetBulkCreateVersionsRequest{BulkCreateVersionsID: 13},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/bulk/property-version-creations/13",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing ID": {
+			request:   GetBulkCreateVersionsRequest{},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetBulkCreateVersions(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			var failures []string
+			for _, failure := range result.Failures() {
+				failures = append(failures, failure.PropertyID)
+			}
+			assert.Equal(t, test.expectedFailures, failures)
+		})
+	}
+}
+
+func TestPapi_WaitForBulkCreateVersions(t *testing.T) {
+	request := GetBulkCreateVersionsRequest{BulkCreateVersionsID: 12}
+	versionsBody := func(status BulkStatus) string {
+		return fmt.Sprintf(`{"bulkCreateVersionsId": 12, "bulkCreateVersionsStatus": "%s", "createPropertyVersions": []}`, status)
+	}
+
+	t.Run("polls until complete", func(t *testing.T) {
+		statuses := []BulkStatus{BulkStatusInProgress, BulkStatusComplete}
+		var requests int
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/papi/v1/bulk/property-version-creations/12", r.URL.String())
+			status := statuses[requests]
+			requests++
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(versionsBody(status)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Millisecond, 2*time.Millisecond))
+		result, err := client.WaitForBulkCreateVersions(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+		assert.Equal(t, BulkStatusComplete, result.BulkCreateVersionsStatus)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(versionsBody(BulkStatusSubmitted)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Hour, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForBulkCreateVersions(ctx, request)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrWaitForBulkCreateVersions), "want: %s; got: %s", ErrWaitForBulkCreateVersions, err)
+	})
+}
@@ -0,0 +1,180 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ModifyRuleTreeRequest contains parameters necessary to perform read-modify-write cycle on a property rule tree
+	ModifyRuleTreeRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+		ValidateMode    string
+		ValidateRules   bool
+		// MaxAttempts is the number of read-modify-write cycles made before giving up, defaults to DefaultMaxAttempts
+		MaxAttempts int
+		// Modify changes the fetched rules in place, it is called once per attempt
+		Modify func(*RulesUpdate) error
+	}
+
+	// ModifyPropertyVersionHostnamesRequest contains parameters necessary to perform read-modify-write cycle on property version hostnames
+	ModifyPropertyVersionHostnamesRequest struct {
+		PropertyID        string
+		PropertyVersion   int
+		ContractID        string
+		GroupID           string
+		ValidateHostnames bool
+		IncludeCertStatus bool
+		// MaxAttempts is the number of read-modify-write cycles made before giving up, defaults to DefaultMaxAttempts
+		MaxAttempts int
+		// Modify returns the new set of hostnames based on the fetched one, it is called once per attempt
+		Modify func([]Hostname) ([]Hostname, error)
+	}
+)
+
+// DefaultMaxAttempts is the number of attempts made by read-modify-write helpers when MaxAttempts is not set
+const DefaultMaxAttempts = 3
+
+var (
+	// ErrModifyRuleTree represents error when read-modify-write cycle on rule tree fails
+	ErrModifyRuleTree = errors.New("modifying rule tree")
+	// ErrModifyPropertyVersionHostnames represents error when read-modify-write cycle on hostnames fails
+	ErrModifyPropertyVersionHostnames = errors.New("modifying hostnames")
+)
+
+// Validate validates ModifyRuleTreeRequest struct
+func (r ModifyRuleTreeRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"ValidateMode":    validation.Validate(r.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"MaxAttempts":     validation.Validate(r.MaxAttempts, validation.Min(0)),
+		"Modify":          validation.Validate(r.Modify, validation.NotNil),
+	}.Filter()
+}
+
+// Validate validates ModifyPropertyVersionHostnamesRequest struct
+func (r ModifyPropertyVersionHostnamesRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"MaxAttempts":     validation.Validate(r.MaxAttempts, validation.Min(0)),
+		"Modify":          validation.Validate(r.Modify, validation.NotNil),
+	}.Filter()
+}
+
+// RetryOnPreconditionFailed calls fn until it succeeds, returns an error other than ErrPreconditionFailed
+// or maxAttempts calls were made. The last error is returned when all attempts failed
+func RetryOnPreconditionFailed(ctx context.Context, maxAttempts int, fn func(context.Context) error) error {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		err = fn(ctx)
+		if err == nil || !errors.Is(err, ErrPreconditionFailed) {
+			return err
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", maxAttempts, err)
+}
+
+// ModifyRuleTree fetches the rule tree, applies request.Modify to it and writes it back with If-Match set to the fetched etag
+// The whole cycle is repeated when the rule tree was modified concurrently, up to request.MaxAttempts times
+func ModifyRuleTree(ctx context.Context, client PropertyRules, request ModifyRuleTreeRequest) (*UpdateRulesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrModifyRuleTree, ErrStructValidation, err)
+	}
+
+	var result *UpdateRulesResponse
+	err := RetryOnPreconditionFailed(ctx, request.MaxAttempts, func(ctx context.Context) error {
+		current, err := client.GetRuleTree(ctx, GetRuleTreeRequest{
+			PropertyID:      request.PropertyID,
+			PropertyVersion: request.PropertyVersion,
+			ContractID:      request.ContractID,
+			GroupID:         request.GroupID,
+			ValidateMode:    request.ValidateMode,
+			ValidateRules:   request.ValidateRules,
+		})
+		if err != nil {
+			return err
+		}
+
+		rules := RulesUpdate{Comments: current.Comments, Rules: current.Rules}
+		if err := request.Modify(&rules); err != nil {
+			return err
+		}
+
+		result, err = client.UpdateRuleTree(ctx, UpdateRulesRequest{
+			PropertyID:      request.PropertyID,
+			PropertyVersion: request.PropertyVersion,
+			ContractID:      request.ContractID,
+			GroupID:         request.GroupID,
+			ValidateMode:    request.ValidateMode,
+			ValidateRules:   request.ValidateRules,
+			Etag:            current.Etag,
+			Rules:           rules,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrModifyRuleTree, err)
+	}
+
+	return result, nil
+}
+
+// ModifyPropertyVersionHostnames fetches property version hostnames, applies request.Modify to them and writes them back
+// with If-Match set to the fetched etag. The whole cycle is repeated when hostnames were modified concurrently,
+// up to request.MaxAttempts times
+func ModifyPropertyVersionHostnames(ctx context.Context, client PropertyVersionHostnames, request ModifyPropertyVersionHostnamesRequest) (*UpdatePropertyVersionHostnamesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrModifyPropertyVersionHostnames, ErrStructValidation, err)
+	}
+
+	var result *UpdatePropertyVersionHostnamesResponse
+	err := RetryOnPreconditionFailed(ctx, request.MaxAttempts, func(ctx context.Context) error {
+		current, err := client.GetPropertyVersionHostnames(ctx, GetPropertyVersionHostnamesRequest{
+			PropertyID:        request.PropertyID,
+			PropertyVersion:   request.PropertyVersion,
+			ContractID:        request.ContractID,
+			GroupID:           request.GroupID,
+			ValidateHostnames: request.ValidateHostnames,
+			IncludeCertStatus: request.IncludeCertStatus,
+		})
+		if err != nil {
+			return err
+		}
+
+		hostnames, err := request.Modify(current.Hostnames.Items)
+		if err != nil {
+			return err
+		}
+
+		result, err = client.UpdatePropertyVersionHostnames(ctx, UpdatePropertyVersionHostnamesRequest{
+			PropertyID:        request.PropertyID,
+			PropertyVersion:   request.PropertyVersion,
+			ContractID:        request.ContractID,
+			GroupID:           request.GroupID,
+			ValidateHostnames: request.ValidateHostnames,
+			IncludeCertStatus: request.IncludeCertStatus,
+			Etag:              current.Etag,
+			Hostnames:         hostnames,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrModifyPropertyVersionHostnames, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,246 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const preconditionFailedBody = `
+{
+    "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+    "title": "Precondition failed",
+    "status": 412
+}`
+
+func TestRetryOnPreconditionFailed(t *testing.T) {
+	errPrecondition := fmt.Errorf("%s: %w", ErrUpdateRuleTree, &PreconditionFailedError{Etag: "etag", Err: &Error{StatusCode: http.StatusPreconditionFailed}})
+	errOther := errors.New("other")
+
+	tests := map[string]struct {
+		maxAttempts   int
+		results       []error
+		expectedCalls int
+		withError     error
+	}{
+		"succeeds on first attempt": {
+			maxAttempts:   3,
+			results:       []error{nil},
+			expectedCalls: 1,
+		},
+		"succeeds after conflicts": {
+			maxAttempts:   3,
+			results:       []error{errPrecondition, errPrecondition, nil},
+			expectedCalls: 3,
+		},
+		"other error is not retried": {
+			maxAttempts:   3,
+			results:       []error{errPrecondition, errOther},
+			expectedCalls: 2,
+			withError:     errOther,
+		},
+		"gives up after max attempts": {
+			maxAttempts:   2,
+			results:       []error{errPrecondition, errPrecondition},
+			expectedCalls: 2,
+			withError:     ErrPreconditionFailed,
+		},
+		"default max attempts": {
+			results:       []error{errPrecondition, errPrecondition, errPrecondition},
+			expectedCalls: DefaultMaxAttempts,
+			withError:     ErrPreconditionFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			err := RetryOnPreconditionFailed(context.Background(), test.maxAttempts, func(context.Context) error {
+				err := test.results[calls]
+				calls++
+				return err
+			})
+			assert.Equal(t, test.expectedCalls, calls)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := RetryOnPreconditionFailed(ctx, 3, func(context.Context) error {
+			t.Fatal("function should not be called")
+			return nil
+		})
+		assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+	})
+}
+
+func TestModifyRuleTree(t *testing.T) {
+	etags := []string{"etag1", "etag2", "etag3"}
+	rulesPath := "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+
+	tests := map[string]struct {
+		conflicts        int
+		maxAttempts      int
+		expectedRequests int
+		expectedResponse *UpdateRulesResponse
+		withError        error
+	}{
+		"no conflict": {
+			expectedRequests: 2,
+			expectedResponse: &UpdateRulesResponse{PropertyID: "prp_175780", Etag: "etag2", Rules: Rules{Name: "default", Comments: "updated"}},
+		},
+		"retried after conflict": {
+			conflicts:        1,
+			expectedRequests: 4,
+			expectedResponse: &UpdateRulesResponse{PropertyID: "prp_175780", Etag: "etag3", Rules: Rules{Name: "default", Comments: "updated"}},
+		},
+		"conflicts exceed max attempts": {
+			conflicts:        2,
+			maxAttempts:      2,
+			expectedRequests: 4,
+			withError:        ErrPreconditionFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gets, puts int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, rulesPath, r.URL.String())
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(fmt.Sprintf(`{"propertyId": "prp_175780", "etag": %q, "rules": {"name": "default"}}`, etags[gets])))
+					assert.NoError(t, err)
+					gets++
+				case http.MethodPut:
+					assert.Equal(t, etags[puts], r.Header.Get("If-Match"))
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"rules": {"name": "default", "comments": "updated", "options": {}}}`, string(body))
+					puts++
+					if puts <= test.conflicts {
+						w.WriteHeader(http.StatusPreconditionFailed)
+						_, err = w.Write([]byte(preconditionFailedBody))
+						assert.NoError(t, err)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(fmt.Sprintf(`{"propertyId": "prp_175780", "etag": %q, "rules": {"name": "default", "comments": "updated"}}`, etags[puts])))
+					assert.NoError(t, err)
+				default:
+					t.Fatalf("unexpected method: %s", r.Method)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := ModifyRuleTree(context.Background(), client, ModifyRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				ValidateRules:   true,
+				MaxAttempts:     test.maxAttempts,
+				Modify: func(rules *RulesUpdate) error {
+					rules.Rules.Comments = "updated"
+					return nil
+				},
+			})
+			assert.Equal(t, test.expectedRequests, gets+puts)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+
+	t.Run("modify error", func(t *testing.T) {
+		errModify := errors.New("modify")
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"propertyId": "prp_175780", "etag": "etag1", "rules": {"name": "default"}}`))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := ModifyRuleTree(context.Background(), client, ModifyRuleTreeRequest{
+			PropertyID:      "prp_175780",
+			PropertyVersion: 3,
+			Modify: func(*RulesUpdate) error {
+				return errModify
+			},
+		})
+		assert.True(t, errors.Is(err, errModify), "want: %s; got: %s", errModify, err)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		_, err := ModifyRuleTree(context.Background(), nil, ModifyRuleTreeRequest{PropertyID: "prp_175780", PropertyVersion: 3})
+		assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+		assert.Contains(t, err.Error(), "Modify")
+	})
+}
+
+func TestModifyPropertyVersionHostnames(t *testing.T) {
+	hostnamesPath := "/papi/v1/properties/prp_175780/versions/3/hostnames?contractId=ctr_1-1TJZH5&groupId=grp_15225&includeCertStatus=false&validateHostnames=false"
+	var gets, puts int
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, hostnamesPath, r.URL.String())
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(fmt.Sprintf(`{"etag": "etag%d", "hostnames": {"items": [{"cnameType": "EDGE_HOSTNAME", "cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgekey.net"}]}}`, gets)))
+			assert.NoError(t, err)
+		case http.MethodPut:
+			puts++
+			assert.Equal(t, fmt.Sprintf("etag%d", puts), r.Header.Get("If-Match"))
+			var hostnames []Hostname
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&hostnames))
+			require.Len(t, hostnames, 2)
+			assert.Equal(t, "api.example.com", hostnames[1].CnameFrom)
+			if puts == 1 {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				_, err := w.Write([]byte(preconditionFailedBody))
+				assert.NoError(t, err)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"etag": "etag3"}`))
+			assert.NoError(t, err)
+		}
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	result, err := ModifyPropertyVersionHostnames(context.Background(), client, ModifyPropertyVersionHostnamesRequest{
+		PropertyID:      "prp_175780",
+		PropertyVersion: 3,
+		ContractID:      "ctr_1-1TJZH5",
+		GroupID:         "grp_15225",
+		Modify: func(hostnames []Hostname) ([]Hostname, error) {
+			return append(hostnames, Hostname{
+				CnameType: HostnameCnameTypeEdgeHostname,
+				CnameFrom: "api.example.com",
+				CnameTo:   "api.example.com.edgekey.net",
+			}), nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "etag3", result.Etag)
+	assert.Equal(t, 2, gets)
+	assert.Equal(t, 2, puts)
+}
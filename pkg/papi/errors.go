@@ -24,6 +24,12 @@ type (
 		Limit         int             `json:"limit"`
 		Remaining     int             `json:"remaining"`
 	}
+
+	// PreconditionFailedError is returned when the etag sent in If-Match header no longer matches the resource
+	PreconditionFailedError struct {
+		Etag string
+		Err  *Error
+	}
 )
 
 // Error parses an error from the response
@@ -77,3 +83,24 @@ func (e *Error) Is(target error) bool {
 
 	return e.Error() == t.Error()
 }
+
+// preconditionFailed parses an error from the 412 response sent when If-Match etag does not match
+func (p *papi) preconditionFailed(r *http.Response, etag string) error {
+	var apiErr *Error
+	errors.As(p.Error(r), &apiErr)
+	return &PreconditionFailedError{Etag: etag, Err: apiErr}
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("%s: etag %q does not match the current one: %s", ErrPreconditionFailed, e.Etag, e.Err)
+}
+
+// Is returns true when target is ErrPreconditionFailed
+func (e *PreconditionFailedError) Is(target error) bool {
+	return target == ErrPreconditionFailed
+}
+
+// Unwrap returns the underlying API error
+func (e *PreconditionFailedError) Unwrap() error {
+	return e.Err
+}
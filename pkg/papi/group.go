@@ -14,6 +14,9 @@ type (
 		// GetGroups provides a read-only list of groups, which may contain properties.
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getgroups
 		GetGroups(context.Context) (*GetGroupsResponse, error)
+
+		// GetGroupTree fetches groups and returns them as GroupTree, use GroupTreeCache to share the tree between calls
+		GetGroupTree(context.Context) (*GroupTree, error)
 	}
 
 	// Group represents a property group resource
@@ -0,0 +1,305 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// GroupTree is the group hierarchy of an account built from GetGroupsResponse
+	GroupTree struct {
+		AccountID   string
+		AccountName string
+		// Roots contains top-level groups in the order returned by PAPI
+		Roots []*GroupNode
+		nodes []*GroupNode
+		byID  map[string]*GroupNode
+	}
+
+	// GroupNode is a group within GroupTree
+	GroupNode struct {
+		Group
+		// Parent is nil for top-level groups
+		Parent   *GroupNode
+		Children []*GroupNode
+	}
+
+	// GroupWalkFunc is called by Walk for every visited group.
+	// Returning ErrSkipChildren skips subgroups of the group, any other error stops the walk
+	GroupWalkFunc func(node *GroupNode) error
+
+	// GroupTreeCache caches group trees per account, so that tools resolving many group names fetch groups once
+	GroupTreeCache struct {
+		ttl     time.Duration
+		now     func() time.Time
+		mu      sync.Mutex
+		entries map[string]groupTreeCacheEntry
+	}
+
+	groupTreeCacheEntry struct {
+		tree    *GroupTree
+		fetched time.Time
+	}
+)
+
+// GroupPathSeparator separates group names in paths accepted by GroupByPath
+const GroupPathSeparator = "/"
+
+var (
+	// ErrGetGroupTree represents error when fetching group tree fails
+	ErrGetGroupTree = errors.New("fetching group tree")
+	// ErrAmbiguousGroup is returned when a group name or path matches more than one group
+	ErrAmbiguousGroup = errors.New("ambiguous group")
+)
+
+// NewGroupTree builds group hierarchy from groups returned by GetGroups.
+// Groups whose parent is not on the list, or whose parent chain forms a cycle, become top-level groups
+func NewGroupTree(groups *GetGroupsResponse) *GroupTree {
+	tree := GroupTree{
+		AccountID:   groups.AccountID,
+		AccountName: groups.AccountName,
+		byID:        make(map[string]*GroupNode),
+	}
+	for _, group := range groups.Groups.Items {
+		if group == nil {
+			continue
+		}
+		id := trimGroupPrefix(group.GroupID)
+		if _, ok := tree.byID[id]; ok {
+			continue
+		}
+		node := &GroupNode{Group: *group}
+		tree.byID[id] = node
+		tree.nodes = append(tree.nodes, node)
+	}
+
+	for _, node := range tree.nodes {
+		parent, ok := tree.byID[trimGroupPrefix(node.ParentGroupID)]
+		if node.ParentGroupID == "" || !ok || createsCycle(node, parent) {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+	return &tree
+}
+
+func createsCycle(node, parent *GroupNode) bool {
+	for ancestor := parent; ancestor != nil; ancestor = ancestor.Parent {
+		if ancestor == node {
+			return true
+		}
+	}
+	return false
+}
+
+// Group returns group with the given ID, the ID is matched with or without "grp_" prefix
+func (t *GroupTree) Group(groupID string) (*GroupNode, error) {
+	node, ok := t.byID[trimGroupPrefix(groupID)]
+	if !ok {
+		return nil, fmt.Errorf("%w: group %s", ErrNotFound, groupID)
+	}
+	return node, nil
+}
+
+// GroupByName returns the only group with the given name
+// ErrAmbiguousGroup is returned if there are more groups with that name, GroupByPath can be used to tell them apart
+func (t *GroupTree) GroupByName(name string) (*GroupNode, error) {
+	return t.single(name, t.match(func(node *GroupNode) bool {
+		return node.GroupName == name
+	}))
+}
+
+// GroupByPath returns the only group matching a path of group names separated with GroupPathSeparator,
+// e.g. "Parent/Child/Grandchild". The path does not have to start at a top-level group,
+// "Child/Grandchild" matches every group named "Grandchild" whose parent is named "Child"
+func (t *GroupTree) GroupByPath(path string) (*GroupNode, error) {
+	names := strings.Split(strings.Trim(path, GroupPathSeparator), GroupPathSeparator)
+	return t.single(path, t.match(func(node *GroupNode) bool {
+		current := node
+		for i := len(names) - 1; i >= 0; i-- {
+			if current == nil || current.GroupName != names[i] {
+				return false
+			}
+			current = current.Parent
+		}
+		return true
+	}))
+}
+
+// ForContract returns a tree containing only groups associated with the given contract.
+// Groups whose parent is not associated with the contract are attached to their nearest ancestor that is
+func (t *GroupTree) ForContract(contractID string) *GroupTree {
+	filtered := GetGroupsResponse{
+		AccountID:   t.AccountID,
+		AccountName: t.AccountName,
+	}
+	for _, node := range t.nodes {
+		if !node.HasContract(contractID) {
+			continue
+		}
+		group := node.Group
+		group.ParentGroupID = ""
+		for _, ancestor := range node.Ancestors() {
+			if ancestor.HasContract(contractID) {
+				group.ParentGroupID = ancestor.GroupID
+				break
+			}
+		}
+		filtered.Groups.Items = append(filtered.Groups.Items, &group)
+	}
+	return NewGroupTree(&filtered)
+}
+
+// Walk visits all groups depth-first, starting with top-level groups
+func (t *GroupTree) Walk(fn GroupWalkFunc) error {
+	for _, root := range t.Roots {
+		if err := root.Walk(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Groups returns all groups of the tree in the order returned by PAPI
+func (t *GroupTree) Groups() []*GroupNode {
+	return t.nodes
+}
+
+func (t *GroupTree) match(fn func(*GroupNode) bool) []*GroupNode {
+	var matches []*GroupNode
+	for _, node := range t.nodes {
+		if fn(node) {
+			matches = append(matches, node)
+		}
+	}
+	return matches
+}
+
+func (t *GroupTree) single(name string, matches []*GroupNode) (*GroupNode, error) {
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: group %q", ErrNotFound, name)
+	case 1:
+		return matches[0], nil
+	}
+	paths := make([]string, 0, len(matches))
+	for _, match := range matches {
+		paths = append(paths, fmt.Sprintf("%s (%s)", match.Path(), match.GroupID))
+	}
+	return nil, fmt.Errorf("%w: %q matches %d groups: %s", ErrAmbiguousGroup, name, len(matches), strings.Join(paths, ", "))
+}
+
+// Ancestors returns parent groups of the group, starting with the nearest one
+func (n *GroupNode) Ancestors() []*GroupNode {
+	var ancestors []*GroupNode
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// Descendants returns all subgroups of the group depth-first
+func (n *GroupNode) Descendants() []*GroupNode {
+	var descendants []*GroupNode
+	for _, child := range n.Children {
+		// the walk function never returns an error
+		_ = child.Walk(func(node *GroupNode) error {
+			descendants = append(descendants, node)
+			return nil
+		})
+	}
+	return descendants
+}
+
+// Walk visits the group and all its subgroups depth-first
+func (n *GroupNode) Walk(fn GroupWalkFunc) error {
+	err := fn(n)
+	if errors.Is(err, ErrSkipChildren) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.Walk(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path returns names of the groups from the top-level group to this one joined with GroupPathSeparator
+func (n *GroupNode) Path() string {
+	ancestors := n.Ancestors()
+	names := make([]string, len(ancestors)+1)
+	for i, ancestor := range ancestors {
+		names[len(ancestors)-1-i] = ancestor.GroupName
+	}
+	names[len(ancestors)] = n.GroupName
+	return strings.Join(names, GroupPathSeparator)
+}
+
+// HasContract returns true if the group is associated with the contract, the ID is matched with or without "ctr_" prefix
+func (n *GroupNode) HasContract(contractID string) bool {
+	for _, id := range n.ContractIDs {
+		if strings.TrimPrefix(id, "ctr_") == strings.TrimPrefix(contractID, "ctr_") {
+			return true
+		}
+	}
+	return false
+}
+
+func trimGroupPrefix(groupID string) string {
+	return strings.TrimPrefix(groupID, "grp_")
+}
+
+func (p *papi) GetGroupTree(ctx context.Context) (*GroupTree, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetGroupTree")
+
+	groups, err := p.GetGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetGroupTree, err)
+	}
+	return NewGroupTree(groups), nil
+}
+
+// NewGroupTreeCache returns a cache keeping group trees for ttl, trees are kept until invalidated if ttl is 0
+func NewGroupTreeCache(ttl time.Duration) *GroupTreeCache {
+	return &GroupTreeCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]groupTreeCacheEntry),
+	}
+}
+
+// Get returns the cached group tree of the account, fetching it with the client if it is missing or expired.
+// The account key identifies the account the client works on, e.g. its account switch key.
+// Concurrent calls wait for each other, so the tree is fetched only once
+func (c *GroupTreeCache) Get(ctx context.Context, account string, client Groups) (*GroupTree, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[account]; ok && (c.ttl == 0 || c.now().Sub(entry.fetched) < c.ttl) {
+		return entry.tree, nil
+	}
+	tree, err := client.GetGroupTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[account] = groupTreeCacheEntry{tree: tree, fetched: c.now()}
+	return tree, nil
+}
+
+// Invalidate removes the group tree of the account from the cache, e.g. after groups were created or moved
+func (c *GroupTreeCache) Invalidate(account string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, account)
+}
@@ -0,0 +1,179 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGroupTree() *GroupTree {
+	return NewGroupTree(&GetGroupsResponse{
+		AccountID:   "act_1",
+		AccountName: "Example",
+		Groups: GroupItems{Items: []*Group{
+			{GroupID: "grp_1", GroupName: "Example", ContractIDs: []string{"ctr_A", "ctr_B"}},
+			{GroupID: "grp_2", GroupName: "Web", ParentGroupID: "grp_1", ContractIDs: []string{"ctr_A"}},
+			{GroupID: "grp_3", GroupName: "Staging", ParentGroupID: "grp_2", ContractIDs: []string{"ctr_A", "ctr_B"}},
+			{GroupID: "grp_4", GroupName: "Media", ParentGroupID: "grp_1", ContractIDs: []string{"ctr_B"}},
+			{GroupID: "grp_5", GroupName: "Staging", ParentGroupID: "grp_4", ContractIDs: []string{"ctr_B"}},
+			{GroupID: "grp_6", GroupName: "Orphan", ParentGroupID: "grp_99", ContractIDs: []string{"ctr_A"}},
+			{GroupID: "grp_7", GroupName: "Loop A", ParentGroupID: "grp_8", ContractIDs: []string{"ctr_A"}},
+			{GroupID: "grp_8", GroupName: "Loop B", ParentGroupID: "grp_7", ContractIDs: []string{"ctr_A"}},
+		}},
+	})
+}
+
+func groupIDs(nodes []*GroupNode) []string {
+	var ids []string
+	for _, node := range nodes {
+		ids = append(ids, node.GroupID)
+	}
+	return ids
+}
+
+func TestNewGroupTree(t *testing.T) {
+	tree := testGroupTree()
+
+	assert.Equal(t, "act_1", tree.AccountID)
+	assert.Equal(t, []string{"grp_1", "grp_6", "grp_8"}, groupIDs(tree.Roots))
+	assert.Len(t, tree.Groups(), 8)
+
+	root, err := tree.Group("grp_1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grp_2", "grp_4"}, groupIDs(root.Children))
+	assert.Equal(t, []string{"grp_2", "grp_3", "grp_4", "grp_5"}, groupIDs(root.Descendants()))
+
+	staging, err := tree.Group("5")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grp_4", "grp_1"}, groupIDs(staging.Ancestors()))
+	assert.Equal(t, "Example/Media/Staging", staging.Path())
+
+	loop, err := tree.Group("grp_7")
+	require.NoError(t, err)
+	assert.Equal(t, "Loop B/Loop A", loop.Path())
+
+	var visited []string
+	err = tree.Walk(func(node *GroupNode) error {
+		visited = append(visited, node.GroupID)
+		if node.GroupName == "Web" {
+			return ErrSkipChildren
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grp_1", "grp_2", "grp_4", "grp_5", "grp_6", "grp_8", "grp_7"}, visited)
+
+	_, err = tree.Group("grp_99")
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+}
+
+func TestGroupTree_Lookup(t *testing.T) {
+	tests := map[string]struct {
+		lookup     func(*GroupTree) (*GroupNode, error)
+		expectedID string
+		withError  error
+	}{
+		"by unique name": {
+			lookup:     func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByName("Media") },
+			expectedID: "grp_4",
+		},
+		"by ambiguous name": {
+			lookup:    func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByName("Staging") },
+			withError: ErrAmbiguousGroup,
+		},
+		"by missing name": {
+			lookup:    func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByName("Nope") },
+			withError: ErrNotFound,
+		},
+		"by full path": {
+			lookup:     func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByPath("Example/Web/Staging") },
+			expectedID: "grp_3",
+		},
+		"by partial path": {
+			lookup:     func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByPath("Media/Staging/") },
+			expectedID: "grp_5",
+		},
+		"by ambiguous path": {
+			lookup:    func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByPath("Staging") },
+			withError: ErrAmbiguousGroup,
+		},
+		"by path not matching parents": {
+			lookup:    func(tree *GroupTree) (*GroupNode, error) { return tree.GroupByPath("Web/Media") },
+			withError: ErrNotFound,
+		},
+	}
+
+	tree := testGroupTree()
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			node, err := test.lookup(tree)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedID, node.GroupID)
+		})
+	}
+}
+
+func TestGroupTree_ForContract(t *testing.T) {
+	tree := testGroupTree().ForContract("B")
+
+	assert.Equal(t, []string{"grp_1"}, groupIDs(tree.Roots))
+	assert.Equal(t, []string{"grp_1", "grp_3", "grp_4", "grp_5"}, groupIDs(tree.Groups()))
+
+	staging, err := tree.GroupByPath("Example/Staging")
+	require.NoError(t, err)
+	assert.Equal(t, "grp_3", staging.GroupID)
+	assert.Equal(t, "grp_1", staging.ParentGroupID)
+
+	original, err := testGroupTree().Group("grp_3")
+	require.NoError(t, err)
+	assert.Equal(t, "grp_2", original.ParentGroupID)
+}
+
+func TestGroupTreeCache(t *testing.T) {
+	var calls int
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/papi/v1/groups", r.URL.Path)
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"accountId": "act_1", "groups": {"items": [{"groupId": "grp_1", "groupName": "Example", "contractIds": ["ctr_A"]}]}}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	now := time.Date(2022, 10, 18, 10, 0, 0, 0, time.UTC)
+	cache := NewGroupTreeCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	tree, err := cache.Get(context.Background(), "act_1", client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grp_1"}, groupIDs(tree.Roots))
+
+	cached, err := cache.Get(context.Background(), "act_1", client)
+	require.NoError(t, err)
+	assert.Same(t, tree, cached)
+	assert.Equal(t, 1, calls)
+
+	_, err = cache.Get(context.Background(), "act_2", client)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	now = now.Add(time.Minute)
+	_, err = cache.Get(context.Background(), "act_1", client)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	cache.Invalidate("act_1")
+	_, err = cache.Get(context.Background(), "act_1", client)
+	require.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,383 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// IDs contains helpers formatting typed IDs according to the client settings
+	IDs interface {
+		// FormatID returns the ID with or without its prefix, depending on WithUsePrefixes setting of the client
+		FormatID(TypedID) string
+	}
+
+	// TypedID is implemented by PAPI identifiers which can be formatted with or without their prefix
+	TypedID interface {
+		Format(usePrefixes bool) string
+	}
+
+	// PropertyID identifies a property, e.g. "prp_175780" or "175780"
+	PropertyID string
+
+	// ContractID identifies a contract, e.g. "ctr_1-1TJZFW" or "1-1TJZFW"
+	ContractID string
+
+	// GroupID identifies a group, e.g. "grp_15166" or "15166"
+	GroupID string
+
+	// EdgeHostnameID identifies an edge hostname, e.g. "ehn_895822" or "895822"
+	EdgeHostnameID string
+
+	// CPCodeID identifies a CP code, e.g. "cpc_33190" or "33190"
+	CPCodeID string
+
+	// ProductID identifies a product, e.g. "prd_Fresca" or "Fresca"
+	ProductID string
+
+	idKind struct {
+		name    string
+		prefix  string
+		numeric bool
+	}
+)
+
+var (
+	// ErrInvalidID is returned when a string is not a valid ID of the requested type
+	ErrInvalidID = errors.New("invalid ID")
+
+	propertyIDKind     = idKind{name: "property", prefix: "prp_", numeric: true}
+	contractIDKind     = idKind{name: "contract", prefix: "ctr_"}
+	groupIDKind        = idKind{name: "group", prefix: "grp_", numeric: true}
+	edgeHostnameIDKind = idKind{name: "edge hostname", prefix: "ehn_", numeric: true}
+	cpCodeIDKind       = idKind{name: "CP code", prefix: "cpc_", numeric: true}
+	productIDKind      = idKind{name: "product", prefix: "prd_"}
+
+	// idPrefixes contains prefixes of all PAPI identifiers, used to reject IDs of a different type
+	idPrefixes = []string{"prp_", "ctr_", "grp_", "ehn_", "cpc_", "prd_", "atv_", "act_", "inc_", "aid_"}
+)
+
+// FormatID returns the ID with or without its prefix, depending on WithUsePrefixes setting of the client
+func (p *papi) FormatID(id TypedID) string {
+	return id.Format(p.usePrefixes)
+}
+
+// parse returns ID without prefix, s may be given with or without the prefix
+func (k idKind) parse(s string) (string, error) {
+	bare := strings.TrimPrefix(s, k.prefix)
+	if bare == "" {
+		return "", fmt.Errorf("%w: %q is not a valid %s ID", ErrInvalidID, s, k.name)
+	}
+	for _, prefix := range idPrefixes {
+		if prefix != k.prefix && strings.HasPrefix(s, prefix) {
+			return "", fmt.Errorf("%w: %q is not a valid %s ID, expected prefix %q", ErrInvalidID, s, k.name, k.prefix)
+		}
+	}
+	if k.numeric {
+		if _, err := strconv.ParseUint(bare, 10, 64); err != nil {
+			return "", fmt.Errorf("%w: %q is not a valid %s ID", ErrInvalidID, s, k.name)
+		}
+	}
+	return bare, nil
+}
+
+func (k idKind) format(s string, usePrefixes bool) string {
+	bare := strings.TrimPrefix(s, k.prefix)
+	if bare == "" || !usePrefixes {
+		return bare
+	}
+	return k.prefix + bare
+}
+
+func (k idKind) validate(s string) error {
+	if s == "" {
+		return nil
+	}
+	_, err := k.parse(s)
+	return err
+}
+
+func (k idKind) marshal(s string) ([]byte, error) {
+	return json.Marshal(k.format(s, true))
+}
+
+// unmarshal accepts both prefixed and bare IDs, as string or number, and returns the prefixed form
+func (k idKind) unmarshal(b []byte) (string, error) {
+	if string(b) == "null" {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return "", fmt.Errorf("%w: %s is not a valid %s ID", ErrInvalidID, b, k.name)
+		}
+		s = n.String()
+	}
+	if s == "" {
+		return "", nil
+	}
+	bare, err := k.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return k.prefix + bare, nil
+}
+
+// ParsePropertyID parses property ID given with or without "prp_" prefix
+func ParsePropertyID(s string) (PropertyID, error) {
+	bare, err := propertyIDKind.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return PropertyID(propertyIDKind.prefix + bare), nil
+}
+
+// String returns the ID with prefix
+func (id PropertyID) String() string {
+	return propertyIDKind.format(string(id), true)
+}
+
+// Bare returns the ID without prefix
+func (id PropertyID) Bare() string {
+	return propertyIDKind.format(string(id), false)
+}
+
+// Format returns the ID with or without prefix
+func (id PropertyID) Format(usePrefixes bool) string {
+	return propertyIDKind.format(string(id), usePrefixes)
+}
+
+// Validate validates PropertyID
+func (id PropertyID) Validate() error {
+	return propertyIDKind.validate(string(id))
+}
+
+// MarshalJSON marshals the ID with prefix, which PAPI accepts regardless of PAPI-Use-Prefixes header
+func (id PropertyID) MarshalJSON() ([]byte, error) {
+	return propertyIDKind.marshal(string(id))
+}
+
+// UnmarshalJSON unmarshals the ID given with or without prefix
+func (id *PropertyID) UnmarshalJSON(b []byte) error {
+	s, err := propertyIDKind.unmarshal(b)
+	*id = PropertyID(s)
+	return err
+}
+
+// ParseContractID parses contract ID given with or without "ctr_" prefix
+func ParseContractID(s string) (ContractID, error) {
+	bare, err := contractIDKind.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return ContractID(contractIDKind.prefix + bare), nil
+}
+
+// String returns the ID with prefix
+func (id ContractID) String() string {
+	return contractIDKind.format(string(id), true)
+}
+
+// Bare returns the ID without prefix
+func (id ContractID) Bare() string {
+	return contractIDKind.format(string(id), false)
+}
+
+// Format returns the ID with or without prefix
+func (id ContractID) Format(usePrefixes bool) string {
+	return contractIDKind.format(string(id), usePrefixes)
+}
+
+// Validate validates ContractID
+func (id ContractID) Validate() error {
+	return contractIDKind.validate(string(id))
+}
+
+// MarshalJSON marshals the ID with prefix, which PAPI accepts regardless of PAPI-Use-Prefixes header
+func (id ContractID) MarshalJSON() ([]byte, error) {
+	return contractIDKind.marshal(string(id))
+}
+
+// UnmarshalJSON unmarshals the ID given with or without prefix
+func (id *ContractID) UnmarshalJSON(b []byte) error {
+	s, err := contractIDKind.unmarshal(b)
+	*id = ContractID(s)
+	return err
+}
+
+// ParseGroupID parses group ID given with or without "grp_" prefix
+func ParseGroupID(s string) (GroupID, error) {
+	bare, err := groupIDKind.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return GroupID(groupIDKind.prefix + bare), nil
+}
+
+// String returns the ID with prefix
+func (id GroupID) String() string {
+	return groupIDKind.format(string(id), true)
+}
+
+// Bare returns the ID without prefix
+func (id GroupID) Bare() string {
+	return groupIDKind.format(string(id), false)
+}
+
+// Format returns the ID with or without prefix
+func (id GroupID) Format(usePrefixes bool) string {
+	return groupIDKind.format(string(id), usePrefixes)
+}
+
+// Validate validates GroupID
+func (id GroupID) Validate() error {
+	return groupIDKind.validate(string(id))
+}
+
+// MarshalJSON marshals the ID with prefix, which PAPI accepts regardless of PAPI-Use-Prefixes header
+func (id GroupID) MarshalJSON() ([]byte, error) {
+	return groupIDKind.marshal(string(id))
+}
+
+// UnmarshalJSON unmarshals the ID given with or without prefix
+func (id *GroupID) UnmarshalJSON(b []byte) error {
+	s, err := groupIDKind.unmarshal(b)
+	*id = GroupID(s)
+	return err
+}
+
+// ParseEdgeHostnameID parses edge hostname ID given with or without "ehn_" prefix
+func ParseEdgeHostnameID(s string) (EdgeHostnameID, error) {
+	bare, err := edgeHostnameIDKind.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return EdgeHostnameID(edgeHostnameIDKind.prefix + bare), nil
+}
+
+// String returns the ID with prefix
+func (id EdgeHostnameID) String() string {
+	return edgeHostnameIDKind.format(string(id), true)
+}
+
+// Bare returns the ID without prefix
+func (id EdgeHostnameID) Bare() string {
+	return edgeHostnameIDKind.format(string(id), false)
+}
+
+// Format returns the ID with or without prefix
+func (id EdgeHostnameID) Format(usePrefixes bool) string {
+	return edgeHostnameIDKind.format(string(id), usePrefixes)
+}
+
+// Validate validates EdgeHostnameID
+func (id EdgeHostnameID) Validate() error {
+	return edgeHostnameIDKind.validate(string(id))
+}
+
+// MarshalJSON marshals the ID with prefix, which PAPI accepts regardless of PAPI-Use-Prefixes header
+func (id EdgeHostnameID) MarshalJSON() ([]byte, error) {
+	return edgeHostnameIDKind.marshal(string(id))
+}
+
+// UnmarshalJSON unmarshals the ID given with or without prefix
+func (id *EdgeHostnameID) UnmarshalJSON(b []byte) error {
+	s, err := edgeHostnameIDKind.unmarshal(b)
+	*id = EdgeHostnameID(s)
+	return err
+}
+
+// ParseCPCodeID parses CP code ID given with or without "cpc_" prefix
+func ParseCPCodeID(s string) (CPCodeID, error) {
+	bare, err := cpCodeIDKind.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return CPCodeID(cpCodeIDKind.prefix + bare), nil
+}
+
+// String returns the ID with prefix
+func (id CPCodeID) String() string {
+	return cpCodeIDKind.format(string(id), true)
+}
+
+// Bare returns the ID without prefix
+func (id CPCodeID) Bare() string {
+	return cpCodeIDKind.format(string(id), false)
+}
+
+// Format returns the ID with or without prefix
+func (id CPCodeID) Format(usePrefixes bool) string {
+	return cpCodeIDKind.format(string(id), usePrefixes)
+}
+
+// Int returns the numeric value of the ID, as used in cpCode behavior options
+func (id CPCodeID) Int() (int, error) {
+	bare, err := cpCodeIDKind.parse(string(id))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(bare)
+}
+
+// Validate validates CPCodeID
+func (id CPCodeID) Validate() error {
+	return cpCodeIDKind.validate(string(id))
+}
+
+// MarshalJSON marshals the ID with prefix, which PAPI accepts regardless of PAPI-Use-Prefixes header
+func (id CPCodeID) MarshalJSON() ([]byte, error) {
+	return cpCodeIDKind.marshal(string(id))
+}
+
+// UnmarshalJSON unmarshals the ID given with or without prefix
+func (id *CPCodeID) UnmarshalJSON(b []byte) error {
+	s, err := cpCodeIDKind.unmarshal(b)
+	*id = CPCodeID(s)
+	return err
+}
+
+// ParseProductID parses product ID given with or without "prd_" prefix
+func ParseProductID(s string) (ProductID, error) {
+	bare, err := productIDKind.parse(s)
+	if err != nil {
+		return "", err
+	}
+	return ProductID(productIDKind.prefix + bare), nil
+}
+
+// String returns the ID with prefix
+func (id ProductID) String() string {
+	return productIDKind.format(string(id), true)
+}
+
+// Bare returns the ID without prefix
+func (id ProductID) Bare() string {
+	return productIDKind.format(string(id), false)
+}
+
+// Format returns the ID with or without prefix
+func (id ProductID) Format(usePrefixes bool) string {
+	return productIDKind.format(string(id), usePrefixes)
+}
+
+// Validate validates ProductID
+func (id ProductID) Validate() error {
+	return productIDKind.validate(string(id))
+}
+
+// MarshalJSON marshals the ID with prefix, which PAPI accepts regardless of PAPI-Use-Prefixes header
+func (id ProductID) MarshalJSON() ([]byte, error) {
+	return productIDKind.marshal(string(id))
+}
+
+// UnmarshalJSON unmarshals the ID given with or without prefix
+func (id *ProductID) UnmarshalJSON(b []byte) error {
+	s, err := productIDKind.unmarshal(b)
+	*id = ProductID(s)
+	return err
+}
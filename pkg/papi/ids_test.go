@@ -0,0 +1,144 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIDs(t *testing.T) {
+	tests := map[string]struct {
+		parse     func(string) (TypedID, error)
+		given     string
+		expected  string
+		bare      string
+		withError error
+	}{
+		"property ID with prefix": {
+			parse:    func(s string) (TypedID, error) { return ParsePropertyID(s) },
+			given:    "prp_175780",
+			expected: "prp_175780",
+			bare:     "175780",
+		},
+		"property ID without prefix": {
+			parse:    func(s string) (TypedID, error) { return ParsePropertyID(s) },
+			given:    "175780",
+			expected: "prp_175780",
+			bare:     "175780",
+		},
+		"property ID with prefix of another type": {
+			parse:     func(s string) (TypedID, error) { return ParsePropertyID(s) },
+			given:     "grp_15166",
+			withError: ErrInvalidID,
+		},
+		"property ID not numeric": {
+			parse:     func(s string) (TypedID, error) { return ParsePropertyID(s) },
+			given:     "prp_abc",
+			withError: ErrInvalidID,
+		},
+		"empty property ID": {
+			parse:     func(s string) (TypedID, error) { return ParsePropertyID(s) },
+			given:     "prp_",
+			withError: ErrInvalidID,
+		},
+		"contract ID": {
+			parse:    func(s string) (TypedID, error) { return ParseContractID(s) },
+			given:    "1-1TJZFW",
+			expected: "ctr_1-1TJZFW",
+			bare:     "1-1TJZFW",
+		},
+		"group ID": {
+			parse:    func(s string) (TypedID, error) { return ParseGroupID(s) },
+			given:    "grp_15166",
+			expected: "grp_15166",
+			bare:     "15166",
+		},
+		"edge hostname ID": {
+			parse:    func(s string) (TypedID, error) { return ParseEdgeHostnameID(s) },
+			given:    "895822",
+			expected: "ehn_895822",
+			bare:     "895822",
+		},
+		"CP code ID": {
+			parse:    func(s string) (TypedID, error) { return ParseCPCodeID(s) },
+			given:    "cpc_33190",
+			expected: "cpc_33190",
+			bare:     "33190",
+		},
+		"product ID with underscore": {
+			parse:    func(s string) (TypedID, error) { return ParseProductID(s) },
+			given:    "Site_Accel",
+			expected: "prd_Site_Accel",
+			bare:     "Site_Accel",
+		},
+		"product ID with prefix of another type": {
+			parse:     func(s string) (TypedID, error) { return ParseProductID(s) },
+			given:     "ctr_1-1TJZFW",
+			withError: ErrInvalidID,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := test.parse(test.given)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, id.Format(true))
+			assert.Equal(t, test.bare, id.Format(false))
+		})
+	}
+}
+
+func TestIDs_JSON(t *testing.T) {
+	type ids struct {
+		PropertyID PropertyID     `json:"propertyId"`
+		ContractID ContractID     `json:"contractId"`
+		GroupID    GroupID        `json:"groupId,omitempty"`
+		CPCodeID   CPCodeID       `json:"cpcodeId"`
+		EdgeID     EdgeHostnameID `json:"edgeHostnameId"`
+		ProductID  ProductID      `json:"productId"`
+	}
+
+	var decoded ids
+	err := json.Unmarshal([]byte(`{"propertyId": "175780", "contractId": "ctr_1-1TJZFW", "cpcodeId": 33190,
+		"edgeHostnameId": "ehn_895822", "productId": "Fresca"}`), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, ids{
+		PropertyID: "prp_175780",
+		ContractID: "ctr_1-1TJZFW",
+		CPCodeID:   "cpc_33190",
+		EdgeID:     "ehn_895822",
+		ProductID:  "prd_Fresca",
+	}, decoded)
+
+	cpCode, err := decoded.CPCodeID.Int()
+	require.NoError(t, err)
+	assert.Equal(t, 33190, cpCode)
+
+	encoded, err := json.Marshal(ids{PropertyID: "175780", ContractID: "1-1TJZFW", CPCodeID: "cpc_33190", EdgeID: "", ProductID: "prd_Fresca"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"propertyId": "prp_175780", "contractId": "ctr_1-1TJZFW", "cpcodeId": "cpc_33190",
+		"edgeHostnameId": "", "productId": "prd_Fresca"}`, string(encoded))
+
+	err = json.Unmarshal([]byte(`{"propertyId": "grp_15166"}`), &decoded)
+	assert.True(t, errors.Is(err, ErrInvalidID), "want: %s; got: %s", ErrInvalidID, err)
+}
+
+func TestIDs_Validate(t *testing.T) {
+	assert.NoError(t, validation.Validate(PropertyID("prp_1"), validation.Required))
+	assert.NoError(t, validation.Validate(GroupID("")))
+	assert.Error(t, validation.Validate(GroupID(""), validation.Required))
+	assert.True(t, errors.Is(validation.Validate(GroupID("ctr_1")), ErrInvalidID))
+}
+
+func TestPapi_FormatID(t *testing.T) {
+	assert.Equal(t, "prp_175780", Client(nil).FormatID(PropertyID("175780")))
+	assert.Equal(t, "175780", Client(nil, WithUsePrefixes(false)).FormatID(PropertyID("prp_175780")))
+}
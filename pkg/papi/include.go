@@ -0,0 +1,414 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Includes contains operations available on Include resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/includes
+	Includes interface {
+		// ListIncludes lists includes available for the current contract and group
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-includes
+		ListIncludes(context.Context, ListIncludesRequest) (*ListIncludesResponse, error)
+
+		// ListIncludeParents lists parent properties which reference the include
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-parents
+		ListIncludeParents(context.Context, ListIncludeParentsRequest) (*ListIncludeParentsResponse, error)
+
+		// GetInclude gets information about a specific include
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include
+		GetInclude(context.Context, GetIncludeRequest) (*GetIncludeResponse, error)
+
+		// CreateInclude creates a new include
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-includes
+		CreateInclude(context.Context, CreateIncludeRequest) (*CreateIncludeResponse, error)
+
+		// DeleteInclude deletes an include which is not activated on any network
+		// See: https://techdocs.akamai.com/property-mgr/reference/delete-include
+		DeleteInclude(context.Context, DeleteIncludeRequest) (*DeleteIncludeResponse, error)
+	}
+
+	// Include represents an include resource
+	Include struct {
+		AccountID         string      `json:"accountId"`
+		AssetID           string      `json:"assetId"`
+		ContractID        string      `json:"contractId"`
+		GroupID           string      `json:"groupId"`
+		IncludeID         string      `json:"includeId"`
+		IncludeName       string      `json:"includeName"`
+		IncludeType       IncludeType `json:"includeType"`
+		LatestVersion     int         `json:"latestVersion"`
+		ProductionVersion *int        `json:"productionVersion"`
+		PropertyType      *string     `json:"propertyType"`
+		StagingVersion    *int        `json:"stagingVersion"`
+	}
+
+	// IncludeItems is an array of includes
+	IncludeItems struct {
+		Items []Include `json:"items"`
+	}
+
+	// ListIncludesRequest contains query params used for listing includes
+	ListIncludesRequest struct {
+		ContractID string
+		GroupID    string
+	}
+
+	// ListIncludesResponse contains the response returned by ListIncludes
+	ListIncludesResponse struct {
+		Includes IncludeItems `json:"includes"`
+	}
+
+	// ListIncludeParentsRequest contains path and query params used for listing parent properties of an include
+	ListIncludeParentsRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+	}
+
+	// ListIncludeParentsResponse contains the response returned by ListIncludeParents
+	ListIncludeParentsResponse struct {
+		Properties ParentPropertyItems `json:"properties"`
+	}
+
+	// ParentPropertyItems is an array of parent properties
+	ParentPropertyItems struct {
+		Items []ParentProperty `json:"items"`
+	}
+
+	// ParentProperty represents a property which references an include
+	ParentProperty struct {
+		AccountID                        string `json:"accountId"`
+		AssetID                          string `json:"assetId"`
+		ContractID                       string `json:"contractId"`
+		GroupID                          string `json:"groupId"`
+		PropertyID                       string `json:"propertyId"`
+		PropertyName                     string `json:"propertyName"`
+		ProductionVersion                *int   `json:"productionVersion"`
+		StagingVersion                   *int   `json:"stagingVersion"`
+		IsIncludeUsedInStagingVersion    bool   `json:"isIncludeUsedInStagingVersion"`
+		IsIncludeUsedInProductionVersion bool   `json:"isIncludeUsedInProductionVersion"`
+	}
+
+	// GetIncludeRequest contains path and query params used to fetch an include
+	GetIncludeRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+	}
+
+	// GetIncludeResponse contains the response returned by GetInclude
+	GetIncludeResponse struct {
+		Includes IncludeItems `json:"includes"`
+		Include  Include      `json:"-"`
+	}
+
+	// CreateIncludeRequest contains query params and request body used to create an include
+	CreateIncludeRequest struct {
+		ContractID string
+		GroupID    string
+		Include    IncludeCreate
+	}
+
+	// IncludeCreate represents a POST /includes request body
+	IncludeCreate struct {
+		IncludeName string            `json:"includeName"`
+		IncludeType IncludeType       `json:"includeType"`
+		ProductID   string            `json:"productId"`
+		RuleFormat  string            `json:"ruleFormat,omitempty"`
+		CloneFrom   *IncludeCloneFrom `json:"cloneFrom,omitempty"`
+	}
+
+	// IncludeCloneFrom optionally identifies another include to clone when creating a new include
+	IncludeCloneFrom struct {
+		IncludeID            string `json:"includeId"`
+		Version              int    `json:"version"`
+		CloneFromVersionEtag string `json:"cloneFromVersionEtag,omitempty"`
+	}
+
+	// CreateIncludeResponse contains the response returned by CreateInclude
+	CreateIncludeResponse struct {
+		IncludeID   string `json:"-"`
+		IncludeLink string `json:"includeLink"`
+	}
+
+	// DeleteIncludeRequest contains path and query params used to delete an include
+	DeleteIncludeRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+	}
+
+	// DeleteIncludeResponse contains the response returned by DeleteInclude
+	DeleteIncludeResponse struct {
+		Message string `json:"message"`
+	}
+
+	// IncludeType is the type of an include
+	IncludeType string
+)
+
+const (
+	// IncludeTypeMicroServices is an include used by microservices teams to manage their part of a property
+	IncludeTypeMicroServices IncludeType = "MICROSERVICES"
+	// IncludeTypeCommonSettings is an include holding settings shared across multiple properties
+	IncludeTypeCommonSettings IncludeType = "COMMON_SETTINGS"
+)
+
+// Validate validates ListIncludesRequest
+func (i ListIncludesRequest) Validate() error {
+	return validation.Errors{
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ListIncludeParentsRequest
+func (i ListIncludeParentsRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetIncludeRequest
+func (i GetIncludeRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateIncludeRequest
+func (i CreateIncludeRequest) Validate() error {
+	errs := validation.Errors{
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+		"Include":    validation.Validate(i.Include),
+	}
+	return edgegriderr.ParseValidationErrors(errs)
+}
+
+// Validate validates IncludeCreate
+func (i IncludeCreate) Validate() error {
+	return validation.Errors{
+		"IncludeName": validation.Validate(i.IncludeName, validation.Required),
+		"IncludeType": validation.Validate(i.IncludeType, validation.Required, validation.In(IncludeTypeMicroServices, IncludeTypeCommonSettings).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", i.IncludeType, IncludeTypeMicroServices, IncludeTypeCommonSettings))),
+		"ProductID":  validation.Validate(i.ProductID, validation.Required),
+		"RuleFormat": validation.Validate(i.RuleFormat, validation.Match(validRuleFormat)),
+		"CloneFrom":  validation.Validate(i.CloneFrom),
+	}.Filter()
+}
+
+// Validate validates IncludeCloneFrom
+func (c IncludeCloneFrom) Validate() error {
+	return validation.Errors{
+		"IncludeID": validation.Validate(c.IncludeID, validation.Required),
+		"Version":   validation.Validate(c.Version, validation.Required),
+	}.Filter()
+}
+
+// Validate validates DeleteIncludeRequest
+func (i DeleteIncludeRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+var (
+	// ErrListIncludes represents error when listing includes fails
+	ErrListIncludes = errors.New("listing includes")
+	// ErrListIncludeParents represents error when listing include parents fails
+	ErrListIncludeParents = errors.New("listing include parents")
+	// ErrGetInclude represents error when fetching include fails
+	ErrGetInclude = errors.New("fetching include")
+	// ErrCreateInclude represents error when creating include fails
+	ErrCreateInclude = errors.New("creating include")
+	// ErrDeleteInclude represents error when deleting include fails
+	ErrDeleteInclude = errors.New("deleting include")
+)
+
+func (p *papi) ListIncludes(ctx context.Context, params ListIncludesRequest) (*ListIncludesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListIncludes, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ListIncludes")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes?contractId=%s&groupId=%s",
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListIncludes, err)
+	}
+
+	var rval ListIncludesResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListIncludes, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListIncludes, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) ListIncludeParents(ctx context.Context, params ListIncludeParentsRequest) (*ListIncludeParentsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListIncludeParents, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ListIncludeParents")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s/parents?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListIncludeParents, err)
+	}
+
+	var rval ListIncludeParentsResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListIncludeParents, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListIncludeParents, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) GetInclude(ctx context.Context, params GetIncludeRequest) (*GetIncludeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetInclude, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetInclude")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetInclude, err)
+	}
+
+	var rval GetIncludeResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetInclude, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetInclude, p.Error(resp))
+	}
+
+	if len(rval.Includes.Items) == 0 {
+		return nil, fmt.Errorf("%s: %w: IncludeID: %s", ErrGetInclude, ErrNotFound, params.IncludeID)
+	}
+	rval.Include = rval.Includes.Items[0]
+
+	return &rval, nil
+}
+
+func (p *papi) CreateInclude(ctx context.Context, params CreateIncludeRequest) (*CreateIncludeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreateInclude, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("CreateInclude")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes?contractId=%s&groupId=%s",
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateInclude, err)
+	}
+
+	var rval CreateIncludeResponse
+	resp, err := p.Exec(req, &rval, params.Include)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateInclude, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateInclude, p.Error(resp))
+	}
+
+	id, err := ResponseLinkParse(rval.IncludeLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateInclude, ErrInvalidResponseLink, err)
+	}
+	rval.IncludeID = id
+
+	return &rval, nil
+}
+
+func (p *papi) DeleteInclude(ctx context.Context, params DeleteIncludeRequest) (*DeleteIncludeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrDeleteInclude, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("DeleteInclude")
+
+	uri, err := url.Parse(fmt.Sprintf("/papi/v1/includes/%s", params.IncludeID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrDeleteInclude, err)
+	}
+	q := uri.Query()
+	q.Add("contractId", params.ContractID)
+	q.Add("groupId", params.GroupID)
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrDeleteInclude, err)
+	}
+
+	var rval DeleteIncludeResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrDeleteInclude, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrDeleteInclude, p.Error(resp))
+	}
+
+	return &rval, nil
+}
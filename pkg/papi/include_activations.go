@@ -0,0 +1,363 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/spf13/cast"
+)
+
+type (
+	// IncludeActivations contains operations available on IncludeActivation resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/include-activations
+	IncludeActivations interface {
+		// ActivateInclude creates a new include activation, which activates the include version on the given network
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-include-activation
+		ActivateInclude(context.Context, ActivateIncludeRequest) (*ActivationIncludeResponse, error)
+
+		// DeactivateInclude creates a new include deactivation, which deactivates the include version on the given network
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-include-activation
+		DeactivateInclude(context.Context, DeactivateIncludeRequest) (*ActivationIncludeResponse, error)
+
+		// GetIncludeActivation gets details about an include activation
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-activation
+		GetIncludeActivation(context.Context, GetIncludeActivationRequest) (*GetIncludeActivationResponse, error)
+
+		// ListIncludeActivations lists activations and deactivations of the include
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-activations
+		ListIncludeActivations(context.Context, ListIncludeActivationsRequest) (*ListIncludeActivationsResponse, error)
+
+		// WaitForIncludeActivation polls an include activation until it is no longer pending or the context is canceled.
+		// The returned activation should be checked for its Status, as failed and aborted activations are not reported as errors
+		WaitForIncludeActivation(context.Context, GetIncludeActivationRequest) (*IncludeActivation, error)
+	}
+
+	// ActivateIncludeRequest contains path and query params, as well as request body used to activate an include
+	ActivateIncludeRequest struct {
+		IncludeID              string
+		ContractID             string
+		GroupID                string
+		Version                int
+		Network                ActivationNetwork
+		Note                   string
+		NotifyEmails           []string
+		AcknowledgeWarnings    []string
+		AcknowledgeAllWarnings bool
+		IgnoreHTTPErrors       *bool
+	}
+
+	// DeactivateIncludeRequest contains path and query params, as well as request body used to deactivate an include
+	DeactivateIncludeRequest ActivateIncludeRequest
+
+	// ActivationIncludeResponse contains the response returned by ActivateInclude and DeactivateInclude
+	ActivationIncludeResponse struct {
+		ActivationID   string `json:"-"`
+		ActivationLink string `json:"activationLink"`
+	}
+
+	// IncludeActivation represents an include activation resource
+	IncludeActivation struct {
+		ActivationID        string                  `json:"activationId"`
+		ActivationType      ActivationType          `json:"activationType"`
+		FallbackInfo        *ActivationFallbackInfo `json:"fallbackInfo,omitempty"`
+		FMAActivationState  string                  `json:"fmaActivationState,omitempty"`
+		IncludeActivationID string                  `json:"includeActivationId,omitempty"`
+		IncludeID           string                  `json:"includeId"`
+		IncludeName         string                  `json:"includeName"`
+		IncludeType         IncludeType             `json:"includeType"`
+		IncludeVersion      int                     `json:"includeVersion"`
+		Network             ActivationNetwork       `json:"network"`
+		Note                string                  `json:"note,omitempty"`
+		NotifyEmails        []string                `json:"notifyEmails"`
+		Status              ActivationStatus        `json:"status"`
+		SubmitDate          string                  `json:"submitDate,omitempty"`
+		UpdateDate          string                  `json:"updateDate,omitempty"`
+	}
+
+	// IncludeActivationItems is an array of include activations
+	IncludeActivationItems struct {
+		Items []IncludeActivation `json:"items"`
+	}
+
+	// GetIncludeActivationRequest contains path and query params used to fetch an include activation
+	GetIncludeActivationRequest struct {
+		IncludeID    string
+		ActivationID string
+		ContractID   string
+		GroupID      string
+	}
+
+	// GetIncludeActivationResponse contains the response returned by GetIncludeActivation
+	GetIncludeActivationResponse struct {
+		AccountID   string                 `json:"accountId"`
+		ContractID  string                 `json:"contractId"`
+		GroupID     string                 `json:"groupId"`
+		Activations IncludeActivationItems `json:"activations"`
+		Activation  IncludeActivation      `json:"-"`
+
+		// RetryAfter is the value of the Retry-After header.
+		//  For activations whose status is PENDING, a Retry-After header provides an estimate for when it’s likely to change.
+		RetryAfter int `json:"-"`
+	}
+
+	// ListIncludeActivationsRequest contains path and query params used to list include activations
+	ListIncludeActivationsRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+	}
+
+	// ListIncludeActivationsResponse contains the response returned by ListIncludeActivations
+	ListIncludeActivationsResponse struct {
+		AccountID   string                 `json:"accountId"`
+		ContractID  string                 `json:"contractId"`
+		GroupID     string                 `json:"groupId"`
+		Activations IncludeActivationItems `json:"activations"`
+	}
+
+	includeActivationBody struct {
+		IncludeVersion         int               `json:"includeVersion"`
+		Network                ActivationNetwork `json:"network"`
+		ActivationType         ActivationType    `json:"activationType"`
+		Note                   string            `json:"note,omitempty"`
+		NotifyEmails           []string          `json:"notifyEmails"`
+		AcknowledgeWarnings    []string          `json:"acknowledgeWarnings,omitempty"`
+		AcknowledgeAllWarnings bool              `json:"acknowledgeAllWarnings"`
+		IgnoreHTTPErrors       *bool             `json:"ignoreHttpErrors,omitempty"`
+	}
+)
+
+// Validate validates ActivateIncludeRequest
+func (i ActivateIncludeRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+		"Version":    validation.Validate(i.Version, validation.Required),
+		"Network": validation.Validate(i.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", i.Network, ActivationNetworkStaging, ActivationNetworkProduction))),
+		"NotifyEmails": validation.Validate(i.NotifyEmails, validation.Required),
+	}.Filter()
+}
+
+// Validate validates DeactivateIncludeRequest
+func (i DeactivateIncludeRequest) Validate() error {
+	return ActivateIncludeRequest(i).Validate()
+}
+
+// Validate validates GetIncludeActivationRequest
+func (i GetIncludeActivationRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":    validation.Validate(i.IncludeID, validation.Required),
+		"ActivationID": validation.Validate(i.ActivationID, validation.Required),
+		"ContractID":   validation.Validate(i.ContractID, validation.Required),
+		"GroupID":      validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ListIncludeActivationsRequest
+func (i ListIncludeActivationsRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+// Pending returns true if the activation has not reached a final status yet
+func (a IncludeActivation) Pending() bool {
+	switch a.Status {
+	case ActivationStatusNew, ActivationStatusPending, ActivationStatusZone1, ActivationStatusZone2,
+		ActivationStatusZone3, ActivationStatusDeactivating:
+		return true
+	}
+	return false
+}
+
+var (
+	// ErrActivateInclude represents error when activating include fails
+	ErrActivateInclude = errors.New("activating include")
+	// ErrDeactivateInclude represents error when deactivating include fails
+	ErrDeactivateInclude = errors.New("deactivating include")
+	// ErrGetIncludeActivation represents error when fetching include activation fails
+	ErrGetIncludeActivation = errors.New("fetching include activation")
+	// ErrListIncludeActivations represents error when listing include activations fails
+	ErrListIncludeActivations = errors.New("listing include activations")
+	// ErrWaitForIncludeActivation represents error when waiting for include activation fails
+	ErrWaitForIncludeActivation = errors.New("waiting for include activation")
+)
+
+func (p *papi) ActivateInclude(ctx context.Context, params ActivateIncludeRequest) (*ActivationIncludeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrActivateInclude, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ActivateInclude")
+
+	rval, err := p.createIncludeActivation(ctx, params, ActivationTypeActivate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrActivateInclude, err)
+	}
+
+	return rval, nil
+}
+
+func (p *papi) DeactivateInclude(ctx context.Context, params DeactivateIncludeRequest) (*ActivationIncludeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrDeactivateInclude, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("DeactivateInclude")
+
+	rval, err := p.createIncludeActivation(ctx, ActivateIncludeRequest(params), ActivationTypeDeactivate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrDeactivateInclude, err)
+	}
+
+	return rval, nil
+}
+
+func (p *papi) createIncludeActivation(ctx context.Context, params ActivateIncludeRequest, activationType ActivationType) (*ActivationIncludeResponse, error) {
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s/activations?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	body := includeActivationBody{
+		IncludeVersion:         params.Version,
+		Network:                params.Network,
+		ActivationType:         activationType,
+		Note:                   params.Note,
+		NotifyEmails:           params.NotifyEmails,
+		AcknowledgeWarnings:    params.AcknowledgeWarnings,
+		AcknowledgeAllWarnings: params.AcknowledgeAllWarnings,
+		IgnoreHTTPErrors:       params.IgnoreHTTPErrors,
+	}
+
+	var rval ActivationIncludeResponse
+	resp, err := p.Exec(req, &rval, body)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, p.Error(resp)
+	}
+
+	id, err := ResponseLinkParse(rval.ActivationLink)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidResponseLink, err)
+	}
+	rval.ActivationID = id
+
+	return &rval, nil
+}
+
+func (p *papi) GetIncludeActivation(ctx context.Context, params GetIncludeActivationRequest) (*GetIncludeActivationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetIncludeActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetIncludeActivation")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s/activations/%s?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.ActivationID,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetIncludeActivation, err)
+	}
+
+	var rval GetIncludeActivationResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetIncludeActivation, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetIncludeActivation, p.Error(resp))
+	}
+
+	// Get the Retry-After header to return the caller
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		rval.RetryAfter = cast.ToInt(retryAfter)
+	}
+
+	if len(rval.Activations.Items) == 0 {
+		return nil, fmt.Errorf("%s: %w: ActivationID: %s", ErrGetIncludeActivation, ErrNotFound, params.ActivationID)
+	}
+	rval.Activation = rval.Activations.Items[0]
+
+	return &rval, nil
+}
+
+func (p *papi) ListIncludeActivations(ctx context.Context, params ListIncludeActivationsRequest) (*ListIncludeActivationsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListIncludeActivations, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ListIncludeActivations")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s/activations?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListIncludeActivations, err)
+	}
+
+	var rval ListIncludeActivationsResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListIncludeActivations, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListIncludeActivations, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) WaitForIncludeActivation(ctx context.Context, params GetIncludeActivationRequest) (*IncludeActivation, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForIncludeActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("WaitForIncludeActivation")
+
+	var activation *IncludeActivation
+	err := p.poll(ctx, func() (bool, error) {
+		rval, err := p.GetIncludeActivation(ctx, params)
+		if err != nil {
+			return false, err
+		}
+		activation = &rval.Activation
+		return !activation.Pending(), nil
+	})
+	if err != nil {
+		return nil, pollError(ctx, ErrWaitForIncludeActivation, err)
+	}
+	return activation, nil
+}
@@ -0,0 +1,506 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_ActivateInclude(t *testing.T) {
+	tests := map[string]struct {
+		request             ActivateIncludeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *ActivationIncludeResponse
+		withError           error
+	}{
+		"201 created": {
+			request: ActivateIncludeRequest{
+				IncludeID:              "inc_12345",
+				ContractID:             "ctr_1-1TJZFW",
+				GroupID:                "grp_15166",
+				Version:                2,
+				Network:                ActivationNetworkStaging,
+				Note:                   "test activation",
+				NotifyEmails:           []string{"jsmith@example.com"},
+				AcknowledgeAllWarnings: true,
+				IgnoreHTTPErrors:       tools.BoolPtr(true),
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"activationLink": "/papi/v1/includes/inc_12345/activations/atv_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"includeVersion":2,"network":"STAGING","activationType":"ACTIVATE","note":"test activation","notifyEmails":["jsmith@example.com"],"acknowledgeAllWarnings":true,"ignoreHttpErrors":true}`,
+			expectedResponse: &ActivationIncludeResponse{
+				ActivationID:   "atv_12345",
+				ActivationLink: "/papi/v1/includes/inc_12345/activations/atv_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"422 unprocessable entity": {
+			request: ActivateIncludeRequest{
+				IncludeID:    "inc_12345",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Version:      2,
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"jsmith@example.com"},
+			},
+			responseStatus: http.StatusUnprocessableEntity,
+			responseBody: `
+{
+	"type": "unprocessable_entity",
+    "title": "Unprocessable Entity",
+    "detail": "Include version is already active",
+    "status": 422
+}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"includeVersion":2,"network":"PRODUCTION","activationType":"ACTIVATE","notifyEmails":["jsmith@example.com"],"acknowledgeAllWarnings":false}`,
+			withError: &Error{
+				Type:       "unprocessable_entity",
+				Title:      "Unprocessable Entity",
+				Detail:     "Include version is already active",
+				StatusCode: http.StatusUnprocessableEntity,
+			},
+		},
+		"validation error - invalid network": {
+			request: ActivateIncludeRequest{
+				IncludeID:    "inc_12345",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Version:      2,
+				Network:      "QA",
+				NotifyEmails: []string{"jsmith@example.com"},
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing notify emails": {
+			request: ActivateIncludeRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Version:    2,
+				Network:    ActivationNetworkStaging,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ActivateInclude(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_DeactivateInclude(t *testing.T) {
+	tests := map[string]struct {
+		request             DeactivateIncludeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *ActivationIncludeResponse
+		withError           error
+	}{
+		"201 created": {
+			request: DeactivateIncludeRequest{
+				IncludeID:    "inc_12345",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Version:      2,
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"jsmith@example.com"},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"activationLink": "/papi/v1/includes/inc_12345/activations/atv_67890?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"includeVersion":2,"network":"PRODUCTION","activationType":"DEACTIVATE","notifyEmails":["jsmith@example.com"],"acknowledgeAllWarnings":false}`,
+			expectedResponse: &ActivationIncludeResponse{
+				ActivationID:   "atv_67890",
+				ActivationLink: "/papi/v1/includes/inc_12345/activations/atv_67890?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"validation error - missing version": {
+			request: DeactivateIncludeRequest{
+				IncludeID:    "inc_12345",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"jsmith@example.com"},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.DeactivateInclude(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetIncludeActivation(t *testing.T) {
+	activation := IncludeActivation{
+		ActivationID:   "atv_12345",
+		ActivationType: ActivationTypeActivate,
+		IncludeID:      "inc_12345",
+		IncludeName:    "common-settings",
+		IncludeType:    IncludeTypeCommonSettings,
+		IncludeVersion: 2,
+		Network:        ActivationNetworkStaging,
+		NotifyEmails:   []string{"jsmith@example.com"},
+		Status:         ActivationStatusPending,
+		SubmitDate:     "2022-10-10T12:00:00Z",
+		UpdateDate:     "2022-10-10T12:01:00Z",
+	}
+	tests := map[string]struct {
+		request          GetIncludeActivationRequest
+		responseStatus   int
+		responseBody     string
+		responseHeaders  map[string]string
+		expectedPath     string
+		expectedResponse *GetIncludeActivationResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: GetIncludeActivationRequest{
+				IncludeID:    "inc_12345",
+				ActivationID: "atv_12345",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+			},
+			responseStatus:  http.StatusOK,
+			responseHeaders: map[string]string{"Retry-After": "120"},
+			responseBody: `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_12345",
+				"activationType": "ACTIVATE",
+				"includeId": "inc_12345",
+				"includeName": "common-settings",
+				"includeType": "COMMON_SETTINGS",
+				"includeVersion": 2,
+				"network": "STAGING",
+				"notifyEmails": ["jsmith@example.com"],
+				"status": "PENDING",
+				"submitDate": "2022-10-10T12:00:00Z",
+				"updateDate": "2022-10-10T12:01:00Z"
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/activations/atv_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &GetIncludeActivationResponse{
+				AccountID:   "act_1-1TJZFB",
+				ContractID:  "ctr_1-1TJZFW",
+				GroupID:     "grp_15166",
+				Activations: IncludeActivationItems{Items: []IncludeActivation{activation}},
+				Activation:  activation,
+				RetryAfter:  120,
+			},
+		},
+		"activation not found": {
+			request: GetIncludeActivationRequest{
+				IncludeID:    "inc_12345",
+				ActivationID: "atv_12345",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"activations": {"items": []}}`,
+			expectedPath:   "/papi/v1/includes/inc_12345/activations/atv_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError:      ErrNotFound,
+		},
+		"validation error - missing activation id": {
+			request: GetIncludeActivationRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				for k, v := range test.responseHeaders {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetIncludeActivation(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_ListIncludeActivations(t *testing.T) {
+	tests := map[string]struct {
+		request          ListIncludeActivationsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListIncludeActivationsResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: ListIncludeActivationsRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_67890",
+				"activationType": "DEACTIVATE",
+				"includeId": "inc_12345",
+				"includeVersion": 1,
+				"network": "PRODUCTION",
+				"status": "DEACTIVATED"
+			},
+			{
+				"activationId": "atv_12345",
+				"activationType": "ACTIVATE",
+				"includeId": "inc_12345",
+				"includeVersion": 1,
+				"network": "PRODUCTION",
+				"status": "INACTIVE"
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &ListIncludeActivationsResponse{
+				AccountID:  "act_1-1TJZFB",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activations: IncludeActivationItems{Items: []IncludeActivation{
+					{
+						ActivationID:   "atv_67890",
+						ActivationType: ActivationTypeDeactivate,
+						IncludeID:      "inc_12345",
+						IncludeVersion: 1,
+						Network:        ActivationNetworkProduction,
+						Status:         ActivationStatusDeactivated,
+					},
+					{
+						ActivationID:   "atv_12345",
+						ActivationType: ActivationTypeActivate,
+						IncludeID:      "inc_12345",
+						IncludeVersion: 1,
+						Network:        ActivationNetworkProduction,
+						Status:         ActivationStatusInactive,
+					},
+				}},
+			},
+		},
+		"500 internal server error": {
+			request: ListIncludeActivationsRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching activations",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching activations",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing include id": {
+			request: ListIncludeActivationsRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListIncludeActivations(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_WaitForIncludeActivation(t *testing.T) {
+	request := GetIncludeActivationRequest{
+		IncludeID:    "inc_12345",
+		ActivationID: "atv_12345",
+		ContractID:   "ctr_1-1TJZFW",
+		GroupID:      "grp_15166",
+	}
+	activationBody := func(status ActivationStatus) string {
+		return fmt.Sprintf(`{"activations": {"items": [{"activationId": "atv_12345", "status": "%s"}]}}`, status)
+	}
+
+	tests := map[string]struct {
+		statuses         []ActivationStatus
+		responseStatus   int
+		expectedRequests int
+		expectedStatus   ActivationStatus
+		withError        error
+	}{
+		"polls until active": {
+			statuses:         []ActivationStatus{ActivationStatusPending, ActivationStatusZone1, ActivationStatusActive},
+			responseStatus:   http.StatusOK,
+			expectedRequests: 3,
+			expectedStatus:   ActivationStatusActive,
+		},
+		"failed activation is returned": {
+			statuses:         []ActivationStatus{ActivationStatusPending, ActivationStatusFailed},
+			responseStatus:   http.StatusOK,
+			expectedRequests: 2,
+			expectedStatus:   ActivationStatusFailed,
+		},
+		"deactivation polls until deactivated": {
+			statuses:         []ActivationStatus{ActivationStatusDeactivating, ActivationStatusDeactivated},
+			responseStatus:   http.StatusOK,
+			expectedRequests: 2,
+			expectedStatus:   ActivationStatusDeactivated,
+		},
+		"api error stops polling": {
+			statuses:         []ActivationStatus{ActivationStatusPending},
+			responseStatus:   http.StatusInternalServerError,
+			expectedRequests: 1,
+			withError:        &Error{StatusCode: http.StatusInternalServerError},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/includes/inc_12345/activations/atv_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166", r.URL.String())
+				status := test.statuses[requests]
+				requests++
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(activationBody(status)))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, WithPollInterval(time.Millisecond, 2*time.Millisecond))
+			result, err := client.WaitForIncludeActivation(context.Background(), request)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, result.Status)
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(activationBody(ActivationStatusPending)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Hour, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForIncludeActivation(ctx, request)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrWaitForIncludeActivation), "want: %s; got: %s", ErrWaitForIncludeActivation, err)
+		assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := client.WaitForIncludeActivation(context.Background(), GetIncludeActivationRequest{})
+		assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+	})
+}
@@ -0,0 +1,189 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// IncludeRules contains operations available on IncludeRule resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/include-version-rules
+	IncludeRules interface {
+		// GetIncludeRuleTree gets the entire rule tree for an include version
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-version-rules
+		GetIncludeRuleTree(context.Context, GetIncludeRuleTreeRequest) (*GetIncludeRuleTreeResponse, error)
+
+		// UpdateIncludeRuleTree replaces the entire rule tree of an include version
+		// See: https://techdocs.akamai.com/property-mgr/reference/put-include-version-rules
+		UpdateIncludeRuleTree(context.Context, UpdateIncludeRuleTreeRequest) (*UpdateIncludeRuleTreeResponse, error)
+	}
+
+	// GetIncludeRuleTreeRequest contains path and query params necessary to perform GET /includes/{includeId}/versions/{includeVersion}/rules request
+	GetIncludeRuleTreeRequest struct {
+		IncludeID      string
+		IncludeVersion int
+		ContractID     string
+		GroupID        string
+		ValidateMode   string
+		ValidateRules  bool
+		RuleFormat     string
+	}
+
+	// GetIncludeRuleTreeResponse contains data returned by performing GET /includes/{includeId}/versions/{includeVersion}/rules request
+	GetIncludeRuleTreeResponse struct {
+		Response
+		IncludeID      string      `json:"includeId"`
+		IncludeName    string      `json:"includeName"`
+		IncludeType    IncludeType `json:"includeType"`
+		IncludeVersion int         `json:"includeVersion"`
+		RuleFormat     string      `json:"ruleFormat"`
+		Rules          Rules       `json:"rules"`
+		Comments       string      `json:"comments,omitempty"`
+	}
+
+	// UpdateIncludeRuleTreeRequest contains path and query params, as well as request body necessary to perform PUT /includes/{includeId}/versions/{includeVersion}/rules request
+	UpdateIncludeRuleTreeRequest struct {
+		IncludeID      string
+		IncludeVersion int
+		ContractID     string
+		GroupID        string
+		DryRun         bool
+		ValidateMode   string
+		ValidateRules  bool
+		Rules          RulesUpdate
+	}
+
+	// UpdateIncludeRuleTreeResponse contains data returned by performing PUT /includes/{includeId}/versions/{includeVersion}/rules request
+	UpdateIncludeRuleTreeResponse struct {
+		Response
+		IncludeID      string      `json:"includeId"`
+		IncludeName    string      `json:"includeName"`
+		IncludeType    IncludeType `json:"includeType"`
+		IncludeVersion int         `json:"includeVersion"`
+		RuleFormat     string      `json:"ruleFormat"`
+		Rules          Rules       `json:"rules"`
+		Comments       string      `json:"comments,omitempty"`
+	}
+)
+
+// Validate validates GetIncludeRuleTreeRequest struct
+func (i GetIncludeRuleTreeRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":      validation.Validate(i.IncludeID, validation.Required),
+		"IncludeVersion": validation.Validate(i.IncludeVersion, validation.Required),
+		"ContractID":     validation.Validate(i.ContractID, validation.Required),
+		"GroupID":        validation.Validate(i.GroupID, validation.Required),
+		"ValidateMode":   validation.Validate(i.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"RuleFormat":     validation.Validate(i.RuleFormat, validation.Match(validRuleFormat)),
+	}.Filter()
+}
+
+// Validate validates UpdateIncludeRuleTreeRequest struct
+func (i UpdateIncludeRuleTreeRequest) Validate() error {
+	errs := validation.Errors{
+		"IncludeID":      validation.Validate(i.IncludeID, validation.Required),
+		"IncludeVersion": validation.Validate(i.IncludeVersion, validation.Required),
+		"ContractID":     validation.Validate(i.ContractID, validation.Required),
+		"GroupID":        validation.Validate(i.GroupID, validation.Required),
+		"ValidateMode":   validation.Validate(i.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"Rules":          validation.Validate(i.Rules),
+	}
+	return edgegriderr.ParseValidationErrors(errs)
+}
+
+var (
+	// ErrGetIncludeRuleTree represents error when fetching include rule tree fails
+	ErrGetIncludeRuleTree = errors.New("fetching include rule tree")
+	// ErrUpdateIncludeRuleTree represents error when updating include rule tree fails
+	ErrUpdateIncludeRuleTree = errors.New("updating include rule tree")
+)
+
+func (p *papi) GetIncludeRuleTree(ctx context.Context, params GetIncludeRuleTreeRequest) (*GetIncludeRuleTreeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetIncludeRuleTree, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetIncludeRuleTree")
+
+	getURL := fmt.Sprintf(
+		"/papi/v1/includes/%s/versions/%d/rules?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.IncludeVersion,
+		params.ContractID,
+		params.GroupID,
+	)
+	if params.ValidateMode != "" {
+		getURL += fmt.Sprintf("&validateMode=%s", params.ValidateMode)
+	}
+	if !params.ValidateRules {
+		getURL += fmt.Sprintf("&validateRules=%t", params.ValidateRules)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetIncludeRuleTree, err)
+	}
+
+	if params.RuleFormat != "" {
+		req.Header.Set("Accept", fmt.Sprintf("application/vnd.akamai.papirules.%s+json", params.RuleFormat))
+	}
+
+	var rules GetIncludeRuleTreeResponse
+	resp, err := p.Exec(req, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetIncludeRuleTree, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetIncludeRuleTree, p.Error(resp))
+	}
+
+	return &rules, nil
+}
+
+func (p *papi) UpdateIncludeRuleTree(ctx context.Context, params UpdateIncludeRuleTreeRequest) (*UpdateIncludeRuleTreeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrUpdateIncludeRuleTree, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("UpdateIncludeRuleTree")
+
+	putURL := fmt.Sprintf(
+		"/papi/v1/includes/%s/versions/%d/rules?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.IncludeVersion,
+		params.ContractID,
+		params.GroupID,
+	)
+	if params.ValidateMode != "" {
+		putURL += fmt.Sprintf("&validateMode=%s", params.ValidateMode)
+	}
+	if !params.ValidateRules {
+		putURL += fmt.Sprintf("&validateRules=%t", params.ValidateRules)
+	}
+	if params.DryRun {
+		putURL += fmt.Sprintf("&dryRun=%t", params.DryRun)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, putURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateIncludeRuleTree, err)
+	}
+
+	var rules UpdateIncludeRuleTreeResponse
+	resp, err := p.Exec(req, &rules, params.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateIncludeRuleTree, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateIncludeRuleTree, p.Error(resp))
+	}
+
+	return &rules, nil
+}
@@ -0,0 +1,261 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_GetIncludeRuleTree(t *testing.T) {
+	tests := map[string]struct {
+		request          GetIncludeRuleTreeRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedAccept   string
+		expectedResponse *GetIncludeRuleTreeResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: GetIncludeRuleTreeRequest{
+				IncludeID:      "inc_12345",
+				IncludeVersion: 2,
+				ContractID:     "ctr_1-1TJZFW",
+				GroupID:        "grp_15166",
+				ValidateMode:   RuleValidateModeFast,
+				RuleFormat:     "v2022-06-28",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"includeId": "inc_12345",
+	"includeName": "common-settings",
+	"includeType": "COMMON_SETTINGS",
+	"includeVersion": 2,
+	"etag": "1a2b3c4d",
+	"ruleFormat": "v2022-06-28",
+	"rules": {
+		"name": "default",
+		"children": [
+			{
+				"name": "Compress",
+				"behaviors": [
+					{
+						"name": "gzipResponse",
+						"options": {
+							"behavior": "ALWAYS"
+						}
+					}
+				],
+				"criteriaMustSatisfy": "all"
+			}
+		],
+		"options": {}
+	}
+}`,
+			expectedPath:   "/papi/v1/includes/inc_12345/versions/2/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166&validateMode=fast&validateRules=false",
+			expectedAccept: "application/vnd.akamai.papirules.v2022-06-28+json",
+			expectedResponse: &GetIncludeRuleTreeResponse{
+				Response: Response{
+					AccountID:  "act_1-1TJZFB",
+					ContractID: "ctr_1-1TJZFW",
+					GroupID:    "grp_15166",
+					Etag:       "1a2b3c4d",
+				},
+				IncludeID:      "inc_12345",
+				IncludeName:    "common-settings",
+				IncludeType:    IncludeTypeCommonSettings,
+				IncludeVersion: 2,
+				RuleFormat:     "v2022-06-28",
+				Rules: Rules{
+					Name: "default",
+					Children: []Rules{
+						{
+							Name: "Compress",
+							Behaviors: []RuleBehavior{
+								{
+									Name:    "gzipResponse",
+									Options: RuleOptionsMap{"behavior": "ALWAYS"},
+								},
+							},
+							CriteriaMustSatisfy: RuleCriteriaMustSatisfyAll,
+						},
+					},
+				},
+			},
+		},
+		"500 internal server error": {
+			request: GetIncludeRuleTreeRequest{
+				IncludeID:      "inc_12345",
+				IncludeVersion: 2,
+				ContractID:     "ctr_1-1TJZFW",
+				GroupID:        "grp_15166",
+				ValidateRules:  true,
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching rule tree",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/versions/2/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching rule tree",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - invalid validate mode": {
+			request: GetIncludeRuleTreeRequest{
+				IncludeID:      "inc_12345",
+				IncludeVersion: 2,
+				ContractID:     "ctr_1-1TJZFW",
+				GroupID:        "grp_15166",
+				ValidateMode:   "slow",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				if test.expectedAccept != "" {
+					assert.Equal(t, test.expectedAccept, r.Header.Get("Accept"))
+				}
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetIncludeRuleTree(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_UpdateIncludeRuleTree(t *testing.T) {
+	tests := map[string]struct {
+		request             UpdateIncludeRuleTreeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *UpdateIncludeRuleTreeResponse
+		withError           error
+	}{
+		"200 OK": {
+			request: UpdateIncludeRuleTreeRequest{
+				IncludeID:      "inc_12345",
+				IncludeVersion: 2,
+				ContractID:     "ctr_1-1TJZFW",
+				GroupID:        "grp_15166",
+				DryRun:         true,
+				ValidateRules:  true,
+				Rules: RulesUpdate{
+					Comments: "add caching",
+					Rules: Rules{
+						Name: "default",
+						Behaviors: []RuleBehavior{
+							{
+								Name:    "caching",
+								Options: RuleOptionsMap{"behavior": "NO_STORE"},
+							},
+						},
+					},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"includeId": "inc_12345",
+	"includeVersion": 2,
+	"includeType": "MICROSERVICES",
+	"etag": "5e6f7a8b",
+	"ruleFormat": "v2022-06-28",
+	"comments": "add caching",
+	"rules": {
+		"name": "default",
+		"behaviors": [
+			{
+				"name": "caching",
+				"options": {
+					"behavior": "NO_STORE"
+				}
+			}
+		]
+	}
+}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/versions/2/rules?contractId=ctr_1-1TJZFW&dryRun=true&groupId=grp_15166",
+			expectedRequestBody: `{"comments":"add caching","rules":{"behaviors":[{"name":"caching","options":{"behavior":"NO_STORE"}}],"name":"default","options":{}}}`,
+			expectedResponse: &UpdateIncludeRuleTreeResponse{
+				Response:       Response{Etag: "5e6f7a8b"},
+				IncludeID:      "inc_12345",
+				IncludeType:    IncludeTypeMicroServices,
+				IncludeVersion: 2,
+				RuleFormat:     "v2022-06-28",
+				Comments:       "add caching",
+				Rules: Rules{
+					Name: "default",
+					Behaviors: []RuleBehavior{
+						{
+							Name:    "caching",
+							Options: RuleOptionsMap{"behavior": "NO_STORE"},
+						},
+					},
+				},
+			},
+		},
+		"validation error - missing rule name": {
+			request: UpdateIncludeRuleTreeRequest{
+				IncludeID:      "inc_12345",
+				IncludeVersion: 2,
+				ContractID:     "ctr_1-1TJZFW",
+				GroupID:        "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateIncludeRuleTree(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,524 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_ListIncludes(t *testing.T) {
+	tests := map[string]struct {
+		request          ListIncludesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListIncludesResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: ListIncludesRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"includes": {
+		"items": [
+			{
+				"accountId": "act_1-1TJZFB",
+				"contractId": "ctr_1-1TJZFW",
+				"groupId": "grp_15166",
+				"latestVersion": 3,
+				"stagingVersion": 2,
+				"productionVersion": null,
+				"assetId": "aid_101",
+				"includeId": "inc_12345",
+				"includeName": "common-settings",
+				"includeType": "COMMON_SETTINGS"
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &ListIncludesResponse{
+				Includes: IncludeItems{Items: []Include{
+					{
+						AccountID:      "act_1-1TJZFB",
+						AssetID:        "aid_101",
+						ContractID:     "ctr_1-1TJZFW",
+						GroupID:        "grp_15166",
+						IncludeID:      "inc_12345",
+						IncludeName:    "common-settings",
+						IncludeType:    IncludeTypeCommonSettings,
+						LatestVersion:  3,
+						StagingVersion: tools.IntPtr(2),
+					},
+				}},
+			},
+		},
+		"500 internal server error": {
+			request: ListIncludesRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching includes",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/includes?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching includes",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing group": {
+			request: ListIncludesRequest{
+				ContractID: "ctr_1-1TJZFW",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListIncludes(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_ListIncludeParents(t *testing.T) {
+	tests := map[string]struct {
+		request          ListIncludeParentsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListIncludeParentsResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: ListIncludeParentsRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"properties": {
+		"items": [
+			{
+				"accountId": "act_1-1TJZFB",
+				"contractId": "ctr_1-1TJZFW",
+				"groupId": "grp_15166",
+				"propertyId": "prp_175780",
+				"propertyName": "example.com",
+				"stagingVersion": 4,
+				"productionVersion": 3,
+				"assetId": "aid_101",
+				"isIncludeUsedInStagingVersion": true,
+				"isIncludeUsedInProductionVersion": false
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/parents?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &ListIncludeParentsResponse{
+				Properties: ParentPropertyItems{Items: []ParentProperty{
+					{
+						AccountID:                     "act_1-1TJZFB",
+						AssetID:                       "aid_101",
+						ContractID:                    "ctr_1-1TJZFW",
+						GroupID:                       "grp_15166",
+						PropertyID:                    "prp_175780",
+						PropertyName:                  "example.com",
+						ProductionVersion:             tools.IntPtr(3),
+						StagingVersion:                tools.IntPtr(4),
+						IsIncludeUsedInStagingVersion: true,
+					},
+				}},
+			},
+		},
+		"validation error - missing include id": {
+			request: ListIncludeParentsRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListIncludeParents(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetInclude(t *testing.T) {
+	tests := map[string]struct {
+		request          GetIncludeRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *GetIncludeResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: GetIncludeRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"includes": {
+		"items": [
+			{
+				"accountId": "act_1-1TJZFB",
+				"contractId": "ctr_1-1TJZFW",
+				"groupId": "grp_15166",
+				"latestVersion": 1,
+				"stagingVersion": null,
+				"productionVersion": null,
+				"assetId": "aid_101",
+				"includeId": "inc_12345",
+				"includeName": "microservice",
+				"includeType": "MICROSERVICES"
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &GetIncludeResponse{
+				Includes: IncludeItems{Items: []Include{
+					{
+						AccountID:     "act_1-1TJZFB",
+						AssetID:       "aid_101",
+						ContractID:    "ctr_1-1TJZFW",
+						GroupID:       "grp_15166",
+						IncludeID:     "inc_12345",
+						IncludeName:   "microservice",
+						IncludeType:   IncludeTypeMicroServices,
+						LatestVersion: 1,
+					},
+				}},
+				Include: Include{
+					AccountID:     "act_1-1TJZFB",
+					AssetID:       "aid_101",
+					ContractID:    "ctr_1-1TJZFW",
+					GroupID:       "grp_15166",
+					IncludeID:     "inc_12345",
+					IncludeName:   "microservice",
+					IncludeType:   IncludeTypeMicroServices,
+					LatestVersion: 1,
+				},
+			},
+		},
+		"include not found": {
+			request: GetIncludeRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"includes": {"items": []}}`,
+			expectedPath:   "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError:      ErrNotFound,
+		},
+		"404 not found": {
+			request: GetIncludeRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "not_found",
+    "title": "Not Found",
+    "detail": "Include not found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				Detail:     "Include not found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing contract": {
+			request: GetIncludeRequest{
+				IncludeID: "inc_12345",
+				GroupID:   "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetInclude(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_CreateInclude(t *testing.T) {
+	tests := map[string]struct {
+		request             CreateIncludeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *CreateIncludeResponse
+		withError           error
+	}{
+		"201 created": {
+			request: CreateIncludeRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Include: IncludeCreate{
+					IncludeName: "common-settings",
+					IncludeType: IncludeTypeCommonSettings,
+					ProductID:   "prd_Fresca",
+					RuleFormat:  "v2022-06-28",
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"includeLink": "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/includes?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"includeName":"common-settings","includeType":"COMMON_SETTINGS","productId":"prd_Fresca","ruleFormat":"v2022-06-28"}`,
+			expectedResponse: &CreateIncludeResponse{
+				IncludeID:   "inc_12345",
+				IncludeLink: "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"201 created from clone": {
+			request: CreateIncludeRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Include: IncludeCreate{
+					IncludeName: "common-settings-copy",
+					IncludeType: IncludeTypeCommonSettings,
+					ProductID:   "prd_Fresca",
+					CloneFrom: &IncludeCloneFrom{
+						IncludeID: "inc_12345",
+						Version:   2,
+					},
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"includeLink": "/papi/v1/includes/inc_67890?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/includes?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"includeName":"common-settings-copy","includeType":"COMMON_SETTINGS","productId":"prd_Fresca","cloneFrom":{"includeId":"inc_12345","version":2}}`,
+			expectedResponse: &CreateIncludeResponse{
+				IncludeID:   "inc_67890",
+				IncludeLink: "/papi/v1/includes/inc_67890?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"500 internal server error": {
+			request: CreateIncludeRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Include: IncludeCreate{
+					IncludeName: "common-settings",
+					IncludeType: IncludeTypeCommonSettings,
+					ProductID:   "prd_Fresca",
+				},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error creating include",
+    "status": 500
+}`,
+			expectedPath:        "/papi/v1/includes?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"includeName":"common-settings","includeType":"COMMON_SETTINGS","productId":"prd_Fresca"}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating include",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - invalid include type": {
+			request: CreateIncludeRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Include: IncludeCreate{
+					IncludeName: "common-settings",
+					IncludeType: "SHARED",
+					ProductID:   "prd_Fresca",
+				},
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid rule format": {
+			request: CreateIncludeRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Include: IncludeCreate{
+					IncludeName: "common-settings",
+					IncludeType: IncludeTypeMicroServices,
+					ProductID:   "prd_Fresca",
+					RuleFormat:  "2022-06-28",
+				},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateInclude(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_DeleteInclude(t *testing.T) {
+	tests := map[string]struct {
+		request          DeleteIncludeRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *DeleteIncludeResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: DeleteIncludeRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"message": "Deletion Successful."}`,
+			expectedPath:     "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &DeleteIncludeResponse{Message: "Deletion Successful."},
+		},
+		"403 include is active": {
+			request: DeleteIncludeRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusForbidden,
+			responseBody: `
+{
+	"type": "forbidden",
+    "title": "Forbidden",
+    "detail": "Include is active",
+    "status": 403
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError: &Error{
+				Type:       "forbidden",
+				Title:      "Forbidden",
+				Detail:     "Include is active",
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		"validation error - missing include id": {
+			request: DeleteIncludeRequest{
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.DeleteInclude(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
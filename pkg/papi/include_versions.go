@@ -0,0 +1,278 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// IncludeVersions contains operations available on IncludeVersion resource
+	// See: https://techdocs.akamai.com/property-mgr/reference/include-versions
+	IncludeVersions interface {
+		// CreateIncludeVersion creates a new include version based on an existing version
+		// See: https://techdocs.akamai.com/property-mgr/reference/post-include-versions
+		CreateIncludeVersion(context.Context, CreateIncludeVersionRequest) (*CreateIncludeVersionResponse, error)
+
+		// GetIncludeVersion fetches a specific include version
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-version
+		GetIncludeVersion(context.Context, GetIncludeVersionRequest) (*GetIncludeVersionResponse, error)
+
+		// ListIncludeVersions lists the include versions
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-include-versions
+		ListIncludeVersions(context.Context, ListIncludeVersionsRequest) (*ListIncludeVersionsResponse, error)
+	}
+
+	// IncludeVersion contains information about a specific include version
+	IncludeVersion struct {
+		Etag             string        `json:"etag"`
+		IncludeVersion   int           `json:"includeVersion"`
+		Note             string        `json:"note"`
+		ProductID        string        `json:"productId"`
+		ProductionStatus VersionStatus `json:"productionStatus"`
+		RuleFormat       string        `json:"ruleFormat"`
+		StagingStatus    VersionStatus `json:"stagingStatus"`
+		UpdatedByUser    string        `json:"updatedByUser"`
+		UpdatedDate      string        `json:"updatedDate"`
+	}
+
+	// IncludeVersionItems is an array of include versions
+	IncludeVersionItems struct {
+		Items []IncludeVersion `json:"items"`
+	}
+
+	// IncludeVersionsResponse contains fields common to responses returning include versions
+	IncludeVersionsResponse struct {
+		AccountID       string              `json:"accountId"`
+		AssetID         string              `json:"assetId"`
+		ContractID      string              `json:"contractId"`
+		GroupID         string              `json:"groupId"`
+		IncludeID       string              `json:"includeId"`
+		IncludeName     string              `json:"includeName"`
+		IncludeType     IncludeType         `json:"includeType"`
+		IncludeVersions IncludeVersionItems `json:"versions"`
+	}
+
+	// CreateIncludeVersionRequest contains path and query params, as well as request body used to create an include version
+	CreateIncludeVersionRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+		Version    IncludeVersionCreate
+	}
+
+	// IncludeVersionCreate contains request body used in POST /includes/{includeId}/versions request
+	IncludeVersionCreate struct {
+		CreateFromVersion     int    `json:"createFromVersion"`
+		CreateFromVersionEtag string `json:"createFromVersionEtag,omitempty"`
+	}
+
+	// CreateIncludeVersionResponse contains a link to the created include version and its version number
+	CreateIncludeVersionResponse struct {
+		VersionLink string `json:"versionLink"`
+		Version     int    `json:"-"`
+	}
+
+	// GetIncludeVersionRequest contains path and query params used to fetch an include version
+	GetIncludeVersionRequest struct {
+		IncludeID  string
+		Version    int
+		ContractID string
+		GroupID    string
+	}
+
+	// GetIncludeVersionResponse contains the response returned by GetIncludeVersion
+	GetIncludeVersionResponse struct {
+		IncludeVersionsResponse
+		IncludeVersion IncludeVersion `json:"-"`
+	}
+
+	// ListIncludeVersionsRequest contains path and query params used to list include versions
+	ListIncludeVersionsRequest struct {
+		IncludeID  string
+		ContractID string
+		GroupID    string
+		Limit      int
+		Offset     int
+	}
+
+	// ListIncludeVersionsResponse contains the response returned by ListIncludeVersions
+	ListIncludeVersionsResponse struct {
+		IncludeVersionsResponse
+	}
+)
+
+// Validate validates CreateIncludeVersionRequest
+func (i CreateIncludeVersionRequest) Validate() error {
+	errs := validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+		"Version":    validation.Validate(i.Version),
+	}
+	return edgegriderr.ParseValidationErrors(errs)
+}
+
+// Validate validates IncludeVersionCreate
+func (i IncludeVersionCreate) Validate() error {
+	return validation.Errors{
+		"CreateFromVersion": validation.Validate(i.CreateFromVersion, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetIncludeVersionRequest
+func (i GetIncludeVersionRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"Version":    validation.Validate(i.Version, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ListIncludeVersionsRequest
+func (i ListIncludeVersionsRequest) Validate() error {
+	return validation.Errors{
+		"IncludeID":  validation.Validate(i.IncludeID, validation.Required),
+		"ContractID": validation.Validate(i.ContractID, validation.Required),
+		"GroupID":    validation.Validate(i.GroupID, validation.Required),
+		"Limit":      validation.Validate(i.Limit, validation.Min(0)),
+		"Offset":     validation.Validate(i.Offset, validation.Min(0)),
+	}.Filter()
+}
+
+var (
+	// ErrCreateIncludeVersion represents error when creating include version fails
+	ErrCreateIncludeVersion = errors.New("creating include version")
+	// ErrGetIncludeVersion represents error when fetching include version fails
+	ErrGetIncludeVersion = errors.New("fetching include version")
+	// ErrListIncludeVersions represents error when listing include versions fails
+	ErrListIncludeVersions = errors.New("listing include versions")
+)
+
+func (p *papi) CreateIncludeVersion(ctx context.Context, params CreateIncludeVersionRequest) (*CreateIncludeVersionResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreateIncludeVersion, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("CreateIncludeVersion")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s/versions?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateIncludeVersion, err)
+	}
+
+	var rval CreateIncludeVersionResponse
+	resp, err := p.Exec(req, &rval, params.Version)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateIncludeVersion, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateIncludeVersion, p.Error(resp))
+	}
+
+	version, err := ResponseLinkParse(rval.VersionLink)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateIncludeVersion, ErrInvalidResponseLink, err)
+	}
+	versionNumber, err := strconv.Atoi(version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w: %s: %s", ErrCreateIncludeVersion, ErrInvalidResponseLink, "version should be a number", version)
+	}
+	rval.Version = versionNumber
+
+	return &rval, nil
+}
+
+func (p *papi) GetIncludeVersion(ctx context.Context, params GetIncludeVersionRequest) (*GetIncludeVersionResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetIncludeVersion, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetIncludeVersion")
+
+	uri := fmt.Sprintf(
+		"/papi/v1/includes/%s/versions/%d?contractId=%s&groupId=%s",
+		params.IncludeID,
+		params.Version,
+		params.ContractID,
+		params.GroupID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetIncludeVersion, err)
+	}
+
+	var rval GetIncludeVersionResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetIncludeVersion, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetIncludeVersion, p.Error(resp))
+	}
+
+	if len(rval.IncludeVersions.Items) == 0 {
+		return nil, fmt.Errorf("%s: %w: Version: %d", ErrGetIncludeVersion, ErrNotFound, params.Version)
+	}
+	rval.IncludeVersion = rval.IncludeVersions.Items[0]
+
+	return &rval, nil
+}
+
+func (p *papi) ListIncludeVersions(ctx context.Context, params ListIncludeVersionsRequest) (*ListIncludeVersionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListIncludeVersions, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ListIncludeVersions")
+
+	uri, err := url.Parse(fmt.Sprintf("/papi/v1/includes/%s/versions", params.IncludeID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListIncludeVersions, err)
+	}
+	q := uri.Query()
+	q.Add("contractId", params.ContractID)
+	q.Add("groupId", params.GroupID)
+	if params.Limit != 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Offset != 0 {
+		q.Add("offset", strconv.Itoa(params.Offset))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListIncludeVersions, err)
+	}
+
+	var rval ListIncludeVersionsResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListIncludeVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListIncludeVersions, p.Error(resp))
+	}
+
+	return &rval, nil
+}
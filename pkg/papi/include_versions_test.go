@@ -0,0 +1,331 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_CreateIncludeVersion(t *testing.T) {
+	tests := map[string]struct {
+		request             CreateIncludeVersionRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *CreateIncludeVersionResponse
+		withError           error
+	}{
+		"201 created": {
+			request: CreateIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Version: IncludeVersionCreate{
+					CreateFromVersion:     2,
+					CreateFromVersionEtag: "1a2b3c4d",
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"versionLink": "/papi/v1/includes/inc_12345/versions/3?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/versions?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"createFromVersion":2,"createFromVersionEtag":"1a2b3c4d"}`,
+			expectedResponse: &CreateIncludeVersionResponse{
+				VersionLink: "/papi/v1/includes/inc_12345/versions/3?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+				Version:     3,
+			},
+		},
+		"invalid version link": {
+			request: CreateIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Version:    IncludeVersionCreate{CreateFromVersion: 2},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"versionLink": "/papi/v1/includes/inc_12345/versions/abc"}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/versions?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"createFromVersion":2}`,
+			withError:           ErrInvalidResponseLink,
+		},
+		"500 internal server error": {
+			request: CreateIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Version:    IncludeVersionCreate{CreateFromVersion: 2},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error creating include version",
+    "status": 500
+}`,
+			expectedPath:        "/papi/v1/includes/inc_12345/versions?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"createFromVersion":2}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating include version",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - missing create from version": {
+			request: CreateIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateIncludeVersion(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetIncludeVersion(t *testing.T) {
+	includeVersion := IncludeVersion{
+		Etag:             "1a2b3c4d",
+		IncludeVersion:   2,
+		Note:             "initial",
+		ProductID:        "prd_Fresca",
+		ProductionStatus: VersionStatusInactive,
+		RuleFormat:       "v2022-06-28",
+		StagingStatus:    VersionStatusActive,
+		UpdatedByUser:    "jsmith",
+		UpdatedDate:      "2022-10-10T12:00:00Z",
+	}
+	tests := map[string]struct {
+		request          GetIncludeVersionRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *GetIncludeVersionResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: GetIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				Version:    2,
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"accountId": "act_1-1TJZFB",
+	"assetId": "aid_101",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"includeId": "inc_12345",
+	"includeName": "common-settings",
+	"includeType": "COMMON_SETTINGS",
+	"versions": {
+		"items": [
+			{
+				"etag": "1a2b3c4d",
+				"includeVersion": 2,
+				"note": "initial",
+				"productId": "prd_Fresca",
+				"productionStatus": "INACTIVE",
+				"ruleFormat": "v2022-06-28",
+				"stagingStatus": "ACTIVE",
+				"updatedByUser": "jsmith",
+				"updatedDate": "2022-10-10T12:00:00Z"
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/versions/2?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedResponse: &GetIncludeVersionResponse{
+				IncludeVersionsResponse: IncludeVersionsResponse{
+					AccountID:       "act_1-1TJZFB",
+					AssetID:         "aid_101",
+					ContractID:      "ctr_1-1TJZFW",
+					GroupID:         "grp_15166",
+					IncludeID:       "inc_12345",
+					IncludeName:     "common-settings",
+					IncludeType:     IncludeTypeCommonSettings,
+					IncludeVersions: IncludeVersionItems{Items: []IncludeVersion{includeVersion}},
+				},
+				IncludeVersion: includeVersion,
+			},
+		},
+		"version not found": {
+			request: GetIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				Version:    2,
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"includeId": "inc_12345", "versions": {"items": []}}`,
+			expectedPath:   "/papi/v1/includes/inc_12345/versions/2?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError:      ErrNotFound,
+		},
+		"validation error - missing version": {
+			request: GetIncludeVersionRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetIncludeVersion(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_ListIncludeVersions(t *testing.T) {
+	tests := map[string]struct {
+		request          ListIncludeVersionsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListIncludeVersionsResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: ListIncludeVersionsRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Limit:      2,
+				Offset:     1,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"includeId": "inc_12345",
+	"includeName": "common-settings",
+	"includeType": "COMMON_SETTINGS",
+	"versions": {
+		"items": [
+			{
+				"etag": "a",
+				"includeVersion": 2,
+				"productionStatus": "INACTIVE",
+				"stagingStatus": "INACTIVE"
+			},
+			{
+				"etag": "b",
+				"includeVersion": 1,
+				"productionStatus": "ACTIVE",
+				"stagingStatus": "ACTIVE"
+			}
+		]
+	}
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/versions?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=2&offset=1",
+			expectedResponse: &ListIncludeVersionsResponse{
+				IncludeVersionsResponse: IncludeVersionsResponse{
+					IncludeID:   "inc_12345",
+					IncludeName: "common-settings",
+					IncludeType: IncludeTypeCommonSettings,
+					IncludeVersions: IncludeVersionItems{Items: []IncludeVersion{
+						{Etag: "a", IncludeVersion: 2, ProductionStatus: VersionStatusInactive, StagingStatus: VersionStatusInactive},
+						{Etag: "b", IncludeVersion: 1, ProductionStatus: VersionStatusActive, StagingStatus: VersionStatusActive},
+					}},
+				},
+			},
+		},
+		"500 internal server error": {
+			request: ListIncludeVersionsRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching include versions",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/includes/inc_12345/versions?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching include versions",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - negative offset": {
+			request: ListIncludeVersionsRequest{
+				IncludeID:  "inc_12345",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Offset:     -1,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListIncludeVersions(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
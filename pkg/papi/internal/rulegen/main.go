@@ -0,0 +1,428 @@
+// Package main implements generator of typed PAPI behaviors and criteria.
+//
+// It reads a rule format JSON schema, as returned by PAPI for a given product and rule format,
+// and writes Go structs representing options of every behavior and criterion from the schema catalog.
+// With -fetch-product the schema is first downloaded from PAPI using edgerc credentials.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+type (
+	schema struct {
+		Type        interface{}        `json:"type"`
+		Ref         string             `json:"$ref"`
+		Enum        []interface{}      `json:"enum"`
+		Items       *schema            `json:"items"`
+		Properties  map[string]*schema `json:"properties"`
+		Definitions map[string]*schema `json:"definitions"`
+		Behaviors   map[string]*schema `json:"behaviors"`
+		Criteria    map[string]*schema `json:"criteria"`
+	}
+
+	generator struct {
+		definitions map[string]*schema
+		types       map[string]string
+		enums       map[string][]string
+	}
+
+	typeKind struct {
+		prefix   string
+		method   string
+		registry string
+		iface    string
+		kindName string
+	}
+)
+
+var (
+	behaviorKind = typeKind{
+		prefix:   "Behavior",
+		method:   "BehaviorName",
+		registry: "generatedBehaviors",
+		iface:    "TypedBehavior",
+		kindName: "behavior",
+	}
+	criterionKind = typeKind{
+		prefix:   "Criterion",
+		method:   "CriterionName",
+		registry: "generatedCriteria",
+		iface:    "TypedCriterion",
+		kindName: "criterion",
+	}
+
+	errInvalidSchema = errors.New("invalid rule format schema")
+)
+
+func main() {
+	schemaFile := flag.String("schema", "", "path to rule format JSON schema")
+	ruleFormat := flag.String("rule-format", "", "rule format the schema describes, e.g. v2022-10-18")
+	out := flag.String("out", "", "output file, standard output if empty")
+	pkg := flag.String("package", "papi", "package name of generated file")
+	product := flag.String("fetch-product", "", "if set, the schema of this product is fetched from PAPI and saved to -schema before generating")
+	edgerc := flag.String("edgerc", edgegrid.DefaultConfigFile, "edgerc file with PAPI credentials used with -fetch-product")
+	section := flag.String("section", edgegrid.DefaultSection, "edgerc section used with -fetch-product")
+	flag.Parse()
+
+	if *schemaFile == "" || *ruleFormat == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *product != "" {
+		if err := fetchSchema(*edgerc, *section, *product, *ruleFormat, *schemaFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	b, err := os.ReadFile(*schemaFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	code, err := generate(b, *ruleFormat, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		if _, err := os.Stdout.Write(code); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := os.WriteFile(*out, code, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fetchSchema saves the complete rule format schema served by PAPI for the product to the file
+func fetchSchema(edgerc, section, product, ruleFormat, file string) error {
+	config, err := edgegrid.New(edgegrid.WithFile(edgerc), edgegrid.WithSection(section))
+	if err != nil {
+		return err
+	}
+	sess, err := session.New(session.WithSigner(config))
+	if err != nil {
+		return err
+	}
+	schema, err := papi.Client(sess).GetRuleFormatSchema(context.Background(), papi.GetRuleFormatSchemaRequest{
+		ProductID:  product,
+		RuleFormat: ruleFormat,
+	})
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(b, '\n'), 0644)
+}
+
+// generate returns formatted source of typed behaviors and criteria described by schemaJSON
+func generate(schemaJSON []byte, ruleFormat, pkg string) ([]byte, error) {
+	var root schema
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidSchema, err)
+	}
+	catalog, ok := root.Definitions["catalog"]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing definitions.catalog", errInvalidSchema)
+	}
+
+	g := &generator{
+		definitions: root.Definitions,
+		types:       make(map[string]string),
+		enums:       make(map[string][]string),
+	}
+	behaviors, err := g.catalogTypes(behaviorKind, catalog.Behaviors)
+	if err != nil {
+		return nil, err
+	}
+	criteria, err := g.catalogTypes(criterionKind, catalog.Criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by rulegen from rule format %s schema; DO NOT EDIT.\n\n", ruleFormat)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	buf.WriteString("type (\n")
+	for _, name := range sortedKeys(g.types) {
+		buf.WriteString(g.types[name])
+		buf.WriteString("\n")
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("const (\n")
+	buf.WriteString("// GeneratedRuleFormat is the rule format typed behaviors and criteria were generated from\n")
+	fmt.Fprintf(&buf, "GeneratedRuleFormat = %q\n", ruleFormat)
+	for _, name := range sortedKeys(g.enums) {
+		buf.WriteString("\n")
+		for _, line := range g.enums[name] {
+			buf.WriteString(line)
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	writeRegistry(&buf, behaviorKind, behaviors)
+	writeRegistry(&buf, criterionKind, criteria)
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %s", err)
+	}
+	return code, nil
+}
+
+// catalogTypes generates struct for options of every catalog entry and returns mapping of entry names to type names
+func (g *generator) catalogTypes(kind typeKind, entries map[string]*schema) (map[string]string, error) {
+	names := make(map[string]string, len(entries))
+	for _, name := range sortedKeys(entries) {
+		typeName := kind.prefix + exportedName(name)
+		if _, ok := g.types[typeName]; ok {
+			return nil, fmt.Errorf("%w: duplicated type %s", errInvalidSchema, typeName)
+		}
+		options := &schema{}
+		if props := entries[name].Properties; props != nil && props["options"] != nil {
+			options = props["options"]
+		}
+		if err := g.structType(typeName, fmt.Sprintf("options of %s %s", name, kind.kindName), options); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", kind.kindName, name, err)
+		}
+		names[name] = typeName
+	}
+	return names, nil
+}
+
+// structType generates struct typeName with a field for every property of s
+// description tells what the struct represents and is used in doc comments of the struct and its nested types
+func (g *generator) structType(typeName, description string, s *schema) error {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "// %s represents %s\n%s struct {\n", typeName, description, typeName)
+	owner := strings.TrimPrefix(description, "options of ")
+	for _, name := range sortedKeys(s.Properties) {
+		fieldType, err := g.fieldType(typeName+exportedName(name), fmt.Sprintf("%s option of %s", name, owner), s.Properties[name])
+		if err != nil {
+			return fmt.Errorf("option %s: %w", name, err)
+		}
+		fmt.Fprintf(&buf, "%s %s `json:\"%s,omitempty\"`\n", exportedName(name), fieldType, name)
+	}
+	buf.WriteString("}\n")
+	g.types[typeName] = buf.String()
+	return nil
+}
+
+func (g *generator) fieldType(typeName, description string, s *schema) (string, error) {
+	elem, err := g.valueType(typeName, description, s)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(elem, "[]") || strings.HasPrefix(elem, "map[") || elem == "interface{}" {
+		return elem, nil
+	}
+	return "*" + elem, nil
+}
+
+func (g *generator) valueType(typeName, description string, s *schema) (string, error) {
+	if s.Ref != "" {
+		return g.refType(s.Ref)
+	}
+	switch schemaType(s) {
+	case "string":
+		if len(s.Enum) > 0 {
+			return g.enumType(typeName, description, s.Enum)
+		}
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}", nil
+		}
+		item, err := g.valueType(typeName, "items of "+description, s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}", nil
+		}
+		if _, ok := g.types[typeName]; !ok {
+			if err := g.structType(typeName, description, s); err != nil {
+				return "", err
+			}
+		}
+		return typeName, nil
+	}
+	return "interface{}", nil
+}
+
+func (g *generator) refType(ref string) (string, error) {
+	name := strings.TrimPrefix(ref, "#/definitions/")
+	definition, ok := g.definitions[name]
+	if !ok || name == ref {
+		return "", fmt.Errorf("%w: unresolved reference %s", errInvalidSchema, ref)
+	}
+	typeName := "RuleFormat" + exportedName(name)
+	if _, ok := g.types[typeName]; ok {
+		return typeName, nil
+	}
+	if schemaType(definition) != "object" || len(definition.Properties) == 0 {
+		return g.valueType(typeName, name+" definition of the rule format", definition)
+	}
+	// reserve the name before generating fields so that recursive definitions terminate
+	g.types[typeName] = ""
+	if err := g.structType(typeName, name+" definition of the rule format", definition); err != nil {
+		return "", err
+	}
+	return typeName, nil
+}
+
+func (g *generator) enumType(typeName, description string, values []interface{}) (string, error) {
+	if _, ok := g.enums[typeName]; ok {
+		return typeName, nil
+	}
+	lines := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		v, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: non-string value %v of string enum", errInvalidSchema, value)
+		}
+		suffix := exportedName(v)
+		if suffix == "" {
+			continue
+		}
+		constName := typeName + suffix
+		if seen[constName] {
+			return "", fmt.Errorf("%w: enum values of %s map to the same constant %s", errInvalidSchema, typeName, constName)
+		}
+		seen[constName] = true
+		lines = append(lines, fmt.Sprintf("// %s const\n%s %s = %q\n", constName, constName, typeName, v))
+	}
+	g.enums[typeName] = lines
+	g.types[typeName] = fmt.Sprintf("// %s represents values of %s\n%s string\n", typeName, description, typeName)
+	return typeName, nil
+}
+
+func writeRegistry(buf *bytes.Buffer, kind typeKind, names map[string]string) {
+	for _, name := range sortedKeys(names) {
+		fmt.Fprintf(buf, "// %s returns name of %s %s\n", kind.method, name, kind.kindName)
+		fmt.Fprintf(buf, "func (%s) %s() string {\nreturn %q\n}\n\n", names[name], kind.method, name)
+	}
+	fmt.Fprintf(buf, "var %s = map[string]func() %s{\n", kind.registry, kind.iface)
+	for _, name := range sortedKeys(names) {
+		fmt.Fprintf(buf, "%q: func() %s { return &%s{} },\n", name, kind.iface, names[name])
+	}
+	buf.WriteString("}\n\n")
+}
+
+func schemaType(s *schema) string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []interface{}:
+		// nullable types are described as ["string", "null"]
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// initialisms maps lower case words to their spelling in Go identifiers
+var initialisms = map[string]string{
+	"acl": "ACL", "api": "API", "ascii": "ASCII", "cdn": "CDN", "cors": "CORS", "cp": "CP", "cpcode": "CPCode",
+	"cpu": "CPU", "css": "CSS", "dns": "DNS", "eof": "EOF", "esi": "ESI", "guid": "GUID", "html": "HTML",
+	"http": "HTTP", "http2": "HTTP2", "https": "HTTPS", "id": "ID", "ip": "IP", "ipv4": "IPV4", "ipv6": "IPV6",
+	"json": "JSON", "rpc": "RPC", "sla": "SLA", "smtp": "SMTP", "sni": "SNI", "sql": "SQL", "ssh": "SSH",
+	"ssl": "SSL", "tcp": "TCP", "tls": "TLS", "ttl": "TTL", "udp": "UDP", "ui": "UI", "uid": "UID", "uri": "URI",
+	"url": "URL", "utf8": "UTF8", "uuid": "UUID", "vm": "VM", "xml": "XML", "xsrf": "XSRF", "xss": "XSS",
+}
+
+// exportedName converts schema names such as "cpCode" or "MAX_AGE" into exported Go identifiers,
+// spelling initialisms in upper case, e.g. "defaultTtl" becomes "DefaultTTL"
+func exportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		for _, word := range splitWords(part) {
+			lower := strings.ToLower(word)
+			if initialism, ok := initialisms[lower]; ok {
+				b.WriteString(initialism)
+				continue
+			}
+			if strings.ToUpper(word) == word {
+				word = lower
+			}
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+	}
+	result := b.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "V" + result
+	}
+	return result
+}
+
+// splitWords splits camel case name into words, keeping upper case names such as "MAX" and runs of
+// upper case letters such as "HTTPS" in "allowHTTPSDowngrade" as single words
+func splitWords(name string) []string {
+	if strings.ToUpper(name) == name {
+		return []string{name}
+	}
+	runes := []rune(name)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		lowerBefore := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+		acronymEnd := unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if unicode.IsUpper(runes[i]) && (lowerBefore || acronymEnd) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
+
+// sortedKeys returns sorted keys of a map with string keys so that generated code is deterministic
+func sortedKeys(m interface{}) []string {
+	value := reflect.ValueOf(m)
+	keys := make([]string, 0, value.Len())
+	for _, k := range value.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	schema, err := os.ReadFile("schemas/v2022-10-18.json")
+	require.NoError(t, err)
+	expected, err := os.ReadFile("../../rule_format.gen.go")
+	require.NoError(t, err)
+
+	code, err := generate(schema, "v2022-10-18", "papi")
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(code), "rule_format.gen.go is outdated, run make generate-papi-rule-format")
+}
+
+func TestGenerate(t *testing.T) {
+	tests := map[string]struct {
+		schema      string
+		contains    []string
+		notContains []string
+		withError   error
+	}{
+		"nested and referenced types": {
+			schema: `{"definitions": {
+				"catalog": {
+					"behaviors": {"test": {"properties": {"options": {"properties": {
+						"nested": {"type": "object", "properties": {"count": {"type": ["integer", "null"]}}},
+						"ref": {"$ref": "#/definitions/shared"},
+						"mode": {"type": "string", "enum": ["ONE_TWO", "three"]},
+						"list": {"type": "array", "items": {"type": "number"}}
+					}}}}},
+					"criteria": {"empty": {"properties": {"options": {}}}}
+				},
+				"shared": {"type": "object", "properties": {"id": {"type": "integer"}}}
+			}}`,
+			contains: []string{
+				"// BehaviorTest represents options of test behavior\n",
+				"BehaviorTest struct",
+				"// BehaviorTestNested represents nested option of test behavior\n",
+				"// BehaviorTestMode represents values of mode option of test behavior\n",
+				"// RuleFormatShared represents shared definition of the rule format\n",
+				"List   []float64",
+				"Nested *BehaviorTestNested",
+				"Count *int",
+				"Ref    *RuleFormatShared",
+				"BehaviorTestModeOneTwo BehaviorTestMode = \"ONE_TWO\"",
+				"BehaviorTestModeThree BehaviorTestMode = \"three\"",
+				"CriterionEmpty struct",
+				"\"test\": func() TypedBehavior { return &BehaviorTest{} }",
+				"\"empty\": func() TypedCriterion { return &CriterionEmpty{} }",
+			},
+			notContains: []string{" ...\n"},
+		},
+		"missing catalog": {
+			schema:    `{"definitions": {}}`,
+			withError: errInvalidSchema,
+		},
+		"unresolved reference": {
+			schema: `{"definitions": {"catalog": {"behaviors": {"test": {"properties": {"options": {"properties": {
+				"ref": {"$ref": "#/definitions/missing"}
+			}}}}}}}}`,
+			withError: errInvalidSchema,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, err := generate([]byte(test.schema), "v2022-10-18", "papi")
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range test.contains {
+				assert.Contains(t, string(code), s)
+			}
+			for _, s := range test.notContains {
+				assert.NotContains(t, string(code), s)
+			}
+		})
+	}
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"cpCode":              "CPCode",
+		"cpcode":              "CPCode",
+		"defaultTtl":          "DefaultTTL",
+		"MAX_AGE":             "MaxAge",
+		"http2":               "HTTP2",
+		"IPV4":                "IPV4",
+		"3rdParty":            "V3rdParty",
+		"":                    "",
+		"application/xml":     "ApplicationXML",
+		"allowHTTPSDowngrade": "AllowHTTPSDowngrade",
+		"originId":            "OriginID",
+		"cacheKeyQueryParams": "CacheKeyQueryParams",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, exportedName(name), name)
+	}
+}
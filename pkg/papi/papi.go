@@ -2,8 +2,11 @@
 package papi
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/spf13/cast"
@@ -15,6 +18,9 @@ var (
 
 	// ErrNotFound is returned when requested resource was not found
 	ErrNotFound = errors.New("resource not found")
+
+	// ErrPreconditionFailed is returned when etag sent in If-Match header does not match the current one
+	ErrPreconditionFailed = errors.New("precondition failed")
 )
 
 type (
@@ -23,21 +29,36 @@ type (
 		Groups
 		Contracts
 		Activations
+		ActivationRollbacks
+		PropertyClones
 		CPCodes
+		ReportingGroups
 		Properties
 		PropertyVersions
 		EdgeHostnames
 		Products
 		Search
 		PropertyVersionHostnames
+		PropertyHostnames
 		ClientSettings
 		PropertyRules
 		RuleFormats
+		Includes
+		IncludeVersions
+		IncludeRules
+		IncludeActivations
+		BulkSearches
+		BulkPatches
+		BulkVersionCreations
+		BulkActivations
+		IDs
 	}
 
 	papi struct {
 		session.Session
-		usePrefixes bool
+		usePrefixes     bool
+		pollInterval    time.Duration
+		maxPollInterval time.Duration
 	}
 
 	// Option defines a PAPI option
@@ -57,11 +78,18 @@ type (
 	}
 )
 
+const (
+	defaultPollInterval    = 30 * time.Second
+	defaultMaxPollInterval = 2 * time.Minute
+)
+
 // Client returns a new papi Client instance with the specified controller
 func Client(sess session.Session, opts ...Option) PAPI {
 	p := &papi{
-		Session:     sess,
-		usePrefixes: true,
+		Session:         sess,
+		usePrefixes:     true,
+		pollInterval:    defaultPollInterval,
+		maxPollInterval: defaultMaxPollInterval,
 	}
 
 	for _, opt := range opts {
@@ -78,6 +106,16 @@ func WithUsePrefixes(usePrefixes bool) Option {
 	}
 }
 
+// WithPollInterval sets the initial and maximum interval used when waiting for include activations and bulk jobs to complete.
+// The interval is doubled after each poll until it reaches the maximum.
+// Non-positive initial interval falls back to the default and maximum shorter than initial interval is raised to it
+func WithPollInterval(initial, max time.Duration) Option {
+	return func(p *papi) {
+		p.pollInterval = initial
+		p.maxPollInterval = max
+	}
+}
+
 // Exec overrides the session.Exec to add papi options
 func (p *papi) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	// explicitly add the PAPI-Use-Prefixes header
@@ -85,3 +123,46 @@ func (p *papi) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.
 
 	return p.Session.Exec(r, out, in...)
 }
+
+// poll calls done until it reports completion or returns an error, sleeping between the calls
+// The interval starts at pollInterval and is doubled after each call up to maxPollInterval.
+// Non-positive poll interval is replaced by the default one, so that the API is not called in a busy loop
+func (p *papi) poll(ctx context.Context, done func() (bool, error)) error {
+	interval, maxInterval := p.pollInterval, p.maxPollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	for {
+		finished, err := done()
+		if err != nil {
+			return err
+		}
+		if finished {
+			return nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// pollError wraps error returned by poll, so that cancellation matches sentinel and API errors remain inspectable
+func pollError(ctx context.Context, sentinel, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return fmt.Errorf("%w: %s", sentinel, err)
+	}
+	return fmt.Errorf("%s: %w", sentinel, err)
+}
@@ -1,12 +1,15 @@
 package papi
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
 	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
@@ -14,7 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func mockAPIClient(t *testing.T, mockServer *httptest.Server) PAPI {
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) PAPI {
 	serverURL, err := url.Parse(mockServer.URL)
 	require.NoError(t, err)
 	certPool := x509.NewCertPool()
@@ -28,7 +31,7 @@ func mockAPIClient(t *testing.T, mockServer *httptest.Server) PAPI {
 	}
 	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
 	assert.NoError(t, err)
-	return Client(s)
+	return Client(s, opts...)
 }
 
 func TestClient(t *testing.T) {
@@ -41,15 +44,28 @@ func TestClient(t *testing.T) {
 		"no options provided, return default": {
 			options: nil,
 			expected: &papi{
-				Session:     sess,
-				usePrefixes: true,
+				Session:         sess,
+				usePrefixes:     true,
+				pollInterval:    defaultPollInterval,
+				maxPollInterval: defaultMaxPollInterval,
 			},
 		},
 		"papi prefixes set to false": {
 			options: []Option{WithUsePrefixes(false)},
 			expected: &papi{
-				Session:     sess,
-				usePrefixes: false,
+				Session:         sess,
+				usePrefixes:     false,
+				pollInterval:    defaultPollInterval,
+				maxPollInterval: defaultMaxPollInterval,
+			},
+		},
+		"poll interval set": {
+			options: []Option{WithPollInterval(time.Second, time.Minute)},
+			expected: &papi{
+				Session:         sess,
+				usePrefixes:     true,
+				pollInterval:    time.Second,
+				maxPollInterval: time.Minute,
 			},
 		},
 	}
@@ -60,3 +76,43 @@ func TestClient(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_poll(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	tests := map[string]struct {
+		initial, max time.Duration
+		maxCalls     int
+	}{
+		"zero interval falls back to default": {
+			initial:  0,
+			max:      0,
+			maxCalls: 1,
+		},
+		"negative interval falls back to default": {
+			initial:  -time.Second,
+			max:      -time.Second,
+			maxCalls: 1,
+		},
+		"maximum shorter than interval is raised to interval": {
+			initial:  20 * time.Millisecond,
+			max:      -time.Second,
+			maxCalls: 3,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := Client(sess, WithPollInterval(test.initial, test.max)).(*papi)
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			var calls int
+			err := p.poll(ctx, func() (bool, error) {
+				calls++
+				return false, nil
+			})
+			assert.True(t, errors.Is(err, context.DeadlineExceeded), "want: %s; got: %s", context.DeadlineExceeded, err)
+			assert.GreaterOrEqual(t, calls, 1)
+			assert.LessOrEqual(t, calls, test.maxCalls)
+		})
+	}
+}
@@ -0,0 +1,545 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PropertyClones contains helpers copying a property into another contract, group or product
+	PropertyClones interface {
+		// CloneProperty creates a new property in the target contract and group from the rule tree and hostnames
+		// of a source property version, remapping CP codes and edge hostnames and removing behaviors and criteria
+		// unavailable in the target product. Items which could not be carried over are listed in report Issues.
+		// If the clone fails after CP codes, edge hostnames or the target property could have been created,
+		// the report listing them is returned along with the error
+		CloneProperty(context.Context, ClonePropertyRequest) (*ClonePropertyReport, error)
+	}
+
+	// ClonePropertyRequest contains parameters of a property clone
+	ClonePropertyRequest struct {
+		Source ClonePropertySource
+		Target ClonePropertyTarget
+		// CPCodeMapping maps CP codes used in the source rule tree to CP codes available in the target contract
+		CPCodeMapping map[int]int
+		// EdgeHostnameMapping maps source edge hostname IDs or domains to edge hostname IDs in the target contract
+		EdgeHostnameMapping map[string]string
+		// CreateCPCodes creates a CP code with the same name in the target contract for every unmapped CP code
+		CreateCPCodes bool
+		// CreateEdgeHostnames creates an edge hostname with the same domain in the target contract for every unmapped
+		// edge hostname. Edge hostnames on edgekey.net require a certificate enrollment and have to be mapped explicitly
+		CreateEdgeHostnames bool
+		// SkipHostnames does not copy property hostnames to the target property
+		SkipHostnames bool
+		// TargetRuleFormatSchema is used to remove behaviors and criteria unavailable in the target product.
+		// If not set and the product changes, the schema is fetched with GetRuleFormatSchema
+		TargetRuleFormatSchema *RuleFormatSchema
+	}
+
+	// ClonePropertySource identifies the property version to clone
+	ClonePropertySource struct {
+		PropertyID string
+		// Version to clone, the latest version is cloned if not set
+		Version    int
+		ContractID string
+		GroupID    string
+	}
+
+	// ClonePropertyTarget describes the property created by CloneProperty
+	ClonePropertyTarget struct {
+		PropertyName string
+		ContractID   string
+		GroupID      string
+		// ProductID of the new property, defaults to product of the source version
+		ProductID string
+		// RuleFormat of the new property, defaults to rule format of the source version
+		RuleFormat string
+	}
+
+	// ClonePropertyReport describes the property created by CloneProperty and everything that was remapped or left out
+	ClonePropertyReport struct {
+		PropertyID      string
+		PropertyVersion int
+		ProductID       string
+		RuleFormat      string
+		SourceVersion   int
+		SourceProductID string
+		CPCodes         []ClonedCPCode
+		EdgeHostnames   []ClonedEdgeHostname
+		Hostnames       []Hostname
+		Issues          []CloneIssue
+	}
+
+	// ClonedCPCode describes a CP code replaced in the cloned rule tree
+	ClonedCPCode struct {
+		From    int
+		To      int
+		Created bool
+	}
+
+	// ClonedEdgeHostname describes an edge hostname replaced in the cloned property hostnames
+	ClonedEdgeHostname struct {
+		From    string
+		To      string
+		Created bool
+	}
+
+	// CloneIssue is an item of the source property which was not carried over or requires review
+	CloneIssue struct {
+		Type CloneIssueType
+		// Location is a JSON pointer to the item in the source rule tree, or a hostname
+		Location string
+		Detail   string
+	}
+
+	// CloneIssueType represents type of CloneIssue
+	CloneIssueType string
+
+	propertyCloner struct {
+		papi         *papi
+		params       ClonePropertyRequest
+		schema       *RuleFormatSchema
+		cpCodes      map[int]int
+		edgeHostname map[string]string
+		report       *ClonePropertyReport
+	}
+)
+
+const (
+	// CloneIssueCPCode is reported for CP codes which could not be mapped to the target contract
+	CloneIssueCPCode CloneIssueType = "CP_CODE"
+	// CloneIssueEdgeHostname is reported for hostnames whose edge hostname could not be mapped to the target contract
+	CloneIssueEdgeHostname CloneIssueType = "EDGE_HOSTNAME"
+	// CloneIssueBehavior is reported for behaviors removed because they are unavailable in the target product
+	CloneIssueBehavior CloneIssueType = "BEHAVIOR"
+	// CloneIssueCriterion is reported for criteria removed because they are unavailable in the target product
+	CloneIssueCriterion CloneIssueType = "CRITERION"
+	// CloneIssueReview is reported for behaviors referencing resources which may not be available to the target contract
+	CloneIssueReview CloneIssueType = "REVIEW"
+	// CloneIssueRules is reported for errors returned by PAPI when saving the cloned rule tree
+	CloneIssueRules CloneIssueType = "RULES"
+)
+
+var (
+	// ErrCloneProperty represents error when cloning property fails
+	ErrCloneProperty = errors.New("cloning property")
+
+	// cloneReviewBehaviors contains behaviors referencing account or contract specific resources
+	cloneReviewBehaviors = map[string]string{
+		"siteShield":           "Site Shield map has to be available to the target contract",
+		"edgeWorker":           "EdgeWorker ID has to be available to the target contract",
+		"cloudletSharedPolicy": "Cloudlet shared policy has to be available to the target contract",
+		"sureRoute":            "custom SureRoute test object map may belong to the source contract",
+	}
+)
+
+// Validate validates ClonePropertyRequest
+func (r ClonePropertyRequest) Validate() error {
+	return validation.Errors{
+		"Source.PropertyID":   validation.Validate(r.Source.PropertyID, validation.Required),
+		"Source.Version":      validation.Validate(r.Source.Version, validation.Min(0)),
+		"Target.PropertyName": validation.Validate(r.Target.PropertyName, validation.Required),
+		"Target.ContractID":   validation.Validate(r.Target.ContractID, validation.Required),
+		"Target.GroupID":      validation.Validate(r.Target.GroupID, validation.Required),
+		"Target.RuleFormat":   validation.Validate(r.Target.RuleFormat, validation.Match(validRuleFormat)),
+	}.Filter()
+}
+
+func (p *papi) CloneProperty(ctx context.Context, params ClonePropertyRequest) (*ClonePropertyReport, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCloneProperty, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("CloneProperty")
+
+	source := params.Source
+	var version *GetPropertyVersionsResponse
+	var err error
+	if source.Version == 0 {
+		version, err = p.GetLatestVersion(ctx, GetLatestVersionRequest{
+			PropertyID: source.PropertyID,
+			ContractID: source.ContractID,
+			GroupID:    source.GroupID,
+		})
+	} else {
+		version, err = p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+			PropertyID:      source.PropertyID,
+			PropertyVersion: source.Version,
+			ContractID:      source.ContractID,
+			GroupID:         source.GroupID,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+	}
+
+	report := ClonePropertyReport{
+		ProductID:       params.Target.ProductID,
+		RuleFormat:      params.Target.RuleFormat,
+		SourceVersion:   version.Version.PropertyVersion,
+		SourceProductID: version.Version.ProductID,
+	}
+	if report.ProductID == "" {
+		report.ProductID = report.SourceProductID
+	}
+	if report.RuleFormat == "" {
+		report.RuleFormat = version.Version.RuleFormat
+	}
+
+	rules, err := p.GetRuleTree(ctx, GetRuleTreeRequest{
+		PropertyID:      source.PropertyID,
+		PropertyVersion: report.SourceVersion,
+		ContractID:      source.ContractID,
+		GroupID:         source.GroupID,
+		ValidateRules:   false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+	}
+
+	var hostnames []Hostname
+	if !params.SkipHostnames {
+		rval, err := p.GetPropertyVersionHostnames(ctx, GetPropertyVersionHostnamesRequest{
+			PropertyID:      source.PropertyID,
+			PropertyVersion: report.SourceVersion,
+			ContractID:      source.ContractID,
+			GroupID:         source.GroupID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+		}
+		hostnames = rval.Hostnames.Items
+	}
+
+	cloner := propertyCloner{
+		papi:         p,
+		params:       params,
+		schema:       params.TargetRuleFormatSchema,
+		cpCodes:      make(map[int]int),
+		edgeHostname: make(map[string]string),
+		report:       &report,
+	}
+	if cloner.schema == nil && report.ProductID != report.SourceProductID {
+		cloner.schema, err = p.GetRuleFormatSchema(ctx, GetRuleFormatSchemaRequest{
+			ProductID:  report.ProductID,
+			RuleFormat: report.RuleFormat,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+		}
+	}
+
+	cloner.resolveCPCodes(ctx, rules.Rules)
+	clonedRules := cloner.rewriteRule(rules.Rules, RuleLocation{Names: []string{rules.Rules.Name}})
+	clonedHostnames := cloner.cloneHostnames(ctx, hostnames)
+
+	property, err := p.CreateProperty(ctx, CreatePropertyRequest{
+		ContractID: params.Target.ContractID,
+		GroupID:    params.Target.GroupID,
+		Property: PropertyCreate{
+			ProductID:    report.ProductID,
+			PropertyName: params.Target.PropertyName,
+			RuleFormat:   report.RuleFormat,
+		},
+	})
+	if err != nil {
+		return &report, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+	}
+	report.PropertyID = property.PropertyID
+	report.PropertyVersion = 1
+
+	updated, err := p.UpdateRuleTree(ctx, UpdateRulesRequest{
+		PropertyID:      report.PropertyID,
+		PropertyVersion: report.PropertyVersion,
+		ContractID:      params.Target.ContractID,
+		GroupID:         params.Target.GroupID,
+		ValidateRules:   true,
+		Rules: RulesUpdate{
+			Comments: rules.Comments,
+			Rules:    clonedRules,
+		},
+	})
+	if err != nil {
+		return &report, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+	}
+	for _, ruleError := range updated.Errors {
+		report.Issues = append(report.Issues, CloneIssue{
+			Type:     CloneIssueRules,
+			Location: ruleError.ErrorLocation,
+			Detail:   ruleError.Detail,
+		})
+	}
+
+	if len(clonedHostnames) > 0 {
+		_, err = p.UpdatePropertyVersionHostnames(ctx, UpdatePropertyVersionHostnamesRequest{
+			PropertyID:      report.PropertyID,
+			PropertyVersion: report.PropertyVersion,
+			ContractID:      params.Target.ContractID,
+			GroupID:         params.Target.GroupID,
+			Hostnames:       clonedHostnames,
+		})
+		if err != nil {
+			return &report, fmt.Errorf("%s: %w", ErrCloneProperty, err)
+		}
+		report.Hostnames = clonedHostnames
+	}
+
+	return &report, nil
+}
+
+// resolveCPCodes maps every CP code used in rules to a CP code in the target contract
+func (c *propertyCloner) resolveCPCodes(ctx context.Context, rules Rules) {
+	names := make(map[int]string)
+	var ids []int
+	_ = rules.Walk(func(rule Rules, _ RuleLocation) error {
+		for _, behavior := range rule.Behaviors {
+			forEachCPCode(behavior, behavior.Options, func(cpCode map[string]interface{}) {
+				id, ok := cpCodeID(cpCode)
+				if !ok {
+					return
+				}
+				if _, seen := names[id]; !seen {
+					ids = append(ids, id)
+				}
+				name, _ := cpCode["name"].(string)
+				names[id] = name
+			})
+		}
+		return nil
+	})
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		if to, ok := c.params.CPCodeMapping[id]; ok {
+			c.cpCodes[id] = to
+			c.report.CPCodes = append(c.report.CPCodes, ClonedCPCode{From: id, To: to})
+			continue
+		}
+		if !c.params.CreateCPCodes {
+			c.issue(CloneIssueCPCode, strconv.Itoa(id), "no mapping for CP code in the target contract")
+			continue
+		}
+		name := names[id]
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", c.params.Target.PropertyName, id)
+		}
+		created, err := c.papi.CreateCPCode(ctx, CreateCPCodeRequest{
+			ContractID: c.params.Target.ContractID,
+			GroupID:    c.params.Target.GroupID,
+			CPCode: CreateCPCode{
+				ProductID:  c.report.ProductID,
+				CPCodeName: name,
+			},
+		})
+		if err != nil {
+			c.issue(CloneIssueCPCode, strconv.Itoa(id), err.Error())
+			continue
+		}
+		to, err := CPCodeID(created.CPCodeID).Int()
+		if err != nil {
+			c.issue(CloneIssueCPCode, strconv.Itoa(id), fmt.Sprintf("invalid ID of created CP code: %s", created.CPCodeID))
+			continue
+		}
+		c.cpCodes[id] = to
+		c.report.CPCodes = append(c.report.CPCodes, ClonedCPCode{From: id, To: to, Created: true})
+	}
+}
+
+// rewriteRule returns a copy of rule with CP codes remapped and behaviors and criteria unavailable in the target product removed
+func (c *propertyCloner) rewriteRule(rule Rules, location RuleLocation) Rules {
+	pointer := location.Pointer()
+
+	behaviors := make([]RuleBehavior, 0, len(rule.Behaviors))
+	for i, behavior := range rule.Behaviors {
+		behaviorPointer := fmt.Sprintf("%s/behaviors/%d", pointer, i)
+		if c.schema != nil && !c.schema.HasBehavior(behavior.Name) {
+			c.issue(CloneIssueBehavior, behaviorPointer, fmt.Sprintf("behavior %q is not available in product %s", behavior.Name, c.report.ProductID))
+			continue
+		}
+		if reason, ok := cloneReviewBehaviors[behavior.Name]; ok {
+			c.issue(CloneIssueReview, behaviorPointer, reason)
+		}
+		if behavior.Name == "origin" && behavior.Options["originType"] == "NET_STORAGE" {
+			c.issue(CloneIssueReview, behaviorPointer, "NetStorage origin has to be available to the target contract")
+		}
+		if behavior.Options != nil {
+			options := RuleOptionsMap(copyOptionValue(map[string]interface{}(behavior.Options)).(map[string]interface{}))
+			forEachCPCode(behavior, options, func(cpCode map[string]interface{}) {
+				if id, ok := cpCodeID(cpCode); ok {
+					if to, ok := c.cpCodes[id]; ok {
+						cpCode["id"] = to
+					}
+				}
+			})
+			behavior.Options = options
+		}
+		behaviors = append(behaviors, behavior)
+	}
+	rule.Behaviors = behaviors
+
+	if c.schema != nil {
+		criteria := make([]RuleBehavior, 0, len(rule.Criteria))
+		for i, criterion := range rule.Criteria {
+			if !c.schema.HasCriterion(criterion.Name) {
+				c.issue(CloneIssueCriterion, fmt.Sprintf("%s/criteria/%d", pointer, i),
+					fmt.Sprintf("criterion %q is not available in product %s", criterion.Name, c.report.ProductID))
+				continue
+			}
+			criteria = append(criteria, criterion)
+		}
+		rule.Criteria = criteria
+	}
+
+	children := make([]Rules, len(rule.Children))
+	for i, child := range rule.Children {
+		children[i] = c.rewriteRule(child, location.child(child.Name, i))
+	}
+	if rule.Children != nil {
+		rule.Children = children
+	}
+	return rule
+}
+
+// cloneHostnames returns hostnames with edge hostnames remapped to the target contract.
+// Hostnames whose edge hostname could not be mapped are left out and reported
+func (c *propertyCloner) cloneHostnames(ctx context.Context, hostnames []Hostname) []Hostname {
+	var cloned []Hostname
+	for _, hostname := range hostnames {
+		to, ok := c.resolveEdgeHostname(ctx, hostname)
+		if !ok {
+			continue
+		}
+		cloned = append(cloned, Hostname{
+			CnameType:            hostname.CnameType,
+			CnameFrom:            hostname.CnameFrom,
+			EdgeHostnameID:       to,
+			CertProvisioningType: hostname.CertProvisioningType,
+		})
+	}
+	return cloned
+}
+
+func (c *propertyCloner) resolveEdgeHostname(ctx context.Context, hostname Hostname) (string, bool) {
+	from := hostname.EdgeHostnameID
+	if from == "" {
+		from = hostname.CnameTo
+	}
+	if to, ok := c.edgeHostname[from]; ok {
+		return to, to != ""
+	}
+	to, created := c.params.EdgeHostnameMapping[hostname.EdgeHostnameID], false
+	if to == "" {
+		to = c.params.EdgeHostnameMapping[hostname.CnameTo]
+	}
+	if to == "" && c.params.CreateEdgeHostnames && hostname.EdgeHostnameID != "" {
+		var err error
+		to, err = c.createEdgeHostname(ctx, hostname.EdgeHostnameID)
+		if err != nil {
+			c.issue(CloneIssueEdgeHostname, hostname.CnameFrom, err.Error())
+		}
+		created = to != ""
+	} else if to == "" {
+		c.issue(CloneIssueEdgeHostname, hostname.CnameFrom, fmt.Sprintf("no mapping for edge hostname %s in the target contract", from))
+	}
+
+	c.edgeHostname[from] = to
+	if to != "" {
+		c.report.EdgeHostnames = append(c.report.EdgeHostnames, ClonedEdgeHostname{From: from, To: to, Created: created})
+	}
+	return to, to != ""
+}
+
+func (c *propertyCloner) createEdgeHostname(ctx context.Context, id string) (string, error) {
+	source, err := c.papi.GetEdgeHostname(ctx, GetEdgeHostnameRequest{
+		EdgeHostnameID: id,
+		ContractID:     c.params.Source.ContractID,
+		GroupID:        c.params.Source.GroupID,
+	})
+	if err != nil {
+		return "", err
+	}
+	edgeHostname := source.EdgeHostname
+
+	create := EdgeHostnameCreate{
+		ProductID:         c.report.ProductID,
+		DomainPrefix:      edgeHostname.DomainPrefix,
+		DomainSuffix:      edgeHostname.DomainSuffix,
+		Secure:            edgeHostname.Secure,
+		IPVersionBehavior: edgeHostname.IPVersionBehavior,
+		UseCases:          edgeHostname.UseCases,
+	}
+	switch edgeHostname.DomainSuffix {
+	case "edgekey.net":
+		return "", fmt.Errorf("edge hostname %s requires a certificate enrollment and has to be mapped", edgeHostname.Domain)
+	case "akamaized.net":
+		create.SecureNetwork = EHSecureNetworkSharedCert
+	default:
+		if edgeHostname.Secure {
+			create.SecureNetwork = EHSecureNetworkStandardTLS
+		}
+	}
+
+	created, err := c.papi.CreateEdgeHostname(ctx, CreateEdgeHostnameRequest{
+		ContractID:   c.params.Target.ContractID,
+		GroupID:      c.params.Target.GroupID,
+		EdgeHostname: create,
+	})
+	if err != nil {
+		return "", err
+	}
+	return created.EdgeHostnameID, nil
+}
+
+func (c *propertyCloner) issue(issueType CloneIssueType, location, detail string) {
+	c.report.Issues = append(c.report.Issues, CloneIssue{Type: issueType, Location: location, Detail: detail})
+}
+
+// forEachCPCode calls fn for every CP code object among behavior options, including options nested in objects and arrays,
+// i.e. value of cpCode behavior and options whose name contains "cpcode"
+func forEachCPCode(behavior RuleBehavior, options RuleOptionsMap, fn func(map[string]interface{})) {
+	for name, value := range options {
+		if cpCode, ok := value.(map[string]interface{}); ok && behavior.Name == "cpCode" && name == "value" {
+			fn(cpCode)
+			continue
+		}
+		forEachNestedCPCode(name, value, fn)
+	}
+}
+
+func forEachNestedCPCode(name string, value interface{}, fn func(map[string]interface{})) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if strings.Contains(strings.ToLower(name), "cpcode") {
+			fn(v)
+			return
+		}
+		for nestedName, nested := range v {
+			forEachNestedCPCode(nestedName, nested, fn)
+		}
+	case []interface{}:
+		for _, item := range v {
+			forEachNestedCPCode(name, item, fn)
+		}
+	}
+}
+
+func cpCodeID(cpCode map[string]interface{}) (int, bool) {
+	switch id := cpCode["id"].(type) {
+	case float64:
+		return int(id), true
+	case int:
+		return id, true
+	case json.Number:
+		i, err := id.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,332 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_CloneProperty(t *testing.T) {
+	const (
+		versionBody = `
+{
+    "propertyId": "prp_1",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 3,
+                "productId": "prd_Fresca",
+                "ruleFormat": "v2022-10-18",
+                "etag": "a1b2"
+            }
+        ]
+    }
+}`
+		rulesBody = `
+{
+    "propertyId": "prp_1",
+    "propertyVersion": 3,
+    "comments": "source rules",
+    "rules": {
+        "name": "default",
+        "behaviors": [
+            {"name": "origin", "options": {"originType": "CUSTOMER", "hostname": "origin.example.com"}},
+            {"name": "cpCode", "options": {"value": {"id": 111, "name": "main"}}},
+            {"name": "edgeRedirector", "options": {"enabled": true, "rules": [{"settings": {"cloudletCpCode": {"id": 111, "name": "main"}}}]}}
+        ],
+        "children": [
+            {
+                "name": "Images",
+                "criteria": [{"name": "fileExtension", "options": {"values": ["jpg"]}}, {"name": "bucket", "options": {"percentage": 10}}],
+                "behaviors": [
+                    {"name": "imageManager", "options": {"enabled": true}},
+                    {"name": "cpCode", "options": {"value": {"id": 222, "name": "images"}}},
+                    {"name": "siteShield", "options": {"ssmap": {"value": "s1.akamaiedge.net"}}}
+                ]
+            }
+        ]
+    }
+}`
+		hostnamesBody = `
+{
+    "hostnames": {
+        "items": [
+            {"cnameType": "EDGE_HOSTNAME", "cnameFrom": "www.example.com", "cnameTo": "www.example.com.edgesuite.net", "edgeHostnameId": "ehn_1"},
+            {"cnameType": "EDGE_HOSTNAME", "cnameFrom": "example.com", "cnameTo": "www.example.com.edgesuite.net", "edgeHostnameId": "ehn_1"},
+            {"cnameType": "EDGE_HOSTNAME", "cnameFrom": "secure.example.com", "cnameTo": "secure.example.com.edgekey.net", "edgeHostnameId": "ehn_2"}
+        ]
+    }
+}`
+		schemaBody = `
+{
+    "definitions": {
+        "catalog": {
+            "behaviors": {"origin": {}, "cpCode": {}, "edgeRedirector": {}, "siteShield": {}},
+            "criteria": {"fileExtension": {}}
+        }
+    }
+}`
+	)
+
+	tests := map[string]struct {
+		request                 ClonePropertyRequest
+		createPropertyStatus    int
+		expectedCreatedCPCodes  []string
+		expectedCreateRequest   string
+		expectedRulesRequest    string
+		expectedHostnames       []Hostname
+		expectedReport          *ClonePropertyReport
+		withError               error
+		expectEdgeHostnameFetch bool
+	}{
+		"clone with mappings into the same product": {
+			request: ClonePropertyRequest{
+				Source:              ClonePropertySource{PropertyID: "prp_1", Version: 3, ContractID: "ctr_1", GroupID: "grp_1"},
+				Target:              ClonePropertyTarget{PropertyName: "clone", ContractID: "ctr_2", GroupID: "grp_2"},
+				CPCodeMapping:       map[int]int{111: 911, 222: 922},
+				EdgeHostnameMapping: map[string]string{"ehn_1": "ehn_91", "secure.example.com.edgekey.net": "ehn_92"},
+			},
+			createPropertyStatus:  http.StatusCreated,
+			expectedCreateRequest: `{"productId": "prd_Fresca", "propertyName": "clone", "ruleFormat": "v2022-10-18"}`,
+			expectedRulesRequest: `
+{
+    "comments": "source rules",
+    "rules": {
+        "name": "default",
+        "behaviors": [
+            {"name": "origin", "options": {"originType": "CUSTOMER", "hostname": "origin.example.com"}},
+            {"name": "cpCode", "options": {"value": {"id": 911, "name": "main"}}},
+            {"name": "edgeRedirector", "options": {"enabled": true, "rules": [{"settings": {"cloudletCpCode": {"id": 911, "name": "main"}}}]}}
+        ],
+        "children": [
+            {
+                "name": "Images",
+                "criteria": [{"name": "fileExtension", "options": {"values": ["jpg"]}}, {"name": "bucket", "options": {"percentage": 10}}],
+                "behaviors": [
+                    {"name": "imageManager", "options": {"enabled": true}},
+                    {"name": "cpCode", "options": {"value": {"id": 922, "name": "images"}}},
+                    {"name": "siteShield", "options": {"ssmap": {"value": "s1.akamaiedge.net"}}}
+                ],
+                "options": {}
+            }
+        ],
+        "options": {}
+    }
+}`,
+			expectedHostnames: []Hostname{
+				{CnameType: "EDGE_HOSTNAME", CnameFrom: "www.example.com", EdgeHostnameID: "ehn_91"},
+				{CnameType: "EDGE_HOSTNAME", CnameFrom: "example.com", EdgeHostnameID: "ehn_91"},
+				{CnameType: "EDGE_HOSTNAME", CnameFrom: "secure.example.com", EdgeHostnameID: "ehn_92"},
+			},
+			expectedReport: &ClonePropertyReport{
+				PropertyID:      "prp_2",
+				PropertyVersion: 1,
+				ProductID:       "prd_Fresca",
+				RuleFormat:      "v2022-10-18",
+				SourceVersion:   3,
+				SourceProductID: "prd_Fresca",
+				CPCodes:         []ClonedCPCode{{From: 111, To: 911}, {From: 222, To: 922}},
+				EdgeHostnames: []ClonedEdgeHostname{
+					{From: "ehn_1", To: "ehn_91"},
+					{From: "ehn_2", To: "ehn_92"},
+				},
+				Hostnames: []Hostname{
+					{CnameType: "EDGE_HOSTNAME", CnameFrom: "www.example.com", EdgeHostnameID: "ehn_91"},
+					{CnameType: "EDGE_HOSTNAME", CnameFrom: "example.com", EdgeHostnameID: "ehn_91"},
+					{CnameType: "EDGE_HOSTNAME", CnameFrom: "secure.example.com", EdgeHostnameID: "ehn_92"},
+				},
+				Issues: []CloneIssue{
+					{Type: CloneIssueReview, Location: "/rules/children/0/behaviors/2", Detail: cloneReviewBehaviors["siteShield"]},
+				},
+			},
+		},
+		"clone into another product creating CP codes and edge hostnames": {
+			request: ClonePropertyRequest{
+				Source:              ClonePropertySource{PropertyID: "prp_1", ContractID: "ctr_1", GroupID: "grp_1"},
+				Target:              ClonePropertyTarget{PropertyName: "clone", ContractID: "ctr_2", GroupID: "grp_2", ProductID: "prd_SPM"},
+				CPCodeMapping:       map[int]int{111: 911},
+				CreateCPCodes:       true,
+				CreateEdgeHostnames: true,
+			},
+			createPropertyStatus:    http.StatusCreated,
+			expectedCreateRequest:   `{"productId": "prd_SPM", "propertyName": "clone", "ruleFormat": "v2022-10-18"}`,
+			expectedCreatedCPCodes:  []string{"images"},
+			expectEdgeHostnameFetch: true,
+			expectedRulesRequest: `
+{
+    "comments": "source rules",
+    "rules": {
+        "name": "default",
+        "behaviors": [
+            {"name": "origin", "options": {"originType": "CUSTOMER", "hostname": "origin.example.com"}},
+            {"name": "cpCode", "options": {"value": {"id": 911, "name": "main"}}},
+            {"name": "edgeRedirector", "options": {"enabled": true, "rules": [{"settings": {"cloudletCpCode": {"id": 911, "name": "main"}}}]}}
+        ],
+        "children": [
+            {
+                "name": "Images",
+                "criteria": [{"name": "fileExtension", "options": {"values": ["jpg"]}}],
+                "behaviors": [
+                    {"name": "cpCode", "options": {"value": {"id": 5555, "name": "images"}}},
+                    {"name": "siteShield", "options": {"ssmap": {"value": "s1.akamaiedge.net"}}}
+                ],
+                "options": {}
+            }
+        ],
+        "options": {}
+    }
+}`,
+			expectedHostnames: []Hostname{
+				{CnameType: "EDGE_HOSTNAME", CnameFrom: "www.example.com", EdgeHostnameID: "ehn_93"},
+				{CnameType: "EDGE_HOSTNAME", CnameFrom: "example.com", EdgeHostnameID: "ehn_93"},
+			},
+			expectedReport: &ClonePropertyReport{
+				PropertyID:      "prp_2",
+				PropertyVersion: 1,
+				ProductID:       "prd_SPM",
+				RuleFormat:      "v2022-10-18",
+				SourceVersion:   3,
+				SourceProductID: "prd_Fresca",
+				CPCodes:         []ClonedCPCode{{From: 111, To: 911}, {From: 222, To: 5555, Created: true}},
+				EdgeHostnames:   []ClonedEdgeHostname{{From: "ehn_1", To: "ehn_93", Created: true}},
+				Hostnames: []Hostname{
+					{CnameType: "EDGE_HOSTNAME", CnameFrom: "www.example.com", EdgeHostnameID: "ehn_93"},
+					{CnameType: "EDGE_HOSTNAME", CnameFrom: "example.com", EdgeHostnameID: "ehn_93"},
+				},
+				Issues: []CloneIssue{
+					{Type: CloneIssueBehavior, Location: "/rules/children/0/behaviors/0", Detail: `behavior "imageManager" is not available in product prd_SPM`},
+					{Type: CloneIssueReview, Location: "/rules/children/0/behaviors/2", Detail: cloneReviewBehaviors["siteShield"]},
+					{Type: CloneIssueCriterion, Location: "/rules/children/0/criteria/1", Detail: `criterion "bucket" is not available in product prd_SPM`},
+					{Type: CloneIssueEdgeHostname, Location: "secure.example.com", Detail: "edge hostname secure.example.com.edgekey.net requires a certificate enrollment and has to be mapped"},
+				},
+			},
+		},
+		"property creation fails": {
+			request: ClonePropertyRequest{
+				Source:              ClonePropertySource{PropertyID: "prp_1", Version: 3, ContractID: "ctr_1", GroupID: "grp_1"},
+				Target:              ClonePropertyTarget{PropertyName: "clone", ContractID: "ctr_2", GroupID: "grp_2", ProductID: "prd_SPM"},
+				CPCodeMapping:       map[int]int{111: 911},
+				CreateCPCodes:       true,
+				CreateEdgeHostnames: true,
+			},
+			createPropertyStatus:    http.StatusForbidden,
+			expectedCreatedCPCodes:  []string{"images"},
+			expectEdgeHostnameFetch: true,
+			expectedReport: &ClonePropertyReport{
+				ProductID:       "prd_SPM",
+				RuleFormat:      "v2022-10-18",
+				SourceVersion:   3,
+				SourceProductID: "prd_Fresca",
+				CPCodes:         []ClonedCPCode{{From: 111, To: 911}, {From: 222, To: 5555, Created: true}},
+				EdgeHostnames:   []ClonedEdgeHostname{{From: "ehn_1", To: "ehn_93", Created: true}},
+				Issues: []CloneIssue{
+					{Type: CloneIssueBehavior, Location: "/rules/children/0/behaviors/0", Detail: `behavior "imageManager" is not available in product prd_SPM`},
+					{Type: CloneIssueReview, Location: "/rules/children/0/behaviors/2", Detail: cloneReviewBehaviors["siteShield"]},
+					{Type: CloneIssueCriterion, Location: "/rules/children/0/criteria/1", Detail: `criterion "bucket" is not available in product prd_SPM`},
+					{Type: CloneIssueEdgeHostname, Location: "secure.example.com", Detail: "edge hostname secure.example.com.edgekey.net requires a certificate enrollment and has to be mapped"},
+				},
+			},
+			withError: &Error{
+				Type:       "forbidden",
+				Title:      "Forbidden",
+				StatusCode: http.StatusForbidden,
+			},
+		},
+		"validation error": {
+			request: ClonePropertyRequest{
+				Source: ClonePropertySource{PropertyID: "prp_1"},
+				Target: ClonePropertyTarget{ContractID: "ctr_2", GroupID: "grp_2"},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var createdCPCodes []string
+			var edgeHostnameFetched bool
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				write := func(status int, body string) {
+					w.WriteHeader(status)
+					_, err := w.Write([]byte(body))
+					assert.NoError(t, err)
+				}
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/properties/prp_1/versions/latest",
+					r.Method == http.MethodGet && r.URL.Path == "/papi/v1/properties/prp_1/versions/3":
+					write(http.StatusOK, versionBody)
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/properties/prp_1/versions/3/rules":
+					write(http.StatusOK, rulesBody)
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/properties/prp_1/versions/3/hostnames":
+					write(http.StatusOK, hostnamesBody)
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/schemas/products/prd_SPM/v2022-10-18":
+					write(http.StatusOK, schemaBody)
+				case r.Method == http.MethodPost && r.URL.Path == "/papi/v1/cpcodes":
+					assert.Equal(t, "ctr_2", r.URL.Query().Get("contractId"))
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"productId": "prd_SPM", "cpcodeName": "images"}`, string(body))
+					createdCPCodes = append(createdCPCodes, "images")
+					write(http.StatusCreated, `{"cpcodeLink": "/papi/v1/cpcodes/cpc_5555?contractId=ctr_2&groupId=grp_2"}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/edgehostnames/ehn_1":
+					edgeHostnameFetched = true
+					write(http.StatusOK, `{"edgeHostnames": {"items": [{"edgeHostnameId": "ehn_1", "edgeHostnameDomain": "www.example.com.edgesuite.net",
+						"productId": "prd_Fresca", "domainPrefix": "www.example.com", "domainSuffix": "edgesuite.net", "secure": false, "ipVersionBehavior": "IPV4"}]}}`)
+				case r.Method == http.MethodGet && r.URL.Path == "/papi/v1/edgehostnames/ehn_2":
+					write(http.StatusOK, `{"edgeHostnames": {"items": [{"edgeHostnameId": "ehn_2", "edgeHostnameDomain": "secure.example.com.edgekey.net",
+						"productId": "prd_Fresca", "domainPrefix": "secure.example.com", "domainSuffix": "edgekey.net", "secure": true, "ipVersionBehavior": "IPV4"}]}}`)
+				case r.Method == http.MethodPost && r.URL.Path == "/papi/v1/edgehostnames":
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, `{"productId": "prd_SPM", "domainPrefix": "www.example.com", "domainSuffix": "edgesuite.net", "ipVersionBehavior": "IPV4"}`, string(body))
+					write(http.StatusCreated, `{"edgeHostnameLink": "/papi/v1/edgehostnames/ehn_93?contractId=ctr_2&groupId=grp_2"}`)
+				case r.Method == http.MethodPost && r.URL.Path == "/papi/v1/properties":
+					assert.Equal(t, "ctr_2", r.URL.Query().Get("contractId"))
+					assert.Equal(t, "grp_2", r.URL.Query().Get("groupId"))
+					if test.expectedCreateRequest != "" {
+						body, err := ioutil.ReadAll(r.Body)
+						assert.NoError(t, err)
+						assert.JSONEq(t, test.expectedCreateRequest, string(body))
+					}
+					if test.createPropertyStatus != http.StatusCreated {
+						write(test.createPropertyStatus, `{"type": "forbidden", "title": "Forbidden", "status": 403}`)
+						return
+					}
+					write(http.StatusCreated, `{"propertyLink": "/papi/v1/properties/prp_2?contractId=ctr_2&groupId=grp_2"}`)
+				case r.Method == http.MethodPut && r.URL.Path == "/papi/v1/properties/prp_2/versions/1/rules":
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, test.expectedRulesRequest, string(body))
+					write(http.StatusOK, `{"propertyId": "prp_2", "propertyVersion": 1, "rules": {"name": "default"}}`)
+				case r.Method == http.MethodPut && r.URL.Path == "/papi/v1/properties/prp_2/versions/1/hostnames":
+					var hostnames []Hostname
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&hostnames))
+					assert.Equal(t, test.expectedHostnames, hostnames)
+					write(http.StatusOK, `{"hostnames": {"items": []}}`)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+				}
+			}))
+			client := mockAPIClient(t, mockServer)
+			report, err := client.CloneProperty(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Equal(t, test.expectedReport, report)
+				assert.Equal(t, test.expectedCreatedCPCodes, createdCPCodes)
+				assert.Equal(t, test.expectEdgeHostnameFetch, edgeHostnameFetched)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedReport, report)
+			assert.Equal(t, test.expectedCreatedCPCodes, createdCPCodes)
+			assert.Equal(t, test.expectEdgeHostnameFetch, edgeHostnameFetched)
+		})
+	}
+}
@@ -0,0 +1,487 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PropertyHostnames contains operations available on property hostname bucket resource.
+	// Unlike PropertyVersionHostnames, hostnames in the bucket are attached to the property itself and are activated
+	// without creating a new property version
+	// See: https://techdocs.akamai.com/property-mgr/reference/property-hostname-buckets
+	PropertyHostnames interface {
+		// GetPropertyHostnames lists hostnames of a property active on staging or production network
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-hostnames
+		GetPropertyHostnames(context.Context, GetPropertyHostnamesRequest) (*GetPropertyHostnamesResponse, error)
+
+		// PatchPropertyHostnames adds hostnames to and removes hostnames from a property on the given network,
+		// which creates a new hostname activation
+		// See: https://techdocs.akamai.com/property-mgr/reference/patch-property-hostnames
+		PatchPropertyHostnames(context.Context, PatchPropertyHostnamesRequest) (*PatchPropertyHostnamesResponse, error)
+
+		// ListPropertyHostnameActivations lists hostname activations of a property
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-hostname-activations
+		ListPropertyHostnameActivations(context.Context, ListPropertyHostnameActivationsRequest) (*ListPropertyHostnameActivationsResponse, error)
+
+		// GetPropertyHostnameActivation gets details about a hostname activation
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-property-hostname-activation
+		GetPropertyHostnameActivation(context.Context, GetPropertyHostnameActivationRequest) (*GetPropertyHostnameActivationResponse, error)
+
+		// WaitForPropertyHostnameActivation polls a hostname activation until it is no longer pending or the context is canceled.
+		// The returned activation should be checked for its Status, as failed and aborted activations are not reported as errors
+		WaitForPropertyHostnameActivation(context.Context, GetPropertyHostnameActivationRequest) (*HostnameActivation, error)
+	}
+
+	// GetPropertyHostnamesRequest contains path and query params used to list property hostnames
+	GetPropertyHostnamesRequest struct {
+		PropertyID        string
+		ContractID        string
+		GroupID           string
+		Network           ActivationNetwork
+		IncludeCertStatus bool
+		Limit             int
+		Offset            int
+	}
+
+	// GetPropertyHostnamesResponse contains the response returned by GetPropertyHostnames
+	GetPropertyHostnamesResponse struct {
+		AccountID  string                `json:"accountId"`
+		ContractID string                `json:"contractId"`
+		GroupID    string                `json:"groupId"`
+		PropertyID string                `json:"propertyId"`
+		Hostnames  PropertyHostnameItems `json:"hostnames"`
+	}
+
+	// PropertyHostnameItems is a page of property hostnames
+	PropertyHostnameItems struct {
+		Items            []PropertyHostname `json:"items"`
+		CurrentItemCount int                `json:"currentItemCount"`
+		TotalItems       int                `json:"totalItems"`
+		NextLink         string             `json:"nextLink,omitempty"`
+		PreviousLink     string             `json:"previousLink,omitempty"`
+	}
+
+	// PropertyHostname is a hostname in the property hostname bucket with its configuration on each network
+	PropertyHostname struct {
+		CnameFrom                string               `json:"cnameFrom"`
+		CnameType                HostnameCnameType    `json:"cnameType"`
+		ProductionCertType       CertProvisioningType `json:"productionCertType,omitempty"`
+		ProductionCnameTo        string               `json:"productionCnameTo,omitempty"`
+		ProductionEdgeHostnameID string               `json:"productionEdgeHostnameId,omitempty"`
+		StagingCertType          CertProvisioningType `json:"stagingCertType,omitempty"`
+		StagingCnameTo           string               `json:"stagingCnameTo,omitempty"`
+		StagingEdgeHostnameID    string               `json:"stagingEdgeHostnameId,omitempty"`
+		CertStatus               *CertStatusItem      `json:"certStatus,omitempty"`
+	}
+
+	// PatchPropertyHostnamesRequest contains path and query params, as well as request body used to patch property hostnames
+	PatchPropertyHostnamesRequest struct {
+		PropertyID        string
+		ContractID        string
+		GroupID           string
+		IncludeCertStatus bool
+		Network           ActivationNetwork
+		Note              string
+		NotifyEmails      []string
+		Add               []PropertyHostnameAdd
+		Remove            []string
+	}
+
+	// PropertyHostnameAdd is a hostname to be added to the property hostname bucket
+	PropertyHostnameAdd struct {
+		CnameFrom            string               `json:"cnameFrom"`
+		CnameType            HostnameCnameType    `json:"cnameType"`
+		EdgeHostnameID       string               `json:"edgeHostnameId"`
+		CertProvisioningType CertProvisioningType `json:"certProvisioningType"`
+	}
+
+	// PatchPropertyHostnamesResponse contains the response returned by PatchPropertyHostnames
+	PatchPropertyHostnamesResponse struct {
+		AccountID      string                       `json:"accountId"`
+		ContractID     string                       `json:"contractId"`
+		GroupID        string                       `json:"groupId"`
+		PropertyID     string                       `json:"propertyId"`
+		Network        ActivationNetwork            `json:"network"`
+		ActivationID   string                       `json:"-"`
+		ActivationLink string                       `json:"activationLink"`
+		Hostnames      []HostnameActivationHostname `json:"hostnames"`
+	}
+
+	// ListPropertyHostnameActivationsRequest contains path and query params used to list hostname activations
+	ListPropertyHostnameActivationsRequest struct {
+		PropertyID string
+		ContractID string
+		GroupID    string
+		Limit      int
+		Offset     int
+	}
+
+	// ListPropertyHostnameActivationsResponse contains the response returned by ListPropertyHostnameActivations
+	ListPropertyHostnameActivationsResponse struct {
+		AccountID           string                  `json:"accountId"`
+		ContractID          string                  `json:"contractId"`
+		GroupID             string                  `json:"groupId"`
+		HostnameActivations HostnameActivationItems `json:"hostnameActivations"`
+	}
+
+	// GetPropertyHostnameActivationRequest contains path and query params used to fetch a hostname activation
+	GetPropertyHostnameActivationRequest struct {
+		PropertyID           string
+		HostnameActivationID string
+		ContractID           string
+		GroupID              string
+		IncludeHostnames     bool
+	}
+
+	// GetPropertyHostnameActivationResponse contains the response returned by GetPropertyHostnameActivation
+	GetPropertyHostnameActivationResponse struct {
+		AccountID           string                  `json:"accountId"`
+		ContractID          string                  `json:"contractId"`
+		GroupID             string                  `json:"groupId"`
+		HostnameActivations HostnameActivationItems `json:"hostnameActivations"`
+		HostnameActivation  HostnameActivation      `json:"-"`
+	}
+
+	// HostnameActivationItems is a page of hostname activations
+	HostnameActivationItems struct {
+		Items            []HostnameActivation `json:"items"`
+		CurrentItemCount int                  `json:"currentItemCount,omitempty"`
+		TotalItems       int                  `json:"totalItems,omitempty"`
+		NextLink         string               `json:"nextLink,omitempty"`
+		PreviousLink     string               `json:"previousLink,omitempty"`
+	}
+
+	// HostnameActivation represents a property hostname activation resource
+	HostnameActivation struct {
+		HostnameActivationID string                       `json:"hostnameActivationId"`
+		ActivationType       ActivationType               `json:"activationType"`
+		PropertyID           string                       `json:"propertyId"`
+		PropertyName         string                       `json:"propertyName"`
+		Network              ActivationNetwork            `json:"network"`
+		Status               ActivationStatus             `json:"status"`
+		Note                 string                       `json:"note,omitempty"`
+		NotifyEmails         []string                     `json:"notifyEmails"`
+		SubmitDate           string                       `json:"submitDate,omitempty"`
+		UpdateDate           string                       `json:"updateDate,omitempty"`
+		Hostnames            []HostnameActivationHostname `json:"hostnames,omitempty"`
+	}
+
+	// HostnameActivationHostname is a hostname added or removed by a hostname activation
+	HostnameActivationHostname struct {
+		Action               HostnameAction       `json:"action"`
+		CnameFrom            string               `json:"cnameFrom"`
+		CnameTo              string               `json:"cnameTo,omitempty"`
+		CnameType            HostnameCnameType    `json:"cnameType,omitempty"`
+		EdgeHostnameID       string               `json:"edgeHostnameId,omitempty"`
+		CertProvisioningType CertProvisioningType `json:"certProvisioningType,omitempty"`
+	}
+
+	// CertProvisioningType represents certificate provisioning type of a hostname
+	CertProvisioningType string
+
+	// HostnameAction represents the change a hostname activation makes to a hostname
+	HostnameAction string
+
+	propertyHostnamesPatchBody struct {
+		Network      ActivationNetwork     `json:"network"`
+		Note         string                `json:"note,omitempty"`
+		NotifyEmails []string              `json:"notifyEmails,omitempty"`
+		Add          []PropertyHostnameAdd `json:"add,omitempty"`
+		Remove       []string              `json:"remove,omitempty"`
+	}
+)
+
+const (
+	// CertProvisioningTypeCPSManaged is used for hostnames secured with a certificate managed in CPS
+	CertProvisioningTypeCPSManaged CertProvisioningType = "CPS_MANAGED"
+	// CertProvisioningTypeDefault is used for hostnames secured with a Default DV certificate provisioned by PAPI
+	CertProvisioningTypeDefault CertProvisioningType = "DEFAULT"
+
+	// HostnameActionAdd const
+	HostnameActionAdd HostnameAction = "ADD"
+	// HostnameActionRemove const
+	HostnameActionRemove HostnameAction = "REMOVE"
+)
+
+// Validate validates GetPropertyHostnamesRequest
+func (r GetPropertyHostnamesRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(r.PropertyID, validation.Required),
+		"Network":    validation.Validate(r.Network, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+		"Limit":      validation.Validate(r.Limit, validation.Min(0)),
+		"Offset":     validation.Validate(r.Offset, validation.Min(0)),
+	}.Filter()
+}
+
+// Validate validates PatchPropertyHostnamesRequest
+func (r PatchPropertyHostnamesRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(r.PropertyID, validation.Required),
+		"Network":    validation.Validate(r.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+		"Add": validation.Validate(r.Add, validation.When(len(r.Remove) == 0,
+			validation.Required.Error("at least one hostname has to be added or removed"))),
+		"Remove": validation.Validate(r.Remove, validation.Each(validation.Required)),
+	}.Filter()
+}
+
+// Validate validates PropertyHostnameAdd
+func (h PropertyHostnameAdd) Validate() error {
+	return validation.Errors{
+		"CnameFrom":      validation.Validate(h.CnameFrom, validation.Required),
+		"CnameType":      validation.Validate(h.CnameType, validation.Required, validation.In(HostnameCnameTypeEdgeHostname)),
+		"EdgeHostnameID": validation.Validate(h.EdgeHostnameID, validation.Required),
+		"CertProvisioningType": validation.Validate(h.CertProvisioningType, validation.Required,
+			validation.In(CertProvisioningTypeCPSManaged, CertProvisioningTypeDefault)),
+	}.Filter()
+}
+
+// Validate validates ListPropertyHostnameActivationsRequest
+func (r ListPropertyHostnameActivationsRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(r.PropertyID, validation.Required),
+		"Limit":      validation.Validate(r.Limit, validation.Min(0)),
+		"Offset":     validation.Validate(r.Offset, validation.Min(0)),
+	}.Filter()
+}
+
+// Validate validates GetPropertyHostnameActivationRequest
+func (r GetPropertyHostnameActivationRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":           validation.Validate(r.PropertyID, validation.Required),
+		"HostnameActivationID": validation.Validate(r.HostnameActivationID, validation.Required),
+	}.Filter()
+}
+
+// Pending returns true if the hostname activation has not reached a final status yet
+func (a HostnameActivation) Pending() bool {
+	switch a.Status {
+	case ActivationStatusNew, ActivationStatusPending, ActivationStatusZone1, ActivationStatusZone2,
+		ActivationStatusZone3, ActivationStatusDeactivating:
+		return true
+	}
+	return false
+}
+
+var (
+	// ErrGetPropertyHostnames represents error when fetching property hostnames fails
+	ErrGetPropertyHostnames = errors.New("fetching property hostnames")
+	// ErrPatchPropertyHostnames represents error when patching property hostnames fails
+	ErrPatchPropertyHostnames = errors.New("patching property hostnames")
+	// ErrListPropertyHostnameActivations represents error when listing hostname activations fails
+	ErrListPropertyHostnameActivations = errors.New("listing hostname activations")
+	// ErrGetPropertyHostnameActivation represents error when fetching hostname activation fails
+	ErrGetPropertyHostnameActivation = errors.New("fetching hostname activation")
+	// ErrWaitForPropertyHostnameActivation represents error when waiting for hostname activation fails
+	ErrWaitForPropertyHostnameActivation = errors.New("waiting for hostname activation")
+)
+
+func (p *papi) GetPropertyHostnames(ctx context.Context, params GetPropertyHostnamesRequest) (*GetPropertyHostnamesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyHostnames, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetPropertyHostnames")
+
+	uri, err := url.Parse(fmt.Sprintf("/papi/v1/properties/%s/hostnames", params.PropertyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetPropertyHostnames, err)
+	}
+	q := propertyHostnamesQuery(uri, params.ContractID, params.GroupID, params.Limit, params.Offset)
+	if params.Network != "" {
+		q.Add("network", string(params.Network))
+	}
+	if params.IncludeCertStatus {
+		q.Add("includeCertStatus", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyHostnames, err)
+	}
+
+	var rval GetPropertyHostnamesResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPropertyHostnames, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetPropertyHostnames, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) PatchPropertyHostnames(ctx context.Context, params PatchPropertyHostnamesRequest) (*PatchPropertyHostnamesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrPatchPropertyHostnames, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("PatchPropertyHostnames")
+
+	uri, err := url.Parse(fmt.Sprintf("/papi/v1/properties/%s/hostnames", params.PropertyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrPatchPropertyHostnames, err)
+	}
+	q := propertyHostnamesQuery(uri, params.ContractID, params.GroupID, 0, 0)
+	if params.IncludeCertStatus {
+		q.Add("includeCertStatus", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrPatchPropertyHostnames, err)
+	}
+	body := propertyHostnamesPatchBody{
+		Network:      params.Network,
+		Note:         params.Note,
+		NotifyEmails: params.NotifyEmails,
+		Add:          params.Add,
+		Remove:       params.Remove,
+	}
+
+	var rval PatchPropertyHostnamesResponse
+	resp, err := p.Exec(req, &rval, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrPatchPropertyHostnames, err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("%s: %w", ErrPatchPropertyHostnames, p.Error(resp))
+	}
+
+	if rval.ActivationLink != "" {
+		id, err := ResponseLinkParse(rval.ActivationLink)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", ErrPatchPropertyHostnames, ErrInvalidResponseLink, err)
+		}
+		rval.ActivationID = id
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) ListPropertyHostnameActivations(ctx context.Context, params ListPropertyHostnameActivationsRequest) (*ListPropertyHostnameActivationsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListPropertyHostnameActivations, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ListPropertyHostnameActivations")
+
+	uri, err := url.Parse(fmt.Sprintf("/papi/v1/properties/%s/hostname-activations", params.PropertyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListPropertyHostnameActivations, err)
+	}
+	uri.RawQuery = propertyHostnamesQuery(uri, params.ContractID, params.GroupID, params.Limit, params.Offset).Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListPropertyHostnameActivations, err)
+	}
+
+	var rval ListPropertyHostnameActivationsResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListPropertyHostnameActivations, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListPropertyHostnameActivations, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) GetPropertyHostnameActivation(ctx context.Context, params GetPropertyHostnameActivationRequest) (*GetPropertyHostnameActivationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyHostnameActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetPropertyHostnameActivation")
+
+	uri, err := url.Parse(fmt.Sprintf("/papi/v1/properties/%s/hostname-activations/%s", params.PropertyID, params.HostnameActivationID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetPropertyHostnameActivation, err)
+	}
+	q := propertyHostnamesQuery(uri, params.ContractID, params.GroupID, 0, 0)
+	if params.IncludeHostnames {
+		q.Add("includeHostnames", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyHostnameActivation, err)
+	}
+
+	var rval GetPropertyHostnameActivationResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetPropertyHostnameActivation, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetPropertyHostnameActivation, p.Error(resp))
+	}
+
+	if len(rval.HostnameActivations.Items) == 0 {
+		return nil, fmt.Errorf("%s: %w: HostnameActivationID: %s", ErrGetPropertyHostnameActivation, ErrNotFound, params.HostnameActivationID)
+	}
+	rval.HostnameActivation = rval.HostnameActivations.Items[0]
+
+	return &rval, nil
+}
+
+func (p *papi) WaitForPropertyHostnameActivation(ctx context.Context, params GetPropertyHostnameActivationRequest) (*HostnameActivation, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForPropertyHostnameActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("WaitForPropertyHostnameActivation")
+
+	var activation *HostnameActivation
+	err := p.poll(ctx, func() (bool, error) {
+		rval, err := p.GetPropertyHostnameActivation(ctx, params)
+		if err != nil {
+			return false, err
+		}
+		activation = &rval.HostnameActivation
+		return !activation.Pending(), nil
+	})
+	if err != nil {
+		return nil, pollError(ctx, ErrWaitForPropertyHostnameActivation, err)
+	}
+	return activation, nil
+}
+
+// propertyHostnamesQuery returns query of uri with optional contractId, groupId, limit and offset params
+func propertyHostnamesQuery(uri *url.URL, contractID, groupID string, limit, offset int) url.Values {
+	q := uri.Query()
+	if contractID != "" {
+		q.Add("contractId", contractID)
+	}
+	if groupID != "" {
+		q.Add("groupId", groupID)
+	}
+	if limit != 0 {
+		q.Add("limit", strconv.Itoa(limit))
+	}
+	if offset != 0 {
+		q.Add("offset", strconv.Itoa(offset))
+	}
+	return q
+}
@@ -0,0 +1,621 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_GetPropertyHostnames(t *testing.T) {
+	tests := map[string]struct {
+		request          GetPropertyHostnamesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *GetPropertyHostnamesResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: GetPropertyHostnamesRequest{
+				PropertyID:        "prp_175780",
+				ContractID:        "ctr_1-1TJZFW",
+				GroupID:           "grp_15166",
+				Network:           ActivationNetworkStaging,
+				IncludeCertStatus: true,
+				Limit:             2,
+				Offset:            10,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "propertyId": "prp_175780",
+    "hostnames": {
+        "currentItemCount": 1,
+        "totalItems": 11,
+        "previousLink": "/papi/v1/properties/prp_175780/hostnames?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=2&network=STAGING&offset=8",
+        "items": [
+            {
+                "cnameFrom": "customer1.example.com",
+                "cnameType": "EDGE_HOSTNAME",
+                "stagingCertType": "DEFAULT",
+                "stagingCnameTo": "saas.example.com.edgekey.net",
+                "stagingEdgeHostnameId": "ehn_895822",
+                "certStatus": {
+                    "validationCname": {
+                        "hostname": "_acme-challenge.customer1.example.com",
+                        "target": "ac.1234.dv.edgekey.net"
+                    },
+                    "staging": [{"status": "PENDING"}]
+                }
+            }
+        ]
+    }
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/hostnames?contractId=ctr_1-1TJZFW&groupId=grp_15166&includeCertStatus=true&limit=2&network=STAGING&offset=10",
+			expectedResponse: &GetPropertyHostnamesResponse{
+				AccountID:  "act_1-1TJZFB",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				PropertyID: "prp_175780",
+				Hostnames: PropertyHostnameItems{
+					CurrentItemCount: 1,
+					TotalItems:       11,
+					PreviousLink:     "/papi/v1/properties/prp_175780/hostnames?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=2&network=STAGING&offset=8",
+					Items: []PropertyHostname{
+						{
+							CnameFrom:             "customer1.example.com",
+							CnameType:             HostnameCnameTypeEdgeHostname,
+							StagingCertType:       CertProvisioningTypeDefault,
+							StagingCnameTo:        "saas.example.com.edgekey.net",
+							StagingEdgeHostnameID: "ehn_895822",
+							CertStatus: &CertStatusItem{
+								ValidationCname: ValidationCname{
+									Hostname: "_acme-challenge.customer1.example.com",
+									Target:   "ac.1234.dv.edgekey.net",
+								},
+								Staging: []StatusItem{{Status: "PENDING"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		"200 OK - only property ID": {
+			request:        GetPropertyHostnamesRequest{PropertyID: "prp_175780"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"propertyId": "prp_175780", "hostnames": {"items": []}}`,
+			expectedPath:   "/papi/v1/properties/prp_175780/hostnames",
+			expectedResponse: &GetPropertyHostnamesResponse{
+				PropertyID: "prp_175780",
+				Hostnames:  PropertyHostnameItems{Items: []PropertyHostname{}},
+			},
+		},
+		"404 not found": {
+			request:        GetPropertyHostnamesRequest{PropertyID: "prp_1"},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/properties/prp_1/hostnames",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing property ID": {
+			request:   GetPropertyHostnamesRequest{ContractID: "ctr_1-1TJZFW"},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid network": {
+			request:   GetPropertyHostnamesRequest{PropertyID: "prp_175780", Network: "QA"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPropertyHostnames(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_PatchPropertyHostnames(t *testing.T) {
+	tests := map[string]struct {
+		request             PatchPropertyHostnamesRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *PatchPropertyHostnamesResponse
+		withError           error
+	}{
+		"200 OK": {
+			request: PatchPropertyHostnamesRequest{
+				PropertyID:   "prp_175780",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Network:      ActivationNetworkProduction,
+				Note:         "onboard customer2",
+				NotifyEmails: []string{"jsmith@example.com"},
+				Add: []PropertyHostnameAdd{
+					{
+						CnameFrom:            "customer2.example.com",
+						CnameType:            HostnameCnameTypeEdgeHostname,
+						EdgeHostnameID:       "ehn_895822",
+						CertProvisioningType: CertProvisioningTypeDefault,
+					},
+				},
+				Remove: []string{"customer0.example.com"},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "propertyId": "prp_175780",
+    "network": "PRODUCTION",
+    "activationLink": "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+    "hostnames": [
+        {
+            "action": "ADD",
+            "cnameFrom": "customer2.example.com",
+            "cnameTo": "saas.example.com.edgekey.net",
+            "cnameType": "EDGE_HOSTNAME",
+            "edgeHostnameId": "ehn_895822",
+            "certProvisioningType": "DEFAULT"
+        },
+        {
+            "action": "REMOVE",
+            "cnameFrom": "customer0.example.com"
+        }
+    ]
+}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/hostnames?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `{"network":"PRODUCTION","note":"onboard customer2","notifyEmails":["jsmith@example.com"],"add":[{"cnameFrom":"customer2.example.com","cnameType":"EDGE_HOSTNAME","edgeHostnameId":"ehn_895822","certProvisioningType":"DEFAULT"}],"remove":["customer0.example.com"]}`,
+			expectedResponse: &PatchPropertyHostnamesResponse{
+				AccountID:      "act_1-1TJZFB",
+				ContractID:     "ctr_1-1TJZFW",
+				GroupID:        "grp_15166",
+				PropertyID:     "prp_175780",
+				Network:        ActivationNetworkProduction,
+				ActivationID:   "atv_1696985",
+				ActivationLink: "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+				Hostnames: []HostnameActivationHostname{
+					{
+						Action:               HostnameActionAdd,
+						CnameFrom:            "customer2.example.com",
+						CnameTo:              "saas.example.com.edgekey.net",
+						CnameType:            HostnameCnameTypeEdgeHostname,
+						EdgeHostnameID:       "ehn_895822",
+						CertProvisioningType: CertProvisioningTypeDefault,
+					},
+					{
+						Action:    HostnameActionRemove,
+						CnameFrom: "customer0.example.com",
+					},
+				},
+			},
+		},
+		"200 OK - remove only with cert status": {
+			request: PatchPropertyHostnamesRequest{
+				PropertyID:        "prp_175780",
+				IncludeCertStatus: true,
+				Network:           ActivationNetworkStaging,
+				Remove:            []string{"customer0.example.com"},
+			},
+			responseStatus:      http.StatusOK,
+			responseBody:        `{"propertyId": "prp_175780", "network": "STAGING", "activationLink": "/papi/v1/properties/prp_175780/hostname-activations/atv_1696986", "hostnames": [{"action": "REMOVE", "cnameFrom": "customer0.example.com"}]}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/hostnames?includeCertStatus=true",
+			expectedRequestBody: `{"network":"STAGING","remove":["customer0.example.com"]}`,
+			expectedResponse: &PatchPropertyHostnamesResponse{
+				PropertyID:     "prp_175780",
+				Network:        ActivationNetworkStaging,
+				ActivationID:   "atv_1696986",
+				ActivationLink: "/papi/v1/properties/prp_175780/hostname-activations/atv_1696986",
+				Hostnames:      []HostnameActivationHostname{{Action: HostnameActionRemove, CnameFrom: "customer0.example.com"}},
+			},
+		},
+		"400 bad request": {
+			request: PatchPropertyHostnamesRequest{
+				PropertyID: "prp_175780",
+				Network:    ActivationNetworkStaging,
+				Remove:     []string{"unknown.example.com"},
+			},
+			responseStatus: http.StatusBadRequest,
+			responseBody: `
+{
+    "type": "bad_request",
+    "title": "Bad Request",
+    "detail": "Hostname unknown.example.com is not assigned to the property",
+    "status": 400
+}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/hostnames",
+			expectedRequestBody: `{"network":"STAGING","remove":["unknown.example.com"]}`,
+			withError: &Error{
+				Type:       "bad_request",
+				Title:      "Bad Request",
+				Detail:     "Hostname unknown.example.com is not assigned to the property",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		"validation error - nothing to add or remove": {
+			request:   PatchPropertyHostnamesRequest{PropertyID: "prp_175780", Network: ActivationNetworkStaging},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing network": {
+			request:   PatchPropertyHostnamesRequest{PropertyID: "prp_175780", Remove: []string{"customer0.example.com"}},
+			withError: ErrStructValidation,
+		},
+		"validation error - invalid cert provisioning type": {
+			request: PatchPropertyHostnamesRequest{
+				PropertyID: "prp_175780",
+				Network:    ActivationNetworkStaging,
+				Add: []PropertyHostnameAdd{
+					{
+						CnameFrom:            "customer2.example.com",
+						CnameType:            HostnameCnameTypeEdgeHostname,
+						EdgeHostnameID:       "ehn_895822",
+						CertProvisioningType: "SELF_SIGNED",
+					},
+				},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.PatchPropertyHostnames(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_ListPropertyHostnameActivations(t *testing.T) {
+	tests := map[string]struct {
+		request          ListPropertyHostnameActivationsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListPropertyHostnameActivationsResponse
+		withError        error
+	}{
+		"200 OK": {
+			request: ListPropertyHostnameActivationsRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Limit:      1,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "hostnameActivations": {
+        "currentItemCount": 1,
+        "totalItems": 4,
+        "nextLink": "/papi/v1/properties/prp_175780/hostname-activations?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=1&offset=1",
+        "items": [
+            {
+                "activationType": "ACTIVATE",
+                "hostnameActivationId": "atv_1696985",
+                "propertyName": "saas.example.com",
+                "propertyId": "prp_175780",
+                "network": "PRODUCTION",
+                "status": "ACTIVE",
+                "submitDate": "2022-10-18T15:00:00Z",
+                "updateDate": "2022-10-18T15:10:00Z",
+                "note": "onboard customer2",
+                "notifyEmails": ["jsmith@example.com"]
+            }
+        ]
+    }
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/hostname-activations?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=1",
+			expectedResponse: &ListPropertyHostnameActivationsResponse{
+				AccountID:  "act_1-1TJZFB",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				HostnameActivations: HostnameActivationItems{
+					CurrentItemCount: 1,
+					TotalItems:       4,
+					NextLink:         "/papi/v1/properties/prp_175780/hostname-activations?contractId=ctr_1-1TJZFW&groupId=grp_15166&limit=1&offset=1",
+					Items: []HostnameActivation{
+						{
+							ActivationType:       ActivationTypeActivate,
+							HostnameActivationID: "atv_1696985",
+							PropertyName:         "saas.example.com",
+							PropertyID:           "prp_175780",
+							Network:              ActivationNetworkProduction,
+							Status:               ActivationStatusActive,
+							SubmitDate:           "2022-10-18T15:00:00Z",
+							UpdateDate:           "2022-10-18T15:10:00Z",
+							Note:                 "onboard customer2",
+							NotifyEmails:         []string{"jsmith@example.com"},
+						},
+					},
+				},
+			},
+		},
+		"500 internal server error": {
+			request:        ListPropertyHostnameActivationsRequest{PropertyID: "prp_175780"},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "status": 500
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/hostname-activations",
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - negative offset": {
+			request:   ListPropertyHostnameActivationsRequest{PropertyID: "prp_175780", Offset: -1},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListPropertyHostnameActivations(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetPropertyHostnameActivation(t *testing.T) {
+	tests := map[string]struct {
+		request          GetPropertyHostnameActivationRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *HostnameActivation
+		withError        error
+	}{
+		"200 OK with hostnames": {
+			request: GetPropertyHostnameActivationRequest{
+				PropertyID:           "prp_175780",
+				HostnameActivationID: "atv_1696985",
+				ContractID:           "ctr_1-1TJZFW",
+				GroupID:              "grp_15166",
+				IncludeHostnames:     true,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "hostnameActivations": {
+        "items": [
+            {
+                "activationType": "ACTIVATE",
+                "hostnameActivationId": "atv_1696985",
+                "propertyName": "saas.example.com",
+                "propertyId": "prp_175780",
+                "network": "STAGING",
+                "status": "PENDING",
+                "notifyEmails": [],
+                "hostnames": [
+                    {
+                        "action": "ADD",
+                        "cnameFrom": "customer2.example.com",
+                        "cnameTo": "saas.example.com.edgekey.net",
+                        "cnameType": "EDGE_HOSTNAME",
+                        "edgeHostnameId": "ehn_895822",
+                        "certProvisioningType": "CPS_MANAGED"
+                    }
+                ]
+            }
+        ]
+    }
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985?contractId=ctr_1-1TJZFW&groupId=grp_15166&includeHostnames=true",
+			expectedResponse: &HostnameActivation{
+				ActivationType:       ActivationTypeActivate,
+				HostnameActivationID: "atv_1696985",
+				PropertyName:         "saas.example.com",
+				PropertyID:           "prp_175780",
+				Network:              ActivationNetworkStaging,
+				Status:               ActivationStatusPending,
+				NotifyEmails:         []string{},
+				Hostnames: []HostnameActivationHostname{
+					{
+						Action:               HostnameActionAdd,
+						CnameFrom:            "customer2.example.com",
+						CnameTo:              "saas.example.com.edgekey.net",
+						CnameType:            HostnameCnameTypeEdgeHostname,
+						EdgeHostnameID:       "ehn_895822",
+						CertProvisioningType: CertProvisioningTypeCPSManaged,
+					},
+				},
+			},
+		},
+		"no activation in response": {
+			request:        GetPropertyHostnameActivationRequest{PropertyID: "prp_175780", HostnameActivationID: "atv_1"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"hostnameActivations": {"items": []}}`,
+			expectedPath:   "/papi/v1/properties/prp_175780/hostname-activations/atv_1",
+			withError:      ErrNotFound,
+		},
+		"404 not found": {
+			request:        GetPropertyHostnameActivationRequest{PropertyID: "prp_175780", HostnameActivationID: "atv_2"},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/properties/prp_175780/hostname-activations/atv_2",
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error - missing activation ID": {
+			request:   GetPropertyHostnameActivationRequest{PropertyID: "prp_175780"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPropertyHostnameActivation(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, &result.HostnameActivation)
+		})
+	}
+}
+
+func TestPapi_WaitForPropertyHostnameActivation(t *testing.T) {
+	request := GetPropertyHostnameActivationRequest{
+		PropertyID:           "prp_175780",
+		HostnameActivationID: "atv_1696985",
+	}
+	activationBody := func(status ActivationStatus) string {
+		return fmt.Sprintf(`{"hostnameActivations": {"items": [{"hostnameActivationId": "atv_1696985", "status": "%s"}]}}`, status)
+	}
+
+	tests := map[string]struct {
+		statuses         []ActivationStatus
+		responseStatus   int
+		expectedRequests int
+		expectedStatus   ActivationStatus
+		withError        error
+	}{
+		"polls until active": {
+			statuses:         []ActivationStatus{ActivationStatusPending, ActivationStatusZone2, ActivationStatusActive},
+			responseStatus:   http.StatusOK,
+			expectedRequests: 3,
+			expectedStatus:   ActivationStatusActive,
+		},
+		"failed activation is returned": {
+			statuses:         []ActivationStatus{ActivationStatusFailed},
+			responseStatus:   http.StatusOK,
+			expectedRequests: 1,
+			expectedStatus:   ActivationStatusFailed,
+		},
+		"api error stops polling": {
+			statuses:         []ActivationStatus{ActivationStatusPending},
+			responseStatus:   http.StatusInternalServerError,
+			expectedRequests: 1,
+			withError:        &Error{StatusCode: http.StatusInternalServerError},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_175780/hostname-activations/atv_1696985", r.URL.String())
+				status := test.statuses[requests]
+				requests++
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(activationBody(status)))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer, WithPollInterval(time.Millisecond, 2*time.Millisecond))
+			result, err := client.WaitForPropertyHostnameActivation(context.Background(), request)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, result.Status)
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(activationBody(ActivationStatusPending)))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer, WithPollInterval(time.Hour, time.Hour))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := client.WaitForPropertyHostnameActivation(ctx, request)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrWaitForPropertyHostnameActivation), "want: %s; got: %s", ErrWaitForPropertyHostnameActivation, err)
+	})
+}
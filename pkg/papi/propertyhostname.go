@@ -84,6 +84,7 @@ type (
 		GroupID           string
 		ValidateHostnames bool
 		IncludeCertStatus bool
+		Etag              string
 		Hostnames         []Hostname
 	}
 
@@ -186,6 +187,9 @@ func (p *papi) UpdatePropertyVersionHostnames(ctx context.Context, params Update
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdatePropertyVersionHostnames, err)
 	}
+	if params.Etag != "" {
+		req.Header.Set("If-Match", params.Etag)
+	}
 
 	var hostnames UpdatePropertyVersionHostnamesResponse
 	newHostnames := params.Hostnames
@@ -197,6 +201,9 @@ func (p *papi) UpdatePropertyVersionHostnames(ctx context.Context, params Update
 		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdatePropertyVersionHostnames, err)
 	}
 
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("%s: %w", ErrUpdatePropertyVersionHostnames, p.preconditionFailed(resp, params.Etag))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("%s: %w", ErrUpdatePropertyVersionHostnames, p.Error(resp))
 	}
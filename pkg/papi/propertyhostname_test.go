@@ -155,6 +155,7 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 		responseStatus   int
 		responseBody     string
 		expectedPath     string
+		expectedIfMatch  string
 		expectedResponse *UpdatePropertyVersionHostnamesResponse
 		withError        func(*testing.T, error)
 	}{
@@ -526,6 +527,31 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
 			},
 		},
+		"412 precondition failed": {
+			params: UpdatePropertyVersionHostnamesRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				GroupID:         "grp_15225",
+				ContractID:      "ctr_1-1TJZH5",
+				Etag:            "6aed418629b4e5c0",
+			},
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+	"title": "Precondition failed",
+	"detail": "The hostnames were modified since they were fetched",
+	"status": 412
+}`,
+			expectedPath:    "/papi/v1/properties/prp_175780/versions/3/hostnames?contractId=ctr_1-1TJZH5&groupId=grp_15225&includeCertStatus=false&validateHostnames=false",
+			expectedIfMatch: "6aed418629b4e5c0",
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrPreconditionFailed), "want: %s; got: %s", ErrPreconditionFailed, err)
+				var precondition *PreconditionFailedError
+				require.True(t, errors.As(err, &precondition))
+				assert.Equal(t, "6aed418629b4e5c0", precondition.Etag)
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -533,6 +559,7 @@ func TestPapi_UpdatePropertyVersionHostnames(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, test.expectedIfMatch, r.Header.Get("If-Match"))
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
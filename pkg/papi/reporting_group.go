@@ -0,0 +1,411 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ReportingGroups contains operations available on CP code reporting groups, using CPRG API
+	// See: https://techdocs.akamai.com/cp-codes/reference/api
+	ReportingGroups interface {
+		// GetReportingGroups lists reporting groups, optionally filtered by contract, group, CP code or name
+		// See: https://techdocs.akamai.com/cp-codes/reference/get-reporting-groups
+		GetReportingGroups(context.Context, GetReportingGroupsRequest) (*GetReportingGroupsResponse, error)
+
+		// GetReportingGroup gets the reporting group with provided ID
+		// See: https://techdocs.akamai.com/cp-codes/reference/get-reporting-group
+		GetReportingGroup(context.Context, GetReportingGroupRequest) (*ReportingGroup, error)
+
+		// CreateReportingGroup creates a new reporting group
+		// See: https://techdocs.akamai.com/cp-codes/reference/post-reporting-group
+		CreateReportingGroup(context.Context, CreateReportingGroupRequest) (*ReportingGroup, error)
+
+		// UpdateReportingGroup modifies name and CP codes of a reporting group
+		// See: https://techdocs.akamai.com/cp-codes/reference/put-reporting-group
+		UpdateReportingGroup(context.Context, UpdateReportingGroupRequest) (*ReportingGroup, error)
+
+		// DeleteReportingGroup removes a reporting group, CP codes in the group are not affected
+		// See: https://techdocs.akamai.com/cp-codes/reference/delete-reporting-group
+		DeleteReportingGroup(context.Context, DeleteReportingGroupRequest) error
+
+		// GetReportingGroupCPCodes lists CP codes in a reporting group across all its contracts
+		GetReportingGroupCPCodes(context.Context, GetReportingGroupCPCodesRequest) (*GetReportingGroupCPCodesResponse, error)
+
+		// GetCPCodeReportingGroups lists reporting groups containing the CP code
+		GetCPCodeReportingGroups(context.Context, GetCPCodeReportingGroupsRequest) (*GetReportingGroupsResponse, error)
+	}
+
+	// ReportingGroup contains reporting group data used in CPRG API calls
+	ReportingGroup struct {
+		ReportingGroupID   int                       `json:"reportingGroupId,omitempty"`
+		ReportingGroupName string                    `json:"reportingGroupName"`
+		AccessGroup        ReportingGroupAccessGroup `json:"accessGroup"`
+		Contracts          []ReportingGroupContract  `json:"contracts"`
+	}
+
+	// ReportingGroupAccessGroup is the group and contract controlling access to a reporting group
+	ReportingGroupAccessGroup struct {
+		GroupID    int    `json:"groupId"`
+		ContractID string `json:"contractId"`
+	}
+
+	// ReportingGroupContract contains CP codes of a single contract in a reporting group
+	ReportingGroupContract struct {
+		ContractID string                 `json:"contractId"`
+		CPCodes    []ReportingGroupCPCode `json:"cpcodes"`
+	}
+
+	// ReportingGroupCPCode is a CP code in a reporting group
+	ReportingGroupCPCode struct {
+		CPCodeID   int    `json:"cpcodeId"`
+		CPCodeName string `json:"cpcodeName,omitempty"`
+	}
+
+	// GetReportingGroupsRequest contains optional filters of the reporting group list
+	GetReportingGroupsRequest struct {
+		ContractID         string
+		GroupID            int
+		CPCodeID           int
+		ReportingGroupName string
+	}
+
+	// GetReportingGroupsResponse is a response returned while listing reporting groups
+	GetReportingGroupsResponse struct {
+		Groups []ReportingGroup `json:"groups"`
+	}
+
+	// GetReportingGroupRequest contains parameters required to fetch a reporting group
+	GetReportingGroupRequest struct {
+		ReportingGroupID int
+	}
+
+	// CreateReportingGroupRequest contains the request body for reporting group creation
+	CreateReportingGroupRequest struct {
+		ReportingGroupName string                    `json:"reportingGroupName"`
+		AccessGroup        ReportingGroupAccessGroup `json:"accessGroup"`
+		Contracts          []ReportingGroupContract  `json:"contracts"`
+	}
+
+	// UpdateReportingGroupRequest contains parameters required to update a reporting group.
+	// Contracts replace the current CP codes of the group, the access group cannot be changed
+	UpdateReportingGroupRequest struct {
+		ReportingGroupID   int                      `json:"-"`
+		ReportingGroupName string                   `json:"reportingGroupName"`
+		Contracts          []ReportingGroupContract `json:"contracts"`
+	}
+
+	// DeleteReportingGroupRequest contains parameters required to delete a reporting group
+	DeleteReportingGroupRequest struct {
+		ReportingGroupID int
+	}
+
+	// GetReportingGroupCPCodesRequest contains parameters required to list CP codes in a reporting group
+	GetReportingGroupCPCodesRequest struct {
+		ReportingGroupID int
+	}
+
+	// GetReportingGroupCPCodesResponse contains CP codes in a reporting group
+	GetReportingGroupCPCodesResponse struct {
+		ReportingGroupID   int
+		ReportingGroupName string
+		CPCodes            []ReportingGroupCPCodeItem
+	}
+
+	// ReportingGroupCPCodeItem is a CP code in a reporting group along with its contract
+	ReportingGroupCPCodeItem struct {
+		ContractID string
+		CPCodeID   int
+		CPCodeName string
+	}
+
+	// GetCPCodeReportingGroupsRequest contains parameters required to list reporting groups of a CP code
+	GetCPCodeReportingGroupsRequest struct {
+		CPCodeID int
+	}
+)
+
+// Validate validates ReportingGroupAccessGroup
+func (g ReportingGroupAccessGroup) Validate() error {
+	return validation.Errors{
+		"GroupID":    validation.Validate(g.GroupID, validation.Required),
+		"ContractID": validation.Validate(g.ContractID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ReportingGroupContract
+func (c ReportingGroupContract) Validate() error {
+	return validation.Errors{
+		"ContractID": validation.Validate(c.ContractID, validation.Required),
+		"CPCodes":    validation.Validate(c.CPCodes, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ReportingGroupCPCode
+func (c ReportingGroupCPCode) Validate() error {
+	return validation.Errors{
+		"CPCodeID": validation.Validate(c.CPCodeID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetReportingGroupRequest
+func (r GetReportingGroupRequest) Validate() error {
+	return validation.Errors{
+		"ReportingGroupID": validation.Validate(r.ReportingGroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateReportingGroupRequest
+func (r CreateReportingGroupRequest) Validate() error {
+	return validation.Errors{
+		"ReportingGroupName": validation.Validate(r.ReportingGroupName, validation.Required),
+		"AccessGroup":        validation.Validate(r.AccessGroup),
+		"Contracts":          validation.Validate(r.Contracts, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateReportingGroupRequest
+func (r UpdateReportingGroupRequest) Validate() error {
+	return validation.Errors{
+		"ReportingGroupID":   validation.Validate(r.ReportingGroupID, validation.Required),
+		"ReportingGroupName": validation.Validate(r.ReportingGroupName, validation.Required),
+		"Contracts":          validation.Validate(r.Contracts, validation.Required),
+	}.Filter()
+}
+
+// Validate validates DeleteReportingGroupRequest
+func (r DeleteReportingGroupRequest) Validate() error {
+	return validation.Errors{
+		"ReportingGroupID": validation.Validate(r.ReportingGroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetReportingGroupCPCodesRequest
+func (r GetReportingGroupCPCodesRequest) Validate() error {
+	return validation.Errors{
+		"ReportingGroupID": validation.Validate(r.ReportingGroupID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetCPCodeReportingGroupsRequest
+func (r GetCPCodeReportingGroupsRequest) Validate() error {
+	return validation.Errors{
+		"CPCodeID": validation.Validate(r.CPCodeID, validation.Required),
+	}.Filter()
+}
+
+var (
+	// ErrGetReportingGroups represents error when fetching reporting groups fails
+	ErrGetReportingGroups = errors.New("fetching reporting groups")
+	// ErrGetReportingGroup represents error when fetching reporting group fails
+	ErrGetReportingGroup = errors.New("fetching reporting group")
+	// ErrCreateReportingGroup represents error when creating reporting group fails
+	ErrCreateReportingGroup = errors.New("creating reporting group")
+	// ErrUpdateReportingGroup represents error when updating reporting group fails
+	ErrUpdateReportingGroup = errors.New("updating reporting group")
+	// ErrDeleteReportingGroup represents error when deleting reporting group fails
+	ErrDeleteReportingGroup = errors.New("deleting reporting group")
+	// ErrGetReportingGroupCPCodes represents error when fetching CP codes of reporting group fails
+	ErrGetReportingGroupCPCodes = errors.New("fetching reporting group CP codes")
+	// ErrGetCPCodeReportingGroups represents error when fetching reporting groups of CP code fails
+	ErrGetCPCodeReportingGroups = errors.New("fetching CP code reporting groups")
+)
+
+func (p *papi) GetReportingGroups(ctx context.Context, params GetReportingGroupsRequest) (*GetReportingGroupsResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetReportingGroups")
+
+	uri, err := url.Parse("/cprg/v1/reporting-groups")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetReportingGroups, err)
+	}
+	q := uri.Query()
+	if params.ContractID != "" {
+		q.Add("contractId", params.ContractID)
+	}
+	if params.GroupID != 0 {
+		q.Add("groupId", strconv.Itoa(params.GroupID))
+	}
+	if params.CPCodeID != 0 {
+		q.Add("cpcodeId", strconv.Itoa(params.CPCodeID))
+	}
+	if params.ReportingGroupName != "" {
+		q.Add("reportingGroupName", params.ReportingGroupName)
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetReportingGroups, err)
+	}
+
+	var rval GetReportingGroupsResponse
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetReportingGroups, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetReportingGroups, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) GetReportingGroup(ctx context.Context, params GetReportingGroupRequest) (*ReportingGroup, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetReportingGroup, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetReportingGroup")
+
+	getURL := fmt.Sprintf("/cprg/v1/reporting-groups/%d", params.ReportingGroupID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetReportingGroup, err)
+	}
+
+	var rval ReportingGroup
+	resp, err := p.Exec(req, &rval)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetReportingGroup, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetReportingGroup, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) CreateReportingGroup(ctx context.Context, params CreateReportingGroupRequest) (*ReportingGroup, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateReportingGroup, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("CreateReportingGroup")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/cprg/v1/reporting-groups", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateReportingGroup, err)
+	}
+
+	var rval ReportingGroup
+	resp, err := p.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateReportingGroup, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateReportingGroup, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) UpdateReportingGroup(ctx context.Context, params UpdateReportingGroupRequest) (*ReportingGroup, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateReportingGroup, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("UpdateReportingGroup")
+
+	updateURL := fmt.Sprintf("/cprg/v1/reporting-groups/%d", params.ReportingGroupID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, updateURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateReportingGroup, err)
+	}
+
+	var rval ReportingGroup
+	resp, err := p.Exec(req, &rval, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateReportingGroup, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateReportingGroup, p.Error(resp))
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) DeleteReportingGroup(ctx context.Context, params DeleteReportingGroupRequest) error {
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrDeleteReportingGroup, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("DeleteReportingGroup")
+
+	deleteURL := fmt.Sprintf("/cprg/v1/reporting-groups/%d", params.ReportingGroupID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, deleteURL, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeleteReportingGroup, err)
+	}
+
+	resp, err := p.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeleteReportingGroup, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %w", ErrDeleteReportingGroup, p.Error(resp))
+	}
+
+	return nil
+}
+
+func (p *papi) GetReportingGroupCPCodes(ctx context.Context, params GetReportingGroupCPCodesRequest) (*GetReportingGroupCPCodesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetReportingGroupCPCodes, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetReportingGroupCPCodes")
+
+	group, err := p.GetReportingGroup(ctx, GetReportingGroupRequest{ReportingGroupID: params.ReportingGroupID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetReportingGroupCPCodes, err)
+	}
+
+	rval := GetReportingGroupCPCodesResponse{
+		ReportingGroupID:   group.ReportingGroupID,
+		ReportingGroupName: group.ReportingGroupName,
+	}
+	for _, contract := range group.Contracts {
+		for _, cpCode := range contract.CPCodes {
+			rval.CPCodes = append(rval.CPCodes, ReportingGroupCPCodeItem{
+				ContractID: contract.ContractID,
+				CPCodeID:   cpCode.CPCodeID,
+				CPCodeName: cpCode.CPCodeName,
+			})
+		}
+	}
+
+	return &rval, nil
+}
+
+func (p *papi) GetCPCodeReportingGroups(ctx context.Context, params GetCPCodeReportingGroupsRequest) (*GetReportingGroupsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetCPCodeReportingGroups, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetCPCodeReportingGroups")
+
+	rval, err := p.GetReportingGroups(ctx, GetReportingGroupsRequest{CPCodeID: params.CPCodeID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetCPCodeReportingGroups, err)
+	}
+
+	return rval, nil
+}
@@ -0,0 +1,412 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reportingGroupBody = `
+{
+    "reportingGroupId": 12345,
+    "reportingGroupName": "billing-group",
+    "accessGroup": {
+        "groupId": 15225,
+        "contractId": "ctr_1-1TJZH5"
+    },
+    "contracts": [
+        {
+            "contractId": "ctr_1-1TJZH5",
+            "cpcodes": [
+                {"cpcodeId": 123, "cpcodeName": "main"},
+                {"cpcodeId": 456, "cpcodeName": "images"}
+            ]
+        },
+        {
+            "contractId": "ctr_2-2ABCDE",
+            "cpcodes": [
+                {"cpcodeId": 789, "cpcodeName": "downloads"}
+            ]
+        }
+    ]
+}`
+
+var reportingGroup = ReportingGroup{
+	ReportingGroupID:   12345,
+	ReportingGroupName: "billing-group",
+	AccessGroup:        ReportingGroupAccessGroup{GroupID: 15225, ContractID: "ctr_1-1TJZH5"},
+	Contracts: []ReportingGroupContract{
+		{
+			ContractID: "ctr_1-1TJZH5",
+			CPCodes: []ReportingGroupCPCode{
+				{CPCodeID: 123, CPCodeName: "main"},
+				{CPCodeID: 456, CPCodeName: "images"},
+			},
+		},
+		{
+			ContractID: "ctr_2-2ABCDE",
+			CPCodes:    []ReportingGroupCPCode{{CPCodeID: 789, CPCodeName: "downloads"}},
+		},
+	},
+}
+
+func TestPapi_GetReportingGroups(t *testing.T) {
+	tests := map[string]struct {
+		params         GetReportingGroupsRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		expected       *GetReportingGroupsResponse
+		withError      func(*testing.T, error)
+	}{
+		"200 OK": {
+			params:         GetReportingGroupsRequest{ContractID: "ctr_1-1TJZH5", GroupID: 15225, ReportingGroupName: "billing group"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"groups": [` + reportingGroupBody + `]}`,
+			expectedPath:   "/cprg/v1/reporting-groups?contractId=ctr_1-1TJZH5&groupId=15225&reportingGroupName=billing+group",
+			expected:       &GetReportingGroupsResponse{Groups: []ReportingGroup{reportingGroup}},
+		},
+		"200 OK without filters": {
+			responseStatus: http.StatusOK,
+			responseBody:   `{"groups": []}`,
+			expectedPath:   "/cprg/v1/reporting-groups",
+			expected:       &GetReportingGroupsResponse{Groups: []ReportingGroup{}},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching reporting groups",
+    "status": 500
+}`,
+			expectedPath: "/cprg/v1/reporting-groups",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error fetching reporting groups",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetReportingGroups(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestPapi_GetReportingGroup(t *testing.T) {
+	tests := map[string]struct {
+		params         GetReportingGroupRequest
+		responseStatus int
+		responseBody   string
+		expectedPath   string
+		expected       *ReportingGroup
+		withError      func(*testing.T, error)
+	}{
+		"200 OK": {
+			params:         GetReportingGroupRequest{ReportingGroupID: 12345},
+			responseStatus: http.StatusOK,
+			responseBody:   reportingGroupBody,
+			expectedPath:   "/cprg/v1/reporting-groups/12345",
+			expected:       &reportingGroup,
+		},
+		"404 not found": {
+			params:         GetReportingGroupRequest{ReportingGroupID: 1},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not_found", "title": "Not Found", "status": 404}`,
+			expectedPath:   "/cprg/v1/reporting-groups/1",
+			withError: func(t *testing.T, err error) {
+				want := &Error{Type: "not_found", Title: "Not Found", StatusCode: http.StatusNotFound}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error": {
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "ReportingGroupID")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetReportingGroup(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestPapi_CreateReportingGroup(t *testing.T) {
+	tests := map[string]struct {
+		params          CreateReportingGroupRequest
+		responseStatus  int
+		responseBody    string
+		expectedRequest string
+		expected        *ReportingGroup
+		withError       func(*testing.T, error)
+	}{
+		"201 Created": {
+			params: CreateReportingGroupRequest{
+				ReportingGroupName: "billing-group",
+				AccessGroup:        reportingGroup.AccessGroup,
+				Contracts:          reportingGroup.Contracts,
+			},
+			responseStatus: http.StatusCreated,
+			responseBody:   reportingGroupBody,
+			expectedRequest: `
+{
+    "reportingGroupName": "billing-group",
+    "accessGroup": {"groupId": 15225, "contractId": "ctr_1-1TJZH5"},
+    "contracts": [
+        {"contractId": "ctr_1-1TJZH5", "cpcodes": [{"cpcodeId": 123, "cpcodeName": "main"}, {"cpcodeId": 456, "cpcodeName": "images"}]},
+        {"contractId": "ctr_2-2ABCDE", "cpcodes": [{"cpcodeId": 789, "cpcodeName": "downloads"}]}
+    ]
+}`,
+			expected: &reportingGroup,
+		},
+		"validation error": {
+			params: CreateReportingGroupRequest{
+				ReportingGroupName: "billing-group",
+				Contracts:          []ReportingGroupContract{{ContractID: "ctr_1-1TJZH5"}},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "AccessGroup")
+				assert.Contains(t, err.Error(), "CPCodes")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cprg/v1/reporting-groups", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateReportingGroup(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestPapi_UpdateReportingGroup(t *testing.T) {
+	tests := map[string]struct {
+		params          UpdateReportingGroupRequest
+		responseStatus  int
+		responseBody    string
+		expectedRequest string
+		expected        *ReportingGroup
+		withError       func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: UpdateReportingGroupRequest{
+				ReportingGroupID:   12345,
+				ReportingGroupName: "billing-group",
+				Contracts:          reportingGroup.Contracts,
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   reportingGroupBody,
+			expectedRequest: `
+{
+    "reportingGroupName": "billing-group",
+    "contracts": [
+        {"contractId": "ctr_1-1TJZH5", "cpcodes": [{"cpcodeId": 123, "cpcodeName": "main"}, {"cpcodeId": 456, "cpcodeName": "images"}]},
+        {"contractId": "ctr_2-2ABCDE", "cpcodes": [{"cpcodeId": 789, "cpcodeName": "downloads"}]}
+    ]
+}`,
+			expected: &reportingGroup,
+		},
+		"400 bad request": {
+			params: UpdateReportingGroupRequest{
+				ReportingGroupID:   12345,
+				ReportingGroupName: "billing-group",
+				Contracts:          []ReportingGroupContract{{ContractID: "ctr_1-1TJZH5", CPCodes: []ReportingGroupCPCode{{CPCodeID: 1}}}},
+			},
+			responseStatus: http.StatusBadRequest,
+			responseBody:   `{"type": "bad_request", "title": "Bad Request", "detail": "CP code 1 not found", "status": 400}`,
+			expectedRequest: `
+{
+    "reportingGroupName": "billing-group",
+    "contracts": [{"contractId": "ctr_1-1TJZH5", "cpcodes": [{"cpcodeId": 1}]}]
+}`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{Type: "bad_request", Title: "Bad Request", Detail: "CP code 1 not found", StatusCode: http.StatusBadRequest}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error": {
+			params: UpdateReportingGroupRequest{ReportingGroupName: "billing-group"},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "ReportingGroupID")
+				assert.Contains(t, err.Error(), "Contracts")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cprg/v1/reporting-groups/12345", r.URL.String())
+				assert.Equal(t, http.MethodPut, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequest, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdateReportingGroup(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestPapi_DeleteReportingGroup(t *testing.T) {
+	tests := map[string]struct {
+		params         DeleteReportingGroupRequest
+		responseStatus int
+		responseBody   string
+		withError      func(*testing.T, error)
+	}{
+		"204 No Content": {
+			params:         DeleteReportingGroupRequest{ReportingGroupID: 12345},
+			responseStatus: http.StatusNoContent,
+		},
+		"403 forbidden": {
+			params:         DeleteReportingGroupRequest{ReportingGroupID: 12345},
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"type": "forbidden", "title": "Forbidden", "status": 403}`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{Type: "forbidden", Title: "Forbidden", StatusCode: http.StatusForbidden}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"validation error": {
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/cprg/v1/reporting-groups/12345", r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DeleteReportingGroup(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_GetReportingGroupCPCodes(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cprg/v1/reporting-groups/12345", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(reportingGroupBody))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	result, err := client.GetReportingGroupCPCodes(context.Background(), GetReportingGroupCPCodesRequest{ReportingGroupID: 12345})
+	require.NoError(t, err)
+	assert.Equal(t, &GetReportingGroupCPCodesResponse{
+		ReportingGroupID:   12345,
+		ReportingGroupName: "billing-group",
+		CPCodes: []ReportingGroupCPCodeItem{
+			{ContractID: "ctr_1-1TJZH5", CPCodeID: 123, CPCodeName: "main"},
+			{ContractID: "ctr_1-1TJZH5", CPCodeID: 456, CPCodeName: "images"},
+			{ContractID: "ctr_2-2ABCDE", CPCodeID: 789, CPCodeName: "downloads"},
+		},
+	}, result)
+
+	_, err = client.GetReportingGroupCPCodes(context.Background(), GetReportingGroupCPCodesRequest{})
+	assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+}
+
+func TestPapi_GetCPCodeReportingGroups(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/cprg/v1/reporting-groups?cpcodeId=456", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"groups": [` + reportingGroupBody + `]}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+
+	result, err := client.GetCPCodeReportingGroups(context.Background(), GetCPCodeReportingGroupsRequest{CPCodeID: 456})
+	require.NoError(t, err)
+	assert.Equal(t, &GetReportingGroupsResponse{Groups: []ReportingGroup{reportingGroup}}, result)
+
+	_, err = client.GetCPCodeReportingGroups(context.Background(), GetCPCodeReportingGroupsRequest{})
+	assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+}
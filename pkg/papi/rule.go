@@ -22,6 +22,10 @@ type (
 		// UpdateRuleTree lists all available CP codes
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#putpropertyversionrules
 		UpdateRuleTree(context.Context, UpdateRulesRequest) (*UpdateRulesResponse, error)
+
+		// PatchRuleTree updates parts of the rule tree using JSON patch operations
+		// See: https://techdocs.akamai.com/property-mgr/reference/patch-property-version-rules
+		PatchRuleTree(context.Context, PatchRuleTreeRequest) (*UpdateRulesResponse, error)
 	}
 
 	// GetRuleTreeRequest contains path and query params necessary to perform GET /rules request
@@ -102,6 +106,7 @@ type (
 		GroupID         string
 		ValidateMode    string
 		ValidateRules   bool
+		Etag            string
 		Rules           RulesUpdate
 	}
 
@@ -127,11 +132,12 @@ type (
 
 	// RuleError represents and entry in error field from PUT /rules response body
 	RuleError struct {
-		Type         string `json:"type"`
-		Title        string `json:"title"`
-		Detail       string `json:"detail"`
-		Instance     string `json:"instance"`
-		BehaviorName string `json:"behaviorName"`
+		Type          string `json:"type"`
+		Title         string `json:"title"`
+		Detail        string `json:"detail"`
+		Instance      string `json:"instance"`
+		BehaviorName  string `json:"behaviorName"`
+		ErrorLocation string `json:"errorLocation,omitempty"`
 	}
 
 	// RuleOptionsMap is a type wrapping map[string]interface{} used for adding rule options
@@ -298,12 +304,18 @@ func (p *papi) UpdateRuleTree(ctx context.Context, request UpdateRulesRequest) (
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateRuleTree, err)
 	}
+	if request.Etag != "" {
+		req.Header.Set("If-Match", request.Etag)
+	}
 
 	var versions UpdateRulesResponse
 	resp, err := p.Exec(req, &versions, request.Rules)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateRuleTree, err)
 	}
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateRuleTree, p.preconditionFailed(resp, request.Etag))
+	}
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("%s: %w", ErrUpdateRuleTree, p.Error(resp))
 	}
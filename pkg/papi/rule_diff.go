@@ -0,0 +1,468 @@
+package papi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// RuleTreeDiff contains structured changes between two rule trees
+	RuleTreeDiff struct {
+		Changes []RuleChange `json:"changes"`
+	}
+
+	// RuleChange represents a single change between two rule trees
+	// Rule and Pointer point to the new tree, except for removals which point to the old one
+	RuleChange struct {
+		Type     RuleChangeType `json:"type"`
+		Rule     string         `json:"rule"`
+		Pointer  string         `json:"pointer"`
+		From     string         `json:"from,omitempty"`
+		Name     string         `json:"name,omitempty"`
+		Option   string         `json:"option,omitempty"`
+		OldValue interface{}    `json:"oldValue,omitempty"`
+		NewValue interface{}    `json:"newValue,omitempty"`
+	}
+
+	// RuleChangeType represents type field values of RuleChange
+	RuleChangeType string
+
+	ruleNode struct {
+		rule     *Rules
+		path     string
+		pointer  string
+		parent   *ruleNode
+		children []*ruleNode
+		match    *ruleNode
+		moved    bool
+	}
+)
+
+const (
+	// RuleChangeRuleAdded const
+	RuleChangeRuleAdded RuleChangeType = "RULE_ADDED"
+	// RuleChangeRuleRemoved const
+	RuleChangeRuleRemoved RuleChangeType = "RULE_REMOVED"
+	// RuleChangeRuleMoved const
+	RuleChangeRuleMoved RuleChangeType = "RULE_MOVED"
+	// RuleChangeRuleRenamed const
+	RuleChangeRuleRenamed RuleChangeType = "RULE_RENAMED"
+	// RuleChangeBehaviorAdded const
+	RuleChangeBehaviorAdded RuleChangeType = "BEHAVIOR_ADDED"
+	// RuleChangeBehaviorRemoved const
+	RuleChangeBehaviorRemoved RuleChangeType = "BEHAVIOR_REMOVED"
+	// RuleChangeCriterionAdded const
+	RuleChangeCriterionAdded RuleChangeType = "CRITERION_ADDED"
+	// RuleChangeCriterionRemoved const
+	RuleChangeCriterionRemoved RuleChangeType = "CRITERION_REMOVED"
+	// RuleChangeOptionChanged const
+	RuleChangeOptionChanged RuleChangeType = "OPTION_CHANGED"
+	// RuleChangeVariableAdded const
+	RuleChangeVariableAdded RuleChangeType = "VARIABLE_ADDED"
+	// RuleChangeVariableRemoved const
+	RuleChangeVariableRemoved RuleChangeType = "VARIABLE_REMOVED"
+	// RuleChangeVariableChanged const
+	RuleChangeVariableChanged RuleChangeType = "VARIABLE_CHANGED"
+	// RuleChangeCriteriaMustSatisfy const
+	RuleChangeCriteriaMustSatisfy RuleChangeType = "CRITERIA_MUST_SATISFY_CHANGED"
+	// RuleChangeRuleFieldChanged const
+	RuleChangeRuleFieldChanged RuleChangeType = "RULE_FIELD_CHANGED"
+)
+
+// DiffRules compares two rule trees and returns the changes needed to turn from into to
+// Rules are matched by UUID when present on both sides, otherwise by name under the matched parent rule
+func DiffRules(from, to Rules) *RuleTreeDiff {
+	oldRoot := newRuleNode(&from, nil, "/rules")
+	newRoot := newRuleNode(&to, nil, "/rules")
+	oldRoot.match, newRoot.match = newRoot, oldRoot
+
+	matchByUUID(oldRoot, newRoot)
+	matchByName(newRoot)
+	newRoot.walk(markDisplaced)
+
+	diff := &RuleTreeDiff{Changes: []RuleChange{}}
+	diff.walkNew(newRoot, false)
+	diff.walkOld(oldRoot, false)
+	return diff
+}
+
+// HasChanges returns true if the diff contains any change
+func (d *RuleTreeDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// JSON returns machine-readable, indented JSON representation of the diff
+func (d *RuleTreeDiff) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// WriteText writes human-readable representation of the diff, one change per line
+func (d *RuleTreeDiff) WriteText(w io.Writer) error {
+	for _, change := range d.Changes {
+		if _, err := fmt.Fprintln(w, change.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns human-readable representation of the diff
+func (d *RuleTreeDiff) String() string {
+	var b strings.Builder
+	_ = d.WriteText(&b)
+	return b.String()
+}
+
+// String returns human-readable representation of the change
+func (c RuleChange) String() string {
+	switch c.Type {
+	case RuleChangeRuleAdded:
+		return fmt.Sprintf("+ %s: rule added", c.Rule)
+	case RuleChangeRuleRemoved:
+		return fmt.Sprintf("- %s: rule removed", c.Rule)
+	case RuleChangeRuleMoved:
+		return fmt.Sprintf("> %s: rule moved from %s", c.Rule, c.From)
+	case RuleChangeRuleRenamed:
+		return fmt.Sprintf("~ %s: rule renamed from %q", c.Rule, c.OldValue)
+	case RuleChangeBehaviorAdded:
+		return fmt.Sprintf("+ %s: behavior %q added", c.Rule, c.Name)
+	case RuleChangeBehaviorRemoved:
+		return fmt.Sprintf("- %s: behavior %q removed", c.Rule, c.Name)
+	case RuleChangeCriterionAdded:
+		return fmt.Sprintf("+ %s: criterion %q added", c.Rule, c.Name)
+	case RuleChangeCriterionRemoved:
+		return fmt.Sprintf("- %s: criterion %q removed", c.Rule, c.Name)
+	case RuleChangeOptionChanged:
+		return fmt.Sprintf("~ %s: %s.%s: %s -> %s", c.Rule, c.Name, c.Option, formatDiffValue(c.OldValue), formatDiffValue(c.NewValue))
+	case RuleChangeVariableAdded:
+		return fmt.Sprintf("+ %s: variable %q added", c.Rule, c.Name)
+	case RuleChangeVariableRemoved:
+		return fmt.Sprintf("- %s: variable %q removed", c.Rule, c.Name)
+	case RuleChangeVariableChanged:
+		return fmt.Sprintf("~ %s: variable %q: %s -> %s", c.Rule, c.Name, formatDiffValue(c.OldValue), formatDiffValue(c.NewValue))
+	case RuleChangeCriteriaMustSatisfy:
+		return fmt.Sprintf("~ %s: criteriaMustSatisfy: %s -> %s", c.Rule, c.OldValue, c.NewValue)
+	case RuleChangeRuleFieldChanged:
+		return fmt.Sprintf("~ %s: %s: %s -> %s", c.Rule, c.Name, formatDiffValue(c.OldValue), formatDiffValue(c.NewValue))
+	}
+	return fmt.Sprintf("? %s: %s", c.Rule, c.Type)
+}
+
+func formatDiffValue(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func newRuleNode(rule *Rules, parent *ruleNode, pointer string) *ruleNode {
+	node := &ruleNode{rule: rule, parent: parent, pointer: pointer, path: rule.Name}
+	if parent != nil {
+		node.path = parent.path + "/" + rule.Name
+	}
+	for i := range rule.Children {
+		node.children = append(node.children, newRuleNode(&rule.Children[i], node, pointer+"/children/"+strconv.Itoa(i)))
+	}
+	return node
+}
+
+func (n *ruleNode) walk(fn func(*ruleNode)) {
+	fn(n)
+	for _, child := range n.children {
+		child.walk(fn)
+	}
+}
+
+func matchByUUID(oldRoot, newRoot *ruleNode) {
+	oldByUUID := make(map[string]*ruleNode)
+	duplicated := make(map[string]bool)
+	oldRoot.walk(func(n *ruleNode) {
+		if n.parent == nil || n.rule.UUID == "" {
+			return
+		}
+		if _, ok := oldByUUID[n.rule.UUID]; ok {
+			duplicated[n.rule.UUID] = true
+		}
+		oldByUUID[n.rule.UUID] = n
+	})
+	newRoot.walk(func(n *ruleNode) {
+		if n.parent == nil || n.rule.UUID == "" || duplicated[n.rule.UUID] {
+			return
+		}
+		if old, ok := oldByUUID[n.rule.UUID]; ok && old.match == nil {
+			old.match, n.match = n, old
+		}
+	})
+}
+
+func matchByName(n *ruleNode) {
+	if n.match != nil {
+		for _, child := range n.children {
+			if child.match != nil {
+				continue
+			}
+			for _, candidate := range n.match.children {
+				if candidate.match == nil && candidate.rule.Name == child.rule.Name {
+					candidate.match, child.match = child, candidate
+					break
+				}
+			}
+		}
+	}
+	for _, child := range n.children {
+		matchByName(child)
+	}
+}
+
+func (d *RuleTreeDiff) walkNew(n *ruleNode, parentAdded bool) {
+	switch {
+	case n.match == nil:
+		if !parentAdded {
+			d.add(RuleChange{Type: RuleChangeRuleAdded, Rule: n.path, Pointer: n.pointer})
+		}
+	default:
+		if n.rule.Name != n.match.rule.Name {
+			d.add(RuleChange{Type: RuleChangeRuleRenamed, Rule: n.path, Pointer: n.pointer, From: n.match.path,
+				OldValue: n.match.rule.Name, NewValue: n.rule.Name})
+		}
+		if n.parent != nil && (n.parent.match != n.match.parent || n.moved) {
+			d.add(RuleChange{Type: RuleChangeRuleMoved, Rule: n.path, Pointer: n.pointer, From: n.match.path})
+		}
+		d.diffRule(n.match, n)
+	}
+	for _, child := range n.children {
+		d.walkNew(child, n.match == nil)
+	}
+}
+
+func (d *RuleTreeDiff) walkOld(n *ruleNode, parentRemoved bool) {
+	if n.match == nil && !parentRemoved {
+		d.add(RuleChange{Type: RuleChangeRuleRemoved, Rule: n.path, Pointer: n.pointer})
+	}
+	for _, child := range n.children {
+		d.walkOld(child, n.match == nil)
+	}
+}
+
+// markDisplaced marks children of the rule which changed their order relative to siblings kept under the same parent
+// The longest common subsequence of the old and new sibling order stays in place, so that inserting, removing
+// or moving a single rule reports only that rule as moved
+func markDisplaced(n *ruleNode) {
+	if n.match == nil {
+		return
+	}
+	var newOrder, oldOrder []*ruleNode
+	for _, child := range n.children {
+		if child.match != nil && child.match.parent == n.match {
+			newOrder = append(newOrder, child)
+		}
+	}
+	for _, child := range n.match.children {
+		if child.match != nil && child.match.parent == n {
+			oldOrder = append(oldOrder, child.match)
+		}
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of newOrder[i:] and oldOrder[j:]
+	lengths := make([][]int, len(newOrder)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(oldOrder)+1)
+	}
+	for i := len(newOrder) - 1; i >= 0; i-- {
+		for j := len(oldOrder) - 1; j >= 0; j-- {
+			switch {
+			case newOrder[i] == oldOrder[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(newOrder); {
+		switch {
+		case j < len(oldOrder) && newOrder[i] == oldOrder[j]:
+			i, j = i+1, j+1
+		case j < len(oldOrder) && lengths[i][j+1] >= lengths[i+1][j]:
+			j++
+		default:
+			newOrder[i].moved = true
+			i++
+		}
+	}
+}
+
+func (d *RuleTreeDiff) diffRule(from, to *ruleNode) {
+	oldMustSatisfy, newMustSatisfy := from.rule.CriteriaMustSatisfy, to.rule.CriteriaMustSatisfy
+	if oldMustSatisfy == "" {
+		oldMustSatisfy = RuleCriteriaMustSatisfyAll
+	}
+	if newMustSatisfy == "" {
+		newMustSatisfy = RuleCriteriaMustSatisfyAll
+	}
+	if oldMustSatisfy != newMustSatisfy {
+		d.add(RuleChange{Type: RuleChangeCriteriaMustSatisfy, Rule: to.path, Pointer: to.pointer + "/criteriaMustSatisfy",
+			OldValue: oldMustSatisfy, NewValue: newMustSatisfy})
+	}
+
+	d.diffRuleField(to, "options.is_secure", "/options/is_secure", from.rule.Options.IsSecure, to.rule.Options.IsSecure)
+	d.diffRuleField(to, "comments", "/comments", from.rule.Comments, to.rule.Comments)
+	d.diffRuleField(to, "customOverride", "/customOverride", customOverrideValue(from.rule.CustomOverride), customOverrideValue(to.rule.CustomOverride))
+	d.diffRuleField(to, "advancedOverride", "/advancedOverride", from.rule.AdvancedOverride, to.rule.AdvancedOverride)
+
+	d.diffBehaviors(from, to, from.rule.Criteria, to.rule.Criteria, "criteria", RuleChangeCriterionAdded, RuleChangeCriterionRemoved)
+	d.diffBehaviors(from, to, from.rule.Behaviors, to.rule.Behaviors, "behaviors", RuleChangeBehaviorAdded, RuleChangeBehaviorRemoved)
+	d.diffVariables(from, to)
+}
+
+func (d *RuleTreeDiff) diffRuleField(to *ruleNode, name, pointer string, from, value interface{}) {
+	if reflect.DeepEqual(from, value) {
+		return
+	}
+	d.add(RuleChange{Type: RuleChangeRuleFieldChanged, Rule: to.path, Pointer: to.pointer + pointer, Name: name,
+		OldValue: from, NewValue: value})
+}
+
+// customOverrideValue returns untyped nil for missing override, so that it is omitted from JSON and rendered as <none>
+func customOverrideValue(override *RuleCustomOverride) interface{} {
+	if override == nil {
+		return nil
+	}
+	return *override
+}
+
+func (d *RuleTreeDiff) diffBehaviors(from, to *ruleNode, oldItems, newItems []RuleBehavior, field string, added, removed RuleChangeType) {
+	matched := make([]int, len(newItems))
+	used := make([]bool, len(oldItems))
+	for i := range newItems {
+		matched[i] = -1
+		if newItems[i].UUID == "" {
+			continue
+		}
+		for j := range oldItems {
+			if !used[j] && oldItems[j].UUID == newItems[i].UUID {
+				matched[i], used[j] = j, true
+				break
+			}
+		}
+	}
+	for i := range newItems {
+		if matched[i] != -1 {
+			continue
+		}
+		for j := range oldItems {
+			if !used[j] && oldItems[j].Name == newItems[i].Name && (oldItems[j].UUID == "" || newItems[i].UUID == "") {
+				matched[i], used[j] = j, true
+				break
+			}
+		}
+	}
+
+	for j := range oldItems {
+		if !used[j] {
+			d.add(RuleChange{Type: removed, Rule: from.path, Pointer: fmt.Sprintf("%s/%s/%d", from.pointer, field, j), Name: oldItems[j].Name})
+		}
+	}
+	for i := range newItems {
+		pointer := fmt.Sprintf("%s/%s/%d", to.pointer, field, i)
+		if matched[i] == -1 {
+			d.add(RuleChange{Type: added, Rule: to.path, Pointer: pointer, Name: newItems[i].Name})
+			continue
+		}
+		d.diffOptions(to.path, pointer+"/options", newItems[i].Name, "",
+			normalizeOptions(oldItems[matched[i]].Options), normalizeOptions(newItems[i].Options))
+	}
+}
+
+func (d *RuleTreeDiff) diffOptions(rule, pointer, name, option string, from, to interface{}) {
+	oldMap, oldIsMap := from.(map[string]interface{})
+	newMap, newIsMap := to.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := make(map[string]struct{}, len(oldMap)+len(newMap))
+		for k := range oldMap {
+			keys[k] = struct{}{}
+		}
+		for k := range newMap {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			child := k
+			if option != "" {
+				child = option + "." + k
+			}
+			d.diffOptions(rule, pointer+"/"+escapeJSONPointer(k), name, child, oldMap[k], newMap[k])
+		}
+		return
+	}
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+	d.add(RuleChange{Type: RuleChangeOptionChanged, Rule: rule, Pointer: pointer, Name: name, Option: option,
+		OldValue: from, NewValue: to})
+}
+
+func (d *RuleTreeDiff) diffVariables(from, to *ruleNode) {
+	oldVars := make(map[string]RuleVariable, len(from.rule.Variables))
+	for _, v := range from.rule.Variables {
+		oldVars[v.Name] = v
+	}
+	newVars := make(map[string]struct{}, len(to.rule.Variables))
+	for i, v := range to.rule.Variables {
+		newVars[v.Name] = struct{}{}
+		pointer := fmt.Sprintf("%s/variables/%d", to.pointer, i)
+		old, ok := oldVars[v.Name]
+		if !ok {
+			d.add(RuleChange{Type: RuleChangeVariableAdded, Rule: to.path, Pointer: pointer, Name: v.Name, NewValue: v})
+			continue
+		}
+		if old != v {
+			d.add(RuleChange{Type: RuleChangeVariableChanged, Rule: to.path, Pointer: pointer, Name: v.Name, OldValue: old, NewValue: v})
+		}
+	}
+	for i, v := range from.rule.Variables {
+		if _, ok := newVars[v.Name]; !ok {
+			d.add(RuleChange{Type: RuleChangeVariableRemoved, Rule: from.path, Pointer: fmt.Sprintf("%s/variables/%d", from.pointer, i),
+				Name: v.Name, OldValue: v})
+		}
+	}
+}
+
+func (d *RuleTreeDiff) add(change RuleChange) {
+	d.Changes = append(d.Changes, change)
+}
+
+// normalizeOptions converts options to the form produced by JSON decoding, so that e.g. int and float64 values compare equal
+func normalizeOptions(options RuleOptionsMap) interface{} {
+	if options == nil {
+		return map[string]interface{}{}
+	}
+	b, err := json.Marshal(options)
+	if err != nil {
+		return map[string]interface{}(options)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(b, &normalized); err != nil {
+		return map[string]interface{}(options)
+	}
+	return normalized
+}
+
+func escapeJSONPointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,275 @@
+package papi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRules(t *testing.T) {
+	base := func() Rules {
+		return Rules{
+			Name: "default",
+			Behaviors: []RuleBehavior{
+				{
+					Name: "origin",
+					Options: RuleOptionsMap{
+						"hostname": "origin.example.com",
+						"httpPort": 80,
+						"cacheKey": map[string]interface{}{"type": "ORIGIN_HOSTNAME"},
+					},
+				},
+				{Name: "cpCode", Options: RuleOptionsMap{"value": map[string]interface{}{"id": 12345}}},
+			},
+			Variables: []RuleVariable{
+				{Name: "PMUSER_ORIGIN", Value: "origin.example.com"},
+			},
+			Children: []Rules{
+				{
+					Name: "Performance",
+					UUID: "uuid-performance",
+					Children: []Rules{
+						{Name: "JPEG Images", UUID: "uuid-jpeg"},
+						{Name: "Compressible Objects"},
+					},
+				},
+				{
+					Name: "Offload",
+					Criteria: []RuleBehavior{
+						{Name: "fileExtension", Options: RuleOptionsMap{"values": []interface{}{"jpg", "png"}}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		modify   func(*Rules)
+		expected []RuleChange
+	}{
+		"no changes": {
+			modify:   func(*Rules) {},
+			expected: []RuleChange{},
+		},
+		"numeric types are normalized": {
+			modify: func(r *Rules) {
+				r.Behaviors[0].Options["httpPort"] = float64(80)
+			},
+			expected: []RuleChange{},
+		},
+		"option changed, added and removed": {
+			modify: func(r *Rules) {
+				r.Behaviors[0].Options["hostname"] = "new-origin.example.com"
+				r.Behaviors[0].Options["cacheKey"] = map[string]interface{}{"type": "CUSTOM", "custom/value": "x"}
+				delete(r.Behaviors[0].Options, "httpPort")
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeOptionChanged, Rule: "default", Pointer: "/rules/behaviors/0/options/cacheKey/custom~1value", Name: "origin", Option: "cacheKey.custom/value", NewValue: "x"},
+				{Type: RuleChangeOptionChanged, Rule: "default", Pointer: "/rules/behaviors/0/options/cacheKey/type", Name: "origin", Option: "cacheKey.type", OldValue: "ORIGIN_HOSTNAME", NewValue: "CUSTOM"},
+				{Type: RuleChangeOptionChanged, Rule: "default", Pointer: "/rules/behaviors/0/options/hostname", Name: "origin", Option: "hostname", OldValue: "origin.example.com", NewValue: "new-origin.example.com"},
+				{Type: RuleChangeOptionChanged, Rule: "default", Pointer: "/rules/behaviors/0/options/httpPort", Name: "origin", Option: "httpPort", OldValue: float64(80)},
+			},
+		},
+		"behaviors and criteria added and removed": {
+			modify: func(r *Rules) {
+				r.Behaviors = r.Behaviors[:1]
+				r.Behaviors = append(r.Behaviors, RuleBehavior{Name: "caching", Options: RuleOptionsMap{"behavior": "NO_STORE"}})
+				r.Children[1].Criteria = []RuleBehavior{{Name: "path", Options: RuleOptionsMap{"values": []interface{}{"/static/*"}}}}
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeBehaviorRemoved, Rule: "default", Pointer: "/rules/behaviors/1", Name: "cpCode"},
+				{Type: RuleChangeBehaviorAdded, Rule: "default", Pointer: "/rules/behaviors/1", Name: "caching"},
+				{Type: RuleChangeCriterionRemoved, Rule: "default/Offload", Pointer: "/rules/children/1/criteria/0", Name: "fileExtension"},
+				{Type: RuleChangeCriterionAdded, Rule: "default/Offload", Pointer: "/rules/children/1/criteria/0", Name: "path"},
+			},
+		},
+		"rules added and removed": {
+			modify: func(r *Rules) {
+				r.Children[0].Children = r.Children[0].Children[:1]
+				r.Children = append(r.Children, Rules{Name: "Security", Children: []Rules{{Name: "WAF"}}})
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeRuleAdded, Rule: "default/Security", Pointer: "/rules/children/2"},
+				{Type: RuleChangeRuleRemoved, Rule: "default/Performance/Compressible Objects", Pointer: "/rules/children/0/children/1"},
+			},
+		},
+		"rule renamed and moved by uuid": {
+			modify: func(r *Rules) {
+				jpeg := r.Children[0].Children[0]
+				r.Children[0].Children = r.Children[0].Children[1:]
+				r.Children[0].Name = "Speed"
+				r.Children[1].Children = []Rules{jpeg}
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeRuleRenamed, Rule: "default/Speed", Pointer: "/rules/children/0", From: "default/Performance", OldValue: "Performance", NewValue: "Speed"},
+				{Type: RuleChangeRuleMoved, Rule: "default/Offload/JPEG Images", Pointer: "/rules/children/1/children/0", From: "default/Performance/JPEG Images"},
+			},
+		},
+		"sibling rules reordered": {
+			modify: func(r *Rules) {
+				r.Children[0], r.Children[1] = r.Children[1], r.Children[0]
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeRuleMoved, Rule: "default/Performance", Pointer: "/rules/children/1", From: "default/Performance"},
+			},
+		},
+		"rule inserted before siblings is not a move": {
+			modify: func(r *Rules) {
+				r.Children = append([]Rules{{Name: "First"}}, r.Children...)
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeRuleAdded, Rule: "default/First", Pointer: "/rules/children/0"},
+			},
+		},
+		"rule fields changed": {
+			modify: func(r *Rules) {
+				r.Options.IsSecure = true
+				r.Comments = "The default rule"
+				r.Children[0].CustomOverride = &RuleCustomOverride{Name: "mdc", OverrideID: "cbo_12345"}
+				r.Children[1].AdvancedOverride = "<match:any/>"
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeRuleFieldChanged, Rule: "default", Pointer: "/rules/options/is_secure", Name: "options.is_secure",
+					OldValue: false, NewValue: true},
+				{Type: RuleChangeRuleFieldChanged, Rule: "default", Pointer: "/rules/comments", Name: "comments",
+					OldValue: "", NewValue: "The default rule"},
+				{Type: RuleChangeRuleFieldChanged, Rule: "default/Performance", Pointer: "/rules/children/0/customOverride", Name: "customOverride",
+					NewValue: RuleCustomOverride{Name: "mdc", OverrideID: "cbo_12345"}},
+				{Type: RuleChangeRuleFieldChanged, Rule: "default/Offload", Pointer: "/rules/children/1/advancedOverride", Name: "advancedOverride",
+					OldValue: "", NewValue: "<match:any/>"},
+			},
+		},
+		"variables and criteria must satisfy": {
+			modify: func(r *Rules) {
+				r.Variables[0].Value = "other.example.com"
+				r.Variables = append(r.Variables, RuleVariable{Name: "PMUSER_NEW", Hidden: true})
+				r.Children[1].CriteriaMustSatisfy = RuleCriteriaMustSatisfyAny
+			},
+			expected: []RuleChange{
+				{Type: RuleChangeVariableChanged, Rule: "default", Pointer: "/rules/variables/0", Name: "PMUSER_ORIGIN",
+					OldValue: RuleVariable{Name: "PMUSER_ORIGIN", Value: "origin.example.com"},
+					NewValue: RuleVariable{Name: "PMUSER_ORIGIN", Value: "other.example.com"}},
+				{Type: RuleChangeVariableAdded, Rule: "default", Pointer: "/rules/variables/1", Name: "PMUSER_NEW",
+					NewValue: RuleVariable{Name: "PMUSER_NEW", Hidden: true}},
+				{Type: RuleChangeCriteriaMustSatisfy, Rule: "default/Offload", Pointer: "/rules/children/1/criteriaMustSatisfy",
+					OldValue: RuleCriteriaMustSatisfyAll, NewValue: RuleCriteriaMustSatisfyAny},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			to := base()
+			test.modify(&to)
+			diff := DiffRules(base(), to)
+			assert.Equal(t, test.expected, diff.Changes)
+			assert.Equal(t, len(test.expected) > 0, diff.HasChanges())
+		})
+	}
+}
+
+func TestDiffRules_Order(t *testing.T) {
+	tests := map[string]struct {
+		from     []string
+		to       []string
+		expected []string
+	}{
+		"unchanged": {
+			from:     []string{"A", "B", "C", "D"},
+			to:       []string{"A", "B", "C", "D"},
+			expected: []string{},
+		},
+		"rule moved to front": {
+			from:     []string{"A", "B", "C", "D"},
+			to:       []string{"D", "A", "B", "C"},
+			expected: []string{"> default/D: rule moved from default/D"},
+		},
+		"rule moved to back": {
+			from:     []string{"A", "B", "C", "D"},
+			to:       []string{"B", "C", "D", "A"},
+			expected: []string{"> default/A: rule moved from default/A"},
+		},
+		"rule inserted and removed": {
+			from: []string{"A", "B", "C", "D"},
+			to:   []string{"A", "E", "C", "D"},
+			expected: []string{
+				"+ default/E: rule added",
+				"- default/B: rule removed",
+			},
+		},
+		"order reversed": {
+			from: []string{"A", "B", "C"},
+			to:   []string{"C", "B", "A"},
+			expected: []string{
+				"> default/B: rule moved from default/B",
+				"> default/A: rule moved from default/A",
+			},
+		},
+	}
+
+	rules := func(names []string) Rules {
+		r := Rules{Name: "default"}
+		for _, name := range names {
+			r.Children = append(r.Children, Rules{Name: name})
+		}
+		return r
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff := DiffRules(rules(test.from), rules(test.to))
+			changes := make([]string, 0, len(diff.Changes))
+			for _, change := range diff.Changes {
+				changes = append(changes, change.String())
+			}
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
+
+func TestRuleTreeDiff_Render(t *testing.T) {
+	from := Rules{
+		Name:    "default",
+		Options: RuleOptions{IsSecure: true},
+		Behaviors: []RuleBehavior{
+			{Name: "origin", Options: RuleOptionsMap{"hostname": "origin.example.com"}},
+		},
+		Children: []Rules{{Name: "Offload"}},
+	}
+	to := Rules{
+		Name: "default",
+		Behaviors: []RuleBehavior{
+			{Name: "origin", Options: RuleOptionsMap{"hostname": "new.example.com"}},
+			{Name: "caching", Options: RuleOptionsMap{"behavior": "NO_STORE"}},
+		},
+		Children: []Rules{{Name: "Security"}},
+	}
+	diff := DiffRules(from, to)
+
+	var text bytes.Buffer
+	require.NoError(t, diff.WriteText(&text))
+	assert.Equal(t, `~ default: options.is_secure: true -> false
+~ default: origin.hostname: "origin.example.com" -> "new.example.com"
++ default: behavior "caching" added
++ default/Security: rule added
+- default/Offload: rule removed
+`, text.String())
+	assert.Equal(t, text.String(), diff.String())
+
+	b, err := diff.JSON()
+	require.NoError(t, err)
+	var decoded RuleTreeDiff
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded.Changes, 5)
+	assert.Equal(t, RuleChange{
+		Type:     RuleChangeOptionChanged,
+		Rule:     "default",
+		Pointer:  "/rules/behaviors/0/options/hostname",
+		Name:     "origin",
+		Option:   "hostname",
+		OldValue: "origin.example.com",
+		NewValue: "new.example.com",
+	}, decoded.Changes[1])
+}
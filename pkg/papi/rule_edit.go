@@ -0,0 +1,213 @@
+package papi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Edit operations below never modify the receiver. The rules along the edited path are copied,
+// while untouched subtrees are shared between the original and the returned tree
+
+var (
+	// ErrRuleEdit represents error when editing rule tree fails
+	ErrRuleEdit = errors.New("editing rule tree")
+)
+
+// UpdateRule replaces the rule under the path of child rule names with the result of fn
+func (r Rules) UpdateRule(names []string, fn func(Rules) (Rules, error)) (Rules, error) {
+	return r.updateAt(names, 0, fn)
+}
+
+func (r Rules) updateAt(names []string, depth int, fn func(Rules) (Rules, error)) (Rules, error) {
+	if depth == len(names) {
+		return fn(r)
+	}
+	index, err := childIndex(r, names[depth], names[:depth])
+	if err != nil {
+		return Rules{}, err
+	}
+	updated, err := r.Children[index].updateAt(names, depth+1, fn)
+	if err != nil {
+		return Rules{}, err
+	}
+	children := make([]Rules, len(r.Children))
+	copy(children, r.Children)
+	children[index] = updated
+	r.Children = children
+	return r, nil
+}
+
+// ReplaceRule replaces the rule under the path of child rule names with the given rule
+func (r Rules) ReplaceRule(names []string, rule Rules) (Rules, error) {
+	return r.UpdateRule(names, func(Rules) (Rules, error) {
+		return rule, nil
+	})
+}
+
+// InsertChild inserts child at the index among children of the rule under parent name path
+// A negative index appends child as the last one
+func (r Rules) InsertChild(parent []string, index int, child Rules) (Rules, error) {
+	return r.UpdateRule(parent, func(rule Rules) (Rules, error) {
+		if index < 0 {
+			index = len(rule.Children)
+		}
+		if index > len(rule.Children) {
+			return Rules{}, fmt.Errorf("%w: index %d out of range for %d children of %q", ErrRuleEdit, index, len(rule.Children), rule.Name)
+		}
+		children := make([]Rules, 0, len(rule.Children)+1)
+		children = append(children, rule.Children[:index]...)
+		children = append(children, child)
+		children = append(children, rule.Children[index:]...)
+		rule.Children = children
+		return rule, nil
+	})
+}
+
+// InsertChildAfter inserts child right after the rule under sibling name path
+func (r Rules) InsertChildAfter(sibling []string, child Rules) (Rules, error) {
+	if len(sibling) == 0 {
+		return Rules{}, fmt.Errorf("%w: cannot insert a sibling of the root rule", ErrRuleEdit)
+	}
+	parent := sibling[:len(sibling)-1]
+	parentRule, _, err := r.RuleByPath(parent...)
+	if err != nil {
+		return Rules{}, err
+	}
+	index, err := childIndex(parentRule, sibling[len(sibling)-1], parent)
+	if err != nil {
+		return Rules{}, err
+	}
+	return r.InsertChild(parent, index+1, child)
+}
+
+// RemoveRule removes the rule under the path of child rule names together with its children
+func (r Rules) RemoveRule(names ...string) (Rules, error) {
+	if len(names) == 0 {
+		return Rules{}, fmt.Errorf("%w: cannot remove the root rule", ErrRuleEdit)
+	}
+	parent, name := names[:len(names)-1], names[len(names)-1]
+	return r.UpdateRule(parent, func(rule Rules) (Rules, error) {
+		index, err := childIndex(rule, name, parent)
+		if err != nil {
+			return Rules{}, err
+		}
+		children := make([]Rules, 0, len(rule.Children)-1)
+		children = append(children, rule.Children[:index]...)
+		children = append(children, rule.Children[index+1:]...)
+		rule.Children = children
+		return rule, nil
+	})
+}
+
+// ReorderChildren reorders children of the rule under parent name path to follow the given names
+// order has to contain the names of all children exactly once
+func (r Rules) ReorderChildren(parent []string, order []string) (Rules, error) {
+	return r.UpdateRule(parent, func(rule Rules) (Rules, error) {
+		if len(order) != len(rule.Children) {
+			return Rules{}, fmt.Errorf("%w: got %d names for %d children of %q", ErrRuleEdit, len(order), len(rule.Children), rule.Name)
+		}
+		children := make([]Rules, 0, len(rule.Children))
+		used := make([]bool, len(rule.Children))
+		for _, name := range order {
+			index, err := childIndex(rule, name, parent)
+			if err != nil {
+				return Rules{}, err
+			}
+			if used[index] {
+				return Rules{}, fmt.Errorf("%w: rule %q listed more than once", ErrRuleEdit, name)
+			}
+			used[index] = true
+			children = append(children, rule.Children[index])
+		}
+		rule.Children = children
+		return rule, nil
+	})
+}
+
+// SetBehaviorOptions merges options into every behavior with the given name in the rule under the path of child rule names
+// Nested option maps are merged recursively, options which are not set in options are left untouched
+func (r Rules) SetBehaviorOptions(names []string, behavior string, options RuleOptionsMap) (Rules, error) {
+	return r.UpdateRule(names, func(rule Rules) (Rules, error) {
+		behaviors := make([]RuleBehavior, len(rule.Behaviors))
+		copy(behaviors, rule.Behaviors)
+		var found bool
+		for i := range behaviors {
+			if behaviors[i].Name != behavior {
+				continue
+			}
+			found = true
+			behaviors[i].Options = mergeOptions(behaviors[i].Options, options)
+		}
+		if !found {
+			return Rules{}, fmt.Errorf("%w: behavior %q in rule %q", ErrNotFound, behavior, strings.Join(append([]string{r.Name}, names...), "/"))
+		}
+		rule.Behaviors = behaviors
+		return rule, nil
+	})
+}
+
+// AddBehavior appends behavior to behaviors of the rule under the path of child rule names
+func (r Rules) AddBehavior(names []string, behavior RuleBehavior) (Rules, error) {
+	return r.UpdateRule(names, func(rule Rules) (Rules, error) {
+		behaviors := make([]RuleBehavior, 0, len(rule.Behaviors)+1)
+		behaviors = append(behaviors, rule.Behaviors...)
+		rule.Behaviors = append(behaviors, behavior)
+		return rule, nil
+	})
+}
+
+// RemoveBehavior removes every behavior with the given name from the rule under the path of child rule names
+func (r Rules) RemoveBehavior(names []string, behavior string) (Rules, error) {
+	return r.UpdateRule(names, func(rule Rules) (Rules, error) {
+		behaviors := make([]RuleBehavior, 0, len(rule.Behaviors))
+		for _, b := range rule.Behaviors {
+			if b.Name != behavior {
+				behaviors = append(behaviors, b)
+			}
+		}
+		if len(behaviors) == len(rule.Behaviors) {
+			return Rules{}, fmt.Errorf("%w: behavior %q in rule %q", ErrNotFound, behavior, strings.Join(append([]string{r.Name}, names...), "/"))
+		}
+		rule.Behaviors = behaviors
+		return rule, nil
+	})
+}
+
+func mergeOptions(dst, src RuleOptionsMap) RuleOptionsMap {
+	merged := make(RuleOptionsMap, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = copyOptionValue(v)
+	}
+	for k, v := range src {
+		v = copyOptionValue(v)
+		existing, ok := merged[k].(map[string]interface{})
+		update, isMap := v.(map[string]interface{})
+		if ok && isMap {
+			merged[k] = map[string]interface{}(mergeOptions(existing, update))
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+func copyOptionValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			copied[k] = copyOptionValue(item)
+		}
+		return copied
+	case RuleOptionsMap:
+		return map[string]interface{}(mergeOptions(nil, value))
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, item := range value {
+			copied[i] = copyOptionValue(item)
+		}
+		return copied
+	}
+	return v
+}
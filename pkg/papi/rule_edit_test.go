@@ -0,0 +1,202 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_SetBehaviorOptions(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, ruleTreeFixture)
+	original := decodeRuleTreeFixture(t, ruleTreeFixture)
+
+	updated, err := rules.SetBehaviorOptions(nil, "cpCode", RuleOptionsMap{
+		"value": map[string]interface{}{"id": float64(67890)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": float64(67890), "name": "my CP code"}, updated.Behaviors[1].Options["value"])
+
+	updated, err = updated.SetBehaviorOptions([]string{"Compress Text Content"}, "gzipResponse", RuleOptionsMap{"behavior": "ORIGIN_RESPONSE"})
+	require.NoError(t, err)
+	assert.Equal(t, RuleOptionsMap{"behavior": "ORIGIN_RESPONSE"}, updated.Children[0].Behaviors[0].Options)
+
+	assert.Equal(t, original, rules, "receiver must not be modified")
+	assert.Equal(t, rules.Behaviors[0], updated.Behaviors[0])
+
+	_, err = rules.SetBehaviorOptions(nil, "caching", RuleOptionsMap{"ttl": "1d"})
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+	_, err = rules.SetBehaviorOptions([]string{"Static Content"}, "caching", RuleOptionsMap{"ttl": "1d"})
+	assert.True(t, errors.Is(err, ErrRulePath), "want: %s; got: %s", ErrRulePath, err)
+}
+
+func TestRules_SetBehaviorOptionsPreservesUnknownFields(t *testing.T) {
+	var rules Rules
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"name": "default",
+		"behaviors": [{
+			"name": "origin",
+			"options": {
+				"hostname": "origin.test.com",
+				"futureOption": {"enabled": true, "nested": [1, 2]}
+			}
+		}]
+	}`), &rules))
+
+	updated, err := rules.SetBehaviorOptions(nil, "origin", RuleOptionsMap{"hostname": "new.test.com"})
+	require.NoError(t, err)
+
+	b, err := json.Marshal(updated.Behaviors[0].Options)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"hostname": "new.test.com", "futureOption": {"enabled": true, "nested": [1, 2]}}`, string(b))
+
+	updated.Behaviors[0].Options["futureOption"].(map[string]interface{})["enabled"] = false
+	assert.Equal(t, true, rules.Behaviors[0].Options["futureOption"].(map[string]interface{})["enabled"])
+}
+
+func TestRules_InsertChild(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+	original := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+
+	tests := map[string]struct {
+		edit          func(Rules) (Rules, error)
+		expectedNames []string
+		parent        []string
+		withError     error
+	}{
+		"append to root": {
+			edit: func(r Rules) (Rules, error) {
+				return r.InsertChild(nil, -1, Rules{Name: "Images"})
+			},
+			expectedNames: []string{"Augment insights", "Images"},
+		},
+		"insert at index": {
+			edit: func(r Rules) (Rules, error) {
+				return r.InsertChild([]string{"Augment insights"}, 0, Rules{Name: "Images"})
+			},
+			parent:        []string{"Augment insights"},
+			expectedNames: []string{"Images", "Compress Text Content"},
+		},
+		"insert after sibling": {
+			edit: func(r Rules) (Rules, error) {
+				return r.InsertChildAfter([]string{"Augment insights", "Compress Text Content"}, Rules{Name: "Images"})
+			},
+			parent:        []string{"Augment insights"},
+			expectedNames: []string{"Compress Text Content", "Images"},
+		},
+		"index out of range": {
+			edit: func(r Rules) (Rules, error) {
+				return r.InsertChild(nil, 5, Rules{Name: "Images"})
+			},
+			withError: ErrRuleEdit,
+		},
+		"sibling of root": {
+			edit: func(r Rules) (Rules, error) {
+				return r.InsertChildAfter(nil, Rules{Name: "Images"})
+			},
+			withError: ErrRuleEdit,
+		},
+		"unknown sibling": {
+			edit: func(r Rules) (Rules, error) {
+				return r.InsertChildAfter([]string{"Augment insights", "Images"}, Rules{Name: "Other"})
+			},
+			withError: ErrRulePath,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			updated, err := test.edit(rules)
+			assert.Equal(t, original, rules, "receiver must not be modified")
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			parent, _, err := updated.RuleByPath(test.parent...)
+			require.NoError(t, err)
+			var names []string
+			for _, child := range parent.Children {
+				names = append(names, child.Name)
+			}
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+}
+
+func TestRules_RemoveRule(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+	original := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+
+	updated, err := rules.RemoveRule("Augment insights", "Compress Text Content")
+	require.NoError(t, err)
+	assert.Empty(t, updated.Children[0].Children)
+	assert.Equal(t, original, rules)
+
+	updated, err = rules.RemoveRule("Augment insights")
+	require.NoError(t, err)
+	assert.Empty(t, updated.Children)
+	assert.Empty(t, updated.FindCriteria("contentType"))
+
+	_, err = rules.RemoveRule()
+	assert.True(t, errors.Is(err, ErrRuleEdit), "want: %s; got: %s", ErrRuleEdit, err)
+	_, err = rules.RemoveRule("Images")
+	assert.True(t, errors.Is(err, ErrRulePath), "want: %s; got: %s", ErrRulePath, err)
+}
+
+func TestRules_ReorderChildren(t *testing.T) {
+	rules := Rules{
+		Name:     "default",
+		Children: []Rules{{Name: "A"}, {Name: "B"}, {Name: "C"}},
+	}
+
+	updated, err := rules.ReorderChildren(nil, []string{"C", "A", "B"})
+	require.NoError(t, err)
+	assert.Equal(t, []Rules{{Name: "C"}, {Name: "A"}, {Name: "B"}}, updated.Children)
+	assert.Equal(t, []Rules{{Name: "A"}, {Name: "B"}, {Name: "C"}}, rules.Children)
+
+	_, err = rules.ReorderChildren(nil, []string{"C", "A"})
+	assert.True(t, errors.Is(err, ErrRuleEdit), "want: %s; got: %s", ErrRuleEdit, err)
+	_, err = rules.ReorderChildren(nil, []string{"C", "A", "A"})
+	assert.True(t, errors.Is(err, ErrRuleEdit), "want: %s; got: %s", ErrRuleEdit, err)
+	_, err = rules.ReorderChildren(nil, []string{"C", "A", "D"})
+	assert.True(t, errors.Is(err, ErrRulePath), "want: %s; got: %s", ErrRulePath, err)
+}
+
+func TestRules_AddRemoveBehavior(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, ruleTreeFixture)
+	original := decodeRuleTreeFixture(t, ruleTreeFixture)
+
+	updated, err := rules.AddBehavior([]string{"Compress Text Content"}, RuleBehavior{Name: "caching", Options: RuleOptionsMap{"behavior": "NO_STORE"}})
+	require.NoError(t, err)
+	assert.Len(t, updated.Children[0].Behaviors, 2)
+	assert.Len(t, updated.FindBehaviors("caching"), 1)
+
+	updated, err = updated.RemoveBehavior(nil, "origin")
+	require.NoError(t, err)
+	assert.Empty(t, updated.FindBehaviors("origin"))
+	assert.Len(t, updated.Behaviors, 1)
+
+	_, err = rules.RemoveBehavior(nil, "caching")
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+	assert.Equal(t, original, rules)
+}
+
+func TestRules_ReplaceRule(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, ruleTreeFixture)
+
+	updated, err := rules.ReplaceRule([]string{"Compress Text Content"}, Rules{Name: "Compress Everything"})
+	require.NoError(t, err)
+	assert.Equal(t, []Rules{{Name: "Compress Everything"}}, updated.Children)
+	assert.Equal(t, "Compress Text Content", rules.Children[0].Name)
+
+	updated, err = rules.UpdateRule(nil, func(r Rules) (Rules, error) {
+		r.Comments = "root comment"
+		return r, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "root comment", updated.Comments)
+	assert.Empty(t, rules.Comments)
+}
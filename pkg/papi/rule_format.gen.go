@@ -0,0 +1,535 @@
+// Code generated by rulegen from rule format v2022-10-18 schema; DO NOT EDIT.
+
+package papi
+
+type (
+	// BehaviorAllowPost represents options of allowPost behavior
+	BehaviorAllowPost struct {
+		AllowWithoutContentLength *bool `json:"allowWithoutContentLength,omitempty"`
+		Enabled                   *bool `json:"enabled,omitempty"`
+	}
+
+	// BehaviorCPCode represents options of cpCode behavior
+	BehaviorCPCode struct {
+		Value *RuleFormatCPCode `json:"value,omitempty"`
+	}
+
+	// BehaviorCaching represents options of caching behavior
+	BehaviorCaching struct {
+		Behavior               *BehaviorCachingBehavior `json:"behavior,omitempty"`
+		CacheControlDirectives *string                  `json:"cacheControlDirectives,omitempty"`
+		DefaultTTL             *string                  `json:"defaultTtl,omitempty"`
+		EnhancedRfcSupport     *bool                    `json:"enhancedRfcSupport,omitempty"`
+		HonorMustRevalidate    *bool                    `json:"honorMustRevalidate,omitempty"`
+		HonorNoCache           *bool                    `json:"honorNoCache,omitempty"`
+		HonorNoStore           *bool                    `json:"honorNoStore,omitempty"`
+		HonorPrivate           *bool                    `json:"honorPrivate,omitempty"`
+		MustRevalidate         *bool                    `json:"mustRevalidate,omitempty"`
+		TTL                    *string                  `json:"ttl,omitempty"`
+	}
+
+	// BehaviorCachingBehavior represents values of behavior option of caching behavior
+	BehaviorCachingBehavior string
+
+	// BehaviorDownstreamCache represents options of downstreamCache behavior
+	BehaviorDownstreamCache struct {
+		AllowBehavior *BehaviorDownstreamCacheAllowBehavior `json:"allowBehavior,omitempty"`
+		Behavior      *BehaviorDownstreamCacheBehavior      `json:"behavior,omitempty"`
+		SendHeaders   *BehaviorDownstreamCacheSendHeaders   `json:"sendHeaders,omitempty"`
+		SendPrivate   *bool                                 `json:"sendPrivate,omitempty"`
+		TTL           *string                               `json:"ttl,omitempty"`
+	}
+
+	// BehaviorDownstreamCacheAllowBehavior represents values of allowBehavior option of downstreamCache behavior
+	BehaviorDownstreamCacheAllowBehavior string
+
+	// BehaviorDownstreamCacheBehavior represents values of behavior option of downstreamCache behavior
+	BehaviorDownstreamCacheBehavior string
+
+	// BehaviorDownstreamCacheSendHeaders represents values of sendHeaders option of downstreamCache behavior
+	BehaviorDownstreamCacheSendHeaders string
+
+	// BehaviorGzipResponse represents options of gzipResponse behavior
+	BehaviorGzipResponse struct {
+		Behavior *BehaviorGzipResponseBehavior `json:"behavior,omitempty"`
+	}
+
+	// BehaviorGzipResponseBehavior represents values of behavior option of gzipResponse behavior
+	BehaviorGzipResponseBehavior string
+
+	// BehaviorHTTP2 represents options of http2 behavior
+	BehaviorHTTP2 struct {
+		Enabled *BehaviorHTTP2Enabled `json:"enabled,omitempty"`
+	}
+
+	// BehaviorHTTP2Enabled represents values of enabled option of http2 behavior
+	BehaviorHTTP2Enabled string
+
+	// BehaviorModifyOutgoingResponseHeader represents options of modifyOutgoingResponseHeader behavior
+	BehaviorModifyOutgoingResponseHeader struct {
+		Action                   *BehaviorModifyOutgoingResponseHeaderAction `json:"action,omitempty"`
+		AvoidDuplicateHeaders    *bool                                       `json:"avoidDuplicateHeaders,omitempty"`
+		CustomHeaderName         *string                                     `json:"customHeaderName,omitempty"`
+		HeaderValue              *string                                     `json:"headerValue,omitempty"`
+		NewHeaderValue           *string                                     `json:"newHeaderValue,omitempty"`
+		StandardAddHeaderName    *string                                     `json:"standardAddHeaderName,omitempty"`
+		StandardDeleteHeaderName *string                                     `json:"standardDeleteHeaderName,omitempty"`
+		StandardModifyHeaderName *string                                     `json:"standardModifyHeaderName,omitempty"`
+	}
+
+	// BehaviorModifyOutgoingResponseHeaderAction represents values of action option of modifyOutgoingResponseHeader behavior
+	BehaviorModifyOutgoingResponseHeaderAction string
+
+	// BehaviorOrigin represents options of origin behavior
+	BehaviorOrigin struct {
+		CacheKeyHostname               *BehaviorOriginCacheKeyHostname   `json:"cacheKeyHostname,omitempty"`
+		Compress                       *bool                             `json:"compress,omitempty"`
+		CustomForwardHostHeader        *string                           `json:"customForwardHostHeader,omitempty"`
+		CustomValidCnValues            []string                          `json:"customValidCnValues,omitempty"`
+		EnableTrueClientIP             *bool                             `json:"enableTrueClientIp,omitempty"`
+		ForwardHostHeader              *BehaviorOriginForwardHostHeader  `json:"forwardHostHeader,omitempty"`
+		Hostname                       *string                           `json:"hostname,omitempty"`
+		HTTPPort                       *int                              `json:"httpPort,omitempty"`
+		HTTPSPort                      *int                              `json:"httpsPort,omitempty"`
+		IPVersion                      *BehaviorOriginIPVersion          `json:"ipVersion,omitempty"`
+		NetStorage                     *RuleFormatNetstorage             `json:"netStorage,omitempty"`
+		OriginCertsToHonor             *BehaviorOriginOriginCertsToHonor `json:"originCertsToHonor,omitempty"`
+		OriginID                       *int                              `json:"originId,omitempty"`
+		OriginSNI                      *bool                             `json:"originSni,omitempty"`
+		OriginType                     *BehaviorOriginOriginType         `json:"originType,omitempty"`
+		SecondHostname                 *string                           `json:"secondHostname,omitempty"`
+		SecondHostnameEnabled          *bool                             `json:"secondHostnameEnabled,omitempty"`
+		StandardCertificateAuthorities []string                          `json:"standardCertificateAuthorities,omitempty"`
+		TrueClientIPClientSetting      *bool                             `json:"trueClientIpClientSetting,omitempty"`
+		TrueClientIPHeader             *string                           `json:"trueClientIpHeader,omitempty"`
+		VerificationMode               *BehaviorOriginVerificationMode   `json:"verificationMode,omitempty"`
+	}
+
+	// BehaviorOriginCacheKeyHostname represents values of cacheKeyHostname option of origin behavior
+	BehaviorOriginCacheKeyHostname string
+
+	// BehaviorOriginForwardHostHeader represents values of forwardHostHeader option of origin behavior
+	BehaviorOriginForwardHostHeader string
+
+	// BehaviorOriginIPVersion represents values of ipVersion option of origin behavior
+	BehaviorOriginIPVersion string
+
+	// BehaviorOriginOriginCertsToHonor represents values of originCertsToHonor option of origin behavior
+	BehaviorOriginOriginCertsToHonor string
+
+	// BehaviorOriginOriginType represents values of originType option of origin behavior
+	BehaviorOriginOriginType string
+
+	// BehaviorOriginVerificationMode represents values of verificationMode option of origin behavior
+	BehaviorOriginVerificationMode string
+
+	// BehaviorPrefetch represents options of prefetch behavior
+	BehaviorPrefetch struct {
+		Enabled *bool `json:"enabled,omitempty"`
+	}
+
+	// BehaviorSureRoute represents options of sureRoute behavior
+	BehaviorSureRoute struct {
+		CustomMap       *string                        `json:"customMap,omitempty"`
+		CustomStatKey   *string                        `json:"customStatKey,omitempty"`
+		EnableCustomKey *bool                          `json:"enableCustomKey,omitempty"`
+		Enabled         *bool                          `json:"enabled,omitempty"`
+		ForceSSLForward *bool                          `json:"forceSslForward,omitempty"`
+		RaceStatTTL     *string                        `json:"raceStatTtl,omitempty"`
+		TestObjectURL   *string                        `json:"testObjectUrl,omitempty"`
+		ToHost          *string                        `json:"toHost,omitempty"`
+		ToHostStatus    *BehaviorSureRouteToHostStatus `json:"toHostStatus,omitempty"`
+		Type            *BehaviorSureRouteType         `json:"type,omitempty"`
+	}
+
+	// BehaviorSureRouteToHostStatus represents values of toHostStatus option of sureRoute behavior
+	BehaviorSureRouteToHostStatus string
+
+	// BehaviorSureRouteType represents values of type option of sureRoute behavior
+	BehaviorSureRouteType string
+
+	// CriterionContentType represents options of contentType criterion
+	CriterionContentType struct {
+		MatchCaseSensitive *bool                              `json:"matchCaseSensitive,omitempty"`
+		MatchOperator      *CriterionContentTypeMatchOperator `json:"matchOperator,omitempty"`
+		MatchWildcard      *bool                              `json:"matchWildcard,omitempty"`
+		Values             []string                           `json:"values,omitempty"`
+	}
+
+	// CriterionContentTypeMatchOperator represents values of matchOperator option of contentType criterion
+	CriterionContentTypeMatchOperator string
+
+	// CriterionFileExtension represents options of fileExtension criterion
+	CriterionFileExtension struct {
+		MatchCaseSensitive *bool                                `json:"matchCaseSensitive,omitempty"`
+		MatchOperator      *CriterionFileExtensionMatchOperator `json:"matchOperator,omitempty"`
+		Values             []string                             `json:"values,omitempty"`
+	}
+
+	// CriterionFileExtensionMatchOperator represents values of matchOperator option of fileExtension criterion
+	CriterionFileExtensionMatchOperator string
+
+	// CriterionHostname represents options of hostname criterion
+	CriterionHostname struct {
+		MatchOperator *CriterionHostnameMatchOperator `json:"matchOperator,omitempty"`
+		Values        []string                        `json:"values,omitempty"`
+	}
+
+	// CriterionHostnameMatchOperator represents values of matchOperator option of hostname criterion
+	CriterionHostnameMatchOperator string
+
+	// CriterionPath represents options of path criterion
+	CriterionPath struct {
+		MatchCaseSensitive *bool                       `json:"matchCaseSensitive,omitempty"`
+		MatchOperator      *CriterionPathMatchOperator `json:"matchOperator,omitempty"`
+		Normalize          *bool                       `json:"normalize,omitempty"`
+		Values             []string                    `json:"values,omitempty"`
+	}
+
+	// CriterionPathMatchOperator represents values of matchOperator option of path criterion
+	CriterionPathMatchOperator string
+
+	// CriterionRequestHeader represents options of requestHeader criterion
+	CriterionRequestHeader struct {
+		HeaderName              *string                              `json:"headerName,omitempty"`
+		MatchCaseSensitiveValue *bool                                `json:"matchCaseSensitiveValue,omitempty"`
+		MatchOperator           *CriterionRequestHeaderMatchOperator `json:"matchOperator,omitempty"`
+		MatchWildcardName       *bool                                `json:"matchWildcardName,omitempty"`
+		MatchWildcardValue      *bool                                `json:"matchWildcardValue,omitempty"`
+		Values                  []string                             `json:"values,omitempty"`
+	}
+
+	// CriterionRequestHeaderMatchOperator represents values of matchOperator option of requestHeader criterion
+	CriterionRequestHeaderMatchOperator string
+
+	// CriterionRequestMethod represents options of requestMethod criterion
+	CriterionRequestMethod struct {
+		MatchOperator *CriterionRequestMethodMatchOperator `json:"matchOperator,omitempty"`
+		Value         *CriterionRequestMethodValue         `json:"value,omitempty"`
+	}
+
+	// CriterionRequestMethodMatchOperator represents values of matchOperator option of requestMethod criterion
+	CriterionRequestMethodMatchOperator string
+
+	// CriterionRequestMethodValue represents values of value option of requestMethod criterion
+	CriterionRequestMethodValue string
+
+	// CriterionRequestProtocol represents options of requestProtocol criterion
+	CriterionRequestProtocol struct {
+		Value *CriterionRequestProtocolValue `json:"value,omitempty"`
+	}
+
+	// CriterionRequestProtocolValue represents values of value option of requestProtocol criterion
+	CriterionRequestProtocolValue string
+
+	// RuleFormatCPCode represents cpcode definition of the rule format
+	RuleFormatCPCode struct {
+		CPCodeLimits *RuleFormatCPCodeCPCodeLimits `json:"cpCodeLimits,omitempty"`
+		CreatedDate  *int                          `json:"createdDate,omitempty"`
+		Description  *string                       `json:"description,omitempty"`
+		ID           *int                          `json:"id,omitempty"`
+		Name         *string                       `json:"name,omitempty"`
+		Products     []string                      `json:"products,omitempty"`
+	}
+
+	// RuleFormatCPCodeCPCodeLimits represents cpCodeLimits option of cpcode definition of the rule format
+	RuleFormatCPCodeCPCodeLimits struct {
+		CurrentCapacity *int    `json:"currentCapacity,omitempty"`
+		Limit           *int    `json:"limit,omitempty"`
+		LimitType       *string `json:"limitType,omitempty"`
+	}
+
+	// RuleFormatNetstorage represents netstorage definition of the rule format
+	RuleFormatNetstorage struct {
+		CPCode             *int    `json:"cpCode,omitempty"`
+		DownloadDomainName *string `json:"downloadDomainName,omitempty"`
+		G2oToken           *string `json:"g2oToken,omitempty"`
+		ID                 *int    `json:"id,omitempty"`
+		Name               *string `json:"name,omitempty"`
+	}
+)
+
+const (
+	// GeneratedRuleFormat is the rule format typed behaviors and criteria were generated from
+	GeneratedRuleFormat = "v2022-10-18"
+
+	// BehaviorCachingBehaviorMaxAge const
+	BehaviorCachingBehaviorMaxAge BehaviorCachingBehavior = "MAX_AGE"
+	// BehaviorCachingBehaviorNoStore const
+	BehaviorCachingBehaviorNoStore BehaviorCachingBehavior = "NO_STORE"
+	// BehaviorCachingBehaviorBypassCache const
+	BehaviorCachingBehaviorBypassCache BehaviorCachingBehavior = "BYPASS_CACHE"
+	// BehaviorCachingBehaviorCacheControlAndExpires const
+	BehaviorCachingBehaviorCacheControlAndExpires BehaviorCachingBehavior = "CACHE_CONTROL_AND_EXPIRES"
+	// BehaviorCachingBehaviorCacheControl const
+	BehaviorCachingBehaviorCacheControl BehaviorCachingBehavior = "CACHE_CONTROL"
+	// BehaviorCachingBehaviorExpires const
+	BehaviorCachingBehaviorExpires BehaviorCachingBehavior = "EXPIRES"
+
+	// BehaviorDownstreamCacheAllowBehaviorLesser const
+	BehaviorDownstreamCacheAllowBehaviorLesser BehaviorDownstreamCacheAllowBehavior = "LESSER"
+	// BehaviorDownstreamCacheAllowBehaviorGreater const
+	BehaviorDownstreamCacheAllowBehaviorGreater BehaviorDownstreamCacheAllowBehavior = "GREATER"
+	// BehaviorDownstreamCacheAllowBehaviorRemainingLifetime const
+	BehaviorDownstreamCacheAllowBehaviorRemainingLifetime BehaviorDownstreamCacheAllowBehavior = "REMAINING_LIFETIME"
+	// BehaviorDownstreamCacheAllowBehaviorFromMaxAge const
+	BehaviorDownstreamCacheAllowBehaviorFromMaxAge BehaviorDownstreamCacheAllowBehavior = "FROM_MAX_AGE"
+	// BehaviorDownstreamCacheAllowBehaviorFromValue const
+	BehaviorDownstreamCacheAllowBehaviorFromValue BehaviorDownstreamCacheAllowBehavior = "FROM_VALUE"
+	// BehaviorDownstreamCacheAllowBehaviorPassOrigin const
+	BehaviorDownstreamCacheAllowBehaviorPassOrigin BehaviorDownstreamCacheAllowBehavior = "PASS_ORIGIN"
+
+	// BehaviorDownstreamCacheBehaviorAllow const
+	BehaviorDownstreamCacheBehaviorAllow BehaviorDownstreamCacheBehavior = "ALLOW"
+	// BehaviorDownstreamCacheBehaviorMustRevalidate const
+	BehaviorDownstreamCacheBehaviorMustRevalidate BehaviorDownstreamCacheBehavior = "MUST_REVALIDATE"
+	// BehaviorDownstreamCacheBehaviorBust const
+	BehaviorDownstreamCacheBehaviorBust BehaviorDownstreamCacheBehavior = "BUST"
+	// BehaviorDownstreamCacheBehaviorTunnelOrigin const
+	BehaviorDownstreamCacheBehaviorTunnelOrigin BehaviorDownstreamCacheBehavior = "TUNNEL_ORIGIN"
+	// BehaviorDownstreamCacheBehaviorNone const
+	BehaviorDownstreamCacheBehaviorNone BehaviorDownstreamCacheBehavior = "NONE"
+
+	// BehaviorDownstreamCacheSendHeadersCacheControlAndExpires const
+	BehaviorDownstreamCacheSendHeadersCacheControlAndExpires BehaviorDownstreamCacheSendHeaders = "CACHE_CONTROL_AND_EXPIRES"
+	// BehaviorDownstreamCacheSendHeadersCacheControl const
+	BehaviorDownstreamCacheSendHeadersCacheControl BehaviorDownstreamCacheSendHeaders = "CACHE_CONTROL"
+	// BehaviorDownstreamCacheSendHeadersExpires const
+	BehaviorDownstreamCacheSendHeadersExpires BehaviorDownstreamCacheSendHeaders = "EXPIRES"
+	// BehaviorDownstreamCacheSendHeadersPassOrigin const
+	BehaviorDownstreamCacheSendHeadersPassOrigin BehaviorDownstreamCacheSendHeaders = "PASS_ORIGIN"
+
+	// BehaviorGzipResponseBehaviorOriginResponse const
+	BehaviorGzipResponseBehaviorOriginResponse BehaviorGzipResponseBehavior = "ORIGIN_RESPONSE"
+	// BehaviorGzipResponseBehaviorAlways const
+	BehaviorGzipResponseBehaviorAlways BehaviorGzipResponseBehavior = "ALWAYS"
+	// BehaviorGzipResponseBehaviorNever const
+	BehaviorGzipResponseBehaviorNever BehaviorGzipResponseBehavior = "NEVER"
+
+	// BehaviorModifyOutgoingResponseHeaderActionAdd const
+	BehaviorModifyOutgoingResponseHeaderActionAdd BehaviorModifyOutgoingResponseHeaderAction = "ADD"
+	// BehaviorModifyOutgoingResponseHeaderActionDelete const
+	BehaviorModifyOutgoingResponseHeaderActionDelete BehaviorModifyOutgoingResponseHeaderAction = "DELETE"
+	// BehaviorModifyOutgoingResponseHeaderActionModify const
+	BehaviorModifyOutgoingResponseHeaderActionModify BehaviorModifyOutgoingResponseHeaderAction = "MODIFY"
+	// BehaviorModifyOutgoingResponseHeaderActionRegex const
+	BehaviorModifyOutgoingResponseHeaderActionRegex BehaviorModifyOutgoingResponseHeaderAction = "REGEX"
+
+	// BehaviorOriginCacheKeyHostnameRequestHostHeader const
+	BehaviorOriginCacheKeyHostnameRequestHostHeader BehaviorOriginCacheKeyHostname = "REQUEST_HOST_HEADER"
+	// BehaviorOriginCacheKeyHostnameOriginHostname const
+	BehaviorOriginCacheKeyHostnameOriginHostname BehaviorOriginCacheKeyHostname = "ORIGIN_HOSTNAME"
+
+	// BehaviorOriginForwardHostHeaderRequestHostHeader const
+	BehaviorOriginForwardHostHeaderRequestHostHeader BehaviorOriginForwardHostHeader = "REQUEST_HOST_HEADER"
+	// BehaviorOriginForwardHostHeaderOriginHostname const
+	BehaviorOriginForwardHostHeaderOriginHostname BehaviorOriginForwardHostHeader = "ORIGIN_HOSTNAME"
+	// BehaviorOriginForwardHostHeaderCustom const
+	BehaviorOriginForwardHostHeaderCustom BehaviorOriginForwardHostHeader = "CUSTOM"
+
+	// BehaviorOriginIPVersionIPV4 const
+	BehaviorOriginIPVersionIPV4 BehaviorOriginIPVersion = "IPV4"
+	// BehaviorOriginIPVersionDualstack const
+	BehaviorOriginIPVersionDualstack BehaviorOriginIPVersion = "DUALSTACK"
+	// BehaviorOriginIPVersionIPV6 const
+	BehaviorOriginIPVersionIPV6 BehaviorOriginIPVersion = "IPV6"
+
+	// BehaviorOriginOriginCertsToHonorCombo const
+	BehaviorOriginOriginCertsToHonorCombo BehaviorOriginOriginCertsToHonor = "COMBO"
+	// BehaviorOriginOriginCertsToHonorStandardCertificateAuthorities const
+	BehaviorOriginOriginCertsToHonorStandardCertificateAuthorities BehaviorOriginOriginCertsToHonor = "STANDARD_CERTIFICATE_AUTHORITIES"
+	// BehaviorOriginOriginCertsToHonorCustomCertificateAuthorities const
+	BehaviorOriginOriginCertsToHonorCustomCertificateAuthorities BehaviorOriginOriginCertsToHonor = "CUSTOM_CERTIFICATE_AUTHORITIES"
+	// BehaviorOriginOriginCertsToHonorCustomCertificates const
+	BehaviorOriginOriginCertsToHonorCustomCertificates BehaviorOriginOriginCertsToHonor = "CUSTOM_CERTIFICATES"
+
+	// BehaviorOriginOriginTypeCustomer const
+	BehaviorOriginOriginTypeCustomer BehaviorOriginOriginType = "CUSTOMER"
+	// BehaviorOriginOriginTypeNetStorage const
+	BehaviorOriginOriginTypeNetStorage BehaviorOriginOriginType = "NET_STORAGE"
+	// BehaviorOriginOriginTypeMediaServiceLive const
+	BehaviorOriginOriginTypeMediaServiceLive BehaviorOriginOriginType = "MEDIA_SERVICE_LIVE"
+	// BehaviorOriginOriginTypeEdgeLoadBalancingOriginGroup const
+	BehaviorOriginOriginTypeEdgeLoadBalancingOriginGroup BehaviorOriginOriginType = "EDGE_LOAD_BALANCING_ORIGIN_GROUP"
+	// BehaviorOriginOriginTypeSaasDynamicOrigin const
+	BehaviorOriginOriginTypeSaasDynamicOrigin BehaviorOriginOriginType = "SAAS_DYNAMIC_ORIGIN"
+
+	// BehaviorOriginVerificationModePlatformSettings const
+	BehaviorOriginVerificationModePlatformSettings BehaviorOriginVerificationMode = "PLATFORM_SETTINGS"
+	// BehaviorOriginVerificationModeCustom const
+	BehaviorOriginVerificationModeCustom BehaviorOriginVerificationMode = "CUSTOM"
+	// BehaviorOriginVerificationModeThirdParty const
+	BehaviorOriginVerificationModeThirdParty BehaviorOriginVerificationMode = "THIRD_PARTY"
+
+	// BehaviorSureRouteToHostStatusIncomingHh const
+	BehaviorSureRouteToHostStatusIncomingHh BehaviorSureRouteToHostStatus = "INCOMING_HH"
+	// BehaviorSureRouteToHostStatusOther const
+	BehaviorSureRouteToHostStatusOther BehaviorSureRouteToHostStatus = "OTHER"
+
+	// BehaviorSureRouteTypePerformance const
+	BehaviorSureRouteTypePerformance BehaviorSureRouteType = "PERFORMANCE"
+	// BehaviorSureRouteTypeCustomMap const
+	BehaviorSureRouteTypeCustomMap BehaviorSureRouteType = "CUSTOM_MAP"
+
+	// CriterionContentTypeMatchOperatorIsOneOf const
+	CriterionContentTypeMatchOperatorIsOneOf CriterionContentTypeMatchOperator = "IS_ONE_OF"
+	// CriterionContentTypeMatchOperatorIsNotOneOf const
+	CriterionContentTypeMatchOperatorIsNotOneOf CriterionContentTypeMatchOperator = "IS_NOT_ONE_OF"
+
+	// CriterionFileExtensionMatchOperatorIsOneOf const
+	CriterionFileExtensionMatchOperatorIsOneOf CriterionFileExtensionMatchOperator = "IS_ONE_OF"
+	// CriterionFileExtensionMatchOperatorIsNotOneOf const
+	CriterionFileExtensionMatchOperatorIsNotOneOf CriterionFileExtensionMatchOperator = "IS_NOT_ONE_OF"
+
+	// CriterionHostnameMatchOperatorIsOneOf const
+	CriterionHostnameMatchOperatorIsOneOf CriterionHostnameMatchOperator = "IS_ONE_OF"
+	// CriterionHostnameMatchOperatorIsNotOneOf const
+	CriterionHostnameMatchOperatorIsNotOneOf CriterionHostnameMatchOperator = "IS_NOT_ONE_OF"
+
+	// CriterionPathMatchOperatorMatchesOneOf const
+	CriterionPathMatchOperatorMatchesOneOf CriterionPathMatchOperator = "MATCHES_ONE_OF"
+	// CriterionPathMatchOperatorDoesNotMatchOneOf const
+	CriterionPathMatchOperatorDoesNotMatchOneOf CriterionPathMatchOperator = "DOES_NOT_MATCH_ONE_OF"
+
+	// CriterionRequestHeaderMatchOperatorIsOneOf const
+	CriterionRequestHeaderMatchOperatorIsOneOf CriterionRequestHeaderMatchOperator = "IS_ONE_OF"
+	// CriterionRequestHeaderMatchOperatorIsNotOneOf const
+	CriterionRequestHeaderMatchOperatorIsNotOneOf CriterionRequestHeaderMatchOperator = "IS_NOT_ONE_OF"
+	// CriterionRequestHeaderMatchOperatorExists const
+	CriterionRequestHeaderMatchOperatorExists CriterionRequestHeaderMatchOperator = "EXISTS"
+	// CriterionRequestHeaderMatchOperatorDoesNotExist const
+	CriterionRequestHeaderMatchOperatorDoesNotExist CriterionRequestHeaderMatchOperator = "DOES_NOT_EXIST"
+
+	// CriterionRequestMethodMatchOperatorIs const
+	CriterionRequestMethodMatchOperatorIs CriterionRequestMethodMatchOperator = "IS"
+	// CriterionRequestMethodMatchOperatorIsNot const
+	CriterionRequestMethodMatchOperatorIsNot CriterionRequestMethodMatchOperator = "IS_NOT"
+
+	// CriterionRequestMethodValueGet const
+	CriterionRequestMethodValueGet CriterionRequestMethodValue = "GET"
+	// CriterionRequestMethodValuePost const
+	CriterionRequestMethodValuePost CriterionRequestMethodValue = "POST"
+	// CriterionRequestMethodValueHead const
+	CriterionRequestMethodValueHead CriterionRequestMethodValue = "HEAD"
+	// CriterionRequestMethodValuePut const
+	CriterionRequestMethodValuePut CriterionRequestMethodValue = "PUT"
+	// CriterionRequestMethodValuePatch const
+	CriterionRequestMethodValuePatch CriterionRequestMethodValue = "PATCH"
+	// CriterionRequestMethodValueHTTPDelete const
+	CriterionRequestMethodValueHTTPDelete CriterionRequestMethodValue = "HTTP_DELETE"
+	// CriterionRequestMethodValueOptions const
+	CriterionRequestMethodValueOptions CriterionRequestMethodValue = "OPTIONS"
+
+	// CriterionRequestProtocolValueHTTP const
+	CriterionRequestProtocolValueHTTP CriterionRequestProtocolValue = "HTTP"
+	// CriterionRequestProtocolValueHTTPS const
+	CriterionRequestProtocolValueHTTPS CriterionRequestProtocolValue = "HTTPS"
+)
+
+// BehaviorName returns name of allowPost behavior
+func (BehaviorAllowPost) BehaviorName() string {
+	return "allowPost"
+}
+
+// BehaviorName returns name of caching behavior
+func (BehaviorCaching) BehaviorName() string {
+	return "caching"
+}
+
+// BehaviorName returns name of cpCode behavior
+func (BehaviorCPCode) BehaviorName() string {
+	return "cpCode"
+}
+
+// BehaviorName returns name of downstreamCache behavior
+func (BehaviorDownstreamCache) BehaviorName() string {
+	return "downstreamCache"
+}
+
+// BehaviorName returns name of gzipResponse behavior
+func (BehaviorGzipResponse) BehaviorName() string {
+	return "gzipResponse"
+}
+
+// BehaviorName returns name of http2 behavior
+func (BehaviorHTTP2) BehaviorName() string {
+	return "http2"
+}
+
+// BehaviorName returns name of modifyOutgoingResponseHeader behavior
+func (BehaviorModifyOutgoingResponseHeader) BehaviorName() string {
+	return "modifyOutgoingResponseHeader"
+}
+
+// BehaviorName returns name of origin behavior
+func (BehaviorOrigin) BehaviorName() string {
+	return "origin"
+}
+
+// BehaviorName returns name of prefetch behavior
+func (BehaviorPrefetch) BehaviorName() string {
+	return "prefetch"
+}
+
+// BehaviorName returns name of sureRoute behavior
+func (BehaviorSureRoute) BehaviorName() string {
+	return "sureRoute"
+}
+
+var generatedBehaviors = map[string]func() TypedBehavior{
+	"allowPost":                    func() TypedBehavior { return &BehaviorAllowPost{} },
+	"caching":                      func() TypedBehavior { return &BehaviorCaching{} },
+	"cpCode":                       func() TypedBehavior { return &BehaviorCPCode{} },
+	"downstreamCache":              func() TypedBehavior { return &BehaviorDownstreamCache{} },
+	"gzipResponse":                 func() TypedBehavior { return &BehaviorGzipResponse{} },
+	"http2":                        func() TypedBehavior { return &BehaviorHTTP2{} },
+	"modifyOutgoingResponseHeader": func() TypedBehavior { return &BehaviorModifyOutgoingResponseHeader{} },
+	"origin":                       func() TypedBehavior { return &BehaviorOrigin{} },
+	"prefetch":                     func() TypedBehavior { return &BehaviorPrefetch{} },
+	"sureRoute":                    func() TypedBehavior { return &BehaviorSureRoute{} },
+}
+
+// CriterionName returns name of contentType criterion
+func (CriterionContentType) CriterionName() string {
+	return "contentType"
+}
+
+// CriterionName returns name of fileExtension criterion
+func (CriterionFileExtension) CriterionName() string {
+	return "fileExtension"
+}
+
+// CriterionName returns name of hostname criterion
+func (CriterionHostname) CriterionName() string {
+	return "hostname"
+}
+
+// CriterionName returns name of path criterion
+func (CriterionPath) CriterionName() string {
+	return "path"
+}
+
+// CriterionName returns name of requestHeader criterion
+func (CriterionRequestHeader) CriterionName() string {
+	return "requestHeader"
+}
+
+// CriterionName returns name of requestMethod criterion
+func (CriterionRequestMethod) CriterionName() string {
+	return "requestMethod"
+}
+
+// CriterionName returns name of requestProtocol criterion
+func (CriterionRequestProtocol) CriterionName() string {
+	return "requestProtocol"
+}
+
+var generatedCriteria = map[string]func() TypedCriterion{
+	"contentType":     func() TypedCriterion { return &CriterionContentType{} },
+	"fileExtension":   func() TypedCriterion { return &CriterionFileExtension{} },
+	"hostname":        func() TypedCriterion { return &CriterionHostname{} },
+	"path":            func() TypedCriterion { return &CriterionPath{} },
+	"requestHeader":   func() TypedCriterion { return &CriterionRequestHeader{} },
+	"requestMethod":   func() TypedCriterion { return &CriterionRequestMethod{} },
+	"requestProtocol": func() TypedCriterion { return &CriterionRequestProtocol{} },
+}
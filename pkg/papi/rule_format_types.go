@@ -0,0 +1,119 @@
+package papi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+//go:generate go run ./internal/rulegen -schema internal/rulegen/schemas/v2022-10-18.json -rule-format v2022-10-18 -out rule_format.gen.go
+
+type (
+	// TypedBehavior is implemented by generated structs representing options of a single behavior
+	TypedBehavior interface {
+		BehaviorName() string
+	}
+
+	// TypedCriterion is implemented by generated structs representing options of a single criterion
+	TypedCriterion interface {
+		CriterionName() string
+	}
+)
+
+var (
+	// ErrTypedOptions represents error when converting between RuleBehavior and its typed representation fails
+	ErrTypedOptions = errors.New("typed rule options")
+)
+
+// ToRuleBehavior converts typed behavior into RuleBehavior which can be placed in a rule tree
+func ToRuleBehavior(behavior TypedBehavior) (RuleBehavior, error) {
+	options, err := toRuleOptions(behavior)
+	if err != nil {
+		return RuleBehavior{}, fmt.Errorf("%w: behavior %s: %s", ErrTypedOptions, behavior.BehaviorName(), err)
+	}
+	return RuleBehavior{Name: behavior.BehaviorName(), Options: options}, nil
+}
+
+// ToRuleCriterion converts typed criterion into RuleBehavior which can be placed in criteria of a rule
+func ToRuleCriterion(criterion TypedCriterion) (RuleBehavior, error) {
+	options, err := toRuleOptions(criterion)
+	if err != nil {
+		return RuleBehavior{}, fmt.Errorf("%w: criterion %s: %s", ErrTypedOptions, criterion.CriterionName(), err)
+	}
+	return RuleBehavior{Name: criterion.CriterionName(), Options: options}, nil
+}
+
+// FromRuleBehavior decodes options of behavior into out, which has to be a pointer to a typed behavior
+// It returns an error if behavior name does not match out or options contain names unknown to the rule format
+func FromRuleBehavior(behavior RuleBehavior, out TypedBehavior) error {
+	if behavior.Name != out.BehaviorName() {
+		return fmt.Errorf("%w: cannot decode behavior %s into %s", ErrTypedOptions, behavior.Name, out.BehaviorName())
+	}
+	if err := fromRuleOptions(behavior.Options, out); err != nil {
+		return fmt.Errorf("%w: behavior %s: %s", ErrTypedOptions, behavior.Name, err)
+	}
+	return nil
+}
+
+// FromRuleCriterion decodes options of criterion into out, which has to be a pointer to a typed criterion
+// It returns an error if criterion name does not match out or options contain names unknown to the rule format
+func FromRuleCriterion(criterion RuleBehavior, out TypedCriterion) error {
+	if criterion.Name != out.CriterionName() {
+		return fmt.Errorf("%w: cannot decode criterion %s into %s", ErrTypedOptions, criterion.Name, out.CriterionName())
+	}
+	if err := fromRuleOptions(criterion.Options, out); err != nil {
+		return fmt.Errorf("%w: criterion %s: %s", ErrTypedOptions, criterion.Name, err)
+	}
+	return nil
+}
+
+// NewTypedBehavior returns typed representation of behavior generated for GeneratedRuleFormat
+// ErrNotFound is returned for behaviors which are not part of the rule format
+func NewTypedBehavior(behavior RuleBehavior) (TypedBehavior, error) {
+	newBehavior, ok := generatedBehaviors[behavior.Name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: behavior %s in rule format %s", ErrTypedOptions, ErrNotFound, behavior.Name, GeneratedRuleFormat)
+	}
+	typed := newBehavior()
+	if err := FromRuleBehavior(behavior, typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+// NewTypedCriterion returns typed representation of criterion generated for GeneratedRuleFormat
+// ErrNotFound is returned for criteria which are not part of the rule format
+func NewTypedCriterion(criterion RuleBehavior) (TypedCriterion, error) {
+	newCriterion, ok := generatedCriteria[criterion.Name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: criterion %s in rule format %s", ErrTypedOptions, ErrNotFound, criterion.Name, GeneratedRuleFormat)
+	}
+	typed := newCriterion()
+	if err := FromRuleCriterion(criterion, typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+func toRuleOptions(typed interface{}) (RuleOptionsMap, error) {
+	b, err := json.Marshal(typed)
+	if err != nil {
+		return nil, err
+	}
+	options := RuleOptionsMap{}
+	if err := json.Unmarshal(b, &options); err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+func fromRuleOptions(options RuleOptionsMap, out interface{}) error {
+	b, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(out)
+}
@@ -0,0 +1,89 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToRuleBehavior(t *testing.T) {
+	behavior := BehaviorCaching{
+		Behavior:       behaviorCachingPtr(BehaviorCachingBehaviorMaxAge),
+		MustRevalidate: tools.BoolPtr(false),
+		TTL:            tools.StringPtr("1d"),
+	}
+
+	result, err := ToRuleBehavior(behavior)
+	require.NoError(t, err)
+	assert.Equal(t, RuleBehavior{
+		Name:    "caching",
+		Options: RuleOptionsMap{"behavior": "MAX_AGE", "mustRevalidate": false, "ttl": "1d"},
+	}, result)
+
+	result, err = ToRuleBehavior(&BehaviorPrefetch{})
+	require.NoError(t, err)
+	assert.Equal(t, RuleBehavior{Name: "prefetch", Options: RuleOptionsMap{}}, result)
+
+	criterion, err := ToRuleCriterion(CriterionPath{Values: []string{"/images/*"}})
+	require.NoError(t, err)
+	assert.Equal(t, RuleBehavior{Name: "path", Options: RuleOptionsMap{"values": []interface{}{"/images/*"}}}, criterion)
+}
+
+func TestFromRuleBehavior(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, ruleTreeFixture)
+
+	var origin BehaviorOrigin
+	require.NoError(t, FromRuleBehavior(rules.Behaviors[0], &origin))
+	assert.Equal(t, "origin.test.com", *origin.Hostname)
+	assert.Equal(t, 80, *origin.HTTPPort)
+	assert.Equal(t, BehaviorOriginOriginTypeCustomer, *origin.OriginType)
+
+	roundTrip, err := ToRuleBehavior(origin)
+	require.NoError(t, err)
+	assert.Equal(t, rules.Behaviors[0].Name, roundTrip.Name)
+	assert.Equal(t, rules.Behaviors[0].Options, roundTrip.Options)
+
+	err = FromRuleBehavior(RuleBehavior{Name: "origin", Options: RuleOptionsMap{"hostnam": "origin.test.com"}}, &origin)
+	assert.True(t, errors.Is(err, ErrTypedOptions), "want: %s; got: %s", ErrTypedOptions, err)
+	assert.Contains(t, err.Error(), "hostnam")
+
+	err = FromRuleBehavior(RuleBehavior{Name: "caching"}, &origin)
+	assert.True(t, errors.Is(err, ErrTypedOptions), "want: %s; got: %s", ErrTypedOptions, err)
+
+	var contentType CriterionContentType
+	err = FromRuleCriterion(RuleBehavior{Name: "contentType", Options: RuleOptionsMap{"values": []interface{}{"text/html"}, "matchWildcard": true}}, &contentType)
+	require.NoError(t, err)
+	assert.Equal(t, CriterionContentType{Values: []string{"text/html"}, MatchWildcard: tools.BoolPtr(true)}, contentType)
+}
+
+func TestNewTypedBehavior(t *testing.T) {
+	typed, err := NewTypedBehavior(RuleBehavior{Name: "cpCode", Options: RuleOptionsMap{"value": map[string]interface{}{"id": float64(12345)}}})
+	require.NoError(t, err)
+	cpCode, ok := typed.(*BehaviorCPCode)
+	require.True(t, ok)
+	assert.Equal(t, 12345, *cpCode.Value.ID)
+
+	_, err = NewTypedBehavior(RuleBehavior{Name: "notABehavior"})
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+
+	_, err = NewTypedBehavior(RuleBehavior{Name: "prefetch", Options: RuleOptionsMap{"enabled": "yes"}})
+	assert.True(t, errors.Is(err, ErrTypedOptions), "want: %s; got: %s", ErrTypedOptions, err)
+
+	criterion, err := NewTypedCriterion(RuleBehavior{Name: "requestProtocol", Options: RuleOptionsMap{"value": "HTTPS"}})
+	require.NoError(t, err)
+	assert.Equal(t, &CriterionRequestProtocol{Value: criterionRequestProtocolPtr(CriterionRequestProtocolValueHTTPS)}, criterion)
+
+	_, err = NewTypedCriterion(RuleBehavior{Name: "origin"})
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+}
+
+func behaviorCachingPtr(v BehaviorCachingBehavior) *BehaviorCachingBehavior {
+	return &v
+}
+
+func criterionRequestProtocolPtr(v CriterionRequestProtocolValue) *CriterionRequestProtocolValue {
+	return &v
+}
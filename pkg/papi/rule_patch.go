@@ -0,0 +1,274 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// PatchRuleTreeRequest contains path and query params, as well as JSON patch operations necessary to perform PATCH /rules request
+	PatchRuleTreeRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		DryRun          bool
+		GroupID         string
+		ValidateMode    string
+		ValidateRules   bool
+		Etag            string
+		Operations      []PatchOperation
+	}
+
+	// PatchOperation represents a single JSON patch (RFC 6902) operation applied to the rules document
+	// HasValue marks the value member as present even if Value is nil, so that JSON null can be added, replaced or tested;
+	// it is implied by non-nil Value
+	PatchOperation struct {
+		Op       PatchOperationType `json:"op"`
+		Path     string             `json:"path"`
+		From     string             `json:"from,omitempty"`
+		Value    interface{}        `json:"value,omitempty"`
+		HasValue bool               `json:"-"`
+	}
+
+	// PatchOperationType represents op field values of a JSON patch operation
+	PatchOperationType string
+
+	// RulePatchBuilder builds a list of JSON patch operations addressed either by JSON pointer
+	// or by a path of rule names resolved against the rule tree the builder was created with
+	RulePatchBuilder struct {
+		rules      *Rules
+		operations []PatchOperation
+		err        error
+	}
+)
+
+const (
+	// PatchOperationAdd const
+	PatchOperationAdd PatchOperationType = "add"
+	// PatchOperationRemove const
+	PatchOperationRemove PatchOperationType = "remove"
+	// PatchOperationReplace const
+	PatchOperationReplace PatchOperationType = "replace"
+	// PatchOperationMove const
+	PatchOperationMove PatchOperationType = "move"
+	// PatchOperationCopy const
+	PatchOperationCopy PatchOperationType = "copy"
+	// PatchOperationTest const
+	PatchOperationTest PatchOperationType = "test"
+)
+
+var validJSONPointer = regexp.MustCompile("^(/([^~]|~[01])*)*$")
+
+var (
+	// ErrPatchRuleTree represents error when patching rule tree fails
+	ErrPatchRuleTree = errors.New("patching rule tree")
+	// ErrRulePath represents error when a path of rule names cannot be resolved to a JSON pointer
+	ErrRulePath = errors.New("resolving rule path")
+)
+
+// Validate validates PatchRuleTreeRequest struct
+func (r PatchRuleTreeRequest) Validate() error {
+	errs := validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"ValidateMode":    validation.Validate(r.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"Operations":      validation.Validate(r.Operations, validation.Required),
+	}
+	return edgegriderr.ParseValidationErrors(errs)
+}
+
+// Validate validates PatchOperation struct
+func (o PatchOperation) Validate() error {
+	return validation.Errors{
+		"Op": validation.Validate(o.Op, validation.Required, validation.In(PatchOperationAdd, PatchOperationRemove,
+			PatchOperationReplace, PatchOperationMove, PatchOperationCopy, PatchOperationTest)),
+		"Path": validation.Validate(o.Path, validation.Required, validation.Match(validJSONPointer)),
+		"From": validation.Validate(o.From,
+			validation.When(o.Op == PatchOperationMove || o.Op == PatchOperationCopy, validation.Required, validation.Match(validJSONPointer)).
+				Else(validation.Empty)),
+		"Value": validation.Validate(o.Value,
+			validation.When((o.Op == PatchOperationAdd || o.Op == PatchOperationReplace || o.Op == PatchOperationTest) && !o.HasValue,
+				validation.NotNil)),
+	}.Filter()
+}
+
+// MarshalJSON marshals the operation, sending null value if HasValue is set and Value is nil
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	type operation PatchOperation
+	if o.Value != nil || !o.HasValue {
+		return json.Marshal(operation(o))
+	}
+	return json.Marshal(struct {
+		operation
+		Value interface{} `json:"value"`
+	}{operation: operation(o)})
+}
+
+// UnmarshalJSON unmarshals the operation, setting HasValue if the value member is present, including null value
+func (o *PatchOperation) UnmarshalJSON(b []byte) error {
+	type operation PatchOperation
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, (*operation)(o)); err != nil {
+		return err
+	}
+	_, o.HasValue = members["value"]
+	return nil
+}
+
+func (p *papi) PatchRuleTree(ctx context.Context, request PatchRuleTreeRequest) (*UpdateRulesResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrPatchRuleTree, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("PatchRuleTree")
+
+	patchURL := fmt.Sprintf(
+		"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
+		request.PropertyID,
+		request.PropertyVersion,
+		request.ContractID,
+		request.GroupID,
+	)
+	if request.ValidateMode != "" {
+		patchURL += fmt.Sprintf("&validateMode=%s", request.ValidateMode)
+	}
+	if !request.ValidateRules {
+		patchURL += fmt.Sprintf("&validateRules=%t", request.ValidateRules)
+	}
+	if request.DryRun {
+		patchURL += fmt.Sprintf("&dryRun=%t", request.DryRun)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, patchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrPatchRuleTree, err)
+	}
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	if request.Etag != "" {
+		req.Header.Set("If-Match", request.Etag)
+	}
+
+	var rules UpdateRulesResponse
+	resp, err := p.Exec(req, &rules, request.Operations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrPatchRuleTree, err)
+	}
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, fmt.Errorf("%s: %w", ErrPatchRuleTree, p.preconditionFailed(resp, request.Etag))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrPatchRuleTree, p.Error(resp))
+	}
+
+	return &rules, nil
+}
+
+// NewRulePatchBuilder returns a builder of JSON patch operations
+// rules is used to resolve rule name paths and may be nil if only JSON pointers are used
+func NewRulePatchBuilder(rules *Rules) *RulePatchBuilder {
+	return &RulePatchBuilder{rules: rules}
+}
+
+// RulePath resolves a path of child rule names, starting below the default rule, to a JSON pointer
+// e.g. RulePath("Performance", "Compressible Objects") may return "/rules/children/1/children/0"
+// Resolution errors are recorded and returned from Build
+func (b *RulePatchBuilder) RulePath(names ...string) string {
+	if b.rules == nil {
+		b.setErr(fmt.Errorf("%w: rule tree was not provided", ErrRulePath))
+		return ""
+	}
+	pointer, err := RulePointer(*b.rules, names...)
+	if err != nil {
+		b.setErr(err)
+		return ""
+	}
+	return pointer
+}
+
+// Add appends an add operation, nil value adds JSON null
+func (b *RulePatchBuilder) Add(path string, value interface{}) *RulePatchBuilder {
+	return b.append(PatchOperation{Op: PatchOperationAdd, Path: path, Value: value, HasValue: true})
+}
+
+// Remove appends a remove operation
+func (b *RulePatchBuilder) Remove(path string) *RulePatchBuilder {
+	return b.append(PatchOperation{Op: PatchOperationRemove, Path: path})
+}
+
+// Replace appends a replace operation, nil value replaces with JSON null
+func (b *RulePatchBuilder) Replace(path string, value interface{}) *RulePatchBuilder {
+	return b.append(PatchOperation{Op: PatchOperationReplace, Path: path, Value: value, HasValue: true})
+}
+
+// Move appends a move operation
+func (b *RulePatchBuilder) Move(from, path string) *RulePatchBuilder {
+	return b.append(PatchOperation{Op: PatchOperationMove, From: from, Path: path})
+}
+
+// Copy appends a copy operation
+func (b *RulePatchBuilder) Copy(from, path string) *RulePatchBuilder {
+	return b.append(PatchOperation{Op: PatchOperationCopy, From: from, Path: path})
+}
+
+// Test appends a test operation, nil value tests for JSON null
+func (b *RulePatchBuilder) Test(path string, value interface{}) *RulePatchBuilder {
+	return b.append(PatchOperation{Op: PatchOperationTest, Path: path, Value: value, HasValue: true})
+}
+
+// AddChildRule appends an add operation inserting rule as the last child of the rule under parent name path
+func (b *RulePatchBuilder) AddChildRule(rule Rules, parent ...string) *RulePatchBuilder {
+	if len(parent) == 0 {
+		return b.Add("/rules/children/-", rule)
+	}
+	path := b.RulePath(parent...)
+	return b.Add(path+"/children/-", rule)
+}
+
+// RemoveRule appends a remove operation deleting the rule under given name path
+func (b *RulePatchBuilder) RemoveRule(names ...string) *RulePatchBuilder {
+	return b.Remove(b.RulePath(names...))
+}
+
+// Build returns the operations built so far or the first error which occurred while building them
+func (b *RulePatchBuilder) Build() ([]PatchOperation, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	for i, op := range b.operations {
+		if err := op.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %s", ErrStructValidation, i, err)
+		}
+	}
+	return b.operations, nil
+}
+
+func (b *RulePatchBuilder) append(op PatchOperation) *RulePatchBuilder {
+	b.operations = append(b.operations, op)
+	return b
+}
+
+func (b *RulePatchBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// RulePointer resolves a path of child rule names, starting below the given rule, to a JSON pointer into rules document
+// An empty path resolves to the rule itself. Sibling rules sharing a name on the path cause an error
+func RulePointer(rules Rules, names ...string) (string, error) {
+	_, location, err := rules.RuleByPath(names...)
+	if err != nil {
+		return "", err
+	}
+	return location.Pointer(), nil
+}
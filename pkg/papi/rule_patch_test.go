@@ -0,0 +1,419 @@
+package papi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPapi_PatchRuleTree(t *testing.T) {
+	tests := map[string]struct {
+		request             PatchRuleTreeRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedIfMatch     string
+		expectedRequestBody string
+		expectedResponse    *UpdateRulesResponse
+		withError           func(*testing.T, error)
+	}{
+		"200 OK": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				ValidateRules:   true,
+				Etag:            "a872c4d1",
+				Operations: []PatchOperation{
+					{Op: PatchOperationTest, Path: "/rules/children/0/name", Value: "Performance"},
+					{Op: PatchOperationReplace, Path: "/rules/children/0/behaviors/0/options/enabled", Value: false},
+					{Op: PatchOperationMove, From: "/rules/children/1", Path: "/rules/children/0"},
+					{Op: PatchOperationRemove, Path: "/rules/children/2"},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "etag": "b9e1f4a7",
+    "ruleFormat": "v2022-06-28",
+    "rules": {
+        "name": "default"
+    }
+}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedIfMatch:     "a872c4d1",
+			expectedRequestBody: `[{"op":"test","path":"/rules/children/0/name","value":"Performance"},{"op":"replace","path":"/rules/children/0/behaviors/0/options/enabled","value":false},{"op":"move","path":"/rules/children/0","from":"/rules/children/1"},{"op":"remove","path":"/rules/children/2"}]`,
+			expectedResponse: &UpdateRulesResponse{
+				AccountID:       "act_1-1TJZFB",
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "b9e1f4a7",
+				RuleFormat:      "v2022-06-28",
+				Rules:           Rules{Name: "default"},
+			},
+		},
+		"200 OK dry run without etag": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				DryRun:          true,
+				ValidateMode:    RuleValidateModeFast,
+				Operations: []PatchOperation{
+					{Op: PatchOperationAdd, Path: "/rules/children/-", Value: Rules{Name: "New rule"}},
+				},
+			},
+			responseStatus:      http.StatusOK,
+			responseBody:        `{"propertyId": "prp_175780", "propertyVersion": 3, "rules": {"name": "default"}}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&dryRun=true&groupId=grp_15166&validateMode=fast&validateRules=false",
+			expectedRequestBody: `[{"op":"add","path":"/rules/children/-","value":{"name":"New rule","options":{}}}]`,
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Rules:           Rules{Name: "default"},
+			},
+		},
+		"412 precondition failed": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				ValidateRules:   true,
+				Etag:            "a872c4d1",
+				Operations: []PatchOperation{
+					{Op: PatchOperationRemove, Path: "/rules/children/2"},
+				},
+			},
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+{
+    "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+    "title": "Precondition failed",
+    "detail": "The rule tree was modified since it was fetched",
+    "status": 412
+}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedIfMatch:     "a872c4d1",
+			expectedRequestBody: `[{"op":"remove","path":"/rules/children/2"}]`,
+			withError: func(t *testing.T, err error) {
+				var precondition *PreconditionFailedError
+				require.True(t, errors.As(err, &precondition), "want: %T; got: %s", precondition, err)
+				assert.Equal(t, "a872c4d1", precondition.Etag)
+				assert.Equal(t, http.StatusPreconditionFailed, precondition.Err.StatusCode)
+				assert.Equal(t, "Precondition failed", precondition.Err.Title)
+			},
+		},
+		"500 internal server error": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				ValidateRules:   true,
+				Operations: []PatchOperation{
+					{Op: PatchOperationRemove, Path: "/rules/children/2"},
+				},
+			},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error patching rule tree",
+    "status": 500
+}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `[{"op":"remove","path":"/rules/children/2"}]`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error patching rule tree",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				var precondition *PreconditionFailedError
+				assert.False(t, errors.As(err, &precondition))
+			},
+		},
+		"200 OK null value": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				ValidateRules:   true,
+				Operations: []PatchOperation{
+					{Op: PatchOperationTest, Path: "/rules/behaviors/0/options/customValue", HasValue: true},
+					{Op: PatchOperationReplace, Path: "/rules/behaviors/0/options/customValue", Value: "x"},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "etag": "b9e1f4a7",
+    "rules": {
+        "name": "default"
+    }
+}`,
+			expectedPath:        "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			expectedRequestBody: `[{"op":"test","path":"/rules/behaviors/0/options/customValue","value":null},{"op":"replace","path":"/rules/behaviors/0/options/customValue","value":"x"}]`,
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Etag:            "b9e1f4a7",
+				Rules:           Rules{Name: "default"},
+			},
+		},
+		"validation error - no operations": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+			},
+		},
+		"validation error - move without from": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Operations: []PatchOperation{
+					{Op: PatchOperationMove, Path: "/rules/children/0"},
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "From")
+			},
+		},
+		"validation error - replace without value": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Operations: []PatchOperation{
+					{Op: PatchOperationReplace, Path: "/rules/name"},
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "Value")
+			},
+		},
+		"validation error - invalid pointer": {
+			request: PatchRuleTreeRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Operations: []PatchOperation{
+					{Op: PatchOperationReplace, Path: "rules/name", Value: "default"},
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "Path")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "application/json-patch+json", r.Header.Get("Content-Type"))
+				assert.Equal(t, test.expectedIfMatch, r.Header.Get("If-Match"))
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.PatchRuleTree(context.Background(), test.request)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestRulePointer(t *testing.T) {
+	rules := Rules{
+		Name: "default",
+		Children: []Rules{
+			{Name: "Offload"},
+			{
+				Name: "Performance",
+				Children: []Rules{
+					{Name: "JPEG Images"},
+					{Name: "Compressible Objects"},
+				},
+			},
+			{
+				Name: "Redirects",
+				Children: []Rules{
+					{Name: "Legacy"},
+					{Name: "Legacy"},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		names           []string
+		expectedPointer string
+		withError       error
+	}{
+		"root rule": {
+			expectedPointer: "/rules",
+		},
+		"top level child": {
+			names:           []string{"Performance"},
+			expectedPointer: "/rules/children/1",
+		},
+		"nested child": {
+			names:           []string{"Performance", "Compressible Objects"},
+			expectedPointer: "/rules/children/1/children/1",
+		},
+		"rule not found": {
+			names:     []string{"Performance", "PNG Images"},
+			withError: ErrRulePath,
+		},
+		"ambiguous rule name": {
+			names:     []string{"Redirects", "Legacy"},
+			withError: ErrRulePath,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pointer, err := RulePointer(rules, test.names...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedPointer, pointer)
+		})
+	}
+}
+
+func TestRulePatchBuilder(t *testing.T) {
+	rules := Rules{
+		Name: "default",
+		Children: []Rules{
+			{Name: "Offload"},
+			{
+				Name:     "Performance",
+				Children: []Rules{{Name: "Compressible Objects"}},
+			},
+		},
+	}
+
+	t.Run("operations addressed by pointer and rule name", func(t *testing.T) {
+		b := NewRulePatchBuilder(&rules)
+		b.Test(b.RulePath("Offload")+"/name", "Offload").
+			Replace(b.RulePath("Performance", "Compressible Objects")+"/comments", "compress text").
+			AddChildRule(Rules{Name: "Images"}, "Performance").
+			AddChildRule(Rules{Name: "Security"}).
+			Copy(b.RulePath("Offload"), "/rules/children/-").
+			RemoveRule("Offload")
+
+		ops, err := b.Build()
+		require.NoError(t, err)
+		assert.Equal(t, []PatchOperation{
+			{Op: PatchOperationTest, Path: "/rules/children/0/name", Value: "Offload", HasValue: true},
+			{Op: PatchOperationReplace, Path: "/rules/children/1/children/0/comments", Value: "compress text", HasValue: true},
+			{Op: PatchOperationAdd, Path: "/rules/children/1/children/-", Value: Rules{Name: "Images"}, HasValue: true},
+			{Op: PatchOperationAdd, Path: "/rules/children/-", Value: Rules{Name: "Security"}, HasValue: true},
+			{Op: PatchOperationCopy, From: "/rules/children/0", Path: "/rules/children/-"},
+			{Op: PatchOperationRemove, Path: "/rules/children/0"},
+		}, ops)
+	})
+
+	t.Run("unknown rule name", func(t *testing.T) {
+		b := NewRulePatchBuilder(&rules)
+		b.RemoveRule("Performance", "Images").Remove("/rules/children/0")
+		_, err := b.Build()
+		assert.True(t, errors.Is(err, ErrRulePath), "want: %s; got: %s", ErrRulePath, err)
+	})
+
+	t.Run("rule name path without rule tree", func(t *testing.T) {
+		b := NewRulePatchBuilder(nil)
+		b.RemoveRule("Offload")
+		_, err := b.Build()
+		assert.True(t, errors.Is(err, ErrRulePath), "want: %s; got: %s", ErrRulePath, err)
+	})
+
+	t.Run("null value", func(t *testing.T) {
+		b := NewRulePatchBuilder(nil)
+		b.Test("/rules/comments", nil).Replace("/rules/comments", nil)
+		ops, err := b.Build()
+		require.NoError(t, err)
+		body, err := json.Marshal(ops)
+		require.NoError(t, err)
+		assert.Equal(t, `[{"op":"test","path":"/rules/comments","value":null},{"op":"replace","path":"/rules/comments","value":null}]`, string(body))
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		b := NewRulePatchBuilder(nil)
+		b.Replace("rules/name", "default")
+		_, err := b.Build()
+		assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+	})
+}
+
+func TestPatchOperation_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected PatchOperation
+	}{
+		"value": {
+			body:     `{"op":"replace","path":"/rules/comments","value":"text"}`,
+			expected: PatchOperation{Op: PatchOperationReplace, Path: "/rules/comments", Value: "text", HasValue: true},
+		},
+		"null value": {
+			body:     `{"op":"test","path":"/rules/comments","value":null}`,
+			expected: PatchOperation{Op: PatchOperationTest, Path: "/rules/comments", HasValue: true},
+		},
+		"no value": {
+			body:     `{"op":"remove","path":"/rules/comments"}`,
+			expected: PatchOperation{Op: PatchOperationRemove, Path: "/rules/comments"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var op PatchOperation
+			require.NoError(t, json.Unmarshal([]byte(test.body), &op))
+			assert.Equal(t, test.expected, op)
+			assert.NoError(t, op.Validate())
+			body, err := json.Marshal(op)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package papi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// RuleLocation describes the position of a rule within the rule tree
+	RuleLocation struct {
+		// Names contains names of all rules from the root down to the located rule, inclusive
+		Names []string
+		// Indexes contains child indexes leading from the root to the located rule
+		Indexes []int
+	}
+
+	// RuleWalkFunc is called by Rules.Walk for each visited rule
+	// Returning ErrSkipChildren skips children of the rule, any other error stops the walk
+	RuleWalkFunc func(rule Rules, location RuleLocation) error
+
+	// RuleBehaviorMatch contains a behavior or criterion found in the rule tree
+	RuleBehaviorMatch struct {
+		Behavior RuleBehavior
+		Rule     RuleLocation
+		Index    int
+		Pointer  string
+	}
+
+	// RuleMatch contains a rule found in the rule tree
+	RuleMatch struct {
+		Rule     Rules
+		Location RuleLocation
+	}
+)
+
+var (
+	// ErrSkipChildren is used as a return value from RuleWalkFunc to skip children of the visited rule
+	ErrSkipChildren = errors.New("skip children")
+)
+
+// Path returns names of the rules leading to the location joined with "/", e.g. "default/Performance"
+func (l RuleLocation) Path() string {
+	return strings.Join(l.Names, "/")
+}
+
+// Pointer returns JSON pointer to the rule within rules document, e.g. "/rules/children/0"
+func (l RuleLocation) Pointer() string {
+	var b strings.Builder
+	b.WriteString("/rules")
+	for _, i := range l.Indexes {
+		b.WriteString("/children/")
+		b.WriteString(strconv.Itoa(i))
+	}
+	return b.String()
+}
+
+// NamePath returns names of the rules below the root leading to the location, as accepted by RuleByPath and edit operations
+func (l RuleLocation) NamePath() []string {
+	if len(l.Names) == 0 {
+		return nil
+	}
+	return l.Names[1:]
+}
+
+func (l RuleLocation) child(name string, index int) RuleLocation {
+	names := make([]string, len(l.Names), len(l.Names)+1)
+	copy(names, l.Names)
+	indexes := make([]int, len(l.Indexes), len(l.Indexes)+1)
+	copy(indexes, l.Indexes)
+	return RuleLocation{Names: append(names, name), Indexes: append(indexes, index)}
+}
+
+// Walk visits the rule and all its descendants depth-first, in tree order
+func (r Rules) Walk(fn RuleWalkFunc) error {
+	err := r.walk(RuleLocation{Names: []string{r.Name}}, fn)
+	if errors.Is(err, ErrSkipChildren) {
+		return nil
+	}
+	return err
+}
+
+func (r Rules) walk(location RuleLocation, fn RuleWalkFunc) error {
+	if err := fn(r, location); err != nil {
+		return err
+	}
+	for i, child := range r.Children {
+		err := child.walk(location.child(child.Name, i), fn)
+		if err != nil && !errors.Is(err, ErrSkipChildren) {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindRules returns all rules satisfying the predicate
+func (r Rules) FindRules(predicate func(Rules, RuleLocation) bool) []RuleMatch {
+	var matches []RuleMatch
+	_ = r.Walk(func(rule Rules, location RuleLocation) error {
+		if predicate(rule, location) {
+			matches = append(matches, RuleMatch{Rule: rule, Location: location})
+		}
+		return nil
+	})
+	return matches
+}
+
+// FindBehaviors returns all behaviors with the given name
+func (r Rules) FindBehaviors(name string) []RuleBehaviorMatch {
+	return r.FindBehaviorsFunc(func(b RuleBehavior, _ RuleLocation) bool {
+		return b.Name == name
+	})
+}
+
+// FindBehaviorsFunc returns all behaviors satisfying the predicate
+func (r Rules) FindBehaviorsFunc(predicate func(RuleBehavior, RuleLocation) bool) []RuleBehaviorMatch {
+	return r.findBehaviors("behaviors", func(rule Rules) []RuleBehavior { return rule.Behaviors }, predicate)
+}
+
+// FindCriteria returns all criteria with the given name
+func (r Rules) FindCriteria(name string) []RuleBehaviorMatch {
+	return r.FindCriteriaFunc(func(b RuleBehavior, _ RuleLocation) bool {
+		return b.Name == name
+	})
+}
+
+// FindCriteriaFunc returns all criteria satisfying the predicate
+func (r Rules) FindCriteriaFunc(predicate func(RuleBehavior, RuleLocation) bool) []RuleBehaviorMatch {
+	return r.findBehaviors("criteria", func(rule Rules) []RuleBehavior { return rule.Criteria }, predicate)
+}
+
+func (r Rules) findBehaviors(field string, items func(Rules) []RuleBehavior, predicate func(RuleBehavior, RuleLocation) bool) []RuleBehaviorMatch {
+	var matches []RuleBehaviorMatch
+	_ = r.Walk(func(rule Rules, location RuleLocation) error {
+		for i, b := range items(rule) {
+			if predicate(b, location) {
+				matches = append(matches, RuleBehaviorMatch{
+					Behavior: b,
+					Rule:     location,
+					Index:    i,
+					Pointer:  fmt.Sprintf("%s/%s/%d", location.Pointer(), field, i),
+				})
+			}
+		}
+		return nil
+	})
+	return matches
+}
+
+// RuleByPath returns the rule under the path of child rule names, starting below the receiver
+// An empty path returns the receiver itself
+func (r Rules) RuleByPath(names ...string) (Rules, RuleLocation, error) {
+	current := r
+	location := RuleLocation{Names: []string{r.Name}}
+	for depth, name := range names {
+		index, err := childIndex(current, name, names[:depth])
+		if err != nil {
+			return Rules{}, RuleLocation{}, err
+		}
+		current = current.Children[index]
+		location = location.child(name, index)
+	}
+	return current, location, nil
+}
+
+// RuleByUUID returns the rule with the given UUID
+func (r Rules) RuleByUUID(uuid string) (Rules, RuleLocation, error) {
+	matches := r.FindRules(func(rule Rules, _ RuleLocation) bool {
+		return uuid != "" && rule.UUID == uuid
+	})
+	if len(matches) == 0 {
+		return Rules{}, RuleLocation{}, fmt.Errorf("%w: rule with UUID %q", ErrNotFound, uuid)
+	}
+	return matches[0].Rule, matches[0].Location, nil
+}
+
+func childIndex(rule Rules, name string, parent []string) (int, error) {
+	index := -1
+	for i, child := range rule.Children {
+		if child.Name != name {
+			continue
+		}
+		if index != -1 {
+			return -1, fmt.Errorf("%w: rule name %q is not unique under %q", ErrRulePath, name, strings.Join(parent, "/"))
+		}
+		index = i
+	}
+	if index == -1 {
+		return -1, fmt.Errorf("%w: rule %q not found", ErrRulePath, strings.Join(append(append([]string{}, parent...), name), "/"))
+	}
+	return index, nil
+}
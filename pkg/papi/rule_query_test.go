@@ -0,0 +1,313 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ruleTreeFixture is a rule tree response with behaviors, criteria, variables and a custom override
+const ruleTreeFixture = `
+{
+    "accountId": "accountID",
+    "contractId": "contract",
+    "groupId": "group",
+    "propertyId": "propertyID",
+    "propertyVersion": 2,
+    "etag": "etag",
+    "ruleFormat": "v2020-09-16",
+    "rules": {
+        "name": "default",
+        "criteria": [],
+        "children": [
+            {
+                "name": "Compress Text Content",
+                "criteria": [
+                    {
+                        "name": "contentType",
+                        "options": {
+                            "matchOperator": "IS_ONE_OF",
+                            "matchWildcard": true,
+                            "matchCaseSensitive": false,
+                            "values": [
+                                "text/html*",
+                                "text/css*",
+                                "application/x-javascript*"
+                            ]
+                        }
+                    }
+                ],
+                "behaviors": [
+                    {
+                        "name": "gzipResponse",
+                        "options": { "behavior": "ALWAYS" }
+                    }
+                ]
+            }
+        ],
+        "options": {
+            "is_secure": false
+        },
+        "behaviors": [
+            {
+                "name": "origin",
+                "options": {
+                    "httpPort": 80,
+                    "enableTrueClientIp": false,
+                    "compress": true,
+                    "cacheKeyHostname": "ORIGIN_HOSTNAME",
+                    "forwardHostHeader": "REQUEST_HOST_HEADER",
+                    "hostname": "origin.test.com",
+                    "originType": "CUSTOMER"
+                }
+            },
+            {
+                "name": "cpCode",
+                "options": {
+                    "value": {
+                        "id": 12345,
+                        "name": "my CP code"
+                    }
+                }
+            }
+        ],
+ 		"customOverride": {
+        	"overrideId": "cbo_12345",
+        	"name": "mdc"
+    	},
+		"variables": [
+            {
+                "name": "VAR_NAME",
+                "value": "default value",
+                "description": "This is a sample Property Manager variable.",
+                "hidden": false,
+                "sensitive": false
+            }
+        ]
+    }
+}`
+
+// nestedRuleTreeFixture is a rule tree response with nested children
+const nestedRuleTreeFixture = `
+{
+	"accountId": "accountID",
+	"contractId": "contract",
+	"groupId": "group",
+	"propertyId": "propertyID",
+	"propertyVersion": 2,
+	"etag": "etag",
+	"ruleFormat": "v2020-09-16",
+	"rules": {
+		"name": "default",
+		"children": [{
+			"name": "Augment insights",
+			"criteria": [],
+			"children": [{
+				"name": "Compress Text Content",
+				"criteria": [{
+					"name": "contentType",
+					"options": {
+						"matchOperator": "IS_ONE_OF",
+						"matchWildcard": true,
+						"matchCaseSensitive": false,
+						"values": [
+							"text/html*",
+							"text/css*",
+							"application/x-javascript*"
+						]
+					}
+				}],
+				"behaviors": []
+			}],
+			"options": {
+				"is_secure": false
+			}
+		}],
+		"behaviors": [{
+				"name": "origin",
+				"options": {
+					"httpPort": 80,
+					"enableTrueClientIp": false,
+					"compress": true,
+					"cacheKeyHostname": "ORIGIN_HOSTNAME",
+					"forwardHostHeader": "REQUEST_HOST_HEADER",
+					"hostname": "origin.test.com",
+					"originType": "CUSTOMER"
+				}
+			},
+			{
+				"name": "cpCode",
+				"options": {
+					"value": {
+						"id": 12345,
+						"name": "my CP code"
+					}
+				}
+			}
+		],
+		"customOverride": {
+			"overrideId": "cbo_12345",
+			"name": "mdc"
+		},
+		"variables": [{
+			"name": "VAR_NAME",
+			"value": "default value",
+			"description": "This is a sample Property Manager variable.",
+			"hidden": false,
+			"sensitive": false
+		}]
+	}
+}`
+
+func decodeRuleTreeFixture(t *testing.T, fixture string) Rules {
+	var response GetRuleTreeResponse
+	require.NoError(t, json.Unmarshal([]byte(fixture), &response))
+	return response.Rules
+}
+
+func TestRules_Walk(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+
+	t.Run("visits all rules in order", func(t *testing.T) {
+		var paths, pointers []string
+		err := rules.Walk(func(rule Rules, location RuleLocation) error {
+			assert.Equal(t, rule.Name, location.Names[len(location.Names)-1])
+			paths = append(paths, location.Path())
+			pointers = append(pointers, location.Pointer())
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"default", "default/Augment insights", "default/Augment insights/Compress Text Content"}, paths)
+		assert.Equal(t, []string{"/rules", "/rules/children/0", "/rules/children/0/children/0"}, pointers)
+	})
+
+	t.Run("skip children", func(t *testing.T) {
+		var paths []string
+		err := rules.Walk(func(_ Rules, location RuleLocation) error {
+			paths = append(paths, location.Path())
+			if location.Path() == "default/Augment insights" {
+				return ErrSkipChildren
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"default", "default/Augment insights"}, paths)
+	})
+
+	t.Run("error stops walk", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var visited int
+		err := rules.Walk(func(Rules, RuleLocation) error {
+			visited++
+			return errStop
+		})
+		assert.True(t, errors.Is(err, errStop), "want: %s; got: %s", errStop, err)
+		assert.Equal(t, 1, visited)
+	})
+}
+
+func TestRules_FindBehaviors(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, ruleTreeFixture)
+
+	origins := rules.FindBehaviors("origin")
+	require.Len(t, origins, 1)
+	assert.Equal(t, "origin.test.com", origins[0].Behavior.Options["hostname"])
+	assert.Equal(t, "/rules/behaviors/0", origins[0].Pointer)
+	assert.Equal(t, "default", origins[0].Rule.Path())
+
+	gzip := rules.FindBehaviors("gzipResponse")
+	require.Len(t, gzip, 1)
+	assert.Equal(t, "/rules/children/0/behaviors/0", gzip[0].Pointer)
+	assert.Equal(t, []string{"Compress Text Content"}, gzip[0].Rule.NamePath())
+
+	assert.Empty(t, rules.FindBehaviors("caching"))
+
+	withOptions := rules.FindBehaviorsFunc(func(b RuleBehavior, location RuleLocation) bool {
+		_, ok := b.Options["value"]
+		return ok && len(location.Names) == 1
+	})
+	require.Len(t, withOptions, 1)
+	assert.Equal(t, "cpCode", withOptions[0].Behavior.Name)
+	assert.Equal(t, 1, withOptions[0].Index)
+}
+
+func TestRules_FindCriteria(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+
+	contentTypes := rules.FindCriteria("contentType")
+	require.Len(t, contentTypes, 1)
+	assert.Equal(t, "/rules/children/0/children/0/criteria/0", contentTypes[0].Pointer)
+	assert.Equal(t, "IS_ONE_OF", contentTypes[0].Behavior.Options["matchOperator"])
+
+	wildcards := rules.FindCriteriaFunc(func(b RuleBehavior, _ RuleLocation) bool {
+		return b.Options["matchWildcard"] == true
+	})
+	assert.Equal(t, contentTypes, wildcards)
+}
+
+func TestRules_FindRules(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+
+	leaves := rules.FindRules(func(rule Rules, _ RuleLocation) bool {
+		return len(rule.Children) == 0
+	})
+	require.Len(t, leaves, 1)
+	assert.Equal(t, "Compress Text Content", leaves[0].Rule.Name)
+	assert.Equal(t, []int{0, 0}, leaves[0].Location.Indexes)
+}
+
+func TestRules_RuleByPath(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+
+	tests := map[string]struct {
+		names           []string
+		expectedName    string
+		expectedPointer string
+		withError       error
+	}{
+		"root": {
+			expectedName:    "default",
+			expectedPointer: "/rules",
+		},
+		"nested rule": {
+			names:           []string{"Augment insights", "Compress Text Content"},
+			expectedName:    "Compress Text Content",
+			expectedPointer: "/rules/children/0/children/0",
+		},
+		"not found": {
+			names:     []string{"Augment insights", "Images"},
+			withError: ErrRulePath,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rule, location, err := rules.RuleByPath(test.names...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedName, rule.Name)
+			assert.Equal(t, test.expectedPointer, location.Pointer())
+		})
+	}
+}
+
+func TestRules_RuleByUUID(t *testing.T) {
+	rules := decodeRuleTreeFixture(t, nestedRuleTreeFixture)
+	rules.Children[0].Children[0].UUID = "5f3b6b6a-cd4d-4e6a-b1b2-2d2d2f0d7c11"
+
+	rule, location, err := rules.RuleByUUID("5f3b6b6a-cd4d-4e6a-b1b2-2d2d2f0d7c11")
+	require.NoError(t, err)
+	assert.Equal(t, "Compress Text Content", rule.Name)
+	assert.Equal(t, "default/Augment insights/Compress Text Content", location.Path())
+
+	_, _, err = rules.RuleByUUID("unknown")
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+	_, _, err = rules.RuleByUUID("")
+	assert.True(t, errors.Is(err, ErrNotFound), "want: %s; got: %s", ErrNotFound, err)
+}
@@ -0,0 +1,396 @@
+package papi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type (
+	// SimulatedRequest describes a client request evaluated against a rule tree by Rules.SimulateRequest
+	SimulatedRequest struct {
+		Hostname string
+		Path     string
+		Query    url.Values
+		// Method is an HTTP method, e.g. GET, defaulting to GET when empty
+		Method  string
+		Headers http.Header
+		Cookies map[string]string
+		// ClientIP is the IP address of the client, required by clientIp criterion
+		ClientIP string
+		// Protocol is either HTTP or HTTPS, defaulting to HTTPS when empty
+		Protocol string
+	}
+
+	// SimulationResult contains outcome of evaluating a request against a rule tree
+	SimulationResult struct {
+		// Behaviors contains effective behaviors in the order they were set
+		Behaviors []SimulatedBehavior
+		// MatchedRules contains locations of all rules whose criteria matched the request
+		MatchedRules []RuleLocation
+		// Unsupported contains criteria which could not be evaluated offline. Such criteria are evaluated as not matching,
+		// so a rule with criteriaMustSatisfy "all" does not match, while a rule with "any" still matches when another criterion does
+		Unsupported []RuleBehaviorMatch
+	}
+
+	// SimulatedBehavior is an effective behavior together with location of the rule which set it
+	SimulatedBehavior struct {
+		Behavior RuleBehavior
+		Rule     RuleLocation
+		Pointer  string
+	}
+
+	criterionEvaluator func(options RuleOptionsMap, request SimulatedRequest) (bool, error)
+)
+
+var (
+	// SimulationCumulativeBehaviors lists behaviors which may apply multiple times to a single request
+	// Every matching instance of such behavior is effective, while for others a later instance overrides an earlier one
+	SimulationCumulativeBehaviors = map[string]bool{
+		"modifyIncomingRequestHeader":  true,
+		"modifyIncomingResponseHeader": true,
+		"modifyOutgoingRequestHeader":  true,
+		"modifyOutgoingResponseHeader": true,
+		"setVariable":                  true,
+	}
+
+	criterionEvaluators = map[string]criterionEvaluator{
+		"hostname":             matchHostnameCriterion,
+		"path":                 matchPathCriterion,
+		"fileExtension":        matchFileExtensionCriterion,
+		"requestHeader":        matchRequestHeaderCriterion,
+		"requestMethod":        matchRequestMethodCriterion,
+		"requestProtocol":      matchRequestProtocolCriterion,
+		"queryStringParameter": matchQueryStringParameterCriterion,
+		"cookie":               matchCookieCriterion,
+		"clientIp":             matchClientIPCriterion,
+	}
+
+	errUnsupportedCriterion = errors.New("criterion cannot be evaluated offline")
+)
+
+// SimulateRequest evaluates rules for the request without calling PAPI
+// Rules are evaluated in tree order honouring criteriaMustSatisfy, children are evaluated only when their parent matches
+// and behaviors of a later matching rule override the same behaviors set before. Criteria which cannot be evaluated offline,
+// such as matchAdvanced, are evaluated as not matching and reported in SimulationResult.Unsupported
+func (r Rules) SimulateRequest(request SimulatedRequest) *SimulationResult {
+	if request.Method == "" {
+		request.Method = http.MethodGet
+	}
+	if request.Protocol == "" {
+		request.Protocol = "HTTPS"
+	}
+
+	var result SimulationResult
+	// the walk function only returns ErrSkipChildren, so there is no error to handle
+	_ = r.Walk(func(rule Rules, location RuleLocation) error {
+		if !result.matches(rule, location, request) {
+			return ErrSkipChildren
+		}
+		result.MatchedRules = append(result.MatchedRules, location)
+		for i, behavior := range rule.Behaviors {
+			result.setBehavior(SimulatedBehavior{
+				Behavior: behavior,
+				Rule:     location,
+				Pointer:  fmt.Sprintf("%s/behaviors/%d", location.Pointer(), i),
+			})
+		}
+		return nil
+	})
+	return &result
+}
+
+// Behavior returns the effective behavior with given name and true, or false if the request does not trigger it
+// For cumulative behaviors the last instance is returned
+func (s *SimulationResult) Behavior(name string) (SimulatedBehavior, bool) {
+	for i := len(s.Behaviors) - 1; i >= 0; i-- {
+		if s.Behaviors[i].Behavior.Name == name {
+			return s.Behaviors[i], true
+		}
+	}
+	return SimulatedBehavior{}, false
+}
+
+func (s *SimulationResult) setBehavior(behavior SimulatedBehavior) {
+	if !SimulationCumulativeBehaviors[behavior.Behavior.Name] {
+		for i := range s.Behaviors {
+			if s.Behaviors[i].Behavior.Name == behavior.Behavior.Name {
+				s.Behaviors = append(s.Behaviors[:i], s.Behaviors[i+1:]...)
+				break
+			}
+		}
+	}
+	s.Behaviors = append(s.Behaviors, behavior)
+}
+
+func (s *SimulationResult) matches(rule Rules, location RuleLocation, request SimulatedRequest) bool {
+	if len(rule.Criteria) == 0 {
+		return true
+	}
+	matchAny := rule.CriteriaMustSatisfy == RuleCriteriaMustSatisfyAny
+	for i, criterion := range rule.Criteria {
+		matched, err := evaluateCriterion(criterion, request)
+		if err != nil {
+			s.Unsupported = append(s.Unsupported, RuleBehaviorMatch{
+				Behavior: criterion,
+				Rule:     location,
+				Index:    i,
+				Pointer:  fmt.Sprintf("%s/criteria/%d", location.Pointer(), i),
+			})
+			matched = false
+		}
+		if matchAny && matched {
+			return true
+		}
+		if !matchAny && !matched {
+			return false
+		}
+	}
+	return !matchAny
+}
+
+func evaluateCriterion(criterion RuleBehavior, request SimulatedRequest) (bool, error) {
+	evaluate, ok := criterionEvaluators[criterion.Name]
+	if !ok {
+		return false, fmt.Errorf("%w: %s", errUnsupportedCriterion, criterion.Name)
+	}
+	return evaluate(criterion.Options, request)
+}
+
+func matchHostnameCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	matched := matchAnyWildcard(optionStrings(options, "values"), request.Hostname, false)
+	return applyMatchOperator(optionString(options, "matchOperator"), matched, "IS_ONE_OF", "IS_NOT_ONE_OF")
+}
+
+func matchPathCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	requestPath := request.Path
+	if optionBool(options, "normalize") {
+		requestPath = path.Clean("/" + requestPath)
+	}
+	matched := matchAnyWildcard(optionStrings(options, "values"), requestPath, optionBool(options, "matchCaseSensitive"))
+	return applyMatchOperator(optionString(options, "matchOperator"), matched, "MATCHES_ONE_OF", "DOES_NOT_MATCH_ONE_OF")
+}
+
+func matchFileExtensionCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	extension := strings.TrimPrefix(path.Ext(request.Path), ".")
+	matched := false
+	if extension != "" {
+		matched = matchAnyWildcard(optionStrings(options, "values"), extension, optionBool(options, "matchCaseSensitive"))
+	}
+	return applyMatchOperator(optionString(options, "matchOperator"), matched, "IS_ONE_OF", "IS_NOT_ONE_OF")
+}
+
+func matchRequestHeaderCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	name := optionString(options, "headerName")
+	var values []string
+	found := false
+	for header, headerValues := range request.Headers {
+		if matchWildcardOption(name, header, optionBool(options, "matchWildcardName"), false) {
+			found = true
+			values = append(values, headerValues...)
+		}
+	}
+	return matchNamedValue(options, found, values, optionBool(options, "matchCaseSensitiveValue"))
+}
+
+func matchQueryStringParameterCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	name := optionString(options, "parameterName")
+	var values []string
+	found := false
+	for parameter, parameterValues := range request.Query {
+		if matchWildcardOption(name, parameter, optionBool(options, "matchWildcardName"), optionBool(options, "matchCaseSensitiveName")) {
+			found = true
+			values = append(values, parameterValues...)
+		}
+	}
+	return matchNamedValue(options, found, values, optionBool(options, "matchCaseSensitiveValue"))
+}
+
+func matchCookieCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	name := optionString(options, "cookieName")
+	var values []string
+	found := false
+	for cookie, value := range request.Cookies {
+		if matchWildcardOption(name, cookie, optionBool(options, "matchWildcardName"), optionBool(options, "matchCaseSensitiveName")) {
+			found = true
+			values = append(values, value)
+		}
+	}
+	return matchNamedValue(options, found, values, optionBool(options, "matchCaseSensitiveValue"))
+}
+
+// matchNamedValue evaluates operators shared by header, query string parameter and cookie criteria
+func matchNamedValue(options RuleOptionsMap, found bool, values []string, caseSensitive bool) (bool, error) {
+	switch operator := optionString(options, "matchOperator"); operator {
+	case "EXISTS":
+		return found, nil
+	case "DOES_NOT_EXIST":
+		return !found, nil
+	case "IS_ONE_OF", "IS_NOT_ONE_OF":
+		matched := false
+		for _, value := range values {
+			if matchWildcardValues(optionStrings(options, "values"), value, optionBool(options, "matchWildcardValue"), caseSensitive) {
+				matched = true
+				break
+			}
+		}
+		return applyMatchOperator(operator, matched, "IS_ONE_OF", "IS_NOT_ONE_OF")
+	case "IS_LESS_THAN", "IS_MORE_THAN", "IS_BETWEEN":
+		for _, value := range values {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			if matchNumericOperator(operator, number, options) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: match operator %q", errUnsupportedCriterion, operator)
+	}
+}
+
+func matchNumericOperator(operator string, value float64, options RuleOptionsMap) bool {
+	lower, lowerOK := optionNumber(options, "lowerBound")
+	upper, upperOK := optionNumber(options, "upperBound")
+	switch operator {
+	case "IS_LESS_THAN":
+		return lowerOK && value < lower
+	case "IS_MORE_THAN":
+		return lowerOK && value > lower
+	default:
+		return lowerOK && upperOK && value >= lower && value <= upper
+	}
+}
+
+func matchRequestMethodCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	method := strings.TrimPrefix(optionString(options, "value"), "HTTP_")
+	matched := strings.EqualFold(method, request.Method)
+	return applyMatchOperator(optionString(options, "matchOperator"), matched, "IS", "IS_NOT")
+}
+
+func matchRequestProtocolCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	return strings.EqualFold(optionString(options, "value"), request.Protocol), nil
+}
+
+func matchClientIPCriterion(options RuleOptionsMap, request SimulatedRequest) (bool, error) {
+	ip := net.ParseIP(request.ClientIP)
+	if ip == nil {
+		return false, fmt.Errorf("%w: client IP %q is not a valid address", errUnsupportedCriterion, request.ClientIP)
+	}
+	matched := false
+	for _, value := range optionStrings(options, "values") {
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			matched = network.Contains(ip)
+		} else {
+			matched = ip.Equal(net.ParseIP(value))
+		}
+		if matched {
+			break
+		}
+	}
+	return applyMatchOperator(optionString(options, "matchOperator"), matched, "IS_ONE_OF", "IS_NOT_ONE_OF")
+}
+
+func applyMatchOperator(operator string, matched bool, positive, negative string) (bool, error) {
+	switch operator {
+	case positive, "":
+		return matched, nil
+	case negative:
+		return !matched, nil
+	}
+	return false, fmt.Errorf("%w: match operator %q", errUnsupportedCriterion, operator)
+}
+
+func matchWildcardOption(pattern, value string, wildcard, caseSensitive bool) bool {
+	if wildcard {
+		return matchWildcard(pattern, value, caseSensitive)
+	}
+	if caseSensitive {
+		return pattern == value
+	}
+	return strings.EqualFold(pattern, value)
+}
+
+func matchWildcardValues(patterns []string, value string, wildcard, caseSensitive bool) bool {
+	for _, pattern := range patterns {
+		if matchWildcardOption(pattern, value, wildcard, caseSensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyWildcard(patterns []string, value string, caseSensitive bool) bool {
+	return matchWildcardValues(patterns, value, true, caseSensitive)
+}
+
+// matchWildcard matches value against pattern in which "*" matches any sequence of characters and "?" a single one
+func matchWildcard(pattern, value string, caseSensitive bool) bool {
+	var expr strings.Builder
+	if !caseSensitive {
+		expr.WriteString("(?i)")
+	}
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	matched, err := regexp.MatchString(expr.String(), value)
+	return err == nil && matched
+}
+
+func optionString(options RuleOptionsMap, name string) string {
+	s, _ := options[name].(string)
+	return s
+}
+
+func optionBool(options RuleOptionsMap, name string) bool {
+	b, _ := options[name].(bool)
+	return b
+}
+
+func optionNumber(options RuleOptionsMap, name string) (float64, bool) {
+	switch n := options[name].(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func optionStrings(options RuleOptionsMap, name string) []string {
+	switch values := options[name].(type) {
+	case []string:
+		return values
+	case []interface{}:
+		result := make([]string, 0, len(values))
+		for _, value := range values {
+			if s, ok := value.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		return []string{values}
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package papi
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func simulationRuleTree() Rules {
+	return Rules{
+		Name: "default",
+		Behaviors: []RuleBehavior{
+			{Name: "origin", Options: RuleOptionsMap{"hostname": "origin.test.com"}},
+			{Name: "caching", Options: RuleOptionsMap{"behavior": "NO_STORE"}},
+		},
+		Children: []Rules{
+			{
+				Name: "Static",
+				Criteria: []RuleBehavior{
+					{Name: "fileExtension", Options: RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": []interface{}{"css", "js", "png"}}},
+				},
+				Behaviors: []RuleBehavior{
+					{Name: "caching", Options: RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "7d"}},
+				},
+				Children: []Rules{
+					{
+						Name:                "Images",
+						CriteriaMustSatisfy: RuleCriteriaMustSatisfyAny,
+						Criteria: []RuleBehavior{
+							{Name: "path", Options: RuleOptionsMap{"matchOperator": "MATCHES_ONE_OF", "values": []interface{}{"/images/*"}}},
+							{Name: "requestHeader", Options: RuleOptionsMap{"headerName": "Accept", "matchOperator": "IS_ONE_OF", "values": []interface{}{"image/*"}, "matchWildcardValue": true}},
+						},
+						Behaviors: []RuleBehavior{
+							{Name: "caching", Options: RuleOptionsMap{"behavior": "MAX_AGE", "ttl": "30d"}},
+						},
+					},
+				},
+			},
+			{
+				Name: "API",
+				Criteria: []RuleBehavior{
+					{Name: "hostname", Options: RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": []interface{}{"api.*.com"}}},
+					{Name: "requestMethod", Options: RuleOptionsMap{"matchOperator": "IS_NOT", "value": "GET"}},
+				},
+				Behaviors: []RuleBehavior{
+					{Name: "origin", Options: RuleOptionsMap{"hostname": "api-origin.test.com"}},
+					{Name: "modifyOutgoingResponseHeader", Options: RuleOptionsMap{"action": "ADD", "customHeaderName": "X-API"}},
+				},
+			},
+			{
+				Name: "Debug",
+				Criteria: []RuleBehavior{
+					{Name: "queryStringParameter", Options: RuleOptionsMap{"parameterName": "debug", "matchOperator": "EXISTS"}},
+					{Name: "cookie", Options: RuleOptionsMap{"cookieName": "version", "matchOperator": "IS_BETWEEN", "lowerBound": 2, "upperBound": 5}},
+					{Name: "clientIp", Options: RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": []interface{}{"10.0.0.0/8"}}},
+				},
+				Behaviors: []RuleBehavior{
+					{Name: "modifyOutgoingResponseHeader", Options: RuleOptionsMap{"action": "ADD", "customHeaderName": "X-Debug"}},
+				},
+			},
+			{
+				Name:     "Advanced",
+				Criteria: []RuleBehavior{{Name: "matchAdvanced", Options: RuleOptionsMap{"openXml": "<match:request.type value=\"CLIENT_REQ\"/>"}}},
+				Behaviors: []RuleBehavior{
+					{Name: "caching", Options: RuleOptionsMap{"behavior": "BYPASS_CACHE"}},
+				},
+			},
+		},
+	}
+}
+
+func TestRules_SimulateRequest(t *testing.T) {
+	rules := simulationRuleTree()
+
+	tests := map[string]struct {
+		request           SimulatedRequest
+		expectedRules     []string
+		expectedBehaviors map[string]string
+	}{
+		"default rule only": {
+			request:           SimulatedRequest{Hostname: "www.test.com", Path: "/index.html"},
+			expectedRules:     []string{"default"},
+			expectedBehaviors: map[string]string{"origin": "default", "caching": "default"},
+		},
+		"static content": {
+			request:           SimulatedRequest{Hostname: "www.test.com", Path: "/styles/main.CSS"},
+			expectedRules:     []string{"default", "default/Static"},
+			expectedBehaviors: map[string]string{"origin": "default", "caching": "default/Static"},
+		},
+		"nested rule with any criteria": {
+			request: SimulatedRequest{
+				Hostname: "www.test.com",
+				Path:     "/assets/logo.png",
+				Headers:  http.Header{"Accept": []string{"image/webp"}},
+			},
+			expectedRules:     []string{"default", "default/Static", "default/Static/Images"},
+			expectedBehaviors: map[string]string{"origin": "default", "caching": "default/Static/Images"},
+		},
+		"all criteria must match": {
+			request:           SimulatedRequest{Hostname: "api.test.com", Path: "/v1/items", Method: http.MethodGet},
+			expectedRules:     []string{"default"},
+			expectedBehaviors: map[string]string{"origin": "default", "caching": "default"},
+		},
+		"later rule overrides behavior": {
+			request:           SimulatedRequest{Hostname: "API.test.com", Path: "/v1/items", Method: http.MethodPost},
+			expectedRules:     []string{"default", "default/API"},
+			expectedBehaviors: map[string]string{"caching": "default", "origin": "default/API", "modifyOutgoingResponseHeader": "default/API"},
+		},
+		"query, cookie and client IP": {
+			request: SimulatedRequest{
+				Hostname: "www.test.com",
+				Path:     "/",
+				Query:    url.Values{"debug": []string{""}},
+				Cookies:  map[string]string{"version": "3"},
+				ClientIP: "10.1.2.3",
+			},
+			expectedRules:     []string{"default", "default/Debug"},
+			expectedBehaviors: map[string]string{"origin": "default", "caching": "default", "modifyOutgoingResponseHeader": "default/Debug"},
+		},
+		"client IP out of range": {
+			request: SimulatedRequest{
+				Hostname: "www.test.com",
+				Path:     "/",
+				Query:    url.Values{"debug": []string{""}},
+				Cookies:  map[string]string{"version": "3"},
+				ClientIP: "192.168.0.1",
+			},
+			expectedRules:     []string{"default"},
+			expectedBehaviors: map[string]string{"origin": "default", "caching": "default"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := rules.SimulateRequest(test.request)
+
+			var matched []string
+			for _, location := range result.MatchedRules {
+				matched = append(matched, location.Path())
+			}
+			assert.Equal(t, test.expectedRules, matched)
+
+			behaviors := make(map[string]string)
+			for _, behavior := range result.Behaviors {
+				behaviors[behavior.Behavior.Name] = behavior.Rule.Path()
+			}
+			assert.Equal(t, test.expectedBehaviors, behaviors)
+
+			require.Len(t, result.Unsupported, 1)
+			assert.Equal(t, "matchAdvanced", result.Unsupported[0].Behavior.Name)
+			assert.Equal(t, "/rules/children/3/criteria/0", result.Unsupported[0].Pointer)
+		})
+	}
+}
+
+func TestRules_SimulateRequestCumulativeBehaviors(t *testing.T) {
+	rules := simulationRuleTree()
+
+	result := rules.SimulateRequest(SimulatedRequest{
+		Hostname: "api.test.com",
+		Method:   http.MethodPost,
+		Query:    url.Values{"debug": []string{"1"}},
+		Cookies:  map[string]string{"version": "2"},
+		ClientIP: "10.0.0.1",
+	})
+
+	var headers []string
+	for _, behavior := range result.Behaviors {
+		if behavior.Behavior.Name == "modifyOutgoingResponseHeader" {
+			headers = append(headers, behavior.Behavior.Options["customHeaderName"].(string))
+		}
+	}
+	assert.Equal(t, []string{"X-API", "X-Debug"}, headers)
+
+	origin, ok := result.Behavior("origin")
+	require.True(t, ok)
+	assert.Equal(t, "api-origin.test.com", origin.Behavior.Options["hostname"])
+	assert.Equal(t, "/rules/children/1/behaviors/0", origin.Pointer)
+
+	_, ok = result.Behavior("gzipResponse")
+	assert.False(t, ok)
+}
+
+func TestRules_SimulateRequestUnsupportedCriteria(t *testing.T) {
+	unsupported := RuleBehavior{Name: "matchAdvanced", Options: RuleOptionsMap{"openXml": "<match:request.type value=\"CLIENT_REQ\"/>"}}
+	hostname := RuleBehavior{Name: "hostname", Options: RuleOptionsMap{"matchOperator": "IS_ONE_OF", "values": []interface{}{"www.test.com"}}}
+
+	tests := map[string]struct {
+		criteriaMustSatisfy RuleCriteriaMustSatisfy
+		expectedRules       []string
+	}{
+		"any matches on other criterion": {
+			criteriaMustSatisfy: RuleCriteriaMustSatisfyAny,
+			expectedRules:       []string{"default", "default/rule"},
+		},
+		"all does not match": {
+			criteriaMustSatisfy: RuleCriteriaMustSatisfyAll,
+			expectedRules:       []string{"default"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules := Rules{
+				Name: "default",
+				Children: []Rules{{
+					Name:                "rule",
+					Criteria:            []RuleBehavior{unsupported, hostname},
+					CriteriaMustSatisfy: test.criteriaMustSatisfy,
+				}},
+			}
+
+			result := rules.SimulateRequest(SimulatedRequest{Hostname: "www.test.com", Path: "/"})
+
+			var matched []string
+			for _, location := range result.MatchedRules {
+				matched = append(matched, location.Path())
+			}
+			assert.Equal(t, test.expectedRules, matched)
+			require.Len(t, result.Unsupported, 1)
+			assert.Equal(t, "/rules/children/0/criteria/0", result.Unsupported[0].Pointer)
+		})
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	tests := map[string]struct {
+		pattern       string
+		value         string
+		caseSensitive bool
+		expected      bool
+	}{
+		"exact":                 {pattern: "/index.html", value: "/index.html", expected: true},
+		"star":                  {pattern: "/images/*", value: "/images/a/b.png", expected: true},
+		"question mark":         {pattern: "/v?/items", value: "/v1/items", expected: true},
+		"question mark single":  {pattern: "/v?/items", value: "/v10/items", expected: false},
+		"meta characters":       {pattern: "/a+b(1).html", value: "/a+b(1).html", expected: true},
+		"case insensitive":      {pattern: "*.PNG", value: "logo.png", expected: true},
+		"case sensitive":        {pattern: "*.PNG", value: "logo.png", caseSensitive: true, expected: false},
+		"no implicit substring": {pattern: "images", value: "/images/logo.png", expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, matchWildcard(test.pattern, test.value, test.caseSensitive))
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package papi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type (
+	// SnippetVariableDefinitions represents contents of variableDefinitions.json file
+	SnippetVariableDefinitions struct {
+		Definitions map[string]SnippetVariableDefinition `json:"definitions"`
+	}
+
+	// SnippetVariableDefinition contains type and default value of a single snippet variable
+	SnippetVariableDefinition struct {
+		Type    string      `json:"type"`
+		Default interface{} `json:"default"`
+	}
+)
+
+const (
+	// SnippetsMainFile is the name of the file containing the root rule of snippets directory
+	SnippetsMainFile = "main.json"
+	// SnippetVariableDefinitionsFile is the name of the file containing snippet variable definitions and their defaults
+	SnippetVariableDefinitionsFile = "variableDefinitions.json"
+	// SnippetVariablesFile is the name of per environment file containing snippet variable values
+	SnippetVariablesFile = "variables.json"
+
+	snippetIncludePrefix = "#include:"
+)
+
+var (
+	// ErrLoadRuleSnippets represents error when assembling rule tree from snippets fails
+	ErrLoadRuleSnippets = errors.New("loading rule snippets")
+	// ErrExportRuleSnippets represents error when splitting rule tree into snippets fails
+	ErrExportRuleSnippets = errors.New("exporting rule snippets")
+	// ErrLoadSnippetVariables represents error when reading snippet variables fails
+	ErrLoadSnippetVariables = errors.New("loading snippet variables")
+
+	snippetVariable   = regexp.MustCompile(`\$\{env\.([A-Za-z0-9_]+)\}`)
+	snippetFileSymbol = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+)
+
+// LoadRuleSnippets assembles rules from main.json file of snippets directory
+// Strings of the form "#include:file.json" are replaced with contents of the referenced file, resolved relative
+// to the including one, and ${env.NAME} references are substituted with values from variables.
+// A string consisting only of a single reference is replaced with the variable value keeping its JSON type
+func LoadRuleSnippets(fsys fs.FS, variables map[string]interface{}) (*RulesUpdate, error) {
+	tree, err := loadSnippet(fsys, SnippetsMainFile, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadRuleSnippets, err)
+	}
+	tree, err = substituteSnippetVariables(tree, variables)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadRuleSnippets, err)
+	}
+
+	b, err := json.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadRuleSnippets, err)
+	}
+	var rules RulesUpdate
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %s: %s", ErrLoadRuleSnippets, SnippetsMainFile, err)
+	}
+	return &rules, nil
+}
+
+// LoadSnippetVariables reads variable defaults from variableDefinitions.json and overrides them
+// with values from environment/variables.json, both relative to fsys
+func LoadSnippetVariables(fsys fs.FS, environment string) (map[string]interface{}, error) {
+	var definitions SnippetVariableDefinitions
+	if err := readSnippetJSON(fsys, SnippetVariableDefinitionsFile, &definitions); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadSnippetVariables, err)
+	}
+	variables := make(map[string]interface{}, len(definitions.Definitions))
+	for name, definition := range definitions.Definitions {
+		variables[name] = definition.Default
+	}
+
+	var values map[string]interface{}
+	if err := readSnippetJSON(fsys, path.Join(environment, SnippetVariablesFile), &values); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLoadSnippetVariables, err)
+	}
+	for name, value := range values {
+		if _, ok := definitions.Definitions[name]; !ok {
+			return nil, fmt.Errorf("%w: variable %q in environment %q is not defined in %s", ErrLoadSnippetVariables, name, environment, SnippetVariableDefinitionsFile)
+		}
+		variables[name] = value
+	}
+	return variables, nil
+}
+
+// ExportRuleSnippets splits rules into snippets directory: each child of the root rule is written to its own file
+// named after the rule and referenced from main.json with "#include:", so that LoadRuleSnippets assembles the same rules
+func ExportRuleSnippets(dir string, rules RulesUpdate) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("%w: %s", ErrExportRuleSnippets, err)
+	}
+
+	root := rules.Rules
+	children := root.Children
+	root.Children = nil
+	main, err := toSnippetMap(RulesUpdate{Comments: rules.Comments, Rules: root})
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrExportRuleSnippets, err)
+	}
+
+	// names of the fixed snippet files are reserved, so that child rules named e.g. "main" do not overwrite them
+	used := map[string]bool{
+		snippetFileKey(SnippetsMainFile):               true,
+		snippetFileKey(SnippetVariableDefinitionsFile): true,
+		snippetFileKey(SnippetVariablesFile):           true,
+	}
+	includes := make([]interface{}, 0, len(children))
+	for _, child := range children {
+		name := snippetFileName(child.Name, used)
+		if err := writeSnippetJSON(filepath.Join(dir, name), child); err != nil {
+			return fmt.Errorf("%w: %s", ErrExportRuleSnippets, err)
+		}
+		includes = append(includes, snippetIncludePrefix+name)
+	}
+	if len(includes) > 0 {
+		main["rules"].(map[string]interface{})["children"] = includes
+	}
+
+	if err := writeSnippetJSON(filepath.Join(dir, SnippetsMainFile), main); err != nil {
+		return fmt.Errorf("%w: %s", ErrExportRuleSnippets, err)
+	}
+	return nil
+}
+
+func loadSnippet(fsys fs.FS, name string, stack []string) (interface{}, error) {
+	for _, included := range stack {
+		if included == name {
+			return nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(stack, " -> "), name)
+		}
+	}
+	var snippet interface{}
+	if err := readSnippetJSON(fsys, name, &snippet); err != nil {
+		return nil, err
+	}
+	return resolveSnippetIncludes(fsys, snippet, append(stack, name))
+}
+
+func resolveSnippetIncludes(fsys fs.FS, value interface{}, stack []string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.HasPrefix(v, snippetIncludePrefix) {
+			return v, nil
+		}
+		current := stack[len(stack)-1]
+		return loadSnippet(fsys, path.Join(path.Dir(current), strings.TrimPrefix(v, snippetIncludePrefix)), stack)
+	case []interface{}:
+		for i := range v {
+			resolved, err := resolveSnippetIncludes(fsys, v[i], stack)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+	case map[string]interface{}:
+		for k := range v {
+			resolved, err := resolveSnippetIncludes(fsys, v[k], stack)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = resolved
+		}
+	}
+	return value, nil
+}
+
+func substituteSnippetVariables(value interface{}, variables map[string]interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if match := snippetVariable.FindStringSubmatch(v); match != nil && match[0] == v {
+			variable, ok := variables[match[1]]
+			if !ok {
+				return nil, fmt.Errorf("variable %q is not defined", match[1])
+			}
+			return variable, nil
+		}
+		var err error
+		substituted := snippetVariable.ReplaceAllStringFunc(v, func(reference string) string {
+			name := snippetVariable.FindStringSubmatch(reference)[1]
+			variable, ok := variables[name]
+			if !ok {
+				err = fmt.Errorf("variable %q is not defined", name)
+				return reference
+			}
+			return fmt.Sprint(variable)
+		})
+		return substituted, err
+	case []interface{}:
+		for i := range v {
+			substituted, err := substituteSnippetVariables(v[i], variables)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = substituted
+		}
+	case map[string]interface{}:
+		for k := range v {
+			substituted, err := substituteSnippetVariables(v[k], variables)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = substituted
+		}
+	}
+	return value, nil
+}
+
+func readSnippetJSON(fsys fs.FS, name string, out interface{}) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+	return nil
+}
+
+func writeSnippetJSON(name string, value interface{}) error {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	return os.WriteFile(name, b.Bytes(), 0644)
+}
+
+func toSnippetMap(rules RulesUpdate) (map[string]interface{}, error) {
+	b, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func snippetFileName(ruleName string, used map[string]bool) string {
+	base := strings.Trim(snippetFileSymbol.ReplaceAllString(ruleName, "_"), "_")
+	if base == "" {
+		base = "rule"
+	}
+	name := base + ".json"
+	for i := 2; used[snippetFileKey(name)]; i++ {
+		name = fmt.Sprintf("%s_%d.json", base, i)
+	}
+	used[snippetFileKey(name)] = true
+	return name
+}
+
+// snippetFileKey returns the key identifying file name in the set of used names
+// Names differing only in case are considered the same, as they clash on case-insensitive file systems
+func snippetFileKey(name string) string {
+	return strings.ToLower(name)
+}
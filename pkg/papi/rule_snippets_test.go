@@ -0,0 +1,215 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportRuleSnippets_RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		rules         RulesUpdate
+		expectedFiles []string
+	}{
+		"rule tree": {
+			rules:         RulesUpdate{Comments: "version comment", Rules: decodeRuleTreeFixture(t, ruleTreeFixture)},
+			expectedFiles: []string{"Compress_Text_Content.json", "main.json"},
+		},
+		"nested rule tree": {
+			rules:         RulesUpdate{Rules: decodeRuleTreeFixture(t, nestedRuleTreeFixture)},
+			expectedFiles: []string{"Augment_insights.json", "main.json"},
+		},
+		"duplicated names": {
+			rules: RulesUpdate{Rules: Rules{
+				Name:     "default",
+				Children: []Rules{{Name: "Images"}, {Name: "Images"}, {Name: "Images_2"}, {Name: "?"}},
+			}},
+			expectedFiles: []string{"Images.json", "Images_2.json", "Images_2_2.json", "main.json", "rule.json"},
+		},
+		"names of fixed snippet files": {
+			rules: RulesUpdate{Rules: Rules{
+				Name:     "default",
+				Children: []Rules{{Name: "main"}, {Name: "Main"}, {Name: "variableDefinitions"}, {Name: "variables"}},
+			}},
+			expectedFiles: []string{"Main_3.json", "main.json", "main_2.json", "variableDefinitions_2.json", "variables_2.json"},
+		},
+		"no children": {
+			rules:         RulesUpdate{Rules: Rules{Name: "default"}},
+			expectedFiles: []string{"main.json"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "property-snippets")
+			require.NoError(t, ExportRuleSnippets(dir, test.rules))
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			var files []string
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			assert.Equal(t, test.expectedFiles, files)
+
+			loaded, err := LoadRuleSnippets(os.DirFS(dir), nil)
+			require.NoError(t, err)
+			expected, err := json.Marshal(test.rules)
+			require.NoError(t, err)
+			actual, err := json.Marshal(loaded)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestExportRuleSnippets_MainFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ExportRuleSnippets(dir, RulesUpdate{Rules: decodeRuleTreeFixture(t, nestedRuleTreeFixture)}))
+
+	b, err := os.ReadFile(filepath.Join(dir, SnippetsMainFile))
+	require.NoError(t, err)
+	var main struct {
+		Rules struct {
+			Children []string `json:"children"`
+		} `json:"rules"`
+	}
+	require.NoError(t, json.Unmarshal(b, &main))
+	assert.Equal(t, []string{"#include:Augment_insights.json"}, main.Rules.Children)
+}
+
+func TestLoadRuleSnippets(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.json": {Data: []byte(`{
+			"rules": {
+				"name": "default",
+				"behaviors": [
+					{"name": "origin", "options": {"hostname": "${env.originHostname}", "httpPort": "${env.httpPort}"}},
+					{"name": "cpCode", "options": {"value": {"id": "${env.cpCode}", "name": "cp code ${env.cpCode} for ${env.env}"}}}
+				],
+				"children": ["#include:Performance.json", "#include:offload/Offload.json"]
+			}
+		}`)},
+		"Performance.json":     {Data: []byte(`{"name": "Performance", "children": ["#include:Images.json"]}`)},
+		"Images.json":          {Data: []byte(`{"name": "Images", "behaviors": [{"name": "imageManager", "options": {"enabled": "${env.imageManager}"}}]}`)},
+		"offload/Offload.json": {Data: []byte(`{"name": "Offload", "children": ["#include:Static.json"]}`)},
+		"offload/Static.json":  {Data: []byte(`{"name": "Static Content"}`)},
+	}
+	variables := map[string]interface{}{
+		"originHostname": "origin.example.com",
+		"httpPort":       80,
+		"cpCode":         12345,
+		"env":            "staging",
+		"imageManager":   false,
+	}
+
+	rules, err := LoadRuleSnippets(fsys, variables)
+	require.NoError(t, err)
+	assert.Equal(t, &RulesUpdate{
+		Rules: Rules{
+			Name: "default",
+			Behaviors: []RuleBehavior{
+				{Name: "origin", Options: RuleOptionsMap{"hostname": "origin.example.com", "httpPort": float64(80)}},
+				{Name: "cpCode", Options: RuleOptionsMap{"value": map[string]interface{}{"id": float64(12345), "name": "cp code 12345 for staging"}}},
+			},
+			Children: []Rules{
+				{
+					Name: "Performance",
+					Children: []Rules{
+						{Name: "Images", Behaviors: []RuleBehavior{{Name: "imageManager", Options: RuleOptionsMap{"enabled": false}}}},
+					},
+				},
+				{
+					Name:     "Offload",
+					Children: []Rules{{Name: "Static Content"}},
+				},
+			},
+		},
+	}, rules)
+}
+
+func TestLoadRuleSnippets_Errors(t *testing.T) {
+	tests := map[string]struct {
+		fsys     fstest.MapFS
+		contains string
+	}{
+		"missing main file": {
+			fsys:     fstest.MapFS{},
+			contains: "main.json",
+		},
+		"missing include": {
+			fsys: fstest.MapFS{
+				"main.json": {Data: []byte(`{"rules": {"name": "default", "children": ["#include:Missing.json"]}}`)},
+			},
+			contains: "Missing.json",
+		},
+		"include cycle": {
+			fsys: fstest.MapFS{
+				"main.json": {Data: []byte(`{"rules": {"name": "default", "children": ["#include:A.json"]}}`)},
+				"A.json":    {Data: []byte(`{"name": "A", "children": ["#include:B.json"]}`)},
+				"B.json":    {Data: []byte(`{"name": "B", "children": ["#include:A.json"]}`)},
+			},
+			contains: "include cycle: main.json -> A.json -> B.json -> A.json",
+		},
+		"undefined variable": {
+			fsys: fstest.MapFS{
+				"main.json": {Data: []byte(`{"rules": {"name": "default", "comments": "for ${env.missing}"}}`)},
+			},
+			contains: `variable "missing" is not defined`,
+		},
+		"invalid JSON": {
+			fsys: fstest.MapFS{
+				"main.json": {Data: []byte(`{"rules": `)},
+			},
+			contains: "main.json",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadRuleSnippets(test.fsys, nil)
+			assert.True(t, errors.Is(err, ErrLoadRuleSnippets), "want: %s; got: %s", ErrLoadRuleSnippets, err)
+			assert.Contains(t, err.Error(), test.contains)
+		})
+	}
+}
+
+func TestLoadSnippetVariables(t *testing.T) {
+	fsys := fstest.MapFS{
+		"variableDefinitions.json": {Data: []byte(`{
+			"definitions": {
+				"originHostname": {"type": "hostname", "default": "origin.example.com"},
+				"cpCode": {"type": "cpCode", "default": null}
+			}
+		}`)},
+		"staging/variables.json": {Data: []byte(`{"cpCode": 12345}`)},
+		"prod/variables.json":    {Data: []byte(`{"cpCode": 67890, "originHostname": "origin-prod.example.com"}`)},
+		"qa/variables.json":      {Data: []byte(`{"unknown": 1}`)},
+	}
+
+	staging, err := LoadSnippetVariables(fsys, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"originHostname": "origin.example.com", "cpCode": json.Number("12345")}, staging)
+
+	prod, err := LoadSnippetVariables(fsys, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"originHostname": "origin-prod.example.com", "cpCode": json.Number("67890")}, prod)
+
+	_, err = LoadSnippetVariables(fsys, "qa")
+	assert.True(t, errors.Is(err, ErrLoadSnippetVariables), "want: %s; got: %s", ErrLoadSnippetVariables, err)
+
+	_, err = LoadSnippetVariables(fsys, "dev")
+	assert.True(t, errors.Is(err, ErrLoadSnippetVariables), "want: %s; got: %s", ErrLoadSnippetVariables, err)
+
+	rules, err := LoadRuleSnippets(fstest.MapFS{
+		"main.json": {Data: []byte(`{"rules": {"name": "default", "behaviors": [{"name": "cpCode", "options": {"value": {"id": "${env.cpCode}"}}}]}}`)},
+	}, prod)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": float64(67890)}, rules.Rules.Behaviors[0].Options["value"])
+}
@@ -647,6 +647,7 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 		responseStatus   int
 		responseBody     string
 		expectedPath     string
+		expectedIfMatch  string
 		expectedResponse *UpdateRulesResponse
 		withError        func(*testing.T, error)
 	}{
@@ -1621,6 +1622,36 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 				assert.Contains(t, err.Error(), "Name")
 			},
 		},
+		"412 precondition failed": {
+			params: UpdateRulesRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+				ValidateRules:   true,
+				Etag:            "etag",
+				Rules: RulesUpdate{
+					Rules: Rules{Name: "default"},
+				},
+			},
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+{
+    "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+    "title": "Precondition failed",
+    "detail": "The rule tree was modified since it was fetched",
+    "status": 412
+}`,
+			expectedPath:    "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			expectedIfMatch: "etag",
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrPreconditionFailed), "want: %s; got: %s", ErrPreconditionFailed, err)
+				var precondition *PreconditionFailedError
+				require.True(t, errors.As(err, &precondition))
+				assert.Equal(t, "etag", precondition.Etag)
+				assert.Equal(t, "Precondition failed", precondition.Err.Title)
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -1628,6 +1659,7 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				assert.Equal(t, test.expectedPath, r.URL.String())
 				assert.Equal(t, http.MethodPut, r.Method)
+				assert.Equal(t, test.expectedIfMatch, r.Header.Get("If-Match"))
 				w.WriteHeader(test.responseStatus)
 				_, err := w.Write([]byte(test.responseBody))
 				assert.NoError(t, err)
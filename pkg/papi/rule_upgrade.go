@@ -0,0 +1,275 @@
+package papi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+type (
+	// RuleUpgradeOptions contains renames applied when upgrading rules to another rule format.
+	// Rule format schemas do not describe renames, so they have to be provided by the caller
+	RuleUpgradeOptions struct {
+		// TargetRuleFormat is the name of the target rule format, reported in RuleUpgradeResult
+		TargetRuleFormat string
+		// BehaviorRenames maps behavior names of the source rule format to their names in the target rule format
+		BehaviorRenames map[string]string
+		// CriterionRenames maps criterion names of the source rule format to their names in the target rule format
+		CriterionRenames map[string]string
+		// OptionRenames maps behavior or criterion name in the target rule format to renames of its top-level options
+		OptionRenames map[string]map[string]string
+	}
+
+	// RuleUpgradeResult contains rules upgraded by UpgradeRuleTree and changes made to them
+	RuleUpgradeResult struct {
+		FromRuleFormat string
+		ToRuleFormat   string
+		Rules          Rules
+		Changes        []RuleUpgradeChange
+	}
+
+	// RuleUpgradeChange describes a single change made, or required, when upgrading rules
+	RuleUpgradeChange struct {
+		Type RuleUpgradeChangeType
+		// Name is the name of the behavior or criterion in the source rules
+		Name string
+		// Option is a path of the option within behavior options, e.g. "value/id"
+		Option string
+		// Location is a JSON pointer to the behavior, criterion or option in the source rules
+		Location string
+		// Value is the removed value or the default set for a new required option
+		Value  interface{}
+		Detail string
+	}
+
+	// RuleUpgradeChangeType represents type of RuleUpgradeChange
+	RuleUpgradeChangeType string
+
+	ruleUpgrader struct {
+		source  *RuleFormatSchema
+		target  *RuleFormatSchema
+		options RuleUpgradeOptions
+		changes []RuleUpgradeChange
+	}
+)
+
+const (
+	// RuleUpgradeBehaviorRenamed is reported for behaviors renamed with BehaviorRenames
+	RuleUpgradeBehaviorRenamed RuleUpgradeChangeType = "BEHAVIOR_RENAMED"
+	// RuleUpgradeBehaviorRemoved is reported for behaviors removed because they do not exist in the target rule format
+	RuleUpgradeBehaviorRemoved RuleUpgradeChangeType = "BEHAVIOR_REMOVED"
+	// RuleUpgradeCriterionRenamed is reported for criteria renamed with CriterionRenames
+	RuleUpgradeCriterionRenamed RuleUpgradeChangeType = "CRITERION_RENAMED"
+	// RuleUpgradeCriterionRemoved is reported for criteria removed because they do not exist in the target rule format
+	RuleUpgradeCriterionRemoved RuleUpgradeChangeType = "CRITERION_REMOVED"
+	// RuleUpgradeOptionRenamed is reported for options renamed with OptionRenames
+	RuleUpgradeOptionRenamed RuleUpgradeChangeType = "OPTION_RENAMED"
+	// RuleUpgradeOptionRemoved is reported for options of the source rule format which do not exist in the target one
+	RuleUpgradeOptionRemoved RuleUpgradeChangeType = "OPTION_REMOVED"
+	// RuleUpgradeOptionAdded is reported for new required options set to their default value
+	RuleUpgradeOptionAdded RuleUpgradeChangeType = "OPTION_ADDED"
+	// RuleUpgradeOptionRequired is reported for new required options without default value, they have to be set manually
+	RuleUpgradeOptionRequired RuleUpgradeChangeType = "OPTION_REQUIRED"
+	// RuleUpgradeOptionInvalidValue is reported for option values no longer allowed in the target rule format
+	RuleUpgradeOptionInvalidValue RuleUpgradeChangeType = "OPTION_INVALID_VALUE"
+)
+
+var (
+	// ErrUpgradeRuleTree represents error when rule tree cannot be upgraded
+	ErrUpgradeRuleTree = errors.New("upgrading rule tree")
+)
+
+// UpgradeRuleTree converts rules of the source rule format to the target rule format.
+// Behaviors and criteria missing in the target format are renamed or removed, options renamed, options which no longer
+// exist removed and new required options set to their defaults. The source rule tree is not modified.
+// Changes which cannot be made automatically, e.g. required options without default, are reported for review
+func UpgradeRuleTree(tree *GetRuleTreeResponse, source, target *RuleFormatSchema, options RuleUpgradeOptions) (*RuleUpgradeResult, error) {
+	if tree == nil || source == nil || target == nil {
+		return nil, fmt.Errorf("%w: rule tree, source and target schema are required", ErrUpgradeRuleTree)
+	}
+
+	u := ruleUpgrader{
+		source:  source,
+		target:  target,
+		options: options,
+	}
+	rules := u.upgradeRule(tree.Rules, RuleLocation{Names: []string{tree.Rules.Name}})
+
+	return &RuleUpgradeResult{
+		FromRuleFormat: tree.RuleFormat,
+		ToRuleFormat:   options.TargetRuleFormat,
+		Rules:          rules,
+		Changes:        u.changes,
+	}, nil
+}
+
+// RequiresReview returns changes which were not made automatically or removed configuration
+func (r RuleUpgradeResult) RequiresReview() []RuleUpgradeChange {
+	var review []RuleUpgradeChange
+	for _, change := range r.Changes {
+		switch change.Type {
+		case RuleUpgradeBehaviorRemoved, RuleUpgradeCriterionRemoved, RuleUpgradeOptionRemoved,
+			RuleUpgradeOptionRequired, RuleUpgradeOptionInvalidValue:
+			review = append(review, change)
+		}
+	}
+	return review
+}
+
+func (u *ruleUpgrader) upgradeRule(rule Rules, location RuleLocation) Rules {
+	pointer := "#" + location.Pointer()
+	rule.Behaviors = u.upgradeBehaviors(rule.Behaviors, pointer+"/behaviors", u.target.behaviors, u.source.behaviors,
+		u.options.BehaviorRenames, RuleUpgradeBehaviorRenamed, RuleUpgradeBehaviorRemoved, "behavior")
+	rule.Criteria = u.upgradeBehaviors(rule.Criteria, pointer+"/criteria", u.target.criteria, u.source.criteria,
+		u.options.CriterionRenames, RuleUpgradeCriterionRenamed, RuleUpgradeCriterionRemoved, "criterion")
+
+	if rule.Children != nil {
+		children := make([]Rules, len(rule.Children))
+		for i, child := range rule.Children {
+			children[i] = u.upgradeRule(child, location.child(child.Name, i))
+		}
+		rule.Children = children
+	}
+	return rule
+}
+
+func (u *ruleUpgrader) upgradeBehaviors(behaviors []RuleBehavior, pointer string, target, source map[string]*ruleSchemaNode,
+	renames map[string]string, renamed, removed RuleUpgradeChangeType, kind string) []RuleBehavior {
+	if behaviors == nil {
+		return nil
+	}
+	upgraded := make([]RuleBehavior, 0, len(behaviors))
+	for i, behavior := range behaviors {
+		behaviorPointer := fmt.Sprintf("%s/%d", pointer, i)
+		name := behavior.Name
+		if _, ok := target[name]; !ok {
+			if newName, ok := renames[name]; ok && target[newName] != nil {
+				u.report(renamed, name, "", behaviorPointer, newName, "The %s %s is renamed to %s.", kind, name, newName)
+				name = newName
+			} else {
+				u.report(removed, name, "", behaviorPointer, behavior.Options,
+					"The %s %s does not exist in the target rule format and was removed.", kind, name)
+				continue
+			}
+		}
+
+		options := map[string]interface{}{}
+		if behavior.Options != nil {
+			options = copyOptionValue(map[string]interface{}(behavior.Options)).(map[string]interface{})
+		}
+		optionRenames := u.options.OptionRenames[name]
+		oldNames := make([]string, 0, len(optionRenames))
+		for oldName := range optionRenames {
+			oldNames = append(oldNames, oldName)
+		}
+		sort.Strings(oldNames)
+		for _, oldName := range oldNames {
+			newName := optionRenames[oldName]
+			value, ok := options[oldName]
+			if !ok {
+				continue
+			}
+			delete(options, oldName)
+			options[newName] = value
+			u.report(RuleUpgradeOptionRenamed, behavior.Name, oldName, behaviorPointer+"/options/"+escapeJSONPointer(oldName), newName,
+				"The %s option is renamed to %s.", oldName, newName)
+		}
+		u.upgradeOptions(options, optionsNode(u.source, source[behavior.Name]), optionsNode(u.target, target[name]),
+			behavior.Name, "", behaviorPointer+"/options")
+
+		behavior.Name = name
+		if behavior.Options != nil || len(options) > 0 {
+			behavior.Options = options
+		}
+		upgraded = append(upgraded, behavior)
+	}
+	return upgraded
+}
+
+// upgradeOptions removes options which exist only in the source schema, adds missing required options
+// and reports values not allowed in the target schema, descending into nested objects
+func (u *ruleUpgrader) upgradeOptions(options map[string]interface{}, source, target *ruleSchemaNode, name, path, pointer string) {
+	if target == nil {
+		return
+	}
+
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		optionPath := joinOptionPath(path, key)
+		optionPointer := pointer + "/" + escapeJSONPointer(key)
+		targetNode := u.target.resolve(target.Properties[key])
+		var sourceNode *ruleSchemaNode
+		if source != nil {
+			sourceNode = u.source.resolve(source.Properties[key])
+		}
+		if targetNode == nil {
+			if sourceNode != nil && !target.additionalPropertiesAllowed() {
+				u.report(RuleUpgradeOptionRemoved, name, optionPath, optionPointer, options[key],
+					"The %s option does not exist in the target rule format and was removed.", optionPath)
+				delete(options, key)
+			}
+			continue
+		}
+
+		switch value := options[key].(type) {
+		case map[string]interface{}:
+			u.upgradeOptions(value, sourceNode, targetNode, name, optionPath, optionPointer)
+		case string:
+			if ruleVariableOnlyReference.MatchString(value) {
+				continue
+			}
+		}
+		if len(targetNode.Enum) > 0 && !valueInEnum(options[key], targetNode.Enum) {
+			u.report(RuleUpgradeOptionInvalidValue, name, optionPath, optionPointer, options[key],
+				"The %v value of %s option is not allowed in the target rule format.", options[key], optionPath)
+		}
+	}
+
+	for _, required := range target.Required {
+		if _, ok := options[required]; ok {
+			continue
+		}
+		optionPath := joinOptionPath(path, required)
+		optionPointer := pointer + "/" + escapeJSONPointer(required)
+		property := u.target.resolve(target.Properties[required])
+		if property == nil || property.Default == nil {
+			u.report(RuleUpgradeOptionRequired, name, optionPath, optionPointer, nil,
+				"The %s option is required in the target rule format and has to be set.", optionPath)
+			continue
+		}
+		options[required] = copyOptionValue(property.Default)
+		u.report(RuleUpgradeOptionAdded, name, optionPath, optionPointer, property.Default,
+			"The %s option is required in the target rule format and was set to its default %v.", optionPath, property.Default)
+	}
+}
+
+func (u *ruleUpgrader) report(changeType RuleUpgradeChangeType, name, option, location string, value interface{}, detail string, args ...interface{}) {
+	u.changes = append(u.changes, RuleUpgradeChange{
+		Type:     changeType,
+		Name:     name,
+		Option:   option,
+		Location: location,
+		Value:    value,
+		Detail:   fmt.Sprintf(detail, args...),
+	})
+}
+
+// optionsNode returns schema of options of a behavior or criterion catalog entry
+func optionsNode(schema *RuleFormatSchema, entry *ruleSchemaNode) *ruleSchemaNode {
+	entry = schema.resolve(entry)
+	if entry == nil {
+		return nil
+	}
+	return schema.resolve(entry.Properties["options"])
+}
+
+func joinOptionPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "/" + key
+}
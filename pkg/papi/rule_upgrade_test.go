@@ -0,0 +1,173 @@
+package papi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeRuleTree(t *testing.T) {
+	source, err := ParseRuleFormatSchema([]byte(`
+{
+    "definitions": {
+        "catalog": {
+            "behaviors": {
+                "caching": {"properties": {"options": {"type": "object", "additionalProperties": false, "properties": {
+                    "behavior": {"type": "string", "enum": ["MAX_AGE", "NO_STORE", "CACHE_CONTROL"]},
+                    "ttl": {"type": "string"},
+                    "legacyMode": {"type": "boolean"}
+                }}}},
+                "oldCompress": {"properties": {"options": {"type": "object", "properties": {"enabled": {"type": "boolean"}}}}},
+                "sureRoute": {"properties": {"options": {"type": "object", "properties": {"enabled": {"type": "boolean"}}}}},
+                "origin": {"properties": {"options": {"$ref": "#/definitions/originOptions"}}}
+            },
+            "criteria": {
+                "path": {"properties": {"options": {"type": "object", "properties": {"values": {"type": "array"}}}}},
+                "deviceCharacteristic": {"properties": {"options": {"type": "object"}}}
+            }
+        },
+        "originOptions": {"type": "object", "additionalProperties": false, "properties": {
+            "hostname": {"type": "string"},
+            "forwardHostHeader": {"type": "string"},
+            "cacheKey": {"type": "object", "additionalProperties": false, "properties": {"hostname": {"type": "string"}, "legacy": {"type": "boolean"}}}
+        }}
+    }
+}`))
+	require.NoError(t, err)
+	target, err := ParseRuleFormatSchema([]byte(`
+{
+    "definitions": {
+        "catalog": {
+            "behaviors": {
+                "caching": {"properties": {"options": {"type": "object", "additionalProperties": false, "required": ["behavior", "mustRevalidate"], "properties": {
+                    "behavior": {"type": "string", "enum": ["MAX_AGE", "NO_STORE"]},
+                    "ttl": {"type": "string"},
+                    "mustRevalidate": {"type": "boolean", "default": false}
+                }}}},
+                "gzipResponse": {"properties": {"options": {"type": "object", "required": ["behavior"], "properties": {
+                    "enabled": {"type": "boolean"},
+                    "behavior": {"type": "string", "enum": ["ALWAYS", "ORIGIN_RESPONSE"]}
+                }}}},
+                "sureRoute": {"properties": {"options": {"type": "object", "properties": {"enabled": {"type": "boolean"}}}}},
+                "origin": {"properties": {"options": {"$ref": "#/definitions/originOptions"}}}
+            },
+            "criteria": {
+                "path": {"properties": {"options": {"type": "object", "properties": {"values": {"type": "array"}}}}}
+            }
+        },
+        "originOptions": {"type": "object", "additionalProperties": false, "properties": {
+            "hostname": {"type": "string"},
+            "forwardHostHeaderValue": {"type": "string"},
+            "cacheKey": {"type": "object", "additionalProperties": false, "required": ["type"], "properties": {
+                "hostname": {"type": "string"},
+                "type": {"type": "string", "default": "ORIGIN_HOSTNAME"}
+            }}
+        }}
+    }
+}`))
+	require.NoError(t, err)
+
+	tree := &GetRuleTreeResponse{
+		RuleFormat: "v2020-03-04",
+		Rules: Rules{
+			Name: "default",
+			Behaviors: []RuleBehavior{
+				{Name: "origin", Options: RuleOptionsMap{
+					"hostname":          "origin.example.com",
+					"forwardHostHeader": "REQUEST_HOST_HEADER",
+					"cacheKey":          map[string]interface{}{"hostname": "ORIGIN", "legacy": true},
+				}},
+				{Name: "caching", Options: RuleOptionsMap{"behavior": "CACHE_CONTROL", "ttl": "1d", "legacyMode": true}},
+			},
+			Children: []Rules{
+				{
+					Name: "Compression",
+					Criteria: []RuleBehavior{
+						{Name: "deviceCharacteristic", Options: RuleOptionsMap{"characteristic": "IS_MOBILE"}},
+						{Name: "path", Options: RuleOptionsMap{"values": []interface{}{"/static/*"}}},
+					},
+					Behaviors: []RuleBehavior{
+						{Name: "oldCompress", Options: RuleOptionsMap{"enabled": true}},
+						{Name: "sureRoute", Options: RuleOptionsMap{"enabled": "{{user.PMUSER_SR}}"}},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := UpgradeRuleTree(tree, source, target, RuleUpgradeOptions{
+		TargetRuleFormat: "v2022-10-18",
+		BehaviorRenames:  map[string]string{"oldCompress": "gzipResponse"},
+		OptionRenames:    map[string]map[string]string{"origin": {"forwardHostHeader": "forwardHostHeaderValue"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v2020-03-04", result.FromRuleFormat)
+	assert.Equal(t, "v2022-10-18", result.ToRuleFormat)
+	assert.Equal(t, Rules{
+		Name: "default",
+		Behaviors: []RuleBehavior{
+			{Name: "origin", Options: RuleOptionsMap{
+				"hostname":               "origin.example.com",
+				"forwardHostHeaderValue": "REQUEST_HOST_HEADER",
+				"cacheKey":               map[string]interface{}{"hostname": "ORIGIN", "type": "ORIGIN_HOSTNAME"},
+			}},
+			{Name: "caching", Options: RuleOptionsMap{"behavior": "CACHE_CONTROL", "ttl": "1d", "mustRevalidate": false}},
+		},
+		Children: []Rules{
+			{
+				Name: "Compression",
+				Criteria: []RuleBehavior{
+					{Name: "path", Options: RuleOptionsMap{"values": []interface{}{"/static/*"}}},
+				},
+				Behaviors: []RuleBehavior{
+					{Name: "gzipResponse", Options: RuleOptionsMap{"enabled": true}},
+					{Name: "sureRoute", Options: RuleOptionsMap{"enabled": "{{user.PMUSER_SR}}"}},
+				},
+			},
+		},
+	}, result.Rules)
+
+	changes := make([]RuleUpgradeChangeType, 0, len(result.Changes))
+	locations := make([]string, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		changes = append(changes, change.Type)
+		locations = append(locations, change.Location)
+	}
+	assert.Equal(t, []RuleUpgradeChangeType{
+		RuleUpgradeOptionRenamed,
+		RuleUpgradeOptionRemoved,
+		RuleUpgradeOptionAdded,
+		RuleUpgradeOptionInvalidValue,
+		RuleUpgradeOptionRemoved,
+		RuleUpgradeOptionAdded,
+		RuleUpgradeBehaviorRenamed,
+		RuleUpgradeOptionRequired,
+		RuleUpgradeCriterionRemoved,
+	}, changes)
+	assert.Equal(t, []string{
+		"#/rules/behaviors/0/options/forwardHostHeader",
+		"#/rules/behaviors/0/options/cacheKey/legacy",
+		"#/rules/behaviors/0/options/cacheKey/type",
+		"#/rules/behaviors/1/options/behavior",
+		"#/rules/behaviors/1/options/legacyMode",
+		"#/rules/behaviors/1/options/mustRevalidate",
+		"#/rules/children/0/behaviors/0",
+		"#/rules/children/0/behaviors/0/options/behavior",
+		"#/rules/children/0/criteria/0",
+	}, locations)
+	assert.Equal(t, "cacheKey/legacy", result.Changes[1].Option)
+	assert.Equal(t, true, result.Changes[1].Value)
+	assert.Equal(t, "ORIGIN_HOSTNAME", result.Changes[2].Value)
+	assert.Len(t, result.RequiresReview(), 5)
+
+	// the source rule tree is not modified
+	assert.Equal(t, "REQUEST_HOST_HEADER", tree.Rules.Behaviors[0].Options["forwardHostHeader"])
+	assert.Equal(t, true, tree.Rules.Behaviors[0].Options["cacheKey"].(map[string]interface{})["legacy"])
+	assert.Len(t, tree.Rules.Children[0].Criteria, 2)
+
+	_, err = UpgradeRuleTree(nil, source, target, RuleUpgradeOptions{})
+	assert.True(t, errors.Is(err, ErrUpgradeRuleTree), "want: %s; got: %s", ErrUpgradeRuleTree, err)
+}
@@ -0,0 +1,446 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type (
+	// RuleFormatSchema represents JSON schema of a rule tree for given product and rule format
+	// It is marshaled back to the original schema document, so it can be cached and read with ParseRuleFormatSchema
+	RuleFormatSchema struct {
+		raw             json.RawMessage
+		behaviors       map[string]*ruleSchemaNode
+		criteria        map[string]*ruleSchemaNode
+		definitions     map[string]*ruleSchemaNode
+		defaultRuleOnly map[string]bool
+	}
+
+	// RuleValidationOptions contains settings of offline rule tree validation
+	RuleValidationOptions struct {
+		// DefaultRuleOnlyBehaviors lists behaviors which are accepted only in the default rule.
+		// If nil, behaviors derived from the schema are used, see RuleFormatSchema.DefaultRuleOnlyBehaviors
+		DefaultRuleOnlyBehaviors []string
+	}
+
+	ruleSchemaNode struct {
+		Type                 interface{}                `json:"type"`
+		Ref                  string                     `json:"$ref"`
+		Enum                 []interface{}              `json:"enum"`
+		Default              interface{}                `json:"default"`
+		Items                *ruleSchemaNode            `json:"items"`
+		AnyOf                []*ruleSchemaNode          `json:"anyOf"`
+		OneOf                []*ruleSchemaNode          `json:"oneOf"`
+		Properties           map[string]*ruleSchemaNode `json:"properties"`
+		Required             []string                   `json:"required"`
+		AdditionalProperties interface{}                `json:"additionalProperties"`
+		Definitions          map[string]*ruleSchemaNode `json:"definitions"`
+		Behaviors            map[string]*ruleSchemaNode `json:"behaviors"`
+		Criteria             map[string]*ruleSchemaNode `json:"criteria"`
+	}
+
+	ruleValidator struct {
+		schema          *RuleFormatSchema
+		variables       map[string]bool
+		defaultRuleOnly map[string]bool
+		errors          []RuleError
+	}
+)
+
+const (
+	// RuleErrorTypeUnknownBehavior is reported for behaviors which are not part of the rule format
+	RuleErrorTypeUnknownBehavior = "unknown_behavior"
+	// RuleErrorTypeUnknownCriterion is reported for criteria which are not part of the rule format
+	RuleErrorTypeUnknownCriterion = "unknown_criterion"
+	// RuleErrorTypeUnknownOption is reported for options which are not defined for a behavior or criterion
+	RuleErrorTypeUnknownOption = "unknown_option"
+	// RuleErrorTypeRequiredOption is reported when a required option is missing
+	RuleErrorTypeRequiredOption = "required_option"
+	// RuleErrorTypeInvalidOptionType is reported when option value has a type other than the schema allows
+	RuleErrorTypeInvalidOptionType = "invalid_option_type"
+	// RuleErrorTypeInvalidOptionValue is reported when option value is not one of the allowed values
+	RuleErrorTypeInvalidOptionValue = "invalid_option_value"
+	// RuleErrorTypeDefaultRuleOnly is reported for behaviors allowed only in the default rule used in a child rule
+	RuleErrorTypeDefaultRuleOnly = "default_rule_only"
+	// RuleErrorTypeCriteriaInDefaultRule is reported for criteria of the default rule
+	RuleErrorTypeCriteriaInDefaultRule = "criteria_in_default_rule"
+	// RuleErrorTypeUndefinedVariable is reported for references to user variables not declared in the default rule
+	RuleErrorTypeUndefinedVariable = "undefined_variable"
+)
+
+var (
+	// ErrParseRuleFormatSchema represents error when rule format schema cannot be read
+	ErrParseRuleFormatSchema = errors.New("parsing rule format schema")
+
+	ruleVariableReference     = regexp.MustCompile(`\{\{user\.([A-Za-z0-9_]+)\}\}`)
+	ruleVariableOnlyReference = regexp.MustCompile(`^\{\{(user|builtin)\.[A-Za-z0-9_]+\}\}$`)
+)
+
+// ParseRuleFormatSchema reads rule format JSON schema, as returned by GetRuleFormatSchema
+func ParseRuleFormatSchema(b []byte) (*RuleFormatSchema, error) {
+	var root ruleSchemaNode
+	if err := json.Unmarshal(b, &root); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrParseRuleFormatSchema, err)
+	}
+	catalog, ok := root.Definitions["catalog"]
+	if !ok || catalog == nil {
+		return nil, fmt.Errorf("%w: missing definitions.catalog", ErrParseRuleFormatSchema)
+	}
+	schema := &RuleFormatSchema{
+		raw:         append(json.RawMessage(nil), b...),
+		behaviors:   catalog.Behaviors,
+		criteria:    catalog.Criteria,
+		definitions: root.Definitions,
+	}
+	schema.defaultRuleOnly = schema.defaultRuleOnlyBehaviors(&root)
+	return schema, nil
+}
+
+// defaultRuleOnlyBehaviors returns catalog behaviors which are missing among behaviors allowed in child rules.
+// Nil is returned if the schema does not describe behaviors of child rules
+func (s *RuleFormatSchema) defaultRuleOnlyBehaviors(root *ruleSchemaNode) map[string]bool {
+	children := s.property(s.property(root, "rules"), "children")
+	if children == nil {
+		return nil
+	}
+	behaviors := s.property(s.resolve(children.Items), "behaviors")
+	if behaviors == nil || behaviors.Items == nil {
+		return nil
+	}
+
+	allowed := make(map[string]bool)
+	s.collectBehaviorNames(behaviors.Items, allowed, 0)
+	if len(allowed) == 0 {
+		return nil
+	}
+	defaultRuleOnly := make(map[string]bool)
+	for name := range s.behaviors {
+		if !allowed[name] {
+			defaultRuleOnly[name] = true
+		}
+	}
+	return defaultRuleOnly
+}
+
+// collectBehaviorNames adds names of behaviors the node accepts, referenced from the catalog directly,
+// through anyOf or oneOf, or listed in enum of the name property
+func (s *RuleFormatSchema) collectBehaviorNames(node *ruleSchemaNode, names map[string]bool, depth int) {
+	if node == nil || depth > len(s.definitions) {
+		return
+	}
+	const catalogBehaviors = "#/definitions/catalog/behaviors/"
+	if strings.HasPrefix(node.Ref, catalogBehaviors) {
+		names[strings.TrimPrefix(node.Ref, catalogBehaviors)] = true
+		return
+	}
+	if node.Ref != "" {
+		s.collectBehaviorNames(s.resolve(node), names, depth+1)
+		return
+	}
+	for _, alternative := range append(append([]*ruleSchemaNode{}, node.AnyOf...), node.OneOf...) {
+		s.collectBehaviorNames(alternative, names, depth+1)
+	}
+	if name := node.Properties["name"]; name != nil {
+		for _, value := range name.Enum {
+			if behavior, ok := value.(string); ok {
+				names[behavior] = true
+			}
+		}
+	}
+}
+
+// MarshalJSON returns the original schema document
+func (s RuleFormatSchema) MarshalJSON() ([]byte, error) {
+	if s.raw == nil {
+		return []byte("null"), nil
+	}
+	return s.raw, nil
+}
+
+// UnmarshalJSON reads the schema document
+func (s *RuleFormatSchema) UnmarshalJSON(b []byte) error {
+	schema, err := ParseRuleFormatSchema(b)
+	if err != nil {
+		return err
+	}
+	*s = *schema
+	return nil
+}
+
+// HasBehavior returns true if behavior with given name is part of the rule format
+func (s *RuleFormatSchema) HasBehavior(name string) bool {
+	_, ok := s.behaviors[name]
+	return ok
+}
+
+// HasCriterion returns true if criterion with given name is part of the rule format
+func (s *RuleFormatSchema) HasCriterion(name string) bool {
+	_, ok := s.criteria[name]
+	return ok
+}
+
+// DefaultRuleOnlyBehaviors returns sorted names of behaviors which the schema does not allow in child rules
+func (s *RuleFormatSchema) DefaultRuleOnlyBehaviors() []string {
+	names := make([]string, 0, len(s.defaultRuleOnly))
+	for name := range s.defaultRuleOnly {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateRules checks rules against the schema without calling PAPI
+// Errors are reported in the format of PAPI rule tree errors, with ErrorLocation set to JSON pointer of the
+// offending element, e.g. "#/rules/children/0/behaviors/1/options/ttl". Nil is returned for valid rules
+func (s *RuleFormatSchema) ValidateRules(rules Rules, options RuleValidationOptions) []RuleError {
+	v := ruleValidator{
+		schema:          s,
+		variables:       make(map[string]bool, len(rules.Variables)),
+		defaultRuleOnly: s.defaultRuleOnly,
+	}
+	for _, variable := range rules.Variables {
+		v.variables[variable.Name] = true
+	}
+	if options.DefaultRuleOnlyBehaviors != nil {
+		v.defaultRuleOnly = make(map[string]bool, len(options.DefaultRuleOnlyBehaviors))
+		for _, name := range options.DefaultRuleOnlyBehaviors {
+			v.defaultRuleOnly[name] = true
+		}
+	}
+
+	// the walk function never fails, so the error can be ignored
+	_ = rules.Walk(func(rule Rules, location RuleLocation) error {
+		v.validateRule(rule, location)
+		return nil
+	})
+	return v.errors
+}
+
+func (v *ruleValidator) validateRule(rule Rules, location RuleLocation) {
+	pointer := "#" + location.Pointer()
+	isDefault := len(location.Indexes) == 0
+
+	for i, behavior := range rule.Behaviors {
+		behaviorPointer := fmt.Sprintf("%s/behaviors/%d", pointer, i)
+		entry, ok := v.schema.behaviors[behavior.Name]
+		if !ok {
+			v.report(RuleErrorTypeUnknownBehavior, "Unknown behavior", behavior.Name, behaviorPointer,
+				"The %s behavior is not available in this rule format.", behavior.Name)
+			continue
+		}
+		if !isDefault && v.defaultRuleOnly[behavior.Name] {
+			v.report(RuleErrorTypeDefaultRuleOnly, "Behavior allowed only in default rule", behavior.Name, behaviorPointer,
+				"The %s behavior can only be used in the default rule.", behavior.Name)
+		}
+		v.validateOptions(entry, behavior, behaviorPointer)
+	}
+
+	for i, criterion := range rule.Criteria {
+		criterionPointer := fmt.Sprintf("%s/criteria/%d", pointer, i)
+		if isDefault {
+			v.report(RuleErrorTypeCriteriaInDefaultRule, "Criteria in default rule", criterion.Name, criterionPointer,
+				"The default rule applies to all requests and cannot contain the %s criterion.", criterion.Name)
+		}
+		entry, ok := v.schema.criteria[criterion.Name]
+		if !ok {
+			v.report(RuleErrorTypeUnknownCriterion, "Unknown criterion", criterion.Name, criterionPointer,
+				"The %s criterion is not available in this rule format.", criterion.Name)
+			continue
+		}
+		v.validateOptions(entry, criterion, criterionPointer)
+	}
+}
+
+func (v *ruleValidator) validateOptions(entry *ruleSchemaNode, behavior RuleBehavior, pointer string) {
+	var options interface{} = map[string]interface{}{}
+	if behavior.Options != nil {
+		// normalize values set in code, e.g. ints or string slices, to their JSON representation
+		b, err := json.Marshal(behavior.Options)
+		if err == nil {
+			err = json.Unmarshal(b, &options)
+		}
+		if err != nil {
+			v.report(RuleErrorTypeInvalidOptionType, "Invalid option type", behavior.Name, pointer+"/options",
+				"Options of %s cannot be represented as JSON: %s.", behavior.Name, err)
+			return
+		}
+	}
+	var node *ruleSchemaNode
+	if entry.Properties != nil {
+		node = entry.Properties["options"]
+	}
+	v.validateValue(node, options, behavior.Name, "options", pointer+"/options")
+}
+
+func (v *ruleValidator) validateValue(node *ruleSchemaNode, value interface{}, behaviorName, name, pointer string) {
+	if s, ok := value.(string); ok {
+		v.validateVariableReferences(s, behaviorName, pointer)
+		if ruleVariableOnlyReference.MatchString(s) {
+			// value is resolved at the edge, so the type cannot be checked
+			return
+		}
+	}
+
+	node = v.schema.resolve(node)
+	if node == nil {
+		return
+	}
+
+	if types := node.types(); len(types) > 0 && !valueMatchesTypes(value, types) {
+		v.report(RuleErrorTypeInvalidOptionType, "Invalid option type", behaviorName, pointer,
+			"The %s option must be of type %s.", name, strings.Join(types, " or "))
+		return
+	}
+	if len(node.Enum) > 0 && !valueInEnum(value, node.Enum) {
+		allowed := make([]string, 0, len(node.Enum))
+		for _, e := range node.Enum {
+			allowed = append(allowed, fmt.Sprintf("%v", e))
+		}
+		v.report(RuleErrorTypeInvalidOptionValue, "Invalid option value", behaviorName, pointer,
+			"The %s option value %v is not one of: %s.", name, value, strings.Join(allowed, ", "))
+		return
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, required := range node.Required {
+			if _, ok := typed[required]; !ok {
+				v.report(RuleErrorTypeRequiredOption, "Required option missing", behaviorName, pointer+"/"+escapeJSONPointer(required),
+					"The %s option is required.", required)
+			}
+		}
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			keyPointer := pointer + "/" + escapeJSONPointer(key)
+			property, ok := node.Properties[key]
+			if !ok {
+				if node.additionalPropertiesAllowed() {
+					continue
+				}
+				v.report(RuleErrorTypeUnknownOption, "Unknown option", behaviorName, keyPointer,
+					"The %s option is not defined for %s.", key, behaviorName)
+				continue
+			}
+			v.validateValue(property, typed[key], behaviorName, key, keyPointer)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			v.validateValue(node.Items, item, behaviorName, name, fmt.Sprintf("%s/%d", pointer, i))
+		}
+	}
+}
+
+func (v *ruleValidator) validateVariableReferences(value, behaviorName, pointer string) {
+	for _, match := range ruleVariableReference.FindAllStringSubmatch(value, -1) {
+		if !v.variables[match[1]] {
+			v.report(RuleErrorTypeUndefinedVariable, "Undefined variable", behaviorName, pointer,
+				"The %s variable is not declared in the default rule.", match[1])
+		}
+	}
+}
+
+func (v *ruleValidator) report(errorType, title, behaviorName, location, detail string, args ...interface{}) {
+	v.errors = append(v.errors, RuleError{
+		Type:          errorType,
+		Title:         title,
+		Detail:        fmt.Sprintf(detail, args...),
+		BehaviorName:  behaviorName,
+		ErrorLocation: location,
+	})
+}
+
+// property returns resolved schema of the object property, or nil if the node does not define it
+func (s *RuleFormatSchema) property(node *ruleSchemaNode, name string) *ruleSchemaNode {
+	if node == nil {
+		return nil
+	}
+	return s.resolve(node.Properties[name])
+}
+
+// resolve follows $ref of the node, returning nil for references which cannot be resolved
+func (s *RuleFormatSchema) resolve(node *ruleSchemaNode) *ruleSchemaNode {
+	for depth := 0; node != nil && node.Ref != ""; depth++ {
+		name := strings.TrimPrefix(node.Ref, "#/definitions/")
+		if name == node.Ref || depth > len(s.definitions) {
+			return nil
+		}
+		node = s.definitions[name]
+	}
+	return node
+}
+
+func (n *ruleSchemaNode) types() []string {
+	switch t := n.Type.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func (n *ruleSchemaNode) additionalPropertiesAllowed() bool {
+	allowed, ok := n.AdditionalProperties.(bool)
+	return !ok || allowed
+}
+
+func valueMatchesTypes(value interface{}, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := value.(float64); ok && f == math.Trunc(f) {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "null":
+			if value == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func valueInEnum(value interface{}, enum []interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(value, allowed) {
+			return true
+		}
+	}
+	return false
+}
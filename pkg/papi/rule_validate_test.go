@@ -0,0 +1,235 @@
+package papi
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadRuleFormatSchema(t *testing.T) *RuleFormatSchema {
+	b, err := os.ReadFile("internal/rulegen/schemas/v2022-10-18.json")
+	require.NoError(t, err)
+	schema, err := ParseRuleFormatSchema(b)
+	require.NoError(t, err)
+	return schema
+}
+
+func TestRuleFormatSchema_ValidateRules(t *testing.T) {
+	schema := loadRuleFormatSchema(t)
+
+	tests := map[string]struct {
+		rules          Rules
+		options        RuleValidationOptions
+		expectedErrors []RuleError
+	}{
+		"valid rule tree": {
+			rules: decodeRuleTreeFixture(t, ruleTreeFixture),
+		},
+		"valid nested rule tree": {
+			rules: decodeRuleTreeFixture(t, nestedRuleTreeFixture),
+		},
+		"options set in code": {
+			rules: Rules{
+				Name: "default",
+				Behaviors: []RuleBehavior{
+					{Name: "origin", Options: RuleOptionsMap{"httpPort": 80, "customValidCnValues": []string{"{{Origin Hostname}}"}}},
+				},
+			},
+		},
+		"unknown behavior and criterion": {
+			rules: Rules{
+				Name: "default",
+				Children: []Rules{{
+					Name:      "Images",
+					Criteria:  []RuleBehavior{{Name: "imageType"}},
+					Behaviors: []RuleBehavior{{Name: "caching"}, {Name: "imageManager"}},
+				}},
+			},
+			expectedErrors: []RuleError{
+				{Type: RuleErrorTypeUnknownBehavior, BehaviorName: "imageManager", ErrorLocation: "#/rules/children/0/behaviors/1"},
+				{Type: RuleErrorTypeUnknownCriterion, BehaviorName: "imageType", ErrorLocation: "#/rules/children/0/criteria/0"},
+			},
+		},
+		"option types and values": {
+			rules: Rules{
+				Name: "default",
+				Behaviors: []RuleBehavior{
+					{Name: "origin", Options: RuleOptionsMap{
+						"httpPort":            "80",
+						"originId":            1.5,
+						"ipVersion":           "IPV5",
+						"hostnam":             "origin.test.com",
+						"customValidCnValues": []interface{}{"a", 1},
+					}},
+					{Name: "cpCode", Options: RuleOptionsMap{"value": map[string]interface{}{"id": "12345"}}},
+				},
+			},
+			expectedErrors: []RuleError{
+				{Type: RuleErrorTypeInvalidOptionType, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/customValidCnValues/1"},
+				{Type: RuleErrorTypeUnknownOption, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/hostnam"},
+				{Type: RuleErrorTypeInvalidOptionType, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/httpPort"},
+				{Type: RuleErrorTypeInvalidOptionValue, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/ipVersion"},
+				{Type: RuleErrorTypeInvalidOptionType, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/originId"},
+				{Type: RuleErrorTypeInvalidOptionType, BehaviorName: "cpCode", ErrorLocation: "#/rules/behaviors/1/options/value/id"},
+			},
+		},
+		"default rule placement": {
+			rules: Rules{
+				Name:      "default",
+				Criteria:  []RuleBehavior{{Name: "path", Options: RuleOptionsMap{"values": []interface{}{"/*"}}}},
+				Behaviors: []RuleBehavior{{Name: "http2"}},
+				Children: []Rules{{
+					Name:      "HTTP/2",
+					Behaviors: []RuleBehavior{{Name: "http2"}},
+				}},
+			},
+			options: RuleValidationOptions{DefaultRuleOnlyBehaviors: []string{"http2"}},
+			expectedErrors: []RuleError{
+				{Type: RuleErrorTypeCriteriaInDefaultRule, BehaviorName: "path", ErrorLocation: "#/rules/criteria/0"},
+				{Type: RuleErrorTypeDefaultRuleOnly, BehaviorName: "http2", ErrorLocation: "#/rules/children/0/behaviors/0"},
+			},
+		},
+		"variables": {
+			rules: Rules{
+				Name:      "default",
+				Variables: []RuleVariable{{Name: "PMUSER_ORIGIN"}},
+				Behaviors: []RuleBehavior{
+					{Name: "origin", Options: RuleOptionsMap{
+						"hostname":           "{{user.PMUSER_ORIGIN}}",
+						"httpPort":           "{{user.PMUSER_PORT}}",
+						"httpsPort":          "{{builtin.AK_PORT}}",
+						"trueClientIpHeader": "X-{{user.PMUSER_ORIGIN}}-{{user.PMUSER_HEADER}}",
+					}},
+				},
+			},
+			expectedErrors: []RuleError{
+				{Type: RuleErrorTypeUndefinedVariable, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/httpPort"},
+				{Type: RuleErrorTypeUndefinedVariable, BehaviorName: "origin", ErrorLocation: "#/rules/behaviors/0/options/trueClientIpHeader"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			errs := schema.ValidateRules(test.rules, test.options)
+			for i := range errs {
+				assert.NotEmpty(t, errs[i].Title)
+				assert.NotEmpty(t, errs[i].Detail)
+				errs[i].Title, errs[i].Detail = "", ""
+			}
+			assert.Equal(t, test.expectedErrors, errs)
+		})
+	}
+}
+
+func TestRuleFormatSchema_RequiredOptions(t *testing.T) {
+	schema, err := ParseRuleFormatSchema([]byte(`{"definitions": {"catalog": {
+		"behaviors": {"redirect": {"properties": {"options": {
+			"type": "object",
+			"required": ["destinationHostname", "responseCode"],
+			"properties": {"destinationHostname": {"type": "string"}, "responseCode": {"type": "integer", "enum": [301, 302]}}
+		}}}},
+		"criteria": {}
+	}}}`))
+	require.NoError(t, err)
+
+	errs := schema.ValidateRules(Rules{
+		Name: "default",
+		Behaviors: []RuleBehavior{
+			{Name: "redirect", Options: RuleOptionsMap{"responseCode": 302, "extra": true}},
+			{Name: "redirect", Options: RuleOptionsMap{"destinationHostname": "example.com", "responseCode": 303}},
+		},
+	}, RuleValidationOptions{})
+	require.Len(t, errs, 2)
+	assert.Equal(t, RuleErrorTypeRequiredOption, errs[0].Type)
+	assert.Equal(t, "#/rules/behaviors/0/options/destinationHostname", errs[0].ErrorLocation)
+	assert.Equal(t, RuleErrorTypeInvalidOptionValue, errs[1].Type)
+	assert.Equal(t, "#/rules/behaviors/1/options/responseCode", errs[1].ErrorLocation)
+}
+
+func TestRuleFormatSchema_DefaultRuleOnlyBehaviors(t *testing.T) {
+	schema, err := ParseRuleFormatSchema([]byte(`{
+		"properties": {"rules": {"$ref": "#/definitions/defaultRule"}},
+		"definitions": {
+			"catalog": {"behaviors": {"origin": {}, "caching": {}, "http2": {}}, "criteria": {"path": {}}},
+			"defaultRule": {"type": "object", "properties": {
+				"behaviors": {"type": "array", "items": {"anyOf": [
+					{"$ref": "#/definitions/catalog/behaviors/origin"},
+					{"$ref": "#/definitions/catalog/behaviors/caching"},
+					{"$ref": "#/definitions/catalog/behaviors/http2"}
+				]}},
+				"children": {"type": "array", "items": {"$ref": "#/definitions/childRule"}}
+			}},
+			"childRule": {"type": "object", "properties": {
+				"behaviors": {"type": "array", "items": {"oneOf": [
+					{"$ref": "#/definitions/catalog/behaviors/caching"},
+					{"properties": {"name": {"enum": ["http2"]}}}
+				]}},
+				"children": {"type": "array", "items": {"$ref": "#/definitions/childRule"}}
+			}}
+		}
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"origin"}, schema.DefaultRuleOnlyBehaviors())
+	assert.Empty(t, loadRuleFormatSchema(t).DefaultRuleOnlyBehaviors())
+
+	rules := Rules{
+		Name:      "default",
+		Behaviors: []RuleBehavior{{Name: "origin"}, {Name: "caching"}},
+		Children: []Rules{{
+			Name:      "Static",
+			Behaviors: []RuleBehavior{{Name: "caching"}, {Name: "origin"}},
+			Children: []Rules{{
+				Name:      "HTTP/2",
+				Behaviors: []RuleBehavior{{Name: "http2"}, {Name: "origin"}},
+			}},
+		}},
+	}
+
+	tests := map[string]struct {
+		options           RuleValidationOptions
+		expectedLocations []string
+	}{
+		"behaviors derived from schema": {
+			expectedLocations: []string{"#/rules/children/0/behaviors/1", "#/rules/children/0/children/0/behaviors/1"},
+		},
+		"overridden behaviors": {
+			options:           RuleValidationOptions{DefaultRuleOnlyBehaviors: []string{"http2"}},
+			expectedLocations: []string{"#/rules/children/0/children/0/behaviors/0"},
+		},
+		"check disabled": {
+			options: RuleValidationOptions{DefaultRuleOnlyBehaviors: []string{}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var locations []string
+			for _, e := range schema.ValidateRules(rules, test.options) {
+				assert.Equal(t, RuleErrorTypeDefaultRuleOnly, e.Type)
+				locations = append(locations, e.ErrorLocation)
+			}
+			assert.Equal(t, test.expectedLocations, locations)
+		})
+	}
+}
+
+func TestRuleFormatSchema_JSON(t *testing.T) {
+	schema := loadRuleFormatSchema(t)
+
+	b, err := json.Marshal(schema)
+	require.NoError(t, err)
+	var cached RuleFormatSchema
+	require.NoError(t, json.Unmarshal(b, &cached))
+	assert.True(t, cached.HasBehavior("origin"))
+	assert.True(t, cached.HasCriterion("path"))
+	assert.False(t, cached.HasBehavior("path"))
+
+	_, err = ParseRuleFormatSchema([]byte(`{"definitions": {}}`))
+	assert.True(t, errors.Is(err, ErrParseRuleFormatSchema), "want: %s; got: %s", ErrParseRuleFormatSchema, err)
+	err = json.Unmarshal([]byte(`[]`), &cached)
+	assert.True(t, errors.Is(err, ErrParseRuleFormatSchema), "want: %s; got: %s", ErrParseRuleFormatSchema, err)
+}
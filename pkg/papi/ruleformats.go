@@ -2,9 +2,12 @@ package papi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
 type (
@@ -14,6 +17,16 @@ type (
 		// GetRuleFormats provides a list of rule formats
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getruleformats
 		GetRuleFormats(context.Context) (*GetRuleFormatsResponse, error)
+
+		// GetRuleFormatSchema gets JSON schema of a rule tree for given product and rule format
+		// See: https://techdocs.akamai.com/property-mgr/reference/get-schemas-product-rule-format
+		GetRuleFormatSchema(context.Context, GetRuleFormatSchemaRequest) (*RuleFormatSchema, error)
+	}
+
+	// GetRuleFormatSchemaRequest contains path params necessary to perform GET /schemas/products/{productId}/{ruleFormat} request
+	GetRuleFormatSchemaRequest struct {
+		ProductID  string
+		RuleFormat string
 	}
 
 	// GetRuleFormatsResponse contains the response body of GET /rule-formats request
@@ -30,8 +43,18 @@ type (
 var (
 	// ErrGetRuleFormats represents error when fetching rule formats fails
 	ErrGetRuleFormats = errors.New("fetching rule formats")
+	// ErrGetRuleFormatSchema represents error when fetching rule format schema fails
+	ErrGetRuleFormatSchema = errors.New("fetching rule format schema")
 )
 
+// Validate validates GetRuleFormatSchemaRequest struct
+func (r GetRuleFormatSchemaRequest) Validate() error {
+	return validation.Errors{
+		"ProductID":  validation.Validate(r.ProductID, validation.Required),
+		"RuleFormat": validation.Validate(r.RuleFormat, validation.Required, validation.Match(validRuleFormat)),
+	}.Filter()
+}
+
 func (p *papi) GetRuleFormats(ctx context.Context) (*GetRuleFormatsResponse, error) {
 	var ruleFormats GetRuleFormatsResponse
 
@@ -54,3 +77,34 @@ func (p *papi) GetRuleFormats(ctx context.Context) (*GetRuleFormatsResponse, err
 
 	return &ruleFormats, nil
 }
+
+func (p *papi) GetRuleFormatSchema(ctx context.Context, params GetRuleFormatSchemaRequest) (*RuleFormatSchema, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetRuleFormatSchema, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetRuleFormatSchema")
+
+	getURL := fmt.Sprintf("/papi/v1/schemas/products/%s/%s", params.ProductID, params.RuleFormat)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetRuleFormatSchema, err)
+	}
+
+	var body json.RawMessage
+	resp, err := p.Exec(req, &body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetRuleFormatSchema, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetRuleFormatSchema, p.Error(resp))
+	}
+
+	schema, err := ParseRuleFormatSchema(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrGetRuleFormatSchema, err)
+	}
+	return schema, nil
+}
@@ -74,3 +74,79 @@ func TestPapi_GetRuleFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_GetRuleFormatSchema(t *testing.T) {
+	tests := map[string]struct {
+		params           GetRuleFormatSchemaRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedBehavior string
+		withError        func(*testing.T, error)
+	}{
+		"200 OK": {
+			params:           GetRuleFormatSchemaRequest{ProductID: "prd_Fresca", RuleFormat: "v2022-10-18"},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"definitions": {"catalog": {"behaviors": {"origin": {}}, "criteria": {"path": {}}}}}`,
+			expectedPath:     "/papi/v1/schemas/products/prd_Fresca/v2022-10-18",
+			expectedBehavior: "origin",
+		},
+		"validation error": {
+			params: GetRuleFormatSchemaRequest{ProductID: "prd_Fresca", RuleFormat: "2022-10-18"},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), "RuleFormat")
+			},
+		},
+		"invalid schema": {
+			params:         GetRuleFormatSchemaRequest{ProductID: "prd_Fresca", RuleFormat: "latest"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"definitions": {}}`,
+			expectedPath:   "/papi/v1/schemas/products/prd_Fresca/latest",
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrGetRuleFormatSchema), "want: %s; got: %s", ErrGetRuleFormatSchema, err)
+			},
+		},
+		"404 not found": {
+			params:         GetRuleFormatSchemaRequest{ProductID: "prd_Unknown", RuleFormat: "latest"},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "detail": "Product not found",
+    "status": 404
+}`,
+			expectedPath: "/papi/v1/schemas/products/prd_Unknown/latest",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "not_found",
+					Title:      "Not Found",
+					Detail:     "Product not found",
+					StatusCode: http.StatusNotFound,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetRuleFormatSchema(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, result.HasBehavior(test.expectedBehavior))
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Reports is a reporting report data API interface
+	Reports interface {
+		// RunReport generates report data for a given report type version, time range and list of objects
+		//
+		// See: https://techdocs.akamai.com/reporting/reference/post-report-data
+		RunReport(context.Context, RunReportRequest) (*RunReportResponse, error)
+	}
+
+	// RunReportRequest contains path and query parameters, as well as request body used to generate a report
+	RunReportRequest struct {
+		Name     string
+		Version  int
+		Start    string
+		End      string
+		Interval Interval
+		ReportParameters
+	}
+
+	// ReportParameters represents the request body used to generate a report
+	ReportParameters struct {
+		ObjectType string              `json:"objectType,omitempty"`
+		ObjectIDs  []string            `json:"objectIds"`
+		Metrics    []string            `json:"metrics,omitempty"`
+		Filters    map[string][]string `json:"filters,omitempty"`
+		Limit      int                 `json:"limit,omitempty"`
+	}
+
+	// RunReportResponse represents a response object returned by RunReport
+	RunReportResponse struct {
+		Metadata          ReportMetadata              `json:"metadata"`
+		Data              []ReportRow                 `json:"data"`
+		SummaryStatistics map[string]SummaryStatistic `json:"summaryStatistics,omitempty"`
+	}
+
+	// ReportMetadata describes the generated report and the columns of its data
+	ReportMetadata struct {
+		Name               string         `json:"name"`
+		Version            string         `json:"version"`
+		OutputType         string         `json:"outputType"`
+		GroupBy            []string       `json:"groupBy,omitempty"`
+		Start              string         `json:"start"`
+		End                string         `json:"end"`
+		Interval           Interval       `json:"interval,omitempty"`
+		AvailableDataEnds  string         `json:"availableDataEnds,omitempty"`
+		SuggestedRetryTime string         `json:"suggestedRetryTime,omitempty"`
+		RowCount           int            `json:"rowCount"`
+		Limit              int            `json:"limit,omitempty"`
+		Filters            []ReportFilter `json:"filters,omitempty"`
+		Columns            []ReportColumn `json:"columns"`
+		ObjectType         string         `json:"objectType"`
+		ObjectIDs          []string       `json:"objectIds"`
+	}
+
+	// ReportColumn describes a single column of report data
+	ReportColumn struct {
+		Name        string `json:"name"`
+		Label       string `json:"label,omitempty"`
+		Type        string `json:"type,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// SummaryStatistic represents a summary value calculated for a metric
+	SummaryStatistic struct {
+		Value   interface{}            `json:"value"`
+		Details map[string]interface{} `json:"details,omitempty"`
+	}
+
+	// ReportRow is a single row of report data keyed by dimension or metric name
+	ReportRow map[string]string
+)
+
+var (
+	// ErrRunReport is returned when RunReport fails
+	ErrRunReport = errors.New("run report")
+
+	// ErrColumnNotFound is returned when a row does not contain requested column
+	ErrColumnNotFound = errors.New("column not found")
+)
+
+// Validate validates RunReportRequest
+func (r RunReportRequest) Validate() error {
+	return validation.Errors{
+		"Name":    validation.Validate(r.Name, validation.Required),
+		"Version": validation.Validate(r.Version, validation.Required, validation.Min(1)),
+		"Start": validation.Validate(r.Start, validation.Required, validation.Date(time.RFC3339).Error(
+			fmt.Sprintf("value '%s' is invalid. It must have format '%s'", r.Start, time.RFC3339))),
+		"End": validation.Validate(r.End, validation.Required, validation.Date(time.RFC3339).Error(
+			fmt.Sprintf("value '%s' is invalid. It must have format '%s'", r.End, time.RFC3339))),
+		"Interval": validation.Validate(r.Interval, validation.In(IntervalFiveMinutes, IntervalHour, IntervalDay, IntervalWeek, IntervalMonth).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s', '%s', '%s' or '%s'", r.Interval,
+				IntervalFiveMinutes, IntervalHour, IntervalDay, IntervalWeek, IntervalMonth))),
+		"ObjectIDs": validation.Validate(r.ObjectIDs, validation.Required),
+	}.Filter()
+}
+
+// UnmarshalJSON decodes report data returned either as a list of objects or as a list of value arrays
+// ordered as described by metadata columns
+func (r *RunReportResponse) UnmarshalJSON(b []byte) error {
+	type runReportResponse RunReportResponse
+	var raw struct {
+		runReportResponse
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*r = RunReportResponse(raw.runReportResponse)
+
+	r.Data = make([]ReportRow, 0, len(raw.Data))
+	for i, rawRow := range raw.Data {
+		row, err := decodeReportRow(rawRow, r.Metadata.Columns)
+		if err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		r.Data = append(r.Data, row)
+	}
+	return nil
+}
+
+func decodeReportRow(raw json.RawMessage, columns []ReportColumn) (ReportRow, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var values []interface{}
+		if err := dec.Decode(&values); err != nil {
+			return nil, err
+		}
+		if len(values) > len(columns) {
+			return nil, fmt.Errorf("row has %d values but only %d columns are defined", len(values), len(columns))
+		}
+		row := make(ReportRow, len(values))
+		for i, v := range values {
+			row[columns[i].Name] = formatValue(v)
+		}
+		return row, nil
+	}
+
+	var values map[string]interface{}
+	if err := dec.Decode(&values); err != nil {
+		return nil, err
+	}
+	row := make(ReportRow, len(values))
+	for k, v := range values {
+		row[k] = formatValue(v)
+	}
+	return row, nil
+}
+
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(b)
+	}
+}
+
+// String returns the raw value of a given column
+func (r ReportRow) String(column string) (string, error) {
+	v, ok := r[column]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrColumnNotFound, column)
+	}
+	return v, nil
+}
+
+// Float returns the value of a given column parsed as float64. Empty values are returned as 0
+func (r ReportRow) Float(column string) (float64, error) {
+	v, err := r.String(column)
+	if err != nil {
+		return 0, err
+	}
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+// Int returns the value of a given column parsed as int64. Empty values are returned as 0
+func (r ReportRow) Int(column string) (int64, error) {
+	v, err := r.String(column)
+	if err != nil {
+		return 0, err
+	}
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// Time returns the value of a given column parsed as RFC3339 time
+func (r ReportRow) Time(column string) (time.Time, error) {
+	v, err := r.String(column)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// Columns returns the names of report data columns, in the order defined by metadata.
+// If metadata does not define columns, names found in data rows are returned sorted alphabetically
+func (r RunReportResponse) Columns() []string {
+	if len(r.Metadata.Columns) > 0 {
+		columns := make([]string, 0, len(r.Metadata.Columns))
+		for _, c := range r.Metadata.Columns {
+			columns = append(columns, c.Name)
+		}
+		return columns
+	}
+
+	seen := make(map[string]struct{})
+	var columns []string
+	for _, row := range r.Data {
+		for k := range row {
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			columns = append(columns, k)
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// WriteCSV writes report data to w in CSV format, with a header row containing column names
+func (r RunReportResponse) WriteCSV(w io.Writer) error {
+	columns := r.Columns()
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, row := range r.Data {
+		for i, c := range columns {
+			record[i] = row[c]
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (r *reporting) RunReport(ctx context.Context, params RunReportRequest) (*RunReportResponse, error) {
+	logger := r.Log(ctx)
+	logger.Debug("RunReport")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrRunReport, ErrStructValidation, err)
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/reporting-api/v1/reports/%s/versions/%d/report-data", url.PathEscape(params.Name), params.Version))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrRunReport, err)
+	}
+
+	q := uri.Query()
+	q.Add("start", params.Start)
+	q.Add("end", params.End)
+	if params.Interval != "" {
+		q.Add("interval", string(params.Interval))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrRunReport, err)
+	}
+
+	var result RunReportResponse
+	resp, err := r.Exec(req, &result, params.ReportParameters)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrRunReport, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrRunReport, r.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,264 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestRunReport(t *testing.T) {
+	tests := map[string]struct {
+		params              RunReportRequest
+		responseStatus      int
+		responseBody        string
+		expectedPath        string
+		expectedRequestBody string
+		expectedResponse    *RunReportResponse
+		withError           error
+	}{
+		"200 OK, object rows": {
+			params: RunReportRequest{
+				Name:     "hits-by-cpcode",
+				Version:  1,
+				Start:    "2022-10-01T00:00:00Z",
+				End:      "2022-10-02T00:00:00Z",
+				Interval: IntervalHour,
+				ReportParameters: ReportParameters{
+					ObjectIDs: []string{"123", "456"},
+					Metrics:   []string{"edgeHits", "originHits"},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "metadata": {
+        "name": "hits-by-cpcode",
+        "version": "1",
+        "outputType": "FLAT",
+        "groupBy": ["startdatetime"],
+        "start": "2022-10-01T00:00:00Z",
+        "end": "2022-10-02T00:00:00Z",
+        "interval": "HOUR",
+        "rowCount": 2,
+        "columns": [
+            {"name": "startdatetime", "type": "DIMENSION"},
+            {"name": "edgeHits", "type": "METRIC"},
+            {"name": "originHits", "type": "METRIC"}
+        ],
+        "objectType": "cpcode",
+        "objectIds": ["123", "456"]
+    },
+    "data": [
+        {"startdatetime": "2022-10-01T00:00:00Z", "edgeHits": "120", "originHits": 3},
+        {"startdatetime": "2022-10-01T01:00:00Z", "edgeHits": "80", "originHits": null}
+    ],
+    "summaryStatistics": {
+        "edgeHitsSum": {"value": 200}
+    }
+}`,
+			expectedPath:        "/reporting-api/v1/reports/hits-by-cpcode/versions/1/report-data?end=2022-10-02T00%3A00%3A00Z&interval=HOUR&start=2022-10-01T00%3A00%3A00Z",
+			expectedRequestBody: `{"objectIds":["123","456"],"metrics":["edgeHits","originHits"]}`,
+			expectedResponse: &RunReportResponse{
+				Metadata: ReportMetadata{
+					Name:       "hits-by-cpcode",
+					Version:    "1",
+					OutputType: "FLAT",
+					GroupBy:    []string{"startdatetime"},
+					Start:      "2022-10-01T00:00:00Z",
+					End:        "2022-10-02T00:00:00Z",
+					Interval:   IntervalHour,
+					RowCount:   2,
+					Columns: []ReportColumn{
+						{Name: "startdatetime", Type: "DIMENSION"},
+						{Name: "edgeHits", Type: "METRIC"},
+						{Name: "originHits", Type: "METRIC"},
+					},
+					ObjectType: "cpcode",
+					ObjectIDs:  []string{"123", "456"},
+				},
+				Data: []ReportRow{
+					{"startdatetime": "2022-10-01T00:00:00Z", "edgeHits": "120", "originHits": "3"},
+					{"startdatetime": "2022-10-01T01:00:00Z", "edgeHits": "80", "originHits": ""},
+				},
+				SummaryStatistics: map[string]SummaryStatistic{
+					"edgeHitsSum": {Value: float64(200)},
+				},
+			},
+		},
+		"200 OK, array rows": {
+			params: RunReportRequest{
+				Name:    "hits-by-cpcode",
+				Version: 1,
+				Start:   "2022-10-01T00:00:00Z",
+				End:     "2022-10-02T00:00:00Z",
+				ReportParameters: ReportParameters{
+					ObjectIDs: []string{"123"},
+					Filters:   map[string][]string{"ca": {"secure"}},
+				},
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "metadata": {
+        "name": "hits-by-cpcode",
+        "version": "1",
+        "columns": [
+            {"name": "cpcode"},
+            {"name": "edgeHits"}
+        ]
+    },
+    "data": [
+        ["123", 42]
+    ]
+}`,
+			expectedPath:        "/reporting-api/v1/reports/hits-by-cpcode/versions/1/report-data?end=2022-10-02T00%3A00%3A00Z&start=2022-10-01T00%3A00%3A00Z",
+			expectedRequestBody: `{"objectIds":["123"],"filters":{"ca":["secure"]}}`,
+			expectedResponse: &RunReportResponse{
+				Metadata: ReportMetadata{
+					Name:    "hits-by-cpcode",
+					Version: "1",
+					Columns: []ReportColumn{
+						{Name: "cpcode"},
+						{Name: "edgeHits"},
+					},
+				},
+				Data: []ReportRow{
+					{"cpcode": "123", "edgeHits": "42"},
+				},
+			},
+		},
+		"validation error": {
+			params: RunReportRequest{
+				Name:     "hits-by-cpcode",
+				Version:  1,
+				Start:    "2022-10-01",
+				End:      "2022-10-02T00:00:00Z",
+				Interval: "YEAR",
+			},
+			withError: ErrStructValidation,
+		},
+		"400 bad request": {
+			params: RunReportRequest{
+				Name:    "hits-by-cpcode",
+				Version: 1,
+				Start:   "2022-10-01T00:00:00Z",
+				End:     "2022-10-02T00:00:00Z",
+				ReportParameters: ReportParameters{
+					ObjectIDs: []string{"123"},
+				},
+			},
+			responseStatus: http.StatusBadRequest,
+			responseBody: `
+{
+    "type": "bad_request",
+    "title": "Bad Request",
+    "detail": "Requested time range exceeds data retention",
+    "status": 400
+}`,
+			expectedPath:        "/reporting-api/v1/reports/hits-by-cpcode/versions/1/report-data?end=2022-10-02T00%3A00%3A00Z&start=2022-10-01T00%3A00%3A00Z",
+			expectedRequestBody: `{"objectIds":["123"]}`,
+			withError: &Error{
+				Type:   "bad_request",
+				Title:  "Bad Request",
+				Detail: "Requested time range exceeds data retention",
+				Status: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.RunReport(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestReportRow(t *testing.T) {
+	row := ReportRow{
+		"startdatetime": "2022-10-01T00:00:00Z",
+		"edgeHits":      "120",
+		"bytes":         "1.5",
+		"empty":         "",
+	}
+
+	hits, err := row.Int("edgeHits")
+	require.NoError(t, err)
+	assert.Equal(t, int64(120), hits)
+
+	bytesValue, err := row.Float("bytes")
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, bytesValue)
+
+	empty, err := row.Float("empty")
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), empty)
+
+	start, err := row.Time("startdatetime")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), start)
+
+	_, err = row.Float("missing")
+	assert.True(t, errors.Is(err, ErrColumnNotFound))
+}
+
+func TestRunReportResponse_WriteCSV(t *testing.T) {
+	tests := map[string]struct {
+		report   RunReportResponse
+		expected string
+	}{
+		"columns from metadata": {
+			report: RunReportResponse{
+				Metadata: ReportMetadata{
+					Columns: []ReportColumn{{Name: "startdatetime"}, {Name: "edgeHits"}},
+				},
+				Data: []ReportRow{
+					{"startdatetime": "2022-10-01T00:00:00Z", "edgeHits": "120"},
+					{"startdatetime": "2022-10-01T01:00:00Z"},
+				},
+			},
+			expected: "startdatetime,edgeHits\n2022-10-01T00:00:00Z,120\n2022-10-01T01:00:00Z,\n",
+		},
+		"columns from data": {
+			report: RunReportResponse{
+				Data: []ReportRow{
+					{"edgeHits": "120", "cpcode": "123"},
+					{"edgeHits": "1,000", "cpcode": "456"},
+				},
+			},
+			expected: "cpcode,edgeHits\n123,120\n456,\"1,000\"\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, test.report.WriteCSV(&buf))
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// ReportTypes is a reporting report types API interface
+	ReportTypes interface {
+		// ListReportTypes lists all available report types
+		//
+		// See: https://techdocs.akamai.com/reporting/reference/get-reports
+		ListReportTypes(context.Context, ListReportTypesRequest) ([]ReportType, error)
+
+		// ListReportTypeVersions lists all versions of a given report type
+		//
+		// See: https://techdocs.akamai.com/reporting/reference/get-report-versions
+		ListReportTypeVersions(context.Context, ListReportTypeVersionsRequest) ([]ReportType, error)
+
+		// GetReportType fetches metadata of a report type version, such as available metrics, filters and intervals
+		//
+		// See: https://techdocs.akamai.com/reporting/reference/get-report-version
+		GetReportType(context.Context, GetReportTypeRequest) (*ReportType, error)
+	}
+
+	// ListReportTypesRequest contains parameters used to list report types
+	ListReportTypesRequest struct {
+		ShowDeprecated   bool
+		ShowUnavailable  bool
+		ShowUnauthorized bool
+	}
+
+	// ListReportTypeVersionsRequest contains parameters used to list versions of a report type
+	ListReportTypeVersionsRequest struct {
+		Name string
+	}
+
+	// GetReportTypeRequest contains parameters used to fetch a report type version
+	GetReportTypeRequest struct {
+		Name    string
+		Version int
+	}
+
+	// ReportType represents a report type version with its metadata
+	ReportType struct {
+		Name               string         `json:"name"`
+		Version            int            `json:"version"`
+		Status             string         `json:"status"`
+		Description        string         `json:"description"`
+		BusinessObjectName string         `json:"businessObjectName"`
+		DataRetentionDays  int            `json:"dataRetentionDays"`
+		Limit              int            `json:"limit,omitempty"`
+		MaxLimit           int            `json:"maxLimit,omitempty"`
+		TimeBased          bool           `json:"timeBased"`
+		OutputType         string         `json:"outputType,omitempty"`
+		SupportsPagination bool           `json:"supportsPagination"`
+		Available          bool           `json:"available"`
+		Deprecated         bool           `json:"deprecated"`
+		Authorized         bool           `json:"authorized"`
+		RequiredProducts   []string       `json:"requiredProducts,omitempty"`
+		GroupBy            []string       `json:"groupBy,omitempty"`
+		Intervals          []Interval     `json:"intervals,omitempty"`
+		Metrics            []ReportMetric `json:"metrics,omitempty"`
+		Filters            []ReportFilter `json:"filters,omitempty"`
+		Links              []Link         `json:"links,omitempty"`
+	}
+
+	// ReportMetric describes a metric available in a report type
+	ReportMetric struct {
+		Name             string `json:"name"`
+		Description      string `json:"description"`
+		Label            string `json:"label,omitempty"`
+		Unit             string `json:"unit,omitempty"`
+		SummaryStatistic bool   `json:"summaryStatistic"`
+		TimeSeries       bool   `json:"timeSeries,omitempty"`
+	}
+
+	// ReportFilter describes a filter available in a report type
+	ReportFilter struct {
+		Name        string   `json:"name"`
+		Type        string   `json:"type"`
+		Description string   `json:"description,omitempty"`
+		Required    bool     `json:"required"`
+		Values      []string `json:"values,omitempty"`
+	}
+
+	// Link represents a hypermedia link
+	Link struct {
+		Rel  string `json:"rel"`
+		Href string `json:"href"`
+	}
+
+	// Interval represents a time interval used to aggregate report data
+	Interval string
+)
+
+const (
+	// IntervalFiveMinutes aggregates data in 5-minute buckets
+	IntervalFiveMinutes Interval = "FIVE_MINUTES"
+	// IntervalHour aggregates data in hourly buckets
+	IntervalHour Interval = "HOUR"
+	// IntervalDay aggregates data in daily buckets
+	IntervalDay Interval = "DAY"
+	// IntervalWeek aggregates data in weekly buckets
+	IntervalWeek Interval = "WEEK"
+	// IntervalMonth aggregates data in monthly buckets
+	IntervalMonth Interval = "MONTH"
+)
+
+var (
+	// ErrListReportTypes is returned when ListReportTypes fails
+	ErrListReportTypes = errors.New("list report types")
+	// ErrListReportTypeVersions is returned when ListReportTypeVersions fails
+	ErrListReportTypeVersions = errors.New("list report type versions")
+	// ErrGetReportType is returned when GetReportType fails
+	ErrGetReportType = errors.New("get report type")
+)
+
+// Validate validates ListReportTypeVersionsRequest
+func (r ListReportTypeVersionsRequest) Validate() error {
+	return validation.Errors{
+		"Name": validation.Validate(r.Name, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetReportTypeRequest
+func (r GetReportTypeRequest) Validate() error {
+	return validation.Errors{
+		"Name":    validation.Validate(r.Name, validation.Required),
+		"Version": validation.Validate(r.Version, validation.Required, validation.Min(1)),
+	}.Filter()
+}
+
+func (r *reporting) ListReportTypes(ctx context.Context, params ListReportTypesRequest) ([]ReportType, error) {
+	logger := r.Log(ctx)
+	logger.Debug("ListReportTypes")
+
+	uri, err := url.Parse("/reporting-api/v1/reports")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListReportTypes, err)
+	}
+
+	q := uri.Query()
+	if params.ShowDeprecated {
+		q.Add("showDeprecated", "true")
+	}
+	if params.ShowUnavailable {
+		q.Add("showUnavailable", "true")
+	}
+	if params.ShowUnauthorized {
+		q.Add("showUnauthorized", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListReportTypes, err)
+	}
+
+	var result []ReportType
+	resp, err := r.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListReportTypes, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListReportTypes, r.Error(resp))
+	}
+
+	return result, nil
+}
+
+func (r *reporting) ListReportTypeVersions(ctx context.Context, params ListReportTypeVersionsRequest) ([]ReportType, error) {
+	logger := r.Log(ctx)
+	logger.Debug("ListReportTypeVersions")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListReportTypeVersions, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/reporting-api/v1/reports/%s/versions", url.PathEscape(params.Name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListReportTypeVersions, err)
+	}
+
+	var result []ReportType
+	resp, err := r.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListReportTypeVersions, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListReportTypeVersions, r.Error(resp))
+	}
+
+	return result, nil
+}
+
+func (r *reporting) GetReportType(ctx context.Context, params GetReportTypeRequest) (*ReportType, error) {
+	logger := r.Log(ctx)
+	logger.Debug("GetReportType")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetReportType, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/reporting-api/v1/reports/%s/versions/%d", url.PathEscape(params.Name), params.Version)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetReportType, err)
+	}
+
+	var result ReportType
+	resp, err := r.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetReportType, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetReportType, r.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,288 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListReportTypes(t *testing.T) {
+	tests := map[string]struct {
+		params           ListReportTypesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse []ReportType
+		withError        error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody: `
+[
+    {
+        "name": "hits-by-cpcode",
+        "version": 1,
+        "status": "PUBLISHED",
+        "description": "Hits by CP code",
+        "businessObjectName": "cpcode",
+        "dataRetentionDays": 92,
+        "timeBased": true,
+        "supportsPagination": false,
+        "available": true,
+        "authorized": true,
+        "intervals": ["FIVE_MINUTES", "HOUR", "DAY"],
+        "links": [
+            {
+                "rel": "self",
+                "href": "/reporting-api/v1/reports/hits-by-cpcode/versions/1"
+            }
+        ]
+    }
+]`,
+			expectedPath: "/reporting-api/v1/reports",
+			expectedResponse: []ReportType{
+				{
+					Name:               "hits-by-cpcode",
+					Version:            1,
+					Status:             "PUBLISHED",
+					Description:        "Hits by CP code",
+					BusinessObjectName: "cpcode",
+					DataRetentionDays:  92,
+					TimeBased:          true,
+					Available:          true,
+					Authorized:         true,
+					Intervals:          []Interval{IntervalFiveMinutes, IntervalHour, IntervalDay},
+					Links: []Link{
+						{
+							Rel:  "self",
+							Href: "/reporting-api/v1/reports/hits-by-cpcode/versions/1",
+						},
+					},
+				},
+			},
+		},
+		"200 OK with query params": {
+			params: ListReportTypesRequest{
+				ShowDeprecated:  true,
+				ShowUnavailable: true,
+			},
+			responseStatus:   http.StatusOK,
+			responseBody:     `[]`,
+			expectedPath:     "/reporting-api/v1/reports?showDeprecated=true&showUnavailable=true",
+			expectedResponse: []ReportType{},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error processing request",
+    "status": 500
+}`,
+			expectedPath: "/reporting-api/v1/reports",
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Detail: "Error processing request",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListReportTypes(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestListReportTypeVersions(t *testing.T) {
+	tests := map[string]struct {
+		params           ListReportTypeVersionsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse []ReportType
+		withError        error
+	}{
+		"200 OK": {
+			params:         ListReportTypeVersionsRequest{Name: "hits-by-cpcode"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+[
+    {"name": "hits-by-cpcode", "version": 1, "status": "DEPRECATED", "deprecated": true},
+    {"name": "hits-by-cpcode", "version": 2, "status": "PUBLISHED"}
+]`,
+			expectedPath: "/reporting-api/v1/reports/hits-by-cpcode/versions",
+			expectedResponse: []ReportType{
+				{Name: "hits-by-cpcode", Version: 1, Status: "DEPRECATED", Deprecated: true},
+				{Name: "hits-by-cpcode", Version: 2, Status: "PUBLISHED"},
+			},
+		},
+		"missing name": {
+			params:    ListReportTypeVersionsRequest{},
+			withError: ErrStructValidation,
+		},
+		"404 not found": {
+			params:         ListReportTypeVersionsRequest{Name: "unknown"},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "status": 404
+}`,
+			expectedPath: "/reporting-api/v1/reports/unknown/versions",
+			withError: &Error{
+				Type:   "not_found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListReportTypeVersions(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetReportType(t *testing.T) {
+	tests := map[string]struct {
+		params           GetReportTypeRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ReportType
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetReportTypeRequest{Name: "hits-by-cpcode", Version: 1},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "name": "hits-by-cpcode",
+    "version": 1,
+    "status": "PUBLISHED",
+    "businessObjectName": "cpcode",
+    "timeBased": true,
+    "available": true,
+    "intervals": ["HOUR", "DAY"],
+    "metrics": [
+        {
+            "name": "edgeHits",
+            "description": "Edge hits",
+            "unit": "COUNT",
+            "summaryStatistic": false
+        },
+        {
+            "name": "edgeHitsSum",
+            "description": "Total edge hits",
+            "unit": "COUNT",
+            "summaryStatistic": true
+        }
+    ],
+    "filters": [
+        {
+            "name": "ca",
+            "type": "enum",
+            "required": false,
+            "values": ["secure", "non_secure"]
+        }
+    ]
+}`,
+			expectedPath: "/reporting-api/v1/reports/hits-by-cpcode/versions/1",
+			expectedResponse: &ReportType{
+				Name:               "hits-by-cpcode",
+				Version:            1,
+				Status:             "PUBLISHED",
+				BusinessObjectName: "cpcode",
+				TimeBased:          true,
+				Available:          true,
+				Intervals:          []Interval{IntervalHour, IntervalDay},
+				Metrics: []ReportMetric{
+					{Name: "edgeHits", Description: "Edge hits", Unit: "COUNT"},
+					{Name: "edgeHitsSum", Description: "Total edge hits", Unit: "COUNT", SummaryStatistic: true},
+				},
+				Filters: []ReportFilter{
+					{Name: "ca", Type: "enum", Values: []string{"secure", "non_secure"}},
+				},
+			},
+		},
+		"missing version": {
+			params:    GetReportTypeRequest{Name: "hits-by-cpcode"},
+			withError: ErrStructValidation,
+		},
+		"500 internal server error": {
+			params:         GetReportTypeRequest{Name: "hits-by-cpcode", Version: 1},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "status": 500
+}`,
+			expectedPath: "/reporting-api/v1/reports/hits-by-cpcode/versions/1",
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetReportType(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
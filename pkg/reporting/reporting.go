@@ -0,0 +1,43 @@
+// Package reporting provides access to the Akamai Reporting API
+package reporting
+
+import (
+	"errors"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// Reporting is the api interface for Reporting API
+	Reporting interface {
+		ReportTypes
+		Reports
+	}
+
+	reporting struct {
+		session.Session
+	}
+
+	// Option defines a Reporting option
+	Option func(*reporting)
+
+	// ClientFunc is a Reporting client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) Reporting
+)
+
+// Client returns a new reporting Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) Reporting {
+	r := &reporting{
+		Session: sess,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
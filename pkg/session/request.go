@@ -56,7 +56,9 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		r.ContentLength = int64(len(data))
 	}
 
-	s.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+	// Copy the client so that concurrent calls do not modify the shared one
+	client := *s.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
 		return s.Sign(req)
 	}
 
@@ -73,7 +75,7 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		}
 	}
 
-	resp, err := s.client.Do(r)
+	resp, err := client.Do(r)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
 	"testing"
 
 	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
@@ -198,3 +199,48 @@ func TestSession_Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_ExecConcurrent(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/test/path", http.StatusFound)
+			return
+		}
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		_, err := w.Write([]byte(`{"a":"text","b":1}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host: serverURL.Host,
+	}), WithClient(httpClient))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, "/redirect", nil)
+			require.NoError(t, err)
+			var out testStruct
+			_, err = s.Exec(req, &out)
+			require.NoError(t, err)
+			assert.Equal(t, testStruct{A: "text", B: 1}, out)
+		}()
+	}
+	wg.Wait()
+	assert.Nil(t, httpClient.CheckRedirect)
+}
@@ -0,0 +1,149 @@
+package siem
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+type (
+	// DecodedAttackData contains attack data with the rule related fields unpacked into one entry per triggered rule
+	DecodedAttackData struct {
+		ConfigID         string
+		PolicyID         string
+		ClientIP         string
+		ClientReputation []string
+		APIID            string
+		APIKey           string
+		Rules            []AttackRule
+	}
+
+	// AttackRule describes a single rule triggered by a request
+	AttackRule struct {
+		ID       string
+		Version  string
+		Message  string
+		Tag      string
+		Data     string
+		Selector string
+		Action   string
+	}
+)
+
+var (
+	// ErrDecodeAttackData is returned when attack data cannot be decoded
+	ErrDecodeAttackData = errors.New("decode attack data")
+)
+
+// DecodeAttackField unpacks a rule related attack data field. The field is URL-encoded and, once unescaped,
+// contains a semicolon-delimited list of base64 encoded values
+func DecodeAttackField(field string) ([]string, error) {
+	if field == "" {
+		return nil, nil
+	}
+
+	unescaped, err := url.PathUnescape(field)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDecodeAttackData, err)
+	}
+
+	parts := strings.Split(strings.TrimSuffix(unescaped, ";"), ";")
+	values := make([]string, 0, len(parts))
+	for i, part := range parts {
+		value, err := base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: value %d: %s", ErrDecodeAttackData, i, err)
+		}
+		values = append(values, string(value))
+	}
+
+	return values, nil
+}
+
+// Decode unpacks the rule related fields of attack data.
+// Rules are matched with their messages, tags, data, selectors and actions by position
+func (a AttackData) Decode() (*DecodedAttackData, error) {
+	fields := map[string]string{
+		"rules":         a.Rules,
+		"ruleVersions":  a.RuleVersions,
+		"ruleMessages":  a.RuleMessages,
+		"ruleTags":      a.RuleTags,
+		"ruleData":      a.RuleData,
+		"ruleSelectors": a.RuleSelectors,
+		"ruleActions":   a.RuleActions,
+	}
+	decoded := make(map[string][]string, len(fields))
+	for name, field := range fields {
+		values, err := DecodeAttackField(field)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		decoded[name] = values
+	}
+
+	reputation, err := url.PathUnescape(a.ClientReputation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: clientReputation: %s", ErrDecodeAttackData, err)
+	}
+
+	result := DecodedAttackData{
+		ConfigID: a.ConfigID,
+		PolicyID: a.PolicyID,
+		ClientIP: a.ClientIP,
+		APIID:    a.APIID,
+		APIKey:   a.APIKey,
+	}
+	if reputation != "" {
+		result.ClientReputation = strings.Split(strings.TrimSuffix(reputation, ";"), ";")
+	}
+
+	at := func(name string, i int) string {
+		if values := decoded[name]; i < len(values) {
+			return values[i]
+		}
+		return ""
+	}
+	for i := range decoded["rules"] {
+		result.Rules = append(result.Rules, AttackRule{
+			ID:       at("rules", i),
+			Version:  at("ruleVersions", i),
+			Message:  at("ruleMessages", i),
+			Tag:      at("ruleTags", i),
+			Data:     at("ruleData", i),
+			Selector: at("ruleSelectors", i),
+			Action:   at("ruleActions", i),
+		})
+	}
+
+	return &result, nil
+}
+
+// DecodeRequestHeaders unpacks the URL-encoded request headers of the HTTP message
+func (m HTTPMessage) DecodeRequestHeaders() (http.Header, error) {
+	return decodeHeaders(m.RequestHeaders)
+}
+
+// DecodeResponseHeaders unpacks the URL-encoded response headers of the HTTP message
+func (m HTTPMessage) DecodeResponseHeaders() (http.Header, error) {
+	return decodeHeaders(m.ResponseHeaders)
+}
+
+func decodeHeaders(headers string) (http.Header, error) {
+	unescaped, err := url.PathUnescape(headers)
+	if err != nil {
+		return nil, fmt.Errorf("%w: headers: %s", ErrDecodeAttackData, err)
+	}
+
+	reader := textproto.NewReader(bufio.NewReader(strings.NewReader(strings.TrimRight(unescaped, "\r\n") + "\r\n\r\n")))
+	mime, err := reader.ReadMIMEHeader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: headers: %s", ErrDecodeAttackData, err)
+	}
+
+	return http.Header(mime), nil
+}
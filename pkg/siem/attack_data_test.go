@@ -0,0 +1,160 @@
+package siem
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDecodeAttackField(t *testing.T) {
+	tests := map[string]struct {
+		field     string
+		expected  []string
+		withError error
+	}{
+		"multiple values": {
+			field:    "OTUwMDA0%3BOTkwMDEx%3B",
+			expected: []string{"950004", "990011"},
+		},
+		"empty value in the middle": {
+			field:    "VmVjdG9yIFNjb3JlOiAxMCwgPHNjcmlwdD4%3D%3B%3B",
+			expected: []string{"Vector Score: 10, <script>", ""},
+		},
+		"not URL-encoded": {
+			field:    "MQ==;Mg==;",
+			expected: []string{"1", "2"},
+		},
+		"base64 with plus sign": {
+			field:    "Pz8%2B%3B",
+			expected: []string{"??>"},
+		},
+		"empty field": {
+			field: "",
+		},
+		"invalid base64": {
+			field:     "not-base64%3B",
+			withError: ErrDecodeAttackData,
+		},
+		"invalid escape": {
+			field:     "MQ%3",
+			withError: ErrDecodeAttackData,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := DecodeAttackField(test.field)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestAttackData_Decode(t *testing.T) {
+	tests := map[string]struct {
+		data      AttackData
+		expected  *DecodedAttackData
+		withError string
+	}{
+		"all fields": {
+			data: AttackData{
+				ConfigID:         "14227",
+				PolicyID:         "qik1_26545",
+				ClientIP:         "192.0.2.82",
+				Rules:            "OTUwMDA0%3BOTkwMDEx%3B",
+				RuleVersions:     "MQ%3D%3D%3BMg%3D%3D%3B",
+				RuleMessages:     "Q3Jvc3Mtc2l0ZSBTY3JpcHRpbmcgKFhTUykgQXR0YWNr%3BUmVxdWVzdCBCb2R5IEluc3BlY3Rpb24gTGltaXQgRXhjZWVkZWQ%3D%3B",
+				RuleTags:         "V0VCX0FUVEFDSy9YU1M%3D%3BUE9MSUNZL1JFUUJPRFlfTElNSVQ%3D%3B",
+				RuleData:         "VmVjdG9yIFNjb3JlOiAxMCwgPHNjcmlwdD4%3D%3B%3B",
+				RuleSelectors:    "QVJHUzpx%3BUkVRVUVTVF9CT0RZ%3B",
+				RuleActions:      "YWxlcnQ%3D%3BbW9uaXRvcg%3D%3D%3B",
+				ClientReputation: "WEBSCRP%3D10%3BDOSATCK%3D3%3B",
+			},
+			expected: &DecodedAttackData{
+				ConfigID:         "14227",
+				PolicyID:         "qik1_26545",
+				ClientIP:         "192.0.2.82",
+				ClientReputation: []string{"WEBSCRP=10", "DOSATCK=3"},
+				Rules: []AttackRule{
+					{
+						ID:       "950004",
+						Version:  "1",
+						Message:  "Cross-site Scripting (XSS) Attack",
+						Tag:      "WEB_ATTACK/XSS",
+						Data:     "Vector Score: 10, <script>",
+						Selector: "ARGS:q",
+						Action:   "alert",
+					},
+					{
+						ID:       "990011",
+						Version:  "2",
+						Message:  "Request Body Inspection Limit Exceeded",
+						Tag:      "POLICY/REQBODY_LIMIT",
+						Selector: "REQUEST_BODY",
+						Action:   "monitor",
+					},
+				},
+			},
+		},
+		"fields shorter than rules": {
+			data: AttackData{
+				Rules:       "OTUwMDA0%3BOTkwMDEx%3B",
+				RuleActions: "YWxlcnQ%3D%3B",
+			},
+			expected: &DecodedAttackData{
+				Rules: []AttackRule{
+					{ID: "950004", Action: "alert"},
+					{ID: "990011"},
+				},
+			},
+		},
+		"invalid field": {
+			data: AttackData{
+				Rules:    "OTUwMDA0%3B",
+				RuleTags: "%%%",
+			},
+			withError: "ruleTags: decode attack data",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := test.data.Decode()
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				assert.True(t, errors.Is(err, ErrDecodeAttackData))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestHTTPMessage_DecodeRequestHeaders(t *testing.T) {
+	message := HTTPMessage{
+		RequestHeaders:  "Host%3A%20www.example.com%0D%0AUser-Agent%3A%20curl%2F7.68%0D%0AAccept%3A%20%2A%2F%2A%0D%0A",
+		ResponseHeaders: "Server%3A%20AkamaiGHost%0D%0AContent-Length%3A%20314%0D%0A",
+	}
+
+	requestHeaders, err := message.DecodeRequestHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, http.Header{
+		"Host":       {"www.example.com"},
+		"User-Agent": {"curl/7.68"},
+		"Accept":     {"*/*"},
+	}, requestHeaders)
+
+	responseHeaders, err := message.DecodeResponseHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, "AkamaiGHost", responseHeaders.Get("Server"))
+	assert.Equal(t, "314", responseHeaders.Get("Content-Length"))
+}
@@ -0,0 +1,214 @@
+package siem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type (
+	// OffsetStore persists the offset of the last fully processed batch of security events,
+	// so that an EventIterator can resume where a previous one stopped
+	OffsetStore interface {
+		// LoadOffset returns the persisted offset or an empty string if no offset was saved yet
+		LoadOffset(context.Context) (string, error)
+
+		// SaveOffset persists the offset
+		SaveOffset(context.Context, string) error
+	}
+
+	// EventIteratorRequest contains parameters used to create an EventIterator.
+	// From and To are only used when neither Offset is set nor the store holds a persisted offset
+	EventIteratorRequest struct {
+		ConfigIDs []int
+		Offset    string
+		From      int64
+		To        int64
+		Limit     int
+		Store     OffsetStore
+	}
+
+	// EventIterator streams security events batch by batch, following the offset returned with each batch.
+	// It stops once a batch smaller than the limit is returned, which means there are no more events available.
+	// The offset is checkpointed in the store after each batch has been fully consumed
+	EventIterator struct {
+		client  SecurityEvents
+		request EventIteratorRequest
+
+		events  []SecurityEvent
+		current SecurityEvent
+		offset  string
+		pending string
+		started bool
+		done    bool
+		err     error
+	}
+
+	// FileOffsetStore is an OffsetStore which keeps the offset in a file
+	FileOffsetStore struct {
+		Path string
+	}
+
+	// MemoryOffsetStore is an OffsetStore which keeps the offset in memory
+	MemoryOffsetStore struct {
+		mu     sync.Mutex
+		offset string
+	}
+)
+
+const (
+	defaultIteratorLimit = 10000
+)
+
+var (
+	// ErrEventIterator is returned when EventIterator fails
+	ErrEventIterator = errors.New("event iterator")
+)
+
+// NewEventIterator returns an EventIterator which fetches events using the given client
+func NewEventIterator(client SecurityEvents, params EventIteratorRequest) *EventIterator {
+	if params.Limit == 0 {
+		params.Limit = defaultIteratorLimit
+	}
+	return &EventIterator{
+		client:  client,
+		request: params,
+		offset:  params.Offset,
+	}
+}
+
+// Next advances the iterator to the next event, fetching a new batch when needed.
+// It returns false when there are no more events or an error occurred, which is then returned by Err
+func (it *EventIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.events) == 0 {
+		if it.started {
+			if err := it.checkpoint(ctx); err != nil {
+				it.err = err
+				return false
+			}
+		}
+		if it.done {
+			return false
+		}
+		if err := it.fetch(ctx); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.current, it.events = it.events[0], it.events[1:]
+	return true
+}
+
+// Event returns the event the iterator currently points at
+func (it *EventIterator) Event() SecurityEvent {
+	return it.current
+}
+
+// Offset returns the offset of the last fully consumed batch, which can be used to resume iteration
+func (it *EventIterator) Offset() string {
+	return it.offset
+}
+
+// Err returns the error which stopped the iterator, if any
+func (it *EventIterator) Err() error {
+	return it.err
+}
+
+func (it *EventIterator) fetch(ctx context.Context) error {
+	if !it.started && it.offset == "" && it.request.Store != nil {
+		offset, err := it.request.Store.LoadOffset(ctx)
+		if err != nil {
+			return fmt.Errorf("%w: failed to load offset: %s", ErrEventIterator, err)
+		}
+		it.offset = offset
+	}
+
+	params := GetSecurityEventsRequest{
+		ConfigIDs: it.request.ConfigIDs,
+		Offset:    it.offset,
+		Limit:     it.request.Limit,
+	}
+	if it.offset == "" {
+		params.From = it.request.From
+		params.To = it.request.To
+	}
+
+	resp, err := it.client.GetSecurityEvents(ctx, params)
+	if err != nil {
+		return fmt.Errorf("%s: %w", ErrEventIterator, err)
+	}
+
+	it.started = true
+	it.events = resp.Events
+	it.pending = resp.Context.Offset
+	it.done = len(resp.Events) < it.request.Limit
+	return nil
+}
+
+func (it *EventIterator) checkpoint(ctx context.Context) error {
+	if it.pending == "" || it.pending == it.offset {
+		return nil
+	}
+	if it.request.Store != nil {
+		if err := it.request.Store.SaveOffset(ctx, it.pending); err != nil {
+			return fmt.Errorf("%w: failed to save offset: %s", ErrEventIterator, err)
+		}
+	}
+	it.offset = it.pending
+	return nil
+}
+
+// LoadOffset reads the offset from the file. A missing file means no offset was saved yet
+func (f FileOffsetStore) LoadOffset(_ context.Context) (string, error) {
+	data, err := ioutil.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveOffset writes the offset to a temporary file which then replaces the offset file
+func (f FileOffsetStore) SaveOffset(_ context.Context, offset string) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(offset); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// LoadOffset returns the offset kept in memory
+func (m *MemoryOffsetStore) LoadOffset(_ context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.offset, nil
+}
+
+// SaveOffset keeps the offset in memory
+func (m *MemoryOffsetStore) SaveOffset(_ context.Context, offset string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offset = offset
+	return nil
+}
@@ -0,0 +1,141 @@
+package siem
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+type fakeSecurityEvents struct {
+	pages    map[string][]SecurityEvent
+	next     map[string]string
+	requests []GetSecurityEventsRequest
+	err      error
+}
+
+func (f *fakeSecurityEvents) GetSecurityEvents(_ context.Context, params GetSecurityEventsRequest) (*GetSecurityEventsResponse, error) {
+	f.requests = append(f.requests, params)
+	if f.err != nil {
+		return nil, f.err
+	}
+	key := params.Offset
+	if key == "" {
+		key = "start"
+	}
+	events := f.pages[key]
+	return &GetSecurityEventsResponse{
+		Events:  events,
+		Context: ResponseContext{Total: len(events), Offset: f.next[key], Limit: params.Limit},
+	}, nil
+}
+
+func eventsWithIDs(ids ...string) []SecurityEvent {
+	var events []SecurityEvent
+	for _, id := range ids {
+		events = append(events, SecurityEvent{HTTPMessage: HTTPMessage{RequestID: id}})
+	}
+	return events
+}
+
+func collect(ctx context.Context, it *EventIterator) []string {
+	var ids []string
+	for it.Next(ctx) {
+		ids = append(ids, it.Event().HTTPMessage.RequestID)
+	}
+	return ids
+}
+
+func TestEventIterator(t *testing.T) {
+	t.Run("follows offsets until a partial batch", func(t *testing.T) {
+		client := &fakeSecurityEvents{
+			pages: map[string][]SecurityEvent{
+				"start": eventsWithIDs("a", "b"),
+				"o1":    eventsWithIDs("c", "d"),
+				"o2":    eventsWithIDs("e"),
+			},
+			next: map[string]string{"start": "o1", "o1": "o2", "o2": "o3"},
+		}
+		store := &MemoryOffsetStore{}
+		it := NewEventIterator(client, EventIteratorRequest{ConfigIDs: []int{1}, From: 1666000000, Limit: 2, Store: store})
+
+		ids := collect(context.Background(), it)
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, ids)
+		assert.Equal(t, "o3", it.Offset())
+		offset, err := store.LoadOffset(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "o3", offset)
+		assert.Equal(t, []GetSecurityEventsRequest{
+			{ConfigIDs: []int{1}, From: 1666000000, Limit: 2},
+			{ConfigIDs: []int{1}, Offset: "o1", Limit: 2},
+			{ConfigIDs: []int{1}, Offset: "o2", Limit: 2},
+		}, client.requests)
+		assert.False(t, it.Next(context.Background()))
+	})
+
+	t.Run("resumes from persisted offset", func(t *testing.T) {
+		client := &fakeSecurityEvents{
+			pages: map[string][]SecurityEvent{"o2": eventsWithIDs("e")},
+			next:  map[string]string{"o2": "o3"},
+		}
+		store := &MemoryOffsetStore{}
+		require.NoError(t, store.SaveOffset(context.Background(), "o2"))
+		it := NewEventIterator(client, EventIteratorRequest{ConfigIDs: []int{1}, From: 1666000000, Store: store})
+
+		ids := collect(context.Background(), it)
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"e"}, ids)
+		assert.Equal(t, []GetSecurityEventsRequest{
+			{ConfigIDs: []int{1}, Offset: "o2", Limit: defaultIteratorLimit},
+		}, client.requests)
+	})
+
+	t.Run("offset is not checkpointed before batch is consumed", func(t *testing.T) {
+		client := &fakeSecurityEvents{
+			pages: map[string][]SecurityEvent{"start": eventsWithIDs("a", "b")},
+			next:  map[string]string{"start": "o1"},
+		}
+		store := &MemoryOffsetStore{}
+		it := NewEventIterator(client, EventIteratorRequest{ConfigIDs: []int{1}, From: 1666000000, Limit: 2, Store: store})
+
+		require.True(t, it.Next(context.Background()))
+		offset, err := store.LoadOffset(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "", offset)
+		assert.Equal(t, "", it.Offset())
+	})
+
+	t.Run("fetch error stops iteration", func(t *testing.T) {
+		fetchErr := errors.New("oops")
+		client := &fakeSecurityEvents{err: fetchErr}
+		it := NewEventIterator(client, EventIteratorRequest{ConfigIDs: []int{1}, Offset: "o1"})
+
+		assert.False(t, it.Next(context.Background()))
+		assert.True(t, errors.Is(it.Err(), fetchErr))
+		assert.False(t, it.Next(context.Background()))
+		assert.Len(t, client.requests, 1)
+	})
+}
+
+func TestFileOffsetStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "offset")
+	store := FileOffsetStore{Path: path}
+
+	offset, err := store.LoadOffset(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "", offset)
+
+	require.NoError(t, store.SaveOffset(context.Background(), "faf5-8d2f-5ff7e26cb72d"))
+	offset, err = store.LoadOffset(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "faf5-8d2f-5ff7e26cb72d", offset)
+
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
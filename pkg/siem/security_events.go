@@ -0,0 +1,252 @@
+package siem
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// SecurityEvents is a SIEM security events API interface
+	SecurityEvents interface {
+		// GetSecurityEvents fetches security events of one or more security configurations.
+		// Events are pulled either from a time range or from an offset returned by a previous call
+		//
+		// See: https://techdocs.akamai.com/siem-integration/reference/get-configid
+		GetSecurityEvents(context.Context, GetSecurityEventsRequest) (*GetSecurityEventsResponse, error)
+	}
+
+	// GetSecurityEventsRequest contains parameters used to fetch security events
+	GetSecurityEventsRequest struct {
+		ConfigIDs []int
+		Offset    string
+		Limit     int
+		From      int64
+		To        int64
+	}
+
+	// GetSecurityEventsResponse represents a response object returned by GetSecurityEvents
+	GetSecurityEventsResponse struct {
+		Events  []SecurityEvent
+		Context ResponseContext
+	}
+
+	// ResponseContext is the last line of a security events response. Offset should be used to fetch the next events
+	ResponseContext struct {
+		Total  int    `json:"total"`
+		Offset string `json:"offset"`
+		Limit  int    `json:"limit"`
+	}
+
+	// SecurityEvent represents a single security event
+	SecurityEvent struct {
+		Type         string        `json:"type"`
+		Format       string        `json:"format"`
+		Version      string        `json:"version"`
+		AttackData   AttackData    `json:"attackData"`
+		HTTPMessage  HTTPMessage   `json:"httpMessage"`
+		Geo          Geo           `json:"geo"`
+		BotData      *BotData      `json:"botData,omitempty"`
+		ClientData   *ClientData   `json:"clientData,omitempty"`
+		UserRiskData *UserRiskData `json:"userRiskData,omitempty"`
+	}
+
+	// AttackData contains information about the attack. Rule related fields are encoded, use Decode to unpack them
+	AttackData struct {
+		ConfigID         string `json:"configId"`
+		PolicyID         string `json:"policyId"`
+		ClientIP         string `json:"clientIP"`
+		Rules            string `json:"rules"`
+		RuleVersions     string `json:"ruleVersions"`
+		RuleMessages     string `json:"ruleMessages"`
+		RuleTags         string `json:"ruleTags"`
+		RuleData         string `json:"ruleData"`
+		RuleSelectors    string `json:"ruleSelectors"`
+		RuleActions      string `json:"ruleActions"`
+		ClientReputation string `json:"clientReputation,omitempty"`
+		APIID            string `json:"apiId,omitempty"`
+		APIKey           string `json:"apiKey,omitempty"`
+	}
+
+	// HTTPMessage contains information about the request and response which triggered the event
+	HTTPMessage struct {
+		RequestID       string `json:"requestId"`
+		Start           string `json:"start"`
+		Protocol        string `json:"protocol"`
+		Method          string `json:"method"`
+		Host            string `json:"host"`
+		Port            string `json:"port"`
+		Path            string `json:"path"`
+		Query           string `json:"query,omitempty"`
+		RequestHeaders  string `json:"requestHeaders"`
+		Status          string `json:"status"`
+		Bytes           string `json:"bytes"`
+		ResponseHeaders string `json:"responseHeaders"`
+		TLS             string `json:"tls,omitempty"`
+	}
+
+	// Geo contains the location of the client
+	Geo struct {
+		Continent  string `json:"continent"`
+		Country    string `json:"country"`
+		City       string `json:"city"`
+		RegionCode string `json:"regionCode"`
+		ASN        string `json:"asn"`
+	}
+
+	// BotData contains bot detection information
+	BotData struct {
+		BotScore        string `json:"botScore,omitempty"`
+		ResponseSegment string `json:"responseSegment,omitempty"`
+	}
+
+	// ClientData contains client fingerprinting information
+	ClientData struct {
+		AppBundleID   string `json:"appBundleId,omitempty"`
+		AppVersion    string `json:"appVersion,omitempty"`
+		SDKVersion    string `json:"sdkVersion,omitempty"`
+		TelemetryType string `json:"telemetryType,omitempty"`
+	}
+
+	// UserRiskData contains account protection risk information
+	UserRiskData struct {
+		UUID    string `json:"uuid,omitempty"`
+		Status  string `json:"status,omitempty"`
+		Score   string `json:"score,omitempty"`
+		Allow   string `json:"allow,omitempty"`
+		General string `json:"general,omitempty"`
+		Risk    string `json:"risk,omitempty"`
+		Trust   string `json:"trust,omitempty"`
+	}
+)
+
+const (
+	// MaxLimit is the maximum number of security events returned by a single GetSecurityEvents call
+	MaxLimit = 600000
+)
+
+var (
+	// ErrGetSecurityEvents is returned when GetSecurityEvents fails
+	ErrGetSecurityEvents = errors.New("get security events")
+)
+
+// Validate validates GetSecurityEventsRequest
+func (r GetSecurityEventsRequest) Validate() error {
+	return validation.Errors{
+		"ConfigIDs": validation.Validate(r.ConfigIDs, validation.Required),
+		"Offset": validation.Validate(r.Offset, validation.When(r.From != 0, validation.Empty.Error(
+			"cannot be provided together with From"))),
+		"From": validation.Validate(r.From, validation.Required.When(r.Offset == "").Error(
+			"one of Offset or From is required")),
+		"To": validation.Validate(r.To, validation.When(r.From == 0, validation.Empty.Error(
+			"can only be provided together with From")), validation.When(r.To != 0, validation.Min(r.From).Error(
+			"must not be before From"))),
+		"Limit": validation.Validate(r.Limit, validation.Min(0), validation.Max(MaxLimit)),
+	}.Filter()
+}
+
+func (s *siem) GetSecurityEvents(ctx context.Context, params GetSecurityEventsRequest) (*GetSecurityEventsResponse, error) {
+	logger := s.Log(ctx)
+	logger.Debug("GetSecurityEvents")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetSecurityEvents, ErrStructValidation, err)
+	}
+
+	configIDs := make([]string, 0, len(params.ConfigIDs))
+	for _, id := range params.ConfigIDs {
+		configIDs = append(configIDs, strconv.Itoa(id))
+	}
+
+	uri, err := url.Parse(fmt.Sprintf("/siem/v1/configs/%s", strings.Join(configIDs, ";")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetSecurityEvents, err)
+	}
+
+	q := uri.Query()
+	if params.Offset != "" {
+		q.Add("offset", params.Offset)
+	}
+	if params.From != 0 {
+		q.Add("from", strconv.FormatInt(params.From, 10))
+	}
+	if params.To != 0 {
+		q.Add("to", strconv.FormatInt(params.To, 10))
+	}
+	if params.Limit != 0 {
+		q.Add("limit", strconv.Itoa(params.Limit))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetSecurityEvents, err)
+	}
+
+	resp, err := s.Exec(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetSecurityEvents, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Errorf("closing response body: %s", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetSecurityEvents, s.Error(resp))
+	}
+
+	result, err := decodeSecurityEvents(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrGetSecurityEvents, err)
+	}
+
+	return result, nil
+}
+
+// decodeSecurityEvents reads newline delimited security events. The last line holds the response context
+func decodeSecurityEvents(r io.Reader) (*GetSecurityEventsResponse, error) {
+	var result GetSecurityEventsResponse
+	var last []byte
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read response: %s", err)
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if last != nil {
+				var event SecurityEvent
+				if err := json.Unmarshal(last, &event); err != nil {
+					return nil, fmt.Errorf("failed to decode event %d: %s", len(result.Events)+1, err)
+				}
+				result.Events = append(result.Events, event)
+			}
+			last = line
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	if last == nil {
+		return nil, errors.New("response context is missing")
+	}
+	if err := json.Unmarshal(last, &result.Context); err != nil {
+		return nil, fmt.Errorf("failed to decode response context: %s", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,177 @@
+package siem
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestGetSecurityEventsRequest_Validate(t *testing.T) {
+	tests := map[string]struct {
+		params    GetSecurityEventsRequest
+		withError string
+	}{
+		"offset": {
+			params: GetSecurityEventsRequest{ConfigIDs: []int{1}, Offset: "abc"},
+		},
+		"time range": {
+			params: GetSecurityEventsRequest{ConfigIDs: []int{1}, From: 1666000000, To: 1666000600, Limit: 1000},
+		},
+		"missing config IDs": {
+			params:    GetSecurityEventsRequest{Offset: "abc"},
+			withError: "ConfigIDs: cannot be blank",
+		},
+		"missing offset and from": {
+			params:    GetSecurityEventsRequest{ConfigIDs: []int{1}},
+			withError: "From: one of Offset or From is required",
+		},
+		"offset and from": {
+			params:    GetSecurityEventsRequest{ConfigIDs: []int{1}, Offset: "abc", From: 1666000000},
+			withError: "Offset: cannot be provided together with From",
+		},
+		"to without from": {
+			params:    GetSecurityEventsRequest{ConfigIDs: []int{1}, Offset: "abc", To: 1666000000},
+			withError: "To: can only be provided together with From",
+		},
+		"to before from": {
+			params:    GetSecurityEventsRequest{ConfigIDs: []int{1}, From: 1666000600, To: 1666000000},
+			withError: "To: must not be before From",
+		},
+		"limit too high": {
+			params:    GetSecurityEventsRequest{ConfigIDs: []int{1}, Offset: "abc", Limit: MaxLimit + 1},
+			withError: "Limit: must be no greater than 600000",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.params.Validate()
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetSecurityEvents(t *testing.T) {
+	tests := map[string]struct {
+		params           GetSecurityEventsRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *GetSecurityEventsResponse
+		withError        error
+	}{
+		"200 OK - events and context": {
+			params:         GetSecurityEventsRequest{ConfigIDs: []int{14227, 14228}, From: 1666000000, Limit: 2},
+			responseStatus: http.StatusOK,
+			responseBody: `{"type": "akamai_siem", "format": "json", "version": "1.0", "attackData": {"configId": "14227", "policyId": "qik1_26545", "clientIP": "192.0.2.82", "rules": "OTUwMDA0%3B", "ruleActions": "YWxlcnQ%3D%3B"}, "httpMessage": {"requestId": "1158db1758e37bfe67b7c09", "start": "1666000100", "protocol": "HTTP/1.1", "method": "GET", "host": "www.example.com", "port": "80", "path": "/", "status": "200"}, "geo": {"continent": "NA", "country": "US", "city": "LOSANGELES", "regionCode": "CA", "asn": "12271"}}
+{"type": "akamai_siem", "format": "json", "version": "1.0", "attackData": {"configId": "14228", "policyId": "qik1_26546", "clientIP": "192.0.2.83"}, "httpMessage": {"requestId": "2158db1758e37bfe67b7c09", "method": "POST"}, "geo": {"country": "PL"}, "botData": {"botScore": "100"}}
+{"total": 2, "offset": "faf5-8d2f-5ff7e26cb72d", "limit": 2}
+`,
+			expectedPath: "/siem/v1/configs/14227;14228?from=1666000000&limit=2",
+			expectedResponse: &GetSecurityEventsResponse{
+				Events: []SecurityEvent{
+					{
+						Type:    "akamai_siem",
+						Format:  "json",
+						Version: "1.0",
+						AttackData: AttackData{
+							ConfigID:    "14227",
+							PolicyID:    "qik1_26545",
+							ClientIP:    "192.0.2.82",
+							Rules:       "OTUwMDA0%3B",
+							RuleActions: "YWxlcnQ%3D%3B",
+						},
+						HTTPMessage: HTTPMessage{
+							RequestID: "1158db1758e37bfe67b7c09",
+							Start:     "1666000100",
+							Protocol:  "HTTP/1.1",
+							Method:    "GET",
+							Host:      "www.example.com",
+							Port:      "80",
+							Path:      "/",
+							Status:    "200",
+						},
+						Geo: Geo{Continent: "NA", Country: "US", City: "LOSANGELES", RegionCode: "CA", ASN: "12271"},
+					},
+					{
+						Type:        "akamai_siem",
+						Format:      "json",
+						Version:     "1.0",
+						AttackData:  AttackData{ConfigID: "14228", PolicyID: "qik1_26546", ClientIP: "192.0.2.83"},
+						HTTPMessage: HTTPMessage{RequestID: "2158db1758e37bfe67b7c09", Method: "POST"},
+						Geo:         Geo{Country: "PL"},
+						BotData:     &BotData{BotScore: "100"},
+					},
+				},
+				Context: ResponseContext{Total: 2, Offset: "faf5-8d2f-5ff7e26cb72d", Limit: 2},
+			},
+		},
+		"200 OK - no events": {
+			params:         GetSecurityEventsRequest{ConfigIDs: []int{14227}, Offset: "faf5-8d2f-5ff7e26cb72d"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"total": 0, "offset": "faf5-8d2f-5ff7e26cb72d", "limit": 10000}`,
+			expectedPath:   "/siem/v1/configs/14227?offset=faf5-8d2f-5ff7e26cb72d",
+			expectedResponse: &GetSecurityEventsResponse{
+				Context: ResponseContext{Offset: "faf5-8d2f-5ff7e26cb72d", Limit: 10000},
+			},
+		},
+		"validation error": {
+			params:    GetSecurityEventsRequest{ConfigIDs: []int{14227}},
+			withError: ErrStructValidation,
+		},
+		"empty response": {
+			params:         GetSecurityEventsRequest{ConfigIDs: []int{14227}, Offset: "abc"},
+			responseStatus: http.StatusOK,
+			expectedPath:   "/siem/v1/configs/14227?offset=abc",
+			withError:      ErrGetSecurityEvents,
+		},
+		"malformed event": {
+			params:         GetSecurityEventsRequest{ConfigIDs: []int{14227}, Offset: "abc"},
+			responseStatus: http.StatusOK,
+			responseBody:   "{\"attackData\": \n{\"total\": 0}",
+			expectedPath:   "/siem/v1/configs/14227?offset=abc",
+			withError:      ErrGetSecurityEvents,
+		},
+		"416 offset out of range": {
+			params:         GetSecurityEventsRequest{ConfigIDs: []int{14227}, Offset: "abc"},
+			responseStatus: http.StatusRequestedRangeNotSatisfiable,
+			responseBody:   `{"type": "https://problems.luna.akamaiapis.net/siem/v1/invalid-offset", "title": "Invalid offset", "status": 416}`,
+			expectedPath:   "/siem/v1/configs/14227?offset=abc",
+			withError: &Error{
+				Type:   "https://problems.luna.akamaiapis.net/siem/v1/invalid-offset",
+				Title:  "Invalid offset",
+				Status: http.StatusRequestedRangeNotSatisfiable,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetSecurityEvents(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Package siem provides access to the Akamai SIEM Integration API
+package siem
+
+import (
+	"errors"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// SIEM is the api interface for SIEM Integration
+	SIEM interface {
+		SecurityEvents
+	}
+
+	siem struct {
+		session.Session
+	}
+
+	// Option defines a SIEM option
+	Option func(*siem)
+
+	// ClientFunc is a SIEM client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) SIEM
+)
+
+// Client returns a new siem Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) SIEM {
+	s := &siem{
+		Session: sess,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
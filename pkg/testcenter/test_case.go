@@ -0,0 +1,432 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// TestCases is a test center functional test cases API interface
+	TestCases interface {
+		// ListTestCases lists functional test cases of a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-test-cases
+		ListTestCases(context.Context, ListTestCasesRequest) (*ListTestCasesResponse, error)
+
+		// GetTestCase fetches a functional test case
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-test-case
+		GetTestCase(context.Context, GetTestCaseRequest) (*TestCase, error)
+
+		// CreateTestCases adds functional test cases to a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-test-cases
+		CreateTestCases(context.Context, CreateTestCasesRequest) (*TestCasesBulkResponse, error)
+
+		// UpdateTestCases updates functional test cases of a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/put-test-cases
+		UpdateTestCases(context.Context, UpdateTestCasesRequest) (*TestCasesBulkResponse, error)
+
+		// DeleteTestCases removes functional test cases from a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-test-cases-delete
+		DeleteTestCases(context.Context, DeleteTestCasesRequest) (*DeleteTestCasesResponse, error)
+	}
+
+	// ListTestCasesRequest contains parameters used to list test cases
+	ListTestCasesRequest struct {
+		TestSuiteID int
+	}
+
+	// ListTestCasesResponse represents a response object returned by ListTestCases
+	ListTestCasesResponse struct {
+		TestCases []TestCase `json:"testCases"`
+	}
+
+	// GetTestCaseRequest contains parameters used to fetch a test case
+	GetTestCaseRequest struct {
+		TestSuiteID int
+		TestCaseID  int
+	}
+
+	// CreateTestCasesRequest contains path parameters and request body used to create test cases
+	CreateTestCasesRequest struct {
+		TestSuiteID int
+		TestCases   []TestCase
+	}
+
+	// UpdateTestCasesRequest contains path parameters and request body used to update test cases
+	UpdateTestCasesRequest struct {
+		TestSuiteID int
+		TestCases   []TestCase
+	}
+
+	// DeleteTestCasesRequest contains path parameters and request body used to delete test cases
+	DeleteTestCasesRequest struct {
+		TestSuiteID int
+		TestCaseIDs []int
+	}
+
+	// TestCasesBulkResponse represents a response object returned by bulk test case operations
+	TestCasesBulkResponse struct {
+		Successes []TestCase        `json:"successes"`
+		Failures  []TestCaseFailure `json:"failures"`
+	}
+
+	// TestCaseFailure represents a test case which could not be processed by a bulk operation
+	TestCaseFailure struct {
+		TestCase
+		Errors []ErrorEntry `json:"errors"`
+	}
+
+	// DeleteTestCasesResponse represents a response object returned by DeleteTestCases
+	DeleteTestCasesResponse struct {
+		Successes []int               `json:"successes"`
+		Failures  []TestCaseIDFailure `json:"failures"`
+	}
+
+	// TestCaseIDFailure represents a test case ID which could not be processed by a bulk operation
+	TestCaseIDFailure struct {
+		TestCaseID int          `json:"testCaseId"`
+		Errors     []ErrorEntry `json:"errors"`
+	}
+
+	// TestCase represents a functional test case
+	TestCase struct {
+		TestCaseID    int           `json:"testCaseId,omitempty"`
+		Order         int           `json:"order,omitempty"`
+		TestRequest   TestRequest   `json:"testRequest"`
+		Condition     Condition     `json:"condition"`
+		ClientProfile ClientProfile `json:"clientProfile"`
+		SetVariables  []SetVariable `json:"setVariables,omitempty"`
+		CreatedBy     string        `json:"createdBy,omitempty"`
+		CreatedDate   string        `json:"createdDate,omitempty"`
+		ModifiedBy    string        `json:"modifiedBy,omitempty"`
+		ModifiedDate  string        `json:"modifiedDate,omitempty"`
+		Warnings      []ErrorEntry  `json:"warnings,omitempty"`
+	}
+
+	// TestRequest describes the request sent by a test case
+	TestRequest struct {
+		TestRequestURL    string          `json:"testRequestUrl"`
+		RequestMethod     RequestMethod   `json:"requestMethod,omitempty"`
+		RequestHeaders    []RequestHeader `json:"requestHeaders,omitempty"`
+		RequestBody       string          `json:"requestBody,omitempty"`
+		EncodeRequestBody bool            `json:"encodeRequestBody,omitempty"`
+		Tags              []string        `json:"tags,omitempty"`
+	}
+
+	// RequestHeader describes a header added, modified or filtered out from a test request
+	RequestHeader struct {
+		HeaderName   string       `json:"headerName"`
+		HeaderValue  string       `json:"headerValue,omitempty"`
+		HeaderAction HeaderAction `json:"headerAction"`
+	}
+
+	// Condition describes the expected outcome of a test case
+	Condition struct {
+		ConditionExpression string `json:"conditionExpression"`
+	}
+
+	// ClientProfile describes the client simulated by a test case
+	ClientProfile struct {
+		Client      ClientType `json:"client"`
+		IPVersion   IPVersion  `json:"ipVersion"`
+		GeoLocation string     `json:"geoLocation,omitempty"`
+	}
+
+	// SetVariable describes a variable set from the test case response, used by stateful test suites
+	SetVariable struct {
+		VariableName  string `json:"variableName"`
+		VariableValue string `json:"variableValue"`
+	}
+
+	// RequestMethod represents HTTP method of a test request
+	RequestMethod string
+
+	// HeaderAction represents an action performed on a request header
+	HeaderAction string
+
+	// ClientType represents a client type simulated by a test case
+	ClientType string
+
+	// IPVersion represents an IP version used by a test case
+	IPVersion string
+)
+
+const (
+	// RequestMethodGet is the GET method
+	RequestMethodGet RequestMethod = "GET"
+	// RequestMethodHead is the HEAD method
+	RequestMethodHead RequestMethod = "HEAD"
+	// RequestMethodPost is the POST method
+	RequestMethodPost RequestMethod = "POST"
+
+	// HeaderActionAdd adds a header
+	HeaderActionAdd HeaderAction = "ADD"
+	// HeaderActionModify modifies a header
+	HeaderActionModify HeaderAction = "MODIFY"
+	// HeaderActionFilter filters out a header
+	HeaderActionFilter HeaderAction = "FILTER"
+
+	// ClientTypeChrome simulates the Chrome browser
+	ClientTypeChrome ClientType = "CHROME"
+	// ClientTypeCurl simulates the curl client
+	ClientTypeCurl ClientType = "CURL"
+
+	// IPVersionIPv4 is IPv4
+	IPVersionIPv4 IPVersion = "IPV4"
+	// IPVersionIPv6 is IPv6
+	IPVersionIPv6 IPVersion = "IPV6"
+)
+
+var (
+	// ErrListTestCases is returned when ListTestCases fails
+	ErrListTestCases = errors.New("list test cases")
+	// ErrGetTestCase is returned when GetTestCase fails
+	ErrGetTestCase = errors.New("get test case")
+	// ErrCreateTestCases is returned when CreateTestCases fails
+	ErrCreateTestCases = errors.New("create test cases")
+	// ErrUpdateTestCases is returned when UpdateTestCases fails
+	ErrUpdateTestCases = errors.New("update test cases")
+	// ErrDeleteTestCases is returned when DeleteTestCases fails
+	ErrDeleteTestCases = errors.New("delete test cases")
+)
+
+// Validate validates ListTestCasesRequest
+func (r ListTestCasesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetTestCaseRequest
+func (r GetTestCaseRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"TestCaseID":  validation.Validate(r.TestCaseID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateTestCasesRequest
+func (r CreateTestCasesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"TestCases":   validation.Validate(r.TestCases, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateTestCasesRequest
+func (r UpdateTestCasesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"TestCases": validation.Validate(r.TestCases, validation.Required, validation.Each(validation.By(func(value interface{}) error {
+			if value.(TestCase).TestCaseID == 0 {
+				return errors.New("TestCaseID is required")
+			}
+			return nil
+		}))),
+	}.Filter()
+}
+
+// Validate validates DeleteTestCasesRequest
+func (r DeleteTestCasesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"TestCaseIDs": validation.Validate(r.TestCaseIDs, validation.Required),
+	}.Filter()
+}
+
+// Validate validates TestCase
+func (c TestCase) Validate() error {
+	return validation.Errors{
+		"TestRequest":   validation.Validate(c.TestRequest),
+		"Condition":     validation.Validate(c.Condition),
+		"ClientProfile": validation.Validate(c.ClientProfile),
+	}.Filter()
+}
+
+// Validate validates TestRequest
+func (r TestRequest) Validate() error {
+	return validation.Errors{
+		"TestRequestURL": validation.Validate(r.TestRequestURL, validation.Required),
+		"RequestMethod": validation.Validate(r.RequestMethod, validation.In(RequestMethodGet, RequestMethodHead, RequestMethodPost).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s' or '%s'", r.RequestMethod, RequestMethodGet,
+				RequestMethodHead, RequestMethodPost))),
+		"RequestHeaders": validation.Validate(r.RequestHeaders),
+		"RequestBody": validation.Validate(r.RequestBody, validation.When(r.RequestMethod != RequestMethodPost,
+			validation.Empty.Error("can only be provided for POST request method"))),
+	}.Filter()
+}
+
+// Validate validates RequestHeader
+func (h RequestHeader) Validate() error {
+	return validation.Errors{
+		"HeaderName": validation.Validate(h.HeaderName, validation.Required),
+		"HeaderAction": validation.Validate(h.HeaderAction, validation.Required, validation.In(HeaderActionAdd, HeaderActionModify, HeaderActionFilter).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s', '%s' or '%s'", h.HeaderAction, HeaderActionAdd,
+				HeaderActionModify, HeaderActionFilter))),
+	}.Filter()
+}
+
+// Validate validates Condition
+func (c Condition) Validate() error {
+	return validation.Errors{
+		"ConditionExpression": validation.Validate(c.ConditionExpression, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ClientProfile
+func (p ClientProfile) Validate() error {
+	return validation.Errors{
+		"Client": validation.Validate(p.Client, validation.Required, validation.In(ClientTypeChrome, ClientTypeCurl).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", p.Client, ClientTypeChrome, ClientTypeCurl))),
+		"IPVersion": validation.Validate(p.IPVersion, validation.Required, validation.In(IPVersionIPv4, IPVersionIPv6).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", p.IPVersion, IPVersionIPv4, IPVersionIPv6))),
+	}.Filter()
+}
+
+func (t *testcenter) ListTestCases(ctx context.Context, params ListTestCasesRequest) (*ListTestCasesResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("ListTestCases")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListTestCases, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/test-cases", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListTestCases, err)
+	}
+
+	var result ListTestCasesResponse
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListTestCases, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListTestCases, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) GetTestCase(ctx context.Context, params GetTestCaseRequest) (*TestCase, error) {
+	logger := t.Log(ctx)
+	logger.Debug("GetTestCase")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetTestCase, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/test-cases/%d", params.TestSuiteID, params.TestCaseID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetTestCase, err)
+	}
+
+	var result TestCase
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetTestCase, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetTestCase, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) CreateTestCases(ctx context.Context, params CreateTestCasesRequest) (*TestCasesBulkResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("CreateTestCases")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateTestCases, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/test-cases", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateTestCases, err)
+	}
+
+	var result TestCasesBulkResponse
+	resp, err := t.Exec(req, &result, params.TestCases)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateTestCases, err)
+	}
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("%s: %w", ErrCreateTestCases, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) UpdateTestCases(ctx context.Context, params UpdateTestCasesRequest) (*TestCasesBulkResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("UpdateTestCases")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateTestCases, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/test-cases", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateTestCases, err)
+	}
+
+	var result TestCasesBulkResponse
+	resp, err := t.Exec(req, &result, params.TestCases)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateTestCases, err)
+	}
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateTestCases, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) DeleteTestCases(ctx context.Context, params DeleteTestCasesRequest) (*DeleteTestCasesResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("DeleteTestCases")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrDeleteTestCases, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/test-cases/delete", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrDeleteTestCases, err)
+	}
+
+	var result DeleteTestCasesResponse
+	resp, err := t.Exec(req, &result, params.TestCaseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrDeleteTestCases, err)
+	}
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("%s: %w", ErrDeleteTestCases, t.Error(resp))
+	}
+
+	return &result, nil
+}
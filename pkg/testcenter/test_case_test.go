@@ -0,0 +1,286 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestTestCase_Validate(t *testing.T) {
+	valid := TestCase{
+		TestRequest:   TestRequest{TestRequestURL: "https://www.example.com/"},
+		Condition:     Condition{ConditionExpression: `Response code is one of "200"`},
+		ClientProfile: ClientProfile{Client: ClientTypeChrome, IPVersion: IPVersionIPv4},
+	}
+	tests := map[string]struct {
+		params    func() TestCase
+		withError string
+	}{
+		"valid": {
+			params: func() TestCase { return valid },
+		},
+		"missing condition": {
+			params: func() TestCase {
+				c := valid
+				c.Condition = Condition{}
+				return c
+			},
+			withError: "Condition: (ConditionExpression: cannot be blank.)",
+		},
+		"invalid request method": {
+			params: func() TestCase {
+				c := valid
+				c.TestRequest.RequestMethod = "PUT"
+				return c
+			},
+			withError: "RequestMethod: value 'PUT' is invalid. Must be one of: 'GET', 'HEAD' or 'POST'",
+		},
+		"body on GET request": {
+			params: func() TestCase {
+				c := valid
+				c.TestRequest.RequestMethod = RequestMethodGet
+				c.TestRequest.RequestBody = "a=b"
+				return c
+			},
+			withError: "RequestBody: can only be provided for POST request method",
+		},
+		"invalid header action": {
+			params: func() TestCase {
+				c := valid
+				c.TestRequest.RequestHeaders = []RequestHeader{{HeaderName: "Accept", HeaderAction: "DROP"}}
+				return c
+			},
+			withError: "HeaderAction: value 'DROP' is invalid",
+		},
+		"invalid client": {
+			params: func() TestCase {
+				c := valid
+				c.ClientProfile.Client = "FIREFOX"
+				return c
+			},
+			withError: "Client: value 'FIREFOX' is invalid. Must be one of: 'CHROME' or 'CURL'",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.params().Validate()
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListTestCases(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12/test-cases", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`
+{
+    "testCases": [
+        {
+            "testCaseId": 100,
+            "order": 1,
+            "testRequest": {"testRequestUrl": "https://www.example.com/", "requestMethod": "GET"},
+            "condition": {"conditionExpression": "Response code is one of \"200\""},
+            "clientProfile": {"client": "CHROME", "ipVersion": "IPV4"}
+        }
+    ]
+}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.ListTestCases(context.Background(), ListTestCasesRequest{TestSuiteID: 12})
+	require.NoError(t, err)
+	assert.Equal(t, &ListTestCasesResponse{
+		TestCases: []TestCase{
+			{
+				TestCaseID:    100,
+				Order:         1,
+				TestRequest:   TestRequest{TestRequestURL: "https://www.example.com/", RequestMethod: RequestMethodGet},
+				Condition:     Condition{ConditionExpression: `Response code is one of "200"`},
+				ClientProfile: ClientProfile{Client: ClientTypeChrome, IPVersion: IPVersionIPv4},
+			},
+		},
+	}, result)
+}
+
+func TestGetTestCase(t *testing.T) {
+	tests := map[string]struct {
+		params           GetTestCaseRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *TestCase
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetTestCaseRequest{TestSuiteID: 12, TestCaseID: 100},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"testCaseId": 100, "testRequest": {"testRequestUrl": "https://www.example.com/"}, "condition": {"conditionExpression": "x"}, "clientProfile": {"client": "CURL", "ipVersion": "IPV6"}}`,
+			expectedResponse: &TestCase{
+				TestCaseID:    100,
+				TestRequest:   TestRequest{TestRequestURL: "https://www.example.com/"},
+				Condition:     Condition{ConditionExpression: "x"},
+				ClientProfile: ClientProfile{Client: ClientTypeCurl, IPVersion: IPVersionIPv6},
+			},
+		},
+		"validation error - missing test case ID": {
+			params:    GetTestCaseRequest{TestSuiteID: 12},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites/12/test-cases/100", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetTestCase(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreateTestCases(t *testing.T) {
+	tests := map[string]struct {
+		params              CreateTestCasesRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *TestCasesBulkResponse
+		withError           error
+	}{
+		"207 Multi-Status": {
+			params: CreateTestCasesRequest{
+				TestSuiteID: 12,
+				TestCases: []TestCase{
+					{
+						TestRequest: TestRequest{
+							TestRequestURL: "https://www.example.com/",
+							RequestHeaders: []RequestHeader{{HeaderName: "Accept", HeaderValue: "text/html", HeaderAction: HeaderActionAdd}},
+						},
+						Condition:     Condition{ConditionExpression: "x"},
+						ClientProfile: ClientProfile{Client: ClientTypeChrome, IPVersion: IPVersionIPv4},
+					},
+				},
+			},
+			responseStatus:      http.StatusMultiStatus,
+			responseBody:        `{"successes": [{"testCaseId": 100, "testRequest": {"testRequestUrl": "https://www.example.com/"}, "condition": {"conditionExpression": "x"}, "clientProfile": {"client": "CHROME", "ipVersion": "IPV4"}}], "failures": []}`,
+			expectedRequestBody: `[{"testRequest":{"testRequestUrl":"https://www.example.com/","requestHeaders":[{"headerName":"Accept","headerValue":"text/html","headerAction":"ADD"}]},"condition":{"conditionExpression":"x"},"clientProfile":{"client":"CHROME","ipVersion":"IPV4"}}]`,
+			expectedResponse: &TestCasesBulkResponse{
+				Successes: []TestCase{
+					{
+						TestCaseID:    100,
+						TestRequest:   TestRequest{TestRequestURL: "https://www.example.com/"},
+						Condition:     Condition{ConditionExpression: "x"},
+						ClientProfile: ClientProfile{Client: ClientTypeChrome, IPVersion: IPVersionIPv4},
+					},
+				},
+				Failures: []TestCaseFailure{},
+			},
+		},
+		"validation error - no test cases": {
+			params:    CreateTestCasesRequest{TestSuiteID: 12},
+			withError: ErrStructValidation,
+		},
+		"400 bad request": {
+			params: CreateTestCasesRequest{
+				TestSuiteID: 12,
+				TestCases: []TestCase{
+					{
+						TestRequest:   TestRequest{TestRequestURL: "https://www.example.com/"},
+						Condition:     Condition{ConditionExpression: "x"},
+						ClientProfile: ClientProfile{Client: ClientTypeChrome, IPVersion: IPVersionIPv4},
+					},
+				},
+			},
+			responseStatus:      http.StatusBadRequest,
+			responseBody:        `{"type": "bad-request", "title": "Bad Request", "status": 400}`,
+			expectedRequestBody: `[{"testRequest":{"testRequestUrl":"https://www.example.com/"},"condition":{"conditionExpression":"x"},"clientProfile":{"client":"CHROME","ipVersion":"IPV4"}}]`,
+			withError: &Error{
+				Type:   "bad-request",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites/12/test-cases", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateTestCases(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateTestCases(t *testing.T) {
+	t.Run("validation error - missing test case ID", func(t *testing.T) {
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := client.UpdateTestCases(context.Background(), UpdateTestCasesRequest{
+			TestSuiteID: 12,
+			TestCases:   []TestCase{{TestRequest: TestRequest{TestRequestURL: "https://www.example.com/"}}},
+		})
+		assert.True(t, errors.Is(err, ErrStructValidation))
+		assert.Contains(t, err.Error(), "TestCaseID is required")
+	})
+}
+
+func TestDeleteTestCases(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12/test-cases/delete", r.URL.String())
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[100,101]`, string(body))
+		w.WriteHeader(http.StatusMultiStatus)
+		_, err = w.Write([]byte(`{"successes": [100], "failures": [{"testCaseId": 101, "errors": [{"title": "Not Found"}]}]}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.DeleteTestCases(context.Background(), DeleteTestCasesRequest{TestSuiteID: 12, TestCaseIDs: []int{100, 101}})
+	require.NoError(t, err)
+	assert.Equal(t, &DeleteTestCasesResponse{
+		Successes: []int{100},
+		Failures:  []TestCaseIDFailure{{TestCaseID: 101, Errors: []ErrorEntry{{Title: "Not Found"}}}},
+	}, result)
+}
@@ -0,0 +1,328 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// TestRuns is a test center test runs API interface
+	TestRuns interface {
+		// ListTestRuns lists test runs
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-test-runs
+		ListTestRuns(context.Context, ListTestRunsRequest) (*ListTestRunsResponse, error)
+
+		// GetTestRun fetches a test run with its execution details
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-test-run
+		GetTestRun(context.Context, GetTestRunRequest) (*TestRun, error)
+
+		// CreateTestRun starts a test run of functional test suites against a property version
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-test-runs
+		CreateTestRun(context.Context, CreateTestRunRequest) (*TestRun, error)
+
+		// WaitForTestRun polls a test run until it completes or the context is canceled
+		WaitForTestRun(context.Context, GetTestRunRequest) (*TestRun, error)
+	}
+
+	// ListTestRunsRequest contains parameters used to list test runs
+	ListTestRunsRequest struct {
+		TestRunIDs []int
+	}
+
+	// ListTestRunsResponse represents a response object returned by ListTestRuns
+	ListTestRunsResponse struct {
+		TestRuns []TestRun `json:"testRuns"`
+	}
+
+	// GetTestRunRequest contains parameters used to fetch a test run
+	GetTestRunRequest struct {
+		TestRunID int
+	}
+
+	// CreateTestRunRequest contains request body used to start a test run
+	CreateTestRunRequest struct {
+		TargetEnvironment     TargetEnvironment    `json:"targetEnvironment"`
+		Note                  string               `json:"note,omitempty"`
+		SendEmailOnCompletion bool                 `json:"sendEmailOnCompletion,omitempty"`
+		Functional            FunctionalTestRunSet `json:"functional"`
+	}
+
+	// FunctionalTestRunSet describes functional test suites executed by a test run
+	FunctionalTestRunSet struct {
+		PropertyManagerExecution *PropertyManagerExecution `json:"propertyManagerExecution,omitempty"`
+		TestSuiteExecutions      []TestSuiteExecution      `json:"testSuiteExecutions,omitempty"`
+	}
+
+	// PropertyManagerExecution describes test suites executed against a property version
+	PropertyManagerExecution struct {
+		PropertyID          int                  `json:"propertyId,omitempty"`
+		PropertyName        string               `json:"propertyName"`
+		PropertyVersion     int                  `json:"propertyVersion"`
+		TestSuiteExecutions []TestSuiteExecution `json:"testSuiteExecutions,omitempty"`
+	}
+
+	// TestSuiteExecution describes the execution of a test suite
+	TestSuiteExecution struct {
+		TestSuiteExecutionID int                 `json:"testSuiteExecutionId,omitempty"`
+		TestSuiteID          int                 `json:"testSuiteId"`
+		Status               TestRunStatus       `json:"status,omitempty"`
+		TestCaseExecutions   []TestCaseExecution `json:"testCaseExecutions,omitempty"`
+	}
+
+	// TestCaseExecution describes the execution of a test case
+	TestCaseExecution struct {
+		TestCaseExecutionID int                        `json:"testCaseExecutionId,omitempty"`
+		TestCaseID          int                        `json:"testCaseId"`
+		Status              TestRunStatus              `json:"status,omitempty"`
+		TestRequest         *TestRequest               `json:"testRequest,omitempty"`
+		Condition           *Condition                 `json:"condition,omitempty"`
+		ConditionEvaluation *ConditionEvaluationResult `json:"conditionEvaluationResult,omitempty"`
+		Errors              []ErrorEntry               `json:"errors,omitempty"`
+	}
+
+	// ConditionEvaluationResult contains the result of evaluating a test case condition
+	ConditionEvaluationResult struct {
+		Result       ConditionResult `json:"result"`
+		ActualValues []string        `json:"actualValues,omitempty"`
+	}
+
+	// TestRun represents a test run
+	TestRun struct {
+		TestRunID             int                  `json:"testRunId"`
+		TargetEnvironment     TargetEnvironment    `json:"targetEnvironment"`
+		Note                  string               `json:"note,omitempty"`
+		SendEmailOnCompletion bool                 `json:"sendEmailOnCompletion,omitempty"`
+		Status                TestRunStatus        `json:"status"`
+		Functional            FunctionalTestRunSet `json:"functional"`
+		SubmittedBy           string               `json:"submittedBy,omitempty"`
+		SubmittedDate         string               `json:"submittedDate,omitempty"`
+		CompletedDate         string               `json:"completedDate,omitempty"`
+	}
+
+	// TargetEnvironment represents the network a test run is executed on
+	TargetEnvironment string
+
+	// TestRunStatus represents the status of a test run or its parts
+	TestRunStatus string
+
+	// ConditionResult represents the result of a test case condition evaluation
+	ConditionResult string
+)
+
+const (
+	// TargetEnvironmentStaging is the staging network
+	TargetEnvironmentStaging TargetEnvironment = "STAGING"
+	// TargetEnvironmentProduction is the production network
+	TargetEnvironmentProduction TargetEnvironment = "PRODUCTION"
+
+	// TestRunStatusPending means the test run is waiting to be executed
+	TestRunStatusPending TestRunStatus = "PENDING"
+	// TestRunStatusInProgress means the test run is being executed
+	TestRunStatusInProgress TestRunStatus = "IN_PROGRESS"
+	// TestRunStatusCompleted means the test run completed
+	TestRunStatusCompleted TestRunStatus = "COMPLETED"
+	// TestRunStatusFailed means the test run could not be completed
+	TestRunStatusFailed TestRunStatus = "FAILED"
+
+	// ConditionResultPassed means the condition was met
+	ConditionResultPassed ConditionResult = "PASSED"
+	// ConditionResultFailed means the condition was not met
+	ConditionResultFailed ConditionResult = "FAILED"
+)
+
+var (
+	// ErrListTestRuns is returned when ListTestRuns fails
+	ErrListTestRuns = errors.New("list test runs")
+	// ErrGetTestRun is returned when GetTestRun fails
+	ErrGetTestRun = errors.New("get test run")
+	// ErrCreateTestRun is returned when CreateTestRun fails
+	ErrCreateTestRun = errors.New("create test run")
+	// ErrWaitForTestRun is returned when WaitForTestRun fails
+	ErrWaitForTestRun = errors.New("wait for test run")
+)
+
+// Done returns true if the test run is no longer pending or in progress
+func (r TestRun) Done() bool {
+	return r.Status != TestRunStatusPending && r.Status != TestRunStatusInProgress
+}
+
+// Validate validates GetTestRunRequest
+func (r GetTestRunRequest) Validate() error {
+	return validation.Errors{
+		"TestRunID": validation.Validate(r.TestRunID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateTestRunRequest
+func (r CreateTestRunRequest) Validate() error {
+	return validation.Errors{
+		"TargetEnvironment": validation.Validate(r.TargetEnvironment, validation.Required, validation.In(TargetEnvironmentStaging,
+			TargetEnvironmentProduction).Error(
+			fmt.Sprintf("value '%s' is invalid. Must be one of: '%s' or '%s'", r.TargetEnvironment, TargetEnvironmentStaging,
+				TargetEnvironmentProduction))),
+		"Functional": validation.Validate(r.Functional),
+	}.Filter()
+}
+
+// Validate validates FunctionalTestRunSet
+func (s FunctionalTestRunSet) Validate() error {
+	return validation.Errors{
+		"PropertyManagerExecution": validation.Validate(s.PropertyManagerExecution, validation.Required.When(len(s.TestSuiteExecutions) == 0).Error(
+			"one of PropertyManagerExecution or TestSuiteExecutions is required")),
+		"TestSuiteExecutions": validation.Validate(s.TestSuiteExecutions),
+	}.Filter()
+}
+
+// Validate validates PropertyManagerExecution
+func (e PropertyManagerExecution) Validate() error {
+	return validation.Errors{
+		"PropertyName":        validation.Validate(e.PropertyName, validation.Required),
+		"PropertyVersion":     validation.Validate(e.PropertyVersion, validation.Required),
+		"TestSuiteExecutions": validation.Validate(e.TestSuiteExecutions),
+	}.Filter()
+}
+
+// Validate validates TestSuiteExecution
+func (e TestSuiteExecution) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(e.TestSuiteID, validation.Required),
+	}.Filter()
+}
+
+func (t *testcenter) ListTestRuns(ctx context.Context, params ListTestRunsRequest) (*ListTestRunsResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("ListTestRuns")
+
+	uri, err := url.Parse("/test-management/v3/test-runs")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListTestRuns, err)
+	}
+
+	q := uri.Query()
+	for _, id := range params.TestRunIDs {
+		q.Add("testRunIds", fmt.Sprint(id))
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListTestRuns, err)
+	}
+
+	var result ListTestRunsResponse
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListTestRuns, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListTestRuns, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) GetTestRun(ctx context.Context, params GetTestRunRequest) (*TestRun, error) {
+	logger := t.Log(ctx)
+	logger.Debug("GetTestRun")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetTestRun, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/test-runs/%d", params.TestRunID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetTestRun, err)
+	}
+
+	var result TestRun
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetTestRun, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetTestRun, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) CreateTestRun(ctx context.Context, params CreateTestRunRequest) (*TestRun, error) {
+	logger := t.Log(ctx)
+	logger.Debug("CreateTestRun")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateTestRun, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/test-management/v3/test-runs", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateTestRun, err)
+	}
+
+	var result TestRun
+	resp, err := t.Exec(req, &result, params)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateTestRun, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateTestRun, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+// WaitForTestRun fetches the test run until its status is no longer pending or in progress.
+// The wait between calls starts at the configured poll interval and is doubled each time up to the configured maximum.
+// Non-positive poll interval is replaced by the default one, so that the API is not called in a busy loop
+func (t *testcenter) WaitForTestRun(ctx context.Context, params GetTestRunRequest) (*TestRun, error) {
+	logger := t.Log(ctx)
+	logger.Debug("WaitForTestRun")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForTestRun, ErrStructValidation, err)
+	}
+
+	interval, maxInterval := t.pollInterval, t.maxPollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+	for {
+		run, err := t.GetTestRun(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrWaitForTestRun, err)
+		}
+		if run.Done() {
+			return run, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: %s", ErrWaitForTestRun, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package testcenter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+)
+
+type (
+	// TestRunSummary contains the pass/fail outcome of a completed test run
+	TestRunSummary struct {
+		TestRunID       int
+		Status          TestRunStatus
+		PropertyID      int
+		PropertyName    string
+		PropertyVersion int
+		Total           int
+		Passed          int
+		Failed          int
+		Errored         int
+		FailedTestCases []FailedTestCase
+	}
+
+	// FailedTestCase identifies a test case which failed or could not be evaluated in a test run
+	FailedTestCase struct {
+		TestSuiteID int
+		TestCaseID  int
+		Result      ConditionResult
+		Errors      []ErrorEntry
+	}
+)
+
+var (
+	// ErrTestRunNotPassed is returned when activation is gated on a test run which did not pass
+	ErrTestRunNotPassed = errors.New("test run did not pass")
+	// ErrTestRunVersionMismatch is returned when activation is gated on a test run of a different property or property version,
+	// or on a test run which did not test a property version
+	ErrTestRunVersionMismatch = errors.New("test run property version does not match activation")
+)
+
+// Summarize counts passed, failed and errored test cases of a test run.
+// A test case without a condition evaluation result is counted as errored
+func Summarize(run TestRun) TestRunSummary {
+	summary := TestRunSummary{
+		TestRunID: run.TestRunID,
+		Status:    run.Status,
+	}
+
+	suites := run.Functional.TestSuiteExecutions
+	if pm := run.Functional.PropertyManagerExecution; pm != nil {
+		summary.PropertyID = pm.PropertyID
+		summary.PropertyName = pm.PropertyName
+		summary.PropertyVersion = pm.PropertyVersion
+		suites = append(append([]TestSuiteExecution{}, pm.TestSuiteExecutions...), suites...)
+	}
+
+	for _, suite := range suites {
+		for _, tc := range suite.TestCaseExecutions {
+			summary.Total++
+			switch {
+			case tc.ConditionEvaluation != nil && tc.ConditionEvaluation.Result == ConditionResultPassed:
+				summary.Passed++
+			case tc.ConditionEvaluation != nil && tc.ConditionEvaluation.Result == ConditionResultFailed:
+				summary.Failed++
+				summary.FailedTestCases = append(summary.FailedTestCases, FailedTestCase{
+					TestSuiteID: suite.TestSuiteID,
+					TestCaseID:  tc.TestCaseID,
+					Result:      ConditionResultFailed,
+					Errors:      tc.Errors,
+				})
+			default:
+				summary.Errored++
+				summary.FailedTestCases = append(summary.FailedTestCases, FailedTestCase{
+					TestSuiteID: suite.TestSuiteID,
+					TestCaseID:  tc.TestCaseID,
+					Errors:      tc.Errors,
+				})
+			}
+		}
+	}
+
+	return summary
+}
+
+// Succeeded returns true if the test run completed and all of its test cases passed
+func (s TestRunSummary) Succeeded() bool {
+	return s.Status == TestRunStatusCompleted && s.Total > 0 && s.Passed == s.Total
+}
+
+// String returns a one line description of the summary
+func (s TestRunSummary) String() string {
+	result := "FAILED"
+	if s.Succeeded() {
+		result = "PASSED"
+	}
+	return fmt.Sprintf("test run %d %s: %d/%d test cases passed, %d failed, %d errored",
+		s.TestRunID, result, s.Passed, s.Total, s.Failed, s.Errored)
+}
+
+// GateActivation returns the given activation request with the summary appended to its note if the test run succeeded.
+// ErrTestRunNotPassed is returned if the test run did not succeed and ErrTestRunVersionMismatch if the test run was
+// executed against a different property or property version than the one being activated, or if the summary does not
+// identify the tested property version
+func (s TestRunSummary) GateActivation(params papi.CreateActivationRequest) (papi.CreateActivationRequest, error) {
+	if !s.Succeeded() {
+		return params, fmt.Errorf("%w: %s", ErrTestRunNotPassed, s)
+	}
+	if s.PropertyID == 0 || s.PropertyVersion == 0 {
+		return params, fmt.Errorf("%w: test run %d has no tested property version", ErrTestRunVersionMismatch, s.TestRunID)
+	}
+	if strconv.Itoa(s.PropertyID) != papi.PropertyID(params.PropertyID).Bare() {
+		return params, fmt.Errorf("%w: tested property %d, activated property %s", ErrTestRunVersionMismatch,
+			s.PropertyID, params.PropertyID)
+	}
+	if s.PropertyVersion != params.Activation.PropertyVersion {
+		return params, fmt.Errorf("%w: tested version %d, activated version %d", ErrTestRunVersionMismatch,
+			s.PropertyVersion, params.Activation.PropertyVersion)
+	}
+
+	note := strings.TrimSpace(params.Activation.Note)
+	if note != "" {
+		note += "; "
+	}
+	params.Activation.Note = note + s.String()
+	return params, nil
+}
@@ -0,0 +1,184 @@
+package testcenter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+)
+
+func completedRun(results ...ConditionResult) TestRun {
+	var executions []TestCaseExecution
+	for i, result := range results {
+		execution := TestCaseExecution{TestCaseID: 100 + i, Status: TestRunStatusCompleted}
+		if result != "" {
+			execution.ConditionEvaluation = &ConditionEvaluationResult{Result: result}
+		}
+		executions = append(executions, execution)
+	}
+	return TestRun{
+		TestRunID: 55,
+		Status:    TestRunStatusCompleted,
+		Functional: FunctionalTestRunSet{
+			PropertyManagerExecution: &PropertyManagerExecution{
+				PropertyID:      1,
+				PropertyName:    "example.com",
+				PropertyVersion: 3,
+				TestSuiteExecutions: []TestSuiteExecution{
+					{TestSuiteID: 12, TestCaseExecutions: executions},
+				},
+			},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	tests := map[string]struct {
+		run       TestRun
+		expected  TestRunSummary
+		succeeded bool
+	}{
+		"all passed": {
+			run: completedRun(ConditionResultPassed, ConditionResultPassed),
+			expected: TestRunSummary{
+				TestRunID:       55,
+				Status:          TestRunStatusCompleted,
+				PropertyID:      1,
+				PropertyName:    "example.com",
+				PropertyVersion: 3,
+				Total:           2,
+				Passed:          2,
+			},
+			succeeded: true,
+		},
+		"failed and errored": {
+			run: completedRun(ConditionResultPassed, ConditionResultFailed, ""),
+			expected: TestRunSummary{
+				TestRunID:       55,
+				Status:          TestRunStatusCompleted,
+				PropertyID:      1,
+				PropertyName:    "example.com",
+				PropertyVersion: 3,
+				Total:           3,
+				Passed:          1,
+				Failed:          1,
+				Errored:         1,
+				FailedTestCases: []FailedTestCase{
+					{TestSuiteID: 12, TestCaseID: 101, Result: ConditionResultFailed},
+					{TestSuiteID: 12, TestCaseID: 102},
+				},
+			},
+		},
+		"no test cases": {
+			run:      TestRun{TestRunID: 55, Status: TestRunStatusCompleted},
+			expected: TestRunSummary{TestRunID: 55, Status: TestRunStatusCompleted},
+		},
+		"run failed": {
+			run: func() TestRun {
+				r := completedRun(ConditionResultPassed)
+				r.Status = TestRunStatusFailed
+				return r
+			}(),
+			expected: TestRunSummary{
+				TestRunID:       55,
+				Status:          TestRunStatusFailed,
+				PropertyID:      1,
+				PropertyName:    "example.com",
+				PropertyVersion: 3,
+				Total:           1,
+				Passed:          1,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			summary := Summarize(test.run)
+			assert.Equal(t, test.expected, summary)
+			assert.Equal(t, test.succeeded, summary.Succeeded())
+		})
+	}
+}
+
+func TestTestRunSummary_GateActivation(t *testing.T) {
+	activation := papi.CreateActivationRequest{
+		PropertyID: "prp_1",
+		ContractID: "ctr_1",
+		GroupID:    "grp_1",
+		Activation: papi.Activation{
+			PropertyVersion: 3,
+			Network:         papi.ActivationNetworkProduction,
+			Note:            "release 42",
+		},
+	}
+	tests := map[string]struct {
+		run          TestRun
+		params       papi.CreateActivationRequest
+		expectedNote string
+		withError    error
+	}{
+		"passed": {
+			run:          completedRun(ConditionResultPassed),
+			params:       activation,
+			expectedNote: "release 42; test run 55 PASSED: 1/1 test cases passed, 0 failed, 0 errored",
+		},
+		"failed": {
+			run:       completedRun(ConditionResultPassed, ConditionResultFailed),
+			params:    activation,
+			withError: ErrTestRunNotPassed,
+		},
+		"version mismatch": {
+			run: completedRun(ConditionResultPassed),
+			params: func() papi.CreateActivationRequest {
+				a := activation
+				a.Activation.PropertyVersion = 4
+				return a
+			}(),
+			withError: ErrTestRunVersionMismatch,
+		},
+		"passed with property ID without prefix": {
+			run: completedRun(ConditionResultPassed),
+			params: func() papi.CreateActivationRequest {
+				a := activation
+				a.PropertyID = "1"
+				return a
+			}(),
+			expectedNote: "release 42; test run 55 PASSED: 1/1 test cases passed, 0 failed, 0 errored",
+		},
+		"property mismatch": {
+			run: completedRun(ConditionResultPassed),
+			params: func() papi.CreateActivationRequest {
+				a := activation
+				a.PropertyID = "prp_2"
+				return a
+			}(),
+			withError: ErrTestRunVersionMismatch,
+		},
+		"no tested property version": {
+			run: func() TestRun {
+				run := completedRun(ConditionResultPassed)
+				run.Functional.TestSuiteExecutions = run.Functional.PropertyManagerExecution.TestSuiteExecutions
+				run.Functional.PropertyManagerExecution = nil
+				return run
+			}(),
+			params:    activation,
+			withError: ErrTestRunVersionMismatch,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := Summarize(test.run).GateActivation(test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Equal(t, test.params, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedNote, result.Activation.Note)
+			assert.Equal(t, test.params.PropertyID, result.PropertyID)
+		})
+	}
+}
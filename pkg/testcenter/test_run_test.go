@@ -0,0 +1,225 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListTestRuns(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/test-runs?testRunIds=1&testRunIds=2", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"testRuns": [{"testRunId": 1, "targetEnvironment": "STAGING", "status": "COMPLETED"}, {"testRunId": 2, "targetEnvironment": "STAGING", "status": "IN_PROGRESS"}]}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.ListTestRuns(context.Background(), ListTestRunsRequest{TestRunIDs: []int{1, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, &ListTestRunsResponse{
+		TestRuns: []TestRun{
+			{TestRunID: 1, TargetEnvironment: TargetEnvironmentStaging, Status: TestRunStatusCompleted},
+			{TestRunID: 2, TargetEnvironment: TargetEnvironmentStaging, Status: TestRunStatusInProgress},
+		},
+	}, result)
+}
+
+func TestCreateTestRun(t *testing.T) {
+	tests := map[string]struct {
+		params              CreateTestRunRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *TestRun
+		withError           error
+	}{
+		"201 Created": {
+			params: CreateTestRunRequest{
+				TargetEnvironment: TargetEnvironmentStaging,
+				Note:              "pre-activation",
+				Functional: FunctionalTestRunSet{
+					PropertyManagerExecution: &PropertyManagerExecution{
+						PropertyName:        "example.com",
+						PropertyVersion:     3,
+						TestSuiteExecutions: []TestSuiteExecution{{TestSuiteID: 12}},
+					},
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"testRunId": 55, "targetEnvironment": "STAGING", "note": "pre-activation", "status": "PENDING", "functional": {"propertyManagerExecution": {"propertyName": "example.com", "propertyVersion": 3, "testSuiteExecutions": [{"testSuiteId": 12}]}}}`,
+			expectedRequestBody: `{"targetEnvironment":"STAGING","note":"pre-activation","functional":{"propertyManagerExecution":{"propertyName":"example.com","propertyVersion":3,"testSuiteExecutions":[{"testSuiteId":12}]}}}`,
+			expectedResponse: &TestRun{
+				TestRunID:         55,
+				TargetEnvironment: TargetEnvironmentStaging,
+				Note:              "pre-activation",
+				Status:            TestRunStatusPending,
+				Functional: FunctionalTestRunSet{
+					PropertyManagerExecution: &PropertyManagerExecution{
+						PropertyName:        "example.com",
+						PropertyVersion:     3,
+						TestSuiteExecutions: []TestSuiteExecution{{TestSuiteID: 12}},
+					},
+				},
+			},
+		},
+		"validation error - invalid environment": {
+			params: CreateTestRunRequest{
+				TargetEnvironment: "QA",
+				Functional:        FunctionalTestRunSet{TestSuiteExecutions: []TestSuiteExecution{{TestSuiteID: 12}}},
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - nothing to execute": {
+			params:    CreateTestRunRequest{TargetEnvironment: TargetEnvironmentStaging},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/test-runs", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateTestRun(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestWaitForTestRun(t *testing.T) {
+	tests := map[string]struct {
+		params           GetTestRunRequest
+		responses        []string
+		responseStatus   int
+		expectedResponse *TestRun
+		withError        error
+	}{
+		"completes after polling": {
+			params: GetTestRunRequest{TestRunID: 55},
+			responses: []string{
+				`{"testRunId": 55, "targetEnvironment": "STAGING", "status": "PENDING"}`,
+				`{"testRunId": 55, "targetEnvironment": "STAGING", "status": "IN_PROGRESS"}`,
+				`{"testRunId": 55, "targetEnvironment": "STAGING", "status": "COMPLETED"}`,
+			},
+			responseStatus:   http.StatusOK,
+			expectedResponse: &TestRun{TestRunID: 55, TargetEnvironment: TargetEnvironmentStaging, Status: TestRunStatusCompleted},
+		},
+		"get fails": {
+			params:         GetTestRunRequest{TestRunID: 55},
+			responses:      []string{`{"type": "not-found", "title": "Not Found", "status": 404}`},
+			responseStatus: http.StatusNotFound,
+			withError: &Error{
+				Type:   "not-found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+			},
+		},
+		"validation error - missing ID": {
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			polls := 0
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/test-runs/55", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				if polls >= len(test.responses) {
+					t.Errorf("unexpected poll request %d", polls+1)
+					return
+				}
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responses[polls]))
+				assert.NoError(t, err)
+				polls++
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.WaitForTestRun(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+			assert.Equal(t, len(test.responses), polls)
+		})
+	}
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"testRunId": 55, "status": "IN_PROGRESS"}`))
+			assert.NoError(t, err)
+		}))
+		client := mockAPIClient(t, mockServer)
+		_, err := client.WaitForTestRun(ctx, GetTestRunRequest{TestRunID: 55})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), context.Canceled.Error())
+	})
+
+	pollIntervals := map[string]struct {
+		initial, max time.Duration
+		maxPolls     int
+	}{
+		"zero poll interval falls back to default": {
+			initial:  0,
+			max:      0,
+			maxPolls: 1,
+		},
+		"negative poll interval falls back to default": {
+			initial:  -time.Second,
+			max:      -time.Second,
+			maxPolls: 1,
+		},
+		"maximum poll interval shorter than interval is raised to interval": {
+			initial:  20 * time.Millisecond,
+			max:      -time.Second,
+			maxPolls: 3,
+		},
+	}
+	for name, test := range pollIntervals {
+		t.Run(name, func(t *testing.T) {
+			var polls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&polls, 1)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`{"testRunId": 55, "status": "IN_PROGRESS"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			WithPollInterval(test.initial, test.max)(client.(*testcenter))
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err := client.WaitForTestRun(ctx, GetTestRunRequest{TestRunID: 55})
+			require.Error(t, err)
+			assert.GreaterOrEqual(t, int(atomic.LoadInt32(&polls)), 1)
+			assert.LessOrEqual(t, int(atomic.LoadInt32(&polls)), test.maxPolls)
+		})
+	}
+}
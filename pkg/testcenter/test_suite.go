@@ -0,0 +1,376 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// TestSuites is a test center test suites API interface
+	TestSuites interface {
+		// ListTestSuites lists functional test suites
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-test-suites
+		ListTestSuites(context.Context, ListTestSuitesRequest) (*ListTestSuitesResponse, error)
+
+		// GetTestSuite fetches a functional test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-test-suite
+		GetTestSuite(context.Context, GetTestSuiteRequest) (*TestSuite, error)
+
+		// CreateTestSuite creates a functional test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-test-suites
+		CreateTestSuite(context.Context, CreateTestSuiteRequest) (*TestSuite, error)
+
+		// UpdateTestSuite updates a functional test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/put-test-suite
+		UpdateTestSuite(context.Context, UpdateTestSuiteRequest) (*TestSuite, error)
+
+		// DeleteTestSuite deletes a functional test suite. Deleted test suites can be restored within 30 days
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/delete-test-suite
+		DeleteTestSuite(context.Context, DeleteTestSuiteRequest) error
+
+		// RestoreTestSuite restores a deleted functional test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-test-suite-restore
+		RestoreTestSuite(context.Context, RestoreTestSuiteRequest) (*TestSuite, error)
+	}
+
+	// ListTestSuitesRequest contains parameters used to list test suites
+	ListTestSuitesRequest struct {
+		PropertyName           string
+		PropertyVersion        int
+		User                   string
+		Search                 string
+		IncludeRecentlyDeleted bool
+	}
+
+	// ListTestSuitesResponse represents a response object returned by ListTestSuites
+	ListTestSuitesResponse struct {
+		TestSuites []TestSuite `json:"testSuites"`
+	}
+
+	// GetTestSuiteRequest contains parameters used to fetch a test suite
+	GetTestSuiteRequest struct {
+		TestSuiteID int
+	}
+
+	// CreateTestSuiteRequest contains request body used to create a test suite
+	CreateTestSuiteRequest struct {
+		TestSuite TestSuite
+	}
+
+	// UpdateTestSuiteRequest contains path parameters and request body used to update a test suite
+	UpdateTestSuiteRequest struct {
+		TestSuiteID int
+		TestSuite   TestSuite
+	}
+
+	// DeleteTestSuiteRequest contains parameters used to delete a test suite
+	DeleteTestSuiteRequest struct {
+		TestSuiteID int
+	}
+
+	// RestoreTestSuiteRequest contains parameters used to restore a deleted test suite
+	RestoreTestSuiteRequest struct {
+		TestSuiteID int
+	}
+
+	// TestSuite represents a functional test suite
+	TestSuite struct {
+		TestSuiteID              int              `json:"testSuiteId,omitempty"`
+		TestSuiteName            string           `json:"testSuiteName"`
+		TestSuiteDescription     string           `json:"testSuiteDescription,omitempty"`
+		IsLocked                 bool             `json:"isLocked"`
+		IsStateful               bool             `json:"isStateful"`
+		Configs                  *TestSuiteConfig `json:"configs,omitempty"`
+		ExecutableTestCasesCount int              `json:"executableTestCasesCount,omitempty"`
+		CreatedBy                string           `json:"createdBy,omitempty"`
+		CreatedDate              string           `json:"createdDate,omitempty"`
+		ModifiedBy               string           `json:"modifiedBy,omitempty"`
+		ModifiedDate             string           `json:"modifiedDate,omitempty"`
+		DeletedBy                string           `json:"deletedBy,omitempty"`
+		DeletedDate              string           `json:"deletedDate,omitempty"`
+	}
+
+	// TestSuiteConfig contains configurations associated with a test suite
+	TestSuiteConfig struct {
+		PropertyManager *PropertyManagerConfig `json:"propertyManager,omitempty"`
+	}
+
+	// PropertyManagerConfig identifies a property version
+	PropertyManagerConfig struct {
+		PropertyID      int    `json:"propertyId,omitempty"`
+		PropertyName    string `json:"propertyName"`
+		PropertyVersion int    `json:"propertyVersion"`
+	}
+)
+
+var (
+	// ErrListTestSuites is returned when ListTestSuites fails
+	ErrListTestSuites = errors.New("list test suites")
+	// ErrGetTestSuite is returned when GetTestSuite fails
+	ErrGetTestSuite = errors.New("get test suite")
+	// ErrCreateTestSuite is returned when CreateTestSuite fails
+	ErrCreateTestSuite = errors.New("create test suite")
+	// ErrUpdateTestSuite is returned when UpdateTestSuite fails
+	ErrUpdateTestSuite = errors.New("update test suite")
+	// ErrDeleteTestSuite is returned when DeleteTestSuite fails
+	ErrDeleteTestSuite = errors.New("delete test suite")
+	// ErrRestoreTestSuite is returned when RestoreTestSuite fails
+	ErrRestoreTestSuite = errors.New("restore test suite")
+)
+
+// Validate validates GetTestSuiteRequest
+func (r GetTestSuiteRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateTestSuiteRequest
+func (r CreateTestSuiteRequest) Validate() error {
+	return validation.Errors{
+		"TestSuite": validation.Validate(r.TestSuite),
+	}.Filter()
+}
+
+// Validate validates UpdateTestSuiteRequest
+func (r UpdateTestSuiteRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"TestSuite":   validation.Validate(r.TestSuite),
+	}.Filter()
+}
+
+// Validate validates DeleteTestSuiteRequest
+func (r DeleteTestSuiteRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates RestoreTestSuiteRequest
+func (r RestoreTestSuiteRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates TestSuite
+func (s TestSuite) Validate() error {
+	return validation.Errors{
+		"TestSuiteName":        validation.Validate(s.TestSuiteName, validation.Required, validation.Length(0, 255)),
+		"TestSuiteDescription": validation.Validate(s.TestSuiteDescription, validation.Length(0, 1000)),
+		"Configs":              validation.Validate(s.Configs),
+	}.Filter()
+}
+
+// Validate validates TestSuiteConfig
+func (c TestSuiteConfig) Validate() error {
+	return validation.Errors{
+		"PropertyManager": validation.Validate(c.PropertyManager),
+	}.Filter()
+}
+
+// Validate validates PropertyManagerConfig
+func (c PropertyManagerConfig) Validate() error {
+	return validation.Errors{
+		"PropertyName":    validation.Validate(c.PropertyName, validation.Required),
+		"PropertyVersion": validation.Validate(c.PropertyVersion, validation.Required),
+	}.Filter()
+}
+
+func (t *testcenter) ListTestSuites(ctx context.Context, params ListTestSuitesRequest) (*ListTestSuitesResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("ListTestSuites")
+
+	uri, err := url.Parse("/test-management/v3/functional/test-suites")
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrListTestSuites, err)
+	}
+
+	q := uri.Query()
+	if params.PropertyName != "" {
+		q.Add("propertyName", params.PropertyName)
+	}
+	if params.PropertyVersion != 0 {
+		q.Add("propertyVersion", strconv.Itoa(params.PropertyVersion))
+	}
+	if params.User != "" {
+		q.Add("user", params.User)
+	}
+	if params.Search != "" {
+		q.Add("search", params.Search)
+	}
+	if params.IncludeRecentlyDeleted {
+		q.Add("includeRecentlyDeleted", "true")
+	}
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListTestSuites, err)
+	}
+
+	var result ListTestSuitesResponse
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListTestSuites, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListTestSuites, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) GetTestSuite(ctx context.Context, params GetTestSuiteRequest) (*TestSuite, error) {
+	logger := t.Log(ctx)
+	logger.Debug("GetTestSuite")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetTestSuite, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetTestSuite, err)
+	}
+
+	var result TestSuite
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetTestSuite, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetTestSuite, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) CreateTestSuite(ctx context.Context, params CreateTestSuiteRequest) (*TestSuite, error) {
+	logger := t.Log(ctx)
+	logger.Debug("CreateTestSuite")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateTestSuite, ErrStructValidation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/test-management/v3/functional/test-suites", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateTestSuite, err)
+	}
+
+	var result TestSuite
+	resp, err := t.Exec(req, &result, params.TestSuite)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateTestSuite, err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%s: %w", ErrCreateTestSuite, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) UpdateTestSuite(ctx context.Context, params UpdateTestSuiteRequest) (*TestSuite, error) {
+	logger := t.Log(ctx)
+	logger.Debug("UpdateTestSuite")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateTestSuite, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateTestSuite, err)
+	}
+
+	body := params.TestSuite
+	body.TestSuiteID = params.TestSuiteID
+
+	var result TestSuite
+	resp, err := t.Exec(req, &result, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateTestSuite, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateTestSuite, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) DeleteTestSuite(ctx context.Context, params DeleteTestSuiteRequest) error {
+	logger := t.Log(ctx)
+	logger.Debug("DeleteTestSuite")
+
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("%s: %w: %s", ErrDeleteTestSuite, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrDeleteTestSuite, err)
+	}
+
+	resp, err := t.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrDeleteTestSuite, err)
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("%s: %w", ErrDeleteTestSuite, t.Error(resp))
+	}
+
+	return nil
+}
+
+func (t *testcenter) RestoreTestSuite(ctx context.Context, params RestoreTestSuiteRequest) (*TestSuite, error) {
+	logger := t.Log(ctx)
+	logger.Debug("RestoreTestSuite")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrRestoreTestSuite, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/restore", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrRestoreTestSuite, err)
+	}
+
+	var result TestSuite
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrRestoreTestSuite, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrRestoreTestSuite, t.Error(resp))
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,288 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListTestSuites(t *testing.T) {
+	tests := map[string]struct {
+		params           ListTestSuitesRequest
+		responseStatus   int
+		responseBody     string
+		expectedPath     string
+		expectedResponse *ListTestSuitesResponse
+		withError        error
+	}{
+		"200 OK": {
+			params: ListTestSuitesRequest{
+				PropertyName:    "example.com",
+				PropertyVersion: 3,
+			},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "testSuites": [
+        {
+            "testSuiteId": 12,
+            "testSuiteName": "example.com regression",
+            "isLocked": false,
+            "isStateful": false,
+            "executableTestCasesCount": 2,
+            "configs": {"propertyManager": {"propertyName": "example.com", "propertyVersion": 3}}
+        }
+    ]
+}`,
+			expectedPath: "/test-management/v3/functional/test-suites?propertyName=example.com&propertyVersion=3",
+			expectedResponse: &ListTestSuitesResponse{
+				TestSuites: []TestSuite{
+					{
+						TestSuiteID:              12,
+						TestSuiteName:            "example.com regression",
+						ExecutableTestCasesCount: 2,
+						Configs: &TestSuiteConfig{
+							PropertyManager: &PropertyManagerConfig{PropertyName: "example.com", PropertyVersion: 3},
+						},
+					},
+				},
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`,
+			expectedPath:   "/test-management/v3/functional/test-suites",
+			withError: &Error{
+				Type:   "internal_error",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListTestSuites(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGetTestSuite(t *testing.T) {
+	tests := map[string]struct {
+		params           GetTestSuiteRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *TestSuite
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetTestSuiteRequest{TestSuiteID: 12},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"testSuiteId": 12, "testSuiteName": "regression", "isLocked": true, "isStateful": false}`,
+			expectedResponse: &TestSuite{TestSuiteID: 12, TestSuiteName: "regression", IsLocked: true},
+		},
+		"validation error - missing ID": {
+			withError: ErrStructValidation,
+		},
+		"404 not found": {
+			params:         GetTestSuiteRequest{TestSuiteID: 12},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not-found", "title": "Not Found", "status": 404}`,
+			withError: &Error{
+				Type:   "not-found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites/12", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetTestSuite(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreateTestSuite(t *testing.T) {
+	tests := map[string]struct {
+		params              CreateTestSuiteRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *TestSuite
+		withError           error
+	}{
+		"201 Created": {
+			params: CreateTestSuiteRequest{
+				TestSuite: TestSuite{
+					TestSuiteName: "regression",
+					Configs: &TestSuiteConfig{
+						PropertyManager: &PropertyManagerConfig{PropertyName: "example.com", PropertyVersion: 3},
+					},
+				},
+			},
+			responseStatus:      http.StatusCreated,
+			responseBody:        `{"testSuiteId": 12, "testSuiteName": "regression", "configs": {"propertyManager": {"propertyName": "example.com", "propertyVersion": 3}}}`,
+			expectedRequestBody: `{"testSuiteName":"regression","isLocked":false,"isStateful":false,"configs":{"propertyManager":{"propertyName":"example.com","propertyVersion":3}}}`,
+			expectedResponse: &TestSuite{
+				TestSuiteID:   12,
+				TestSuiteName: "regression",
+				Configs: &TestSuiteConfig{
+					PropertyManager: &PropertyManagerConfig{PropertyName: "example.com", PropertyVersion: 3},
+				},
+			},
+		},
+		"validation error - missing name": {
+			params:    CreateTestSuiteRequest{},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing property version": {
+			params: CreateTestSuiteRequest{
+				TestSuite: TestSuite{
+					TestSuiteName: "regression",
+					Configs: &TestSuiteConfig{
+						PropertyManager: &PropertyManagerConfig{PropertyName: "example.com"},
+					},
+				},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateTestSuite(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateTestSuite(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12", r.URL.String())
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"testSuiteId":12,"testSuiteName":"renamed","isLocked":true,"isStateful":false}`, string(body))
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write([]byte(`{"testSuiteId": 12, "testSuiteName": "renamed", "isLocked": true}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.UpdateTestSuite(context.Background(), UpdateTestSuiteRequest{
+		TestSuiteID: 12,
+		TestSuite:   TestSuite{TestSuiteName: "renamed", IsLocked: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &TestSuite{TestSuiteID: 12, TestSuiteName: "renamed", IsLocked: true}, result)
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	tests := map[string]struct {
+		params         DeleteTestSuiteRequest
+		responseStatus int
+		responseBody   string
+		withError      error
+	}{
+		"204 No Content": {
+			params:         DeleteTestSuiteRequest{TestSuiteID: 12},
+			responseStatus: http.StatusNoContent,
+		},
+		"validation error - missing ID": {
+			withError: ErrStructValidation,
+		},
+		"403 forbidden": {
+			params:         DeleteTestSuiteRequest{TestSuiteID: 12},
+			responseStatus: http.StatusForbidden,
+			responseBody:   `{"type": "forbidden", "title": "Forbidden", "status": 403}`,
+			withError: &Error{
+				Type:   "forbidden",
+				Title:  "Forbidden",
+				Status: http.StatusForbidden,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites/12", r.URL.String())
+				assert.Equal(t, http.MethodDelete, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			err := client.DeleteTestSuite(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRestoreTestSuite(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12/restore", r.URL.String())
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"testSuiteId": 12, "testSuiteName": "regression"}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.RestoreTestSuite(context.Background(), RestoreTestSuiteRequest{TestSuiteID: 12})
+	require.NoError(t, err)
+	assert.Equal(t, &TestSuite{TestSuiteID: 12, TestSuiteName: "regression"}, result)
+}
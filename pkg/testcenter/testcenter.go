@@ -0,0 +1,65 @@
+// Package testcenter provides access to the Akamai Test Center API
+package testcenter
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wzagrajcz/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrStructValidation is returned when given struct validation failed
+	ErrStructValidation = errors.New("struct validation")
+)
+
+type (
+	// TestCenter is the api interface for Test Center
+	TestCenter interface {
+		TestCases
+		TestRuns
+		TestSuites
+		Variables
+	}
+
+	testcenter struct {
+		session.Session
+		pollInterval    time.Duration
+		maxPollInterval time.Duration
+	}
+
+	// Option defines a TestCenter option
+	Option func(*testcenter)
+
+	// ClientFunc is a TestCenter client new method, this can be used for mocking
+	ClientFunc func(sess session.Session, opts ...Option) TestCenter
+)
+
+const (
+	defaultPollInterval    = 10 * time.Second
+	defaultMaxPollInterval = time.Minute
+)
+
+// Client returns a new testcenter Client instance with the specified controller
+func Client(sess session.Session, opts ...Option) TestCenter {
+	t := &testcenter{
+		Session:         sess,
+		pollInterval:    defaultPollInterval,
+		maxPollInterval: defaultMaxPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// WithPollInterval sets the initial and maximum interval used when waiting for a test run to complete.
+// The interval is doubled after each poll until it reaches the maximum.
+// Non-positive initial interval falls back to the default and maximum shorter than initial interval is raised to it
+func WithPollInterval(initial, max time.Duration) Option {
+	return func(t *testcenter) {
+		t.pollInterval = initial
+		t.maxPollInterval = max
+	}
+}
@@ -0,0 +1,314 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type (
+	// Variables is a test center functional test suite variables API interface
+	Variables interface {
+		// ListVariables lists variables of a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-variables
+		ListVariables(context.Context, ListVariablesRequest) (*ListVariablesResponse, error)
+
+		// GetVariable fetches a test suite variable
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/get-variable
+		GetVariable(context.Context, GetVariableRequest) (*Variable, error)
+
+		// CreateVariables adds variables to a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-variables
+		CreateVariables(context.Context, CreateVariablesRequest) (*VariablesBulkResponse, error)
+
+		// UpdateVariables updates variables of a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/put-variables
+		UpdateVariables(context.Context, UpdateVariablesRequest) (*VariablesBulkResponse, error)
+
+		// DeleteVariables removes variables from a test suite
+		//
+		// See: https://techdocs.akamai.com/test-ctr/reference/post-variables-delete
+		DeleteVariables(context.Context, DeleteVariablesRequest) (*DeleteVariablesResponse, error)
+	}
+
+	// ListVariablesRequest contains parameters used to list variables
+	ListVariablesRequest struct {
+		TestSuiteID int
+	}
+
+	// ListVariablesResponse represents a response object returned by ListVariables
+	ListVariablesResponse struct {
+		Variables []Variable `json:"variables"`
+	}
+
+	// GetVariableRequest contains parameters used to fetch a variable
+	GetVariableRequest struct {
+		TestSuiteID int
+		VariableID  int
+	}
+
+	// CreateVariablesRequest contains path parameters and request body used to create variables
+	CreateVariablesRequest struct {
+		TestSuiteID int
+		Variables   []Variable
+	}
+
+	// UpdateVariablesRequest contains path parameters and request body used to update variables
+	UpdateVariablesRequest struct {
+		TestSuiteID int
+		Variables   []Variable
+	}
+
+	// DeleteVariablesRequest contains path parameters and request body used to delete variables
+	DeleteVariablesRequest struct {
+		TestSuiteID int
+		VariableIDs []int
+	}
+
+	// VariablesBulkResponse represents a response object returned by bulk variable operations
+	VariablesBulkResponse struct {
+		Successes []Variable        `json:"successes"`
+		Failures  []VariableFailure `json:"failures"`
+	}
+
+	// VariableFailure represents a variable which could not be processed by a bulk operation
+	VariableFailure struct {
+		Variable
+		Errors []ErrorEntry `json:"errors"`
+	}
+
+	// DeleteVariablesResponse represents a response object returned by DeleteVariables
+	DeleteVariablesResponse struct {
+		Successes []int               `json:"successes"`
+		Failures  []VariableIDFailure `json:"failures"`
+	}
+
+	// VariableIDFailure represents a variable ID which could not be processed by a bulk operation
+	VariableIDFailure struct {
+		VariableID int          `json:"variableId"`
+		Errors     []ErrorEntry `json:"errors"`
+	}
+
+	// Variable represents a test suite variable which can be referenced in test cases as {{variableName}}
+	Variable struct {
+		VariableID    int    `json:"variableId,omitempty"`
+		VariableName  string `json:"variableName"`
+		VariableValue string `json:"variableValue"`
+		CreatedBy     string `json:"createdBy,omitempty"`
+		CreatedDate   string `json:"createdDate,omitempty"`
+		ModifiedBy    string `json:"modifiedBy,omitempty"`
+		ModifiedDate  string `json:"modifiedDate,omitempty"`
+	}
+)
+
+var (
+	// ErrListVariables is returned when ListVariables fails
+	ErrListVariables = errors.New("list variables")
+	// ErrGetVariable is returned when GetVariable fails
+	ErrGetVariable = errors.New("get variable")
+	// ErrCreateVariables is returned when CreateVariables fails
+	ErrCreateVariables = errors.New("create variables")
+	// ErrUpdateVariables is returned when UpdateVariables fails
+	ErrUpdateVariables = errors.New("update variables")
+	// ErrDeleteVariables is returned when DeleteVariables fails
+	ErrDeleteVariables = errors.New("delete variables")
+)
+
+// Validate validates ListVariablesRequest
+func (r ListVariablesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates GetVariableRequest
+func (r GetVariableRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"VariableID":  validation.Validate(r.VariableID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates CreateVariablesRequest
+func (r CreateVariablesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"Variables":   validation.Validate(r.Variables, validation.Required),
+	}.Filter()
+}
+
+// Validate validates UpdateVariablesRequest
+func (r UpdateVariablesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"Variables": validation.Validate(r.Variables, validation.Required, validation.Each(validation.By(func(value interface{}) error {
+			if value.(Variable).VariableID == 0 {
+				return errors.New("VariableID is required")
+			}
+			return nil
+		}))),
+	}.Filter()
+}
+
+// Validate validates DeleteVariablesRequest
+func (r DeleteVariablesRequest) Validate() error {
+	return validation.Errors{
+		"TestSuiteID": validation.Validate(r.TestSuiteID, validation.Required),
+		"VariableIDs": validation.Validate(r.VariableIDs, validation.Required),
+	}.Filter()
+}
+
+// Validate validates Variable
+func (v Variable) Validate() error {
+	return validation.Errors{
+		"VariableName":  validation.Validate(v.VariableName, validation.Required),
+		"VariableValue": validation.Validate(v.VariableValue, validation.Required),
+	}.Filter()
+}
+
+func (t *testcenter) ListVariables(ctx context.Context, params ListVariablesRequest) (*ListVariablesResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("ListVariables")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListVariables, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/variables", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrListVariables, err)
+	}
+
+	var result ListVariablesResponse
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrListVariables, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrListVariables, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) GetVariable(ctx context.Context, params GetVariableRequest) (*Variable, error) {
+	logger := t.Log(ctx)
+	logger.Debug("GetVariable")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetVariable, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/variables/%d", params.TestSuiteID, params.VariableID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetVariable, err)
+	}
+
+	var result Variable
+	resp, err := t.Exec(req, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrGetVariable, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %w", ErrGetVariable, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) CreateVariables(ctx context.Context, params CreateVariablesRequest) (*VariablesBulkResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("CreateVariables")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateVariables, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/variables", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateVariables, err)
+	}
+
+	var result VariablesBulkResponse
+	resp, err := t.Exec(req, &result, params.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrCreateVariables, err)
+	}
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("%s: %w", ErrCreateVariables, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) UpdateVariables(ctx context.Context, params UpdateVariablesRequest) (*VariablesBulkResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("UpdateVariables")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdateVariables, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/variables", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateVariables, err)
+	}
+
+	var result VariablesBulkResponse
+	resp, err := t.Exec(req, &result, params.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrUpdateVariables, err)
+	}
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("%s: %w", ErrUpdateVariables, t.Error(resp))
+	}
+
+	return &result, nil
+}
+
+func (t *testcenter) DeleteVariables(ctx context.Context, params DeleteVariablesRequest) (*DeleteVariablesResponse, error) {
+	logger := t.Log(ctx)
+	logger.Debug("DeleteVariables")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrDeleteVariables, ErrStructValidation, err)
+	}
+
+	uri := fmt.Sprintf("/test-management/v3/functional/test-suites/%d/variables/delete", params.TestSuiteID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %s", ErrDeleteVariables, err)
+	}
+
+	var result DeleteVariablesResponse
+	resp, err := t.Exec(req, &result, params.VariableIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: request failed: %s", ErrDeleteVariables, err)
+	}
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("%s: %w", ErrDeleteVariables, t.Error(resp))
+	}
+
+	return &result, nil
+}
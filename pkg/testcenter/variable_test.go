@@ -0,0 +1,174 @@
+package testcenter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestListVariables(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12/variables", r.URL.String())
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"variables": [{"variableId": 7, "variableName": "host", "variableValue": "www.example.com"}]}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.ListVariables(context.Background(), ListVariablesRequest{TestSuiteID: 12})
+	require.NoError(t, err)
+	assert.Equal(t, &ListVariablesResponse{
+		Variables: []Variable{{VariableID: 7, VariableName: "host", VariableValue: "www.example.com"}},
+	}, result)
+}
+
+func TestGetVariable(t *testing.T) {
+	tests := map[string]struct {
+		params           GetVariableRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *Variable
+		withError        error
+	}{
+		"200 OK": {
+			params:           GetVariableRequest{TestSuiteID: 12, VariableID: 7},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"variableId": 7, "variableName": "host", "variableValue": "www.example.com"}`,
+			expectedResponse: &Variable{VariableID: 7, VariableName: "host", VariableValue: "www.example.com"},
+		},
+		"validation error - missing variable ID": {
+			params:    GetVariableRequest{TestSuiteID: 12},
+			withError: ErrStructValidation,
+		},
+		"404 not found": {
+			params:         GetVariableRequest{TestSuiteID: 12, VariableID: 7},
+			responseStatus: http.StatusNotFound,
+			responseBody:   `{"type": "not-found", "title": "Not Found", "status": 404}`,
+			withError: &Error{
+				Type:   "not-found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites/12/variables/7", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetVariable(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCreateVariables(t *testing.T) {
+	tests := map[string]struct {
+		params              CreateVariablesRequest
+		responseStatus      int
+		responseBody        string
+		expectedRequestBody string
+		expectedResponse    *VariablesBulkResponse
+		withError           error
+	}{
+		"207 Multi-Status": {
+			params: CreateVariablesRequest{
+				TestSuiteID: 12,
+				Variables:   []Variable{{VariableName: "host", VariableValue: "www.example.com"}},
+			},
+			responseStatus:      http.StatusMultiStatus,
+			responseBody:        `{"successes": [{"variableId": 7, "variableName": "host", "variableValue": "www.example.com"}], "failures": []}`,
+			expectedRequestBody: `[{"variableName":"host","variableValue":"www.example.com"}]`,
+			expectedResponse: &VariablesBulkResponse{
+				Successes: []Variable{{VariableID: 7, VariableName: "host", VariableValue: "www.example.com"}},
+				Failures:  []VariableFailure{},
+			},
+		},
+		"validation error - missing value": {
+			params: CreateVariablesRequest{
+				TestSuiteID: 12,
+				Variables:   []Variable{{VariableName: "host"}},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/test-management/v3/functional/test-suites/12/variables", r.URL.String())
+				assert.Equal(t, http.MethodPost, r.Method)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expectedRequestBody, string(body))
+				w.WriteHeader(test.responseStatus)
+				_, err = w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateVariables(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestUpdateVariables(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12/variables", r.URL.String())
+		assert.Equal(t, http.MethodPut, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"variableId":7,"variableName":"host","variableValue":"staging.example.com"}]`, string(body))
+		w.WriteHeader(http.StatusMultiStatus)
+		_, err = w.Write([]byte(`{"successes": [{"variableId": 7, "variableName": "host", "variableValue": "staging.example.com"}]}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.UpdateVariables(context.Background(), UpdateVariablesRequest{
+		TestSuiteID: 12,
+		Variables:   []Variable{{VariableID: 7, VariableName: "host", VariableValue: "staging.example.com"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &VariablesBulkResponse{
+		Successes: []Variable{{VariableID: 7, VariableName: "host", VariableValue: "staging.example.com"}},
+	}, result)
+}
+
+func TestDeleteVariables(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test-management/v3/functional/test-suites/12/variables/delete", r.URL.String())
+		assert.Equal(t, http.MethodPost, r.Method)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[7]`, string(body))
+		w.WriteHeader(http.StatusMultiStatus)
+		_, err = w.Write([]byte(`{"successes": [7], "failures": []}`))
+		assert.NoError(t, err)
+	}))
+	client := mockAPIClient(t, mockServer)
+	result, err := client.DeleteVariables(context.Background(), DeleteVariablesRequest{TestSuiteID: 12, VariableIDs: []int{7}})
+	require.NoError(t, err)
+	assert.Equal(t, &DeleteVariablesResponse{Successes: []int{7}, Failures: []VariableIDFailure{}}, result)
+}